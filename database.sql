@@ -1,3 +1,6 @@
+-- Enable trigram matching for typo tolerant search
+CREATE EXTENSION IF NOT EXISTS pg_trgm
+
 -- Create Books table
 CREATE TABLE books (
     id VARCHAR(27),
@@ -14,16 +17,31 @@ CREATE TABLE books (
     quantity INT,
     added_at TIMESTAMP WITHOUT TIME ZONE,
     updated_at TIMESTAMP WITHOUT TIME ZONE,
+    search_vector TSVECTOR,
     CONSTRAINT books_pkey PRIMARY KEY (id)
 )
 
+-- Create Books search indexes
+CREATE INDEX books_search_vector_idx ON books USING GIN (search_vector)
+CREATE INDEX books_title_trgm_idx ON books USING GIN (title gin_trgm_ops)
+
 -- Create Subjects table
 CREATE TABLE subjects (
     id SERIAL,
-    subject VARCHAR,
+    subject VARCHAR UNIQUE,
+    scope_note TEXT DEFAULT '',
+    preferred_id INT DEFAULT 0,
     CONSTRAINT subjects_pkey PRIMARY KEY (id)
 )
 
+-- Create Subject_Relations table
+CREATE TABLE subject_relations (
+    subject_id INT REFERENCES subjects (id),
+    relation VARCHAR,
+    related_id INT REFERENCES subjects (id),
+    CONSTRAINT subject_relations_pkey PRIMARY KEY (subject_id, relation, related_id)
+)
+
 -- Create Books_Subjects table
 CREATE TABLE books_subjects (
     book_id VARCHAR(27) REFERENCES books (id),
@@ -33,16 +51,28 @@ CREATE TABLE books_subjects (
 
 -- Create Authors table
 CREATE TABLE authors (
-    id VARCHAR(27),
+    id SERIAL,
     name VARCHAR,
+    biography TEXT DEFAULT '',
+    birth_year INT DEFAULT 0,
+    death_year INT DEFAULT 0,
     CONSTRAINT authors_pkey PRIMARY KEY (id)
 )
 
+-- Create Author_Names table
+CREATE TABLE author_names (
+    author_id INT REFERENCES authors (id),
+    name VARCHAR,
+    CONSTRAINT author_names_pkey PRIMARY KEY (author_id, name)
+)
+
 -- Create Books_Authors table
 CREATE TABLE books_authors (
     book_id VARCHAR(27) REFERENCES books (id),
     author_id INT REFERENCES authors (id),
-    CONSTRAINT books_authors_pkey PRIMARY KEY (book_id, author_id)
+    role VARCHAR DEFAULT 'author',
+    position INT DEFAULT 0,
+    CONSTRAINT books_authors_pkey PRIMARY KEY (book_id, author_id, role)
 )
 
 -- Create Bookcopies table
@@ -51,15 +81,127 @@ CREATE TABLE bookcopies (
     barcode VARCHAR UNIQUE,
     book_id VARCHAR(27) REFERENCES books(id),
     condition VARCHAR,
+    category VARCHAR,
+    status VARCHAR DEFAULT 'available',
+    acquisition_price INT DEFAULT 0,
+    home_branch_id VARCHAR(27) DEFAULT '',
+    home_location_id VARCHAR(27) DEFAULT '',
+    current_branch_id VARCHAR(27) DEFAULT '',
+    current_location_id VARCHAR(27) DEFAULT '',
     added_at TIMESTAMP WITHOUT TIME ZONE,
     updated_at TIMESTAMP WITHOUT TIME ZONE,
     CONSTRAINT bookcopies_pkey PRIMARY KEY (id)
 )
 
+-- Create Branches table
+CREATE TABLE branches (
+    id VARCHAR(27),
+    code VARCHAR UNIQUE,
+    name VARCHAR,
+    address TEXT,
+    added_at TIMESTAMP WITHOUT TIME ZONE,
+    CONSTRAINT branches_pkey PRIMARY KEY (id)
+)
+
+-- Create Locations table
+CREATE TABLE locations (
+    id VARCHAR(27),
+    branch_id VARCHAR(27) REFERENCES branches(id),
+    name VARCHAR,
+    floor VARCHAR,
+    room VARCHAR,
+    shelf_from VARCHAR,
+    shelf_to VARCHAR,
+    added_at TIMESTAMP WITHOUT TIME ZONE,
+    CONSTRAINT locations_pkey PRIMARY KEY (id)
+)
+
+-- Create Transfers table
+CREATE TABLE transfers (
+    id VARCHAR(27),
+    bookcopy_id VARCHAR(27) REFERENCES bookcopies(id),
+    from_branch_id VARCHAR(27) REFERENCES branches(id),
+    to_branch_id VARCHAR(27) REFERENCES branches(id),
+    status VARCHAR,
+    note TEXT,
+    sent_by VARCHAR,
+    received_by VARCHAR,
+    sent_at TIMESTAMP WITHOUT TIME ZONE,
+    received_at TIMESTAMP WITHOUT TIME ZONE,
+    CONSTRAINT transfers_pkey PRIMARY KEY (id)
+)
+
+-- Create Stocktake Sessions table
+CREATE TABLE stocktake_sessions (
+    id VARCHAR(27),
+    branch_id VARCHAR(27) REFERENCES branches(id),
+    location_id VARCHAR(27) REFERENCES locations(id),
+    status VARCHAR,
+    opened_by VARCHAR,
+    closed_by VARCHAR,
+    opened_at TIMESTAMP WITHOUT TIME ZONE,
+    closed_at TIMESTAMP WITHOUT TIME ZONE,
+    results_kept BOOLEAN DEFAULT FALSE,
+    CONSTRAINT stocktake_sessions_pkey PRIMARY KEY (id)
+)
+
+-- Create Stocktake Scans table
+CREATE TABLE stocktake_scans (
+    id VARCHAR(27),
+    session_id VARCHAR(27) REFERENCES stocktake_sessions(id),
+    barcode VARCHAR,
+    bookcopy_id VARCHAR(27),
+    scanned_by VARCHAR,
+    scanned_at TIMESTAMP WITHOUT TIME ZONE,
+    CONSTRAINT stocktake_scans_pkey PRIMARY KEY (id)
+)
+
+-- Create Stocktake Results table
+CREATE TABLE stocktake_results (
+    session_id VARCHAR(27) REFERENCES stocktake_sessions(id),
+    kind VARCHAR,
+    barcode VARCHAR,
+    bookcopy_id VARCHAR(27),
+    book_id VARCHAR(27),
+    status VARCHAR,
+    current_branch_id VARCHAR(27),
+    current_location_id VARCHAR(27),
+    CONSTRAINT stocktake_results_pkey PRIMARY KEY (session_id, kind, barcode)
+)
+
+-- Create Import Jobs table
+CREATE TABLE import_jobs (
+    id VARCHAR(27),
+    mode VARCHAR,
+    dry_run BOOLEAN,
+    status VARCHAR,
+    total_rows INT,
+    imported_rows INT,
+    failed_rows INT,
+    created_by VARCHAR,
+    created_at TIMESTAMP WITHOUT TIME ZONE,
+    CONSTRAINT import_jobs_pkey PRIMARY KEY (id)
+)
+
+-- Create Import Rows table
+CREATE TABLE import_rows (
+    job_id VARCHAR(27) REFERENCES import_jobs(id),
+    number INT,
+    title VARCHAR,
+    isbn VARCHAR,
+    call_number VARCHAR,
+    status VARCHAR,
+    book_id VARCHAR(27),
+    copies_added INT,
+    errors TEXT,
+    CONSTRAINT import_rows_pkey PRIMARY KEY (job_id, number)
+)
+
 -- Create Users table
 CREATE TABLE users (
     id VARCHAR(27),
     student_id VARCHAR(8) UNIQUE,
+    card_number VARCHAR UNIQUE,
     role VARCHAR,
     username VARCHAR UNIQUE,
     email VARCHAR UNIQUE,
@@ -31,8 +31,21 @@ func TestMain(m *testing.M) {
 	// userService := user.NewUserService(repository.UserRepository)
 	// authService := auth.NewAuthService(repository.AuthRepository, userService)
 	// bookService := book.NewBookService(repository.BookRepository)
-	// bookCopyService := bookcopy.NewBookCopyService(repository.BookCopyRepository, bookService)
-	// borrowService := borrowing.NewBorrowingService(repository.BorrowRepository, userService, bookCopyService)
+	// branchService := branch.NewBranchService(repository.BranchRepository)
+	// bookCopyService := bookcopy.NewBookCopyService(repository.BookCopyRepository, bookService, branchService, barcode.NewSchemeFromEnv())
+	// policyService := policy.NewPolicyService(repository.PolicyRepository)
+	// calendarService := calendar.NewCalendarService(repository.CalendarRepository, calendar.LocationFromEnv())
+	// fineService := fine.NewFineService(repository.FineRepository, userService)
+	// transferService := transfer.NewTransferService(repository.TransferRepository, bookCopyService, bookService, branchService)
+	// borrowService := borrowing.NewBorrowingService(repository.BorrowRepository, userService, bookService, bookCopyService, policyService, calendarService, fineService, transferService)
+	// circulationService := circulation.NewCirculationService(borrowService, userService, bookCopyService)
+	// labelService := label.NewLabelService(bookCopyService, bookService)
+	// stocktakeService := stocktake.NewStocktakeService(repository.StocktakeRepository, bookCopyService, branchService)
+	// authorService := author.NewAuthorService(repository.AuthorRepository, bookService)
+	// subjectService := subject.NewSubjectService(repository.SubjectRepository, bookService)
+	// marcService := marc.NewMARCService(bookService)
+	// importingService := importing.NewImportingService(repository.ImportingRepository, bookService, bookCopyService, branchService)
+	// coverService := cover.NewCoverService(storage.NewBlobStoreFromEnv(), bookService)
 
 	// srv = server.NewServer(deployment, authService, bookService, bookCopyService, userService, borrowService)
 
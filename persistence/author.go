@@ -0,0 +1,144 @@
+package persistence
+
+import (
+	"github.com/jmoiron/sqlx"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/author"
+)
+
+type authorRepository struct {
+	DB *sqlx.DB
+}
+
+// NewAuthorRepository returns initialized implementations of the repository for
+// Author domain model.
+func NewAuthorRepository(DB *sqlx.DB) author.Repository {
+	return &authorRepository{
+		DB: DB,
+	}
+}
+
+func (repo *authorRepository) Save(author *author.Author) (*author.Author, error) {
+	err := repo.DB.QueryRow("INSERT INTO authors (name, biography, birth_year, death_year) VALUES ($1, $2, $3, $4) RETURNING id", author.Name, author.Biography, author.BirthYear, author.DeathYear).Scan(&author.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return author, nil
+}
+
+func (repo *authorRepository) Get(authorID int64) (*author.Author, error) {
+	author := author.Author{}
+
+	err := repo.DB.QueryRowx("SELECT * FROM authors WHERE id=$1", authorID).StructScan(&author)
+	if err != nil {
+		return nil, err
+	}
+
+	return &author, nil
+}
+
+func (repo *authorRepository) Update(author *author.Author) (*author.Author, error) {
+	_, err := repo.DB.NamedExec("UPDATE authors SET name=:name, biography=:biography, birth_year=:birth_year, death_year=:death_year WHERE id=:id", author)
+	if err != nil {
+		return nil, err
+	}
+
+	return author, nil
+}
+
+func (repo *authorRepository) Delete(authorID int64) error {
+	_, err := repo.DB.Exec("DELETE FROM author_names WHERE author_id=$1", authorID)
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.DB.Exec("DELETE FROM authors WHERE id=$1", authorID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *authorRepository) List() ([]*author.Author, error) {
+	authors := []*author.Author{}
+
+	err := repo.DB.Select(&authors, "SELECT * FROM authors ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+
+	return authors, nil
+}
+
+// SaveAlternateNames replaces the alternate names of the Author.
+func (repo *authorRepository) SaveAlternateNames(authorID int64, names []string) error {
+	_, err := repo.DB.Exec("DELETE FROM author_names WHERE author_id=$1", authorID)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		_, err := repo.DB.Exec("INSERT INTO author_names (author_id, name) VALUES ($1, $2) ON CONFLICT DO NOTHING", authorID, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repo *authorRepository) GetAlternateNames(authorID int64) ([]string, error) {
+	names := []string{}
+
+	err := repo.DB.Select(&names, "SELECT name FROM author_names WHERE author_id=$1 ORDER BY name", authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (repo *authorRepository) GetBookIDs(authorID int64) ([]string, error) {
+	bookIDs := []string{}
+
+	err := repo.DB.Select(&bookIDs, "SELECT DISTINCT book_id FROM books_authors WHERE author_id=$1 ORDER BY book_id", authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return bookIDs, nil
+}
+
+// Merge moves everything referencing the duplicate Author to the Author
+// and deletes the duplicate, all at once or not at all.
+func (repo *authorRepository) Merge(authorID int64, duplicateID int64) error {
+	tx, err := repo.DB.Beginx()
+	if err != nil {
+		return err
+	}
+
+	statements := []struct {
+		query string
+		args  []interface{}
+	}{
+		// Books already referencing the Author in the same role keep a single reference.
+		{"UPDATE books_authors SET author_id=$1 WHERE author_id=$2 AND NOT EXISTS(SELECT 1 FROM books_authors AS existing WHERE existing.book_id=books_authors.book_id AND existing.role=books_authors.role AND existing.author_id=$1)", []interface{}{authorID, duplicateID}},
+		{"DELETE FROM books_authors WHERE author_id=$1", []interface{}{duplicateID}},
+		{"INSERT INTO author_names (author_id, name) SELECT $1, name FROM author_names WHERE author_id=$2 ON CONFLICT DO NOTHING", []interface{}{authorID, duplicateID}},
+		{"INSERT INTO author_names (author_id, name) SELECT $1, name FROM authors WHERE id=$2 ON CONFLICT DO NOTHING", []interface{}{authorID, duplicateID}},
+		{"DELETE FROM author_names WHERE author_id=$1", []interface{}{duplicateID}},
+		{"DELETE FROM authors WHERE id=$1", []interface{}{duplicateID}},
+	}
+
+	for _, statement := range statements {
+		_, err = tx.Exec(statement.query, statement.args...)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
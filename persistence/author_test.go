@@ -0,0 +1,48 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/author"
+)
+
+func TestAuthorSave(t *testing.T) {
+	validAuthor := author.NewAuthor(0, "Alan A. A. Donovan", "", 0, 0, nil)
+
+	Mock.ExpectQuery("INSERT INTO authors (.+) RETURNING id").
+		WithArgs(validAuthor.Name, validAuthor.Biography, validAuthor.BirthYear, validAuthor.DeathYear).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+
+	newAuthor, err := AuthorTestingRepository.Save(validAuthor)
+	require.Nil(t, err)
+	require.Equal(t, int64(7), newAuthor.ID)
+}
+
+func TestAuthorMerge(t *testing.T) {
+	authorID := int64(1)
+	duplicateID := int64(2)
+
+	Mock.ExpectBegin()
+	Mock.ExpectExec("UPDATE books_authors SET author_id").WithArgs(authorID, duplicateID).WillReturnResult(sqlmock.NewResult(0, 3))
+	Mock.ExpectExec("DELETE FROM books_authors").WithArgs(duplicateID).WillReturnResult(sqlmock.NewResult(0, 1))
+	Mock.ExpectExec("INSERT INTO author_names (.+) FROM author_names").WithArgs(authorID, duplicateID).WillReturnResult(sqlmock.NewResult(0, 1))
+	Mock.ExpectExec("INSERT INTO author_names (.+) FROM authors").WithArgs(authorID, duplicateID).WillReturnResult(sqlmock.NewResult(0, 1))
+	Mock.ExpectExec("DELETE FROM author_names").WithArgs(duplicateID).WillReturnResult(sqlmock.NewResult(0, 1))
+	Mock.ExpectExec("DELETE FROM authors").WithArgs(duplicateID).WillReturnResult(sqlmock.NewResult(0, 1))
+	Mock.ExpectCommit()
+
+	err := AuthorTestingRepository.Merge(authorID, duplicateID)
+	require.Nil(t, err)
+
+	// A failed Merge leaves both Authors as they were.
+	Mock.ExpectBegin()
+	Mock.ExpectExec("UPDATE books_authors SET author_id").WithArgs(authorID, duplicateID).WillReturnError(errors.New("merge failed"))
+	Mock.ExpectRollback()
+
+	err = AuthorTestingRepository.Merge(authorID, duplicateID)
+	require.NotNil(t, err)
+}
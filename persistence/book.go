@@ -1,13 +1,59 @@
 package persistence
 
 import (
+	"database/sql"
+	"fmt"
+	"strings"
+
 	"github.com/jmoiron/sqlx"
 
+	util "github.com/joshuabezaleel/library-server/pkg"
 	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/isbn"
+)
+
+const bookColumns = "id, title, publisher, year_published, call_number, cover_picture, isbn, book_collation, edition, description, loc_classification, quantity, added_at"
+
+// bookSortColumns maps the sorting keys of a Book filter
+// to the columns of books table.
+var bookSortColumns = map[string]string{
+	book.SortByTitle:         "title",
+	book.SortByYearPublished: "year_published",
+	book.SortByAddedAt:       "added_at",
+}
+
+// Options of ts_headline to mark the matching words of a search in
+// the whole title and in the fragments of the description.
+const (
+	titleHighlightOptions = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+	snippetOptions        = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10"
 )
 
+// escapeHTML returns the SQL expression of the text of column escaped as HTML,
+// so that the marks of ts_headline are the only markup of a highlight.
+func escapeHTML(column string) string {
+	return fmt.Sprintf(`replace(replace(replace(replace(replace(COALESCE(%s, ''), '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`, column)
+}
+
+// searchTrigramThreshold is the minimum trigram similarity for a title
+// or an author to match a search that has typos in it.
+const searchTrigramThreshold = 0.3
+
+// bookAvailableCondition holds when any copy of the Book is available on the shelf.
+const bookAvailableCondition = "EXISTS(SELECT 1 FROM bookcopies WHERE bookcopies.book_id=books.id AND bookcopies.status='available')"
+
+// facetLimit is the maximum number of values returned for each facet.
+const facetLimit = 20
+
+type searchRow struct {
+	book.Book
+	Score          float64 `db:"score"`
+	TitleHighlight string  `db:"title_highlight"`
+	Snippet        string  `db:"snippet"`
+}
+
 type bookRepository struct {
-	DB *sqlx.DB
+	DB executor
 }
 
 // NewBookRepository returns initialized implementations of the repository for
@@ -18,6 +64,13 @@ func NewBookRepository(DB *sqlx.DB) book.Repository {
 	}
 }
 
+// WithTransaction returns the repository running its queries in the Transaction.
+func (repo *bookRepository) WithTransaction(tx util.Transaction) book.Repository {
+	return &bookRepository{
+		DB: inTransaction(tx),
+	}
+}
+
 func (repo *bookRepository) Save(book *book.Book) (*book.Book, error) {
 	_, err := repo.DB.NamedExec("INSERT INTO books (id, title, publisher, year_published, call_number, cover_picture, isbn, book_collation, edition, description, loc_classification, quantity, added_at) VALUES (:id, :title, :publisher, :year_published, :call_number, :cover_picture, :isbn, :book_collation, :edition, :description, :loc_classification, :quantity, :added_at)", book)
 
@@ -31,7 +84,7 @@ func (repo *bookRepository) Save(book *book.Book) (*book.Book, error) {
 func (repo *bookRepository) Get(bookID string) (*book.Book, error) {
 	book := book.Book{}
 
-	err := repo.DB.QueryRowx("SELECT * FROM books WHERE id=$1", bookID).StructScan(&book)
+	err := repo.DB.QueryRowx("SELECT "+bookColumns+" FROM books WHERE id=$1", bookID).StructScan(&book)
 	if err != nil {
 		return nil, err
 	}
@@ -63,13 +116,225 @@ func (repo *bookRepository) Delete(bookID string) error {
 	return nil
 }
 
+func (repo *bookRepository) List(filter *book.Filter) ([]*book.Book, error) {
+	books := []*book.Book{}
+
+	conditions, args := bookFilterConditions(filter, nil)
+	where := whereClause(conditions)
+
+	sortColumn, ok := bookSortColumns[filter.SortBy]
+	if !ok {
+		sortColumn = bookSortColumns[book.SortByTitle]
+	}
+
+	order := "ASC"
+	if filter.Descending {
+		order = "DESC"
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf("SELECT %s FROM books%s ORDER BY %s %s, id LIMIT $%d OFFSET $%d", bookColumns, where, sortColumn, order, len(args)-1, len(args))
+
+	err := repo.DB.Select(&books, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return books, nil
+}
+
+func (repo *bookRepository) Count(filter *book.Filter) (int, error) {
+	var total int
+
+	conditions, args := bookFilterConditions(filter, nil)
+
+	err := repo.DB.QueryRow("SELECT COUNT(*) FROM books"+whereClause(conditions), args...).Scan(&total)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (repo *bookRepository) Search(query string, filter *book.Filter) ([]*book.SearchResult, error) {
+	rows := []*searchRow{}
+
+	conditions, args := searchConditions(query, filter)
+
+	args = append(args, filter.Limit, filter.Offset)
+	statement := fmt.Sprintf(`SELECT %s,
+		ts_rank_cd(search_vector, plainto_tsquery('english', $1)) + similarity(title, $1) AS score,
+		ts_headline('english', %s, plainto_tsquery('english', $1), '%s') AS title_highlight,
+		ts_headline('english', %s, plainto_tsquery('english', $1), '%s') AS snippet
+		FROM books%s ORDER BY score DESC, id LIMIT $%d OFFSET $%d`,
+		bookColumns, escapeHTML("title"), titleHighlightOptions, escapeHTML("description"), snippetOptions, whereClause(conditions), len(args)-1, len(args))
+
+	err := repo.DB.Select(&rows, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*book.SearchResult, 0, len(rows))
+	for _, row := range rows {
+		foundBook := row.Book
+		results = append(results, book.NewSearchResult(&foundBook, row.Score, row.TitleHighlight, row.Snippet))
+	}
+
+	return results, nil
+}
+
+func (repo *bookRepository) CountSearch(query string, filter *book.Filter) (int, error) {
+	var total int
+
+	conditions, args := searchConditions(query, filter)
+
+	err := repo.DB.QueryRow("SELECT COUNT(*) FROM books"+whereClause(conditions), args...).Scan(&total)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (repo *bookRepository) GetFacets(query string, filter *book.Filter) (*book.Facets, error) {
+	var conditions []string
+	var args []interface{}
+	if query == "" {
+		conditions, args = bookFilterConditions(filter, nil)
+	} else {
+		conditions, args = searchConditions(query, filter)
+	}
+	where := whereClause(conditions)
+
+	facets := &book.Facets{}
+
+	facetQueries := []struct {
+		values  *[]*book.FacetValue
+		value   string
+		joins   string
+		orderBy string
+	}{
+		{
+			values:  &facets.LOCClassification,
+			value:   "LEFT(loc_classification, 1)",
+			orderBy: "count DESC, value",
+		},
+		{
+			values:  &facets.Subject,
+			value:   "subjects.subject",
+			joins:   " INNER JOIN books_subjects ON books_subjects.book_id=books.id INNER JOIN subjects ON subjects.id=books_subjects.subject_id",
+			orderBy: "count DESC, value",
+		},
+		{
+			values:  &facets.Author,
+			value:   "authors.name",
+			joins:   " INNER JOIN books_authors ON books_authors.book_id=books.id INNER JOIN authors ON authors.id=books_authors.author_id",
+			orderBy: "count DESC, value",
+		},
+		{
+			values:  &facets.Publisher,
+			value:   "publisher",
+			orderBy: "count DESC, value",
+		},
+		{
+			values:  &facets.Decade,
+			value:   "CAST(year_published/10*10 AS VARCHAR)",
+			orderBy: "value",
+		},
+		{
+			values:  &facets.Availability,
+			value:   fmt.Sprintf("CASE WHEN %s THEN '%s' ELSE '%s' END", bookAvailableCondition, book.Available, book.Unavailable),
+			orderBy: "value",
+		},
+	}
+
+	for _, facetQuery := range facetQueries {
+		values := []*book.FacetValue{}
+
+		statement := fmt.Sprintf("SELECT value, COUNT(DISTINCT id) AS count FROM (SELECT books.id AS id, %s AS value FROM books%s%s) AS facet WHERE value IS NOT NULL AND value<>'' GROUP BY value ORDER BY %s LIMIT %d",
+			facetQuery.value, facetQuery.joins, where, facetQuery.orderBy, facetLimit)
+
+		err := repo.DB.Select(&values, statement, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		*facetQuery.values = values
+	}
+
+	return facets, nil
+}
+
+func (repo *bookRepository) RefreshSearchVector(bookID string) error {
+	_, err := repo.DB.Exec(`UPDATE books SET search_vector =
+		setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+		setweight(to_tsvector('english', COALESCE((SELECT string_agg(authors.name, ' ') FROM books_authors INNER JOIN authors ON authors.id=books_authors.author_id WHERE books_authors.book_id=books.id), '')), 'A') ||
+		setweight(to_tsvector('english', COALESCE((SELECT string_agg(subjects.subject, ' ') FROM books_subjects INNER JOIN subjects ON subjects.id=books_subjects.subject_id WHERE books_subjects.book_id=books.id), '')), 'B') ||
+		setweight(to_tsvector('english', COALESCE(description, '')), 'C')
+		WHERE id=$1`, bookID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *bookRepository) ISBNExists(isbn13 string) (bool, error) {
+	var exists bool
+
+	err := repo.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM books WHERE isbn=$1)", isbn13).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (repo *bookRepository) AddQuantity(bookID string, quantity int) error {
+	_, err := repo.DB.Exec("UPDATE books SET quantity=quantity+$1 WHERE id=$2", quantity, bookID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *bookRepository) CallNumberExists(callNumber string) (bool, error) {
+	var exists bool
+
+	err := repo.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM books WHERE call_number=$1)", callNumber).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (repo *bookRepository) GetAvailability(bookID string) ([]*book.BranchAvailability, error) {
+	availability := []*book.BranchAvailability{}
+
+	err := repo.DB.Select(&availability, `SELECT bookcopies.current_branch_id AS branch_id, COALESCE(branches.name, '') AS branch_name,
+		COUNT(*) AS total, COUNT(*) FILTER (WHERE bookcopies.status='available') AS available
+		FROM bookcopies LEFT JOIN branches ON branches.id=bookcopies.current_branch_id
+		WHERE bookcopies.book_id=$1 GROUP BY bookcopies.current_branch_id, branches.name ORDER BY branch_name`, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return availability, nil
+}
+
+// GetSubjectIDs resolves synonyms to their preferred terms and leaves out
+// the subjects which are not in the vocabulary.
 func (repo *bookRepository) GetSubjectIDs(subjects []string) ([]int64, error) {
 	var subjectID int64
 	var subjectIDs []int64
 
 	for _, subject := range subjects {
-		err := repo.DB.QueryRow("SELECT id FROM subjects WHERE subject=$1", subject).Scan(&subjectID)
-
+		err := repo.DB.QueryRow("SELECT CASE WHEN preferred_id=0 THEN id ELSE preferred_id END FROM subjects WHERE subject=$1", subject).Scan(&subjectID)
+		if err == sql.ErrNoRows {
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -134,7 +399,7 @@ func (repo *bookRepository) SaveAuthors(authors []string) error {
 	var isAuthorExists bool
 
 	for _, author := range authors {
-		err := repo.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM authors WHERE name=$1)", author).Scan(&isAuthorExists)
+		err := repo.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM authors WHERE name=$1 UNION SELECT 1 FROM author_names WHERE name=$1)", author).Scan(&isAuthorExists)
 		if err != nil {
 			return err
 		}
@@ -150,12 +415,14 @@ func (repo *bookRepository) SaveAuthors(authors []string) error {
 	return nil
 }
 
+// GetAuthorIDs resolves the authors by their names,
+// falling back to their alternate names.
 func (repo *bookRepository) GetAuthorIDs(authors []string) ([]int64, error) {
 	var authorID int64
 	var authorIDs []int64
 
 	for _, author := range authors {
-		err := repo.DB.QueryRow("SELECT id FROM authors WHERE name=$1", author).Scan(&authorID)
+		err := repo.DB.QueryRow("SELECT id FROM authors WHERE name=$1 UNION ALL SELECT author_id FROM author_names WHERE name=$1 LIMIT 1", author).Scan(&authorID)
 
 		if err != nil {
 			return nil, err
@@ -167,9 +434,22 @@ func (repo *bookRepository) GetAuthorIDs(authors []string) ([]int64, error) {
 	return authorIDs, nil
 }
 
-func (repo *bookRepository) SaveBookAuthors(bookID string, authorIDs []int64) error {
-	for _, authorID := range authorIDs {
-		_, err := repo.DB.Exec("INSERT INTO books_authors (book_id, author_id) VALUES ($1,$2)", bookID, authorID)
+func (repo *bookRepository) AuthorExists(authorID int64) (bool, error) {
+	var isAuthorExists bool
+
+	err := repo.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM authors WHERE id=$1)", authorID).Scan(&isAuthorExists)
+	if err != nil {
+		return false, err
+	}
+
+	return isAuthorExists, nil
+}
+
+// SaveBookAuthors saves the Authors of the Book along with their position,
+// so that they are retrieved in the order they were given.
+func (repo *bookRepository) SaveBookAuthors(bookID string, authors []*book.BookAuthor) error {
+	for position, author := range authors {
+		_, err := repo.DB.Exec("INSERT INTO books_authors (book_id, author_id, role, position) VALUES ($1,$2,$3,$4)", bookID, author.AuthorID, author.Role, position)
 
 		if err != nil {
 			return err
@@ -179,40 +459,115 @@ func (repo *bookRepository) SaveBookAuthors(bookID string, authorIDs []int64) er
 	return nil
 }
 
-func (repo *bookRepository) GetBookAuthorIDs(bookID string) ([]int64, error) {
-	var authorID int64
-	var authorIDs []int64
-
-	rows, err := repo.DB.Query("SELECT author_id FROM books_authors WHERE book_id=$1", bookID)
+func (repo *bookRepository) GetBookAuthors(bookID string) ([]*book.BookAuthor, error) {
+	authors := []*book.BookAuthor{}
 
+	err := repo.DB.Select(&authors, "SELECT books_authors.author_id, authors.name, books_authors.role FROM books_authors INNER JOIN authors ON authors.id=books_authors.author_id WHERE books_authors.book_id=$1 ORDER BY books_authors.position, books_authors.author_id", bookID)
 	if err != nil {
 		return nil, err
 	}
 
-	for rows.Next() {
-		err = rows.Scan(&authorID)
-		if err != nil {
-			return nil, err
+	return authors, nil
+}
+
+// bookFilterConditions builds the conditions of a Book filter, numbering
+// the placeholders after the given arguments. The values are always passed
+// as query arguments and never written into the query.
+func bookFilterConditions(filter *book.Filter, args []interface{}) ([]string, []interface{}) {
+	var conditions []string
+
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.ISBN != "" {
+		addCondition("isbn=$%d", filter.ISBN)
+	}
+
+	if filter.Publisher != "" {
+		addCondition("publisher=$%d", filter.Publisher)
+	}
+
+	if filter.LOCClassification != "" {
+		addCondition("LEFT(loc_classification, LENGTH($%[1]d))=$%[1]d", filter.LOCClassification)
+	}
+
+	if filter.Subject != "" {
+		addCondition("EXISTS(SELECT 1 FROM books_subjects INNER JOIN subjects ON subjects.id=books_subjects.subject_id WHERE books_subjects.book_id=books.id AND subjects.subject=$%d)", filter.Subject)
+	}
+
+	if filter.Author != "" {
+		addCondition("EXISTS(SELECT 1 FROM books_authors INNER JOIN authors ON authors.id=books_authors.author_id WHERE books_authors.book_id=books.id AND authors.name=$%d)", filter.Author)
+	}
+
+	if filter.YearFrom != 0 {
+		addCondition("year_published>=$%d", filter.YearFrom)
+	}
+
+	if filter.YearTo != 0 {
+		addCondition("year_published<=$%d", filter.YearTo)
+	}
+
+	if filter.Decade != 0 {
+		addCondition("year_published/10*10=$%d", filter.Decade)
+	}
+
+	if filter.Available != nil {
+		if *filter.Available {
+			conditions = append(conditions, bookAvailableCondition)
+		} else {
+			conditions = append(conditions, "NOT "+bookAvailableCondition)
 		}
+	}
 
-		authorIDs = append(authorIDs, authorID)
+	return conditions, args
+}
+
+// searchConditions builds the conditions of a catalogue search. A Book matches
+// when its search vector matches the query, or when its title or one of its
+// authors is similar enough to the query to tolerate typos.
+func searchConditions(query string, filter *book.Filter) ([]string, []interface{}) {
+	args := []interface{}{query}
+	match := fmt.Sprintf("(search_vector @@ plainto_tsquery('english', $1) OR similarity(title, $1) > %[1]v OR EXISTS(SELECT 1 FROM books_authors INNER JOIN authors ON authors.id=books_authors.author_id WHERE books_authors.book_id=books.id AND similarity(authors.name, $1) > %[1]v))", searchTrigramThreshold)
+
+	conditions, args := bookFilterConditions(filter, args)
+
+	return append([]string{match}, conditions...), args
+}
+
+// whereClause joins the conditions into a WHERE clause.
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
 	}
 
-	return authorIDs, nil
+	return " WHERE " + strings.Join(conditions, " AND ")
 }
 
-func (repo *bookRepository) GetAuthorsByID(authorIDs []int64) ([]string, error) {
-	var author string
-	var authors []string
+// normalizeISBNs rewrites the ISBNs of the Books saved before ISBNs were
+// normalized, such as hyphenated ISBNs and ISBN-10s, to the ISBN-13 which
+// Books are looked up by. ISBNs which are not valid are left as they are,
+// and so is an ISBN whose ISBN-13 is already the ISBN of another Book.
+func normalizeISBNs(DB executor) error {
+	legacyBooks := []*book.Book{}
 
-	for _, authorID := range authorIDs {
-		err := repo.DB.QueryRow("SELECT name FROM authors WHERE id=$1", authorID).Scan(&author)
+	err := DB.Select(&legacyBooks, "SELECT id, isbn FROM books WHERE isbn<>'' AND isbn !~ '^[0-9]{13}$'")
+	if err != nil {
+		return err
+	}
+
+	for _, legacyBook := range legacyBooks {
+		isbn13, err := isbn.Normalize(legacyBook.ISBN)
 		if err != nil {
-			return nil, err
+			continue
 		}
 
-		authors = append(authors, author)
+		_, err = DB.Exec("UPDATE books SET isbn=$1 WHERE id=$2 AND NOT EXISTS(SELECT 1 FROM books WHERE isbn=$1)", isbn13, legacyBook.ID)
+		if err != nil {
+			return err
+		}
 	}
 
-	return authors, nil
+	return nil
 }
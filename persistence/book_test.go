@@ -213,3 +213,424 @@ func TestBookDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestBookList(t *testing.T) {
+	tt := []struct {
+		name   string
+		filter *book.Filter
+		err    bool
+	}{
+		{
+			name: "list books with a valid filter",
+			filter: &book.Filter{
+				Publisher: "testPublisher",
+				Subject:   "testSubject",
+				YearFrom:  1990,
+				SortBy:    book.SortByYearPublished,
+				Limit:     10,
+				Offset:    20,
+			},
+			err: false,
+		},
+		{
+			name: "list books with an invalid filter",
+			filter: &book.Filter{
+				Author: "testAuthor",
+				Limit:  10,
+			},
+			err: true,
+		},
+	}
+
+	// Assert a list for a valid filter.
+	validFilter := tt[0].filter
+	validBook := &book.Book{
+		ID:    util.NewID(),
+		Title: "testTitle",
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "title"}).
+		AddRow(validBook.ID, validBook.Title)
+
+	Mock.ExpectQuery(`SELECT (.+) FROM books WHERE publisher=\$1 AND EXISTS(.+)subjects.subject=\$2\) AND year_published>=\$3 ORDER BY year_published ASC, id LIMIT \$4 OFFSET \$5`).
+		WithArgs(validFilter.Publisher, validFilter.Subject, validFilter.YearFrom, validFilter.Limit, validFilter.Offset).
+		WillReturnRows(rows)
+
+	// Tests.
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			books, err := BookTestingRepository.List(tc.filter)
+
+			if tc.err {
+				require.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Len(t, books, 1)
+			require.Equal(t, validBook.ID, books[0].ID)
+		})
+	}
+}
+
+func TestBookListByISBN(t *testing.T) {
+	filter := &book.Filter{ISBN: "9780262011532", SortBy: book.SortByTitle, Limit: 10}
+
+	rows := sqlmock.NewRows([]string{"id", "isbn"}).
+		AddRow(util.NewID(), "9780262011532")
+
+	Mock.ExpectQuery(`SELECT (.+) FROM books WHERE isbn=\$1 ORDER BY title ASC, id LIMIT \$2 OFFSET \$3`).
+		WithArgs("9780262011532", filter.Limit, filter.Offset).
+		WillReturnRows(rows)
+
+	books, err := BookTestingRepository.List(filter)
+	require.Nil(t, err)
+	require.Len(t, books, 1)
+}
+
+func TestBookListByAvailability(t *testing.T) {
+	available := true
+	unavailable := false
+
+	tt := []struct {
+		name      string
+		available *bool
+		condition string
+	}{
+		{
+			name:      "list books with copies available",
+			available: &available,
+			condition: `WHERE EXISTS\(SELECT 1 FROM bookcopies (.+)\) ORDER BY`,
+		},
+		{
+			name:      "list books without copies available",
+			available: &unavailable,
+			condition: `WHERE NOT EXISTS\(SELECT 1 FROM bookcopies (.+)\) ORDER BY`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := &book.Filter{Available: tc.available, SortBy: book.SortByTitle, Limit: 10}
+
+			rows := sqlmock.NewRows([]string{"id", "title"}).
+				AddRow(util.NewID(), "testTitle")
+
+			Mock.ExpectQuery(`SELECT (.+) FROM books `+tc.condition).
+				WithArgs(filter.Limit, filter.Offset).
+				WillReturnRows(rows)
+
+			books, err := BookTestingRepository.List(filter)
+			require.Nil(t, err)
+			require.Len(t, books, 1)
+			require.Nil(t, Mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestBookCount(t *testing.T) {
+	tt := []struct {
+		name   string
+		filter *book.Filter
+		err    bool
+	}{
+		{
+			name: "count books with a valid filter",
+			filter: &book.Filter{
+				LOCClassification: "QA",
+			},
+			err: false,
+		},
+		{
+			name: "count books with an invalid filter",
+			filter: &book.Filter{
+				Author: "testAuthor",
+			},
+			err: true,
+		},
+	}
+
+	// Assert a count for a valid filter.
+	validFilter := tt[0].filter
+
+	rows := sqlmock.NewRows([]string{"count"}).
+		AddRow(3)
+
+	Mock.ExpectQuery(`SELECT COUNT\(\*\) FROM books WHERE LEFT\(loc_classification, LENGTH\(\$1\)\)=\$1`).
+		WithArgs(validFilter.LOCClassification).
+		WillReturnRows(rows)
+
+	// Tests.
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			total, err := BookTestingRepository.Count(tc.filter)
+
+			if tc.err {
+				require.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, 3, total)
+		})
+	}
+}
+
+func TestBookSearch(t *testing.T) {
+	tt := []struct {
+		name   string
+		query  string
+		filter *book.Filter
+		err    bool
+	}{
+		{
+			name:  "search books with a valid query",
+			query: "calculus",
+			filter: &book.Filter{
+				Publisher: "testPublisher",
+				Limit:     10,
+			},
+			err: false,
+		},
+		{
+			name:  "search books with an invalid query",
+			query: "anotherQuery",
+			filter: &book.Filter{
+				Limit: 10,
+			},
+			err: true,
+		},
+	}
+
+	// Assert a search for a valid query.
+	validQuery := tt[0].query
+	validFilter := tt[0].filter
+	validBook := &book.Book{
+		ID:    util.NewID(),
+		Title: "Calculus",
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "title", "score", "title_highlight", "snippet"}).
+		AddRow(validBook.ID, validBook.Title, 0.8, "<mark>Calculus</mark>", "")
+
+	// The catalogue text is escaped before ts_headline marks the matching words.
+	Mock.ExpectQuery(`SELECT (.+) ts_headline\('english', replace\((.+)COALESCE\(title, ''\), '&', '&amp;'\)(.+) FROM books WHERE \(search_vector @@ (.+)\) AND publisher=\$2 ORDER BY score DESC, id LIMIT \$3 OFFSET \$4`).
+		WithArgs(validQuery, validFilter.Publisher, validFilter.Limit, validFilter.Offset).
+		WillReturnRows(rows)
+
+	// Tests.
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			results, err := BookTestingRepository.Search(tc.query, tc.filter)
+
+			if tc.err {
+				require.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Len(t, results, 1)
+			require.Equal(t, validBook.ID, results[0].Book.ID)
+			require.Equal(t, 0.8, results[0].Score)
+			require.Equal(t, "<mark>Calculus</mark>", results[0].TitleHighlight)
+		})
+	}
+}
+
+func TestBookCountSearch(t *testing.T) {
+	query := "calculus"
+	filter := &book.Filter{}
+
+	rows := sqlmock.NewRows([]string{"count"}).
+		AddRow(1)
+
+	Mock.ExpectQuery(`SELECT COUNT\(\*\) FROM books WHERE \(search_vector @@ (.+)\)`).
+		WithArgs(query).
+		WillReturnRows(rows)
+
+	total, err := BookTestingRepository.CountSearch(query, filter)
+
+	require.Nil(t, err)
+	require.Equal(t, 1, total)
+}
+
+func TestBookRefreshSearchVector(t *testing.T) {
+	bookID := util.NewID()
+
+	result := sqlmock.NewResult(1, 1)
+
+	Mock.ExpectExec("UPDATE books SET search_vector").
+		WithArgs(bookID).
+		WillReturnResult(result)
+
+	err := BookTestingRepository.RefreshSearchVector(bookID)
+
+	require.Nil(t, err)
+}
+
+func TestBookGetFacets(t *testing.T) {
+	available := true
+	filter := &book.Filter{
+		LOCClassification: "Q",
+		Available:         &available,
+	}
+
+	facetValues := []string{"Q", "Mathematics", "author1", "testPublisher", "1990", book.Available}
+	for _, facetValue := range facetValues {
+		rows := sqlmock.NewRows([]string{"value", "count"}).
+			AddRow(facetValue, 2)
+
+		Mock.ExpectQuery(`SELECT value, COUNT\(DISTINCT id\) AS count FROM \(SELECT (.+) FROM books(.*) WHERE LEFT\(loc_classification, LENGTH\(\$1\)\)=\$1 AND EXISTS(.+)\) AS facet`).
+			WithArgs(filter.LOCClassification).
+			WillReturnRows(rows)
+	}
+
+	facets, err := BookTestingRepository.GetFacets("", filter)
+
+	require.Nil(t, err)
+	require.Equal(t, "Q", facets.LOCClassification[0].Value)
+	require.Equal(t, "Mathematics", facets.Subject[0].Value)
+	require.Equal(t, "author1", facets.Author[0].Value)
+	require.Equal(t, "testPublisher", facets.Publisher[0].Value)
+	require.Equal(t, "1990", facets.Decade[0].Value)
+	require.Equal(t, book.Available, facets.Availability[0].Value)
+	require.Equal(t, 2, facets.Availability[0].Count)
+}
+
+func TestBookGetAvailability(t *testing.T) {
+	bookID := util.NewID()
+	branchID := util.NewID()
+
+	rows := sqlmock.NewRows([]string{"branch_id", "branch_name", "total", "available"}).
+		AddRow("", "", 1, 0).
+		AddRow(branchID, "Main Library", 3, 2)
+
+	Mock.ExpectQuery("SELECT (.+) FROM bookcopies LEFT JOIN branches (.+) WHERE bookcopies.book_id=(.+) GROUP BY (.+)").
+		WithArgs(bookID).
+		WillReturnRows(rows)
+
+	availability, err := BookTestingRepository.GetAvailability(bookID)
+	require.Nil(t, err)
+	require.Len(t, availability, 2)
+	require.Equal(t, branchID, availability[1].BranchID)
+	require.Equal(t, 2, availability[1].Available)
+}
+
+func TestBookAuthorExists(t *testing.T) {
+	rows := sqlmock.NewRows([]string{"exists"}).
+		AddRow(false)
+
+	Mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM authors WHERE id=\$1\)`).
+		WithArgs(int64(99)).
+		WillReturnRows(rows)
+
+	isAuthorExists, err := BookTestingRepository.AuthorExists(99)
+	require.Nil(t, err)
+	require.False(t, isAuthorExists)
+}
+
+func TestBookSaveBookAuthors(t *testing.T) {
+	bookID := util.NewID()
+	authors := []*book.BookAuthor{
+		{AuthorID: 2, Role: book.RoleAuthor},
+		{AuthorID: 1, Role: book.RoleAuthor},
+	}
+
+	// The Authors are saved along with their position in the Book.
+	for position, author := range authors {
+		Mock.ExpectExec("INSERT INTO books_authors").
+			WithArgs(bookID, author.AuthorID, author.Role, position).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+
+	err := BookTestingRepository.SaveBookAuthors(bookID, authors)
+	require.Nil(t, err)
+	require.Nil(t, Mock.ExpectationsWereMet())
+}
+
+func TestBookGetBookAuthors(t *testing.T) {
+	bookID := util.NewID()
+
+	rows := sqlmock.NewRows([]string{"author_id", "name", "role"}).
+		AddRow(1, "Alan A. A. Donovan", book.RoleAuthor).
+		AddRow(2, "Brian W. Kernighan", book.RoleAuthor).
+		AddRow(3, "Jane Doe", book.RoleTranslator)
+
+	Mock.ExpectQuery(`SELECT (.+) FROM books_authors INNER JOIN authors (.+) WHERE books_authors.book_id=\$1 ORDER BY books_authors.position`).
+		WithArgs(bookID).
+		WillReturnRows(rows)
+
+	authors, err := BookTestingRepository.GetBookAuthors(bookID)
+	require.Nil(t, err)
+	require.Len(t, authors, 3)
+	require.Equal(t, int64(3), authors[2].AuthorID)
+	require.Equal(t, book.RoleTranslator, authors[2].Role)
+}
+
+func TestBookGetSubjectIDs(t *testing.T) {
+	// A synonym resolves to its preferred term, an unknown subject is left out.
+	Mock.ExpectQuery(`SELECT CASE WHEN preferred_id=0 THEN id ELSE preferred_id END FROM subjects WHERE subject=\$1`).
+		WithArgs("Maths").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	Mock.ExpectQuery(`SELECT CASE WHEN preferred_id=0 THEN id ELSE preferred_id END FROM subjects WHERE subject=\$1`).
+		WithArgs("Alchemy").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	subjectIDs, err := BookTestingRepository.GetSubjectIDs([]string{"Maths", "Alchemy"})
+	require.Nil(t, err)
+	require.Equal(t, []int64{1}, subjectIDs)
+}
+
+func TestBookISBNExists(t *testing.T) {
+	Mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM books WHERE isbn=\$1\)`).
+		WithArgs("9780134190440").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	exists, err := BookTestingRepository.ISBNExists("9780134190440")
+	require.Nil(t, err)
+	require.True(t, exists)
+}
+
+func TestBookCallNumberExists(t *testing.T) {
+	Mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM books WHERE call_number=\$1\)`).
+		WithArgs("QA76.73.G63 D66 2016").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	exists, err := BookTestingRepository.CallNumberExists("QA76.73.G63 D66 2016")
+	require.Nil(t, err)
+	require.False(t, exists)
+}
+
+func TestBookAddQuantity(t *testing.T) {
+	bookID := util.NewID()
+
+	Mock.ExpectExec(`UPDATE books SET quantity=quantity\+\$1 WHERE id=\$2`).
+		WithArgs(1, bookID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := BookTestingRepository.AddQuantity(bookID, 1)
+	require.Nil(t, err)
+}
+
+func TestNormalizeISBNs(t *testing.T) {
+	hyphenatedID := util.NewID()
+	isbn10ID := util.NewID()
+
+	rows := sqlmock.NewRows([]string{"id", "isbn"}).
+		AddRow(hyphenatedID, "978-0-13-419044-0").
+		AddRow(isbn10ID, "0-262-01153-0").
+		AddRow(util.NewID(), "not an ISBN")
+
+	Mock.ExpectQuery("SELECT id, isbn FROM books WHERE (.+)").
+		WillReturnRows(rows)
+	Mock.ExpectExec("UPDATE books SET isbn=(.+) WHERE id=(.+) AND NOT EXISTS").
+		WithArgs("9780134190440", hyphenatedID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	Mock.ExpectExec("UPDATE books SET isbn=(.+) WHERE id=(.+) AND NOT EXISTS").
+		WithArgs("9780262011532", isbn10ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := normalizeISBNs(DB)
+	require.Nil(t, err)
+	require.Nil(t, Mock.ExpectationsWereMet())
+}
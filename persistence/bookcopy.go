@@ -1,13 +1,16 @@
 package persistence
 
 import (
+	"time"
+
 	"github.com/jmoiron/sqlx"
 
+	util "github.com/joshuabezaleel/library-server/pkg"
 	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
 )
 
 type bookCopyRepository struct {
-	DB *sqlx.DB
+	DB executor
 }
 
 // NewBookCopyRepository returns initialized implementations of the repository for
@@ -18,8 +21,15 @@ func NewBookCopyRepository(DB *sqlx.DB) bookcopy.Repository {
 	}
 }
 
+// WithTransaction returns the repository running its queries in the Transaction.
+func (repo *bookCopyRepository) WithTransaction(tx util.Transaction) bookcopy.Repository {
+	return &bookCopyRepository{
+		DB: inTransaction(tx),
+	}
+}
+
 func (repo *bookCopyRepository) Save(bookCopy *bookcopy.BookCopy) (*bookcopy.BookCopy, error) {
-	_, err := repo.DB.NamedExec("INSERT INTO bookcopies (id, barcode, book_id, condition, added_at) VALUES (:id, :barcode, :book_id, :condition, :added_at)", bookCopy)
+	_, err := repo.DB.NamedExec("INSERT INTO bookcopies (id, barcode, book_id, condition, category, status, acquisition_price, home_branch_id, home_location_id, current_branch_id, current_location_id, added_at) VALUES (:id, :barcode, :book_id, :condition, :category, :status, :acquisition_price, :home_branch_id, :home_location_id, :current_branch_id, :current_location_id, :added_at)", bookCopy)
 
 	if err != nil {
 		return nil, err
@@ -40,7 +50,7 @@ func (repo *bookCopyRepository) Get(bookCopyID string) (*bookcopy.BookCopy, erro
 }
 
 func (repo *bookCopyRepository) Update(bookCopy *bookcopy.BookCopy) (*bookcopy.BookCopy, error) {
-	_, err := repo.DB.NamedExec("UPDATE bookcopies SET barcode=:barcode, book_id=:book_id, condition=:condition WHERE id=:id", bookCopy)
+	_, err := repo.DB.NamedExec("UPDATE bookcopies SET barcode=:barcode, book_id=:book_id, condition=:condition, category=:category, acquisition_price=:acquisition_price, home_branch_id=:home_branch_id, home_location_id=:home_location_id WHERE id=:id", bookCopy)
 
 	if err != nil {
 		return nil, err
@@ -63,3 +73,86 @@ func (repo *bookCopyRepository) Delete(bookCopyID string) error {
 
 	return nil
 }
+
+func (repo *bookCopyRepository) GetByBarcode(barcode string) (*bookcopy.BookCopy, error) {
+	bookCopy := bookcopy.BookCopy{}
+
+	err := repo.DB.QueryRowx("SELECT * FROM bookcopies WHERE barcode=$1", barcode).StructScan(&bookCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bookCopy, nil
+}
+
+func (repo *bookCopyRepository) ListAddedBetween(from time.Time, to time.Time) ([]*bookcopy.BookCopy, error) {
+	bookCopies := []*bookcopy.BookCopy{}
+
+	err := repo.DB.Select(&bookCopies, "SELECT * FROM bookcopies WHERE added_at BETWEEN $1 AND $2 ORDER BY added_at", from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return bookCopies, nil
+}
+
+func (repo *bookCopyRepository) ListByLocation(locationID string) ([]*bookcopy.BookCopy, error) {
+	bookCopies := []*bookcopy.BookCopy{}
+
+	err := repo.DB.Select(&bookCopies, "SELECT * FROM bookcopies WHERE current_location_id=$1 ORDER BY barcode", locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return bookCopies, nil
+}
+
+func (repo *bookCopyRepository) NextBarcodeSequence() (int64, error) {
+	var sequence int64
+
+	err := repo.DB.QueryRow("SELECT nextval('bookcopy_barcode_seq')").Scan(&sequence)
+	if err != nil {
+		return 0, err
+	}
+
+	return sequence, nil
+}
+
+func (repo *bookCopyRepository) UpdateStatus(bookCopy *bookcopy.BookCopy) error {
+	_, err := repo.DB.NamedExec("UPDATE bookcopies SET status=:status WHERE id=:id", bookCopy)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *bookCopyRepository) UpdateLocation(bookCopy *bookcopy.BookCopy) error {
+	_, err := repo.DB.NamedExec("UPDATE bookcopies SET current_branch_id=:current_branch_id, current_location_id=:current_location_id WHERE id=:id", bookCopy)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *bookCopyRepository) SaveStatusChange(statusChange *bookcopy.StatusChange) (*bookcopy.StatusChange, error) {
+	_, err := repo.DB.NamedExec("INSERT INTO bookcopy_status_changes (id, bookcopy_id, from_status, to_status, changed_by, note, changed_at) VALUES (:id, :bookcopy_id, :from_status, :to_status, :changed_by, :note, :changed_at)", statusChange)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return statusChange, nil
+}
+
+func (repo *bookCopyRepository) GetStatusChanges(bookCopyID string) ([]*bookcopy.StatusChange, error) {
+	statusChanges := []*bookcopy.StatusChange{}
+
+	err := repo.DB.Select(&statusChanges, "SELECT * FROM bookcopy_status_changes WHERE bookcopy_id=$1 ORDER BY changed_at", bookCopyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return statusChanges, nil
+}
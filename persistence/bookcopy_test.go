@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/require"
@@ -40,7 +41,7 @@ func TestBookCopySave(t *testing.T) {
 	result := sqlmock.NewResult(1, 1)
 
 	Mock.ExpectExec("INSERT INTO bookcopies").
-		WithArgs(validBookCopy.ID, validBookCopy.Barcode, validBookCopy.BookID, validBookCopy.Condition, validBookCopy.AddedAt).
+		WithArgs(validBookCopy.ID, validBookCopy.Barcode, validBookCopy.BookID, validBookCopy.Condition, validBookCopy.Category, validBookCopy.Status, validBookCopy.AcquisitionPrice, validBookCopy.HomeBranchID, validBookCopy.HomeLocationID, validBookCopy.CurrentBranchID, validBookCopy.CurrentLocationID, validBookCopy.AddedAt).
 		WillReturnResult(result)
 
 	// Tests.
@@ -139,7 +140,7 @@ func TestBookCopyUpdate(t *testing.T) {
 	result := sqlmock.NewResult(1, 1)
 
 	Mock.ExpectExec("UPDATE bookcopies SET").
-		WithArgs(validBookCopy.Barcode, validBookCopy.BookID, validBookCopy.Condition, validBookCopy.ID).
+		WithArgs(validBookCopy.Barcode, validBookCopy.BookID, validBookCopy.Condition, validBookCopy.Category, validBookCopy.AcquisitionPrice, validBookCopy.HomeBranchID, validBookCopy.HomeLocationID, validBookCopy.ID).
 		WillReturnResult(result)
 
 	rows := sqlmock.NewRows([]string{"id", "condition"}).
@@ -213,3 +214,155 @@ func TestBookCopyDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestBookCopyUpdateStatus(t *testing.T) {
+	tt := []struct {
+		name     string
+		bookCopy *bookcopy.BookCopy
+		err      bool
+	}{
+		{
+			name: "update the status of a valid book copy",
+			bookCopy: &bookcopy.BookCopy{
+				ID:     util.NewID(),
+				Status: bookcopy.StatusInRepair,
+			},
+			err: false,
+		},
+		{
+			name: "update the status of an invalid book copy",
+			bookCopy: &bookcopy.BookCopy{
+				ID:     util.NewID(),
+				Status: bookcopy.StatusInRepair,
+			},
+			err: true,
+		},
+	}
+
+	// Assert a status update for a valid Book Copy.
+	validBookCopy := tt[0].bookCopy
+
+	result := sqlmock.NewResult(1, 1)
+
+	Mock.ExpectExec("UPDATE bookcopies SET status").
+		WithArgs(validBookCopy.Status, validBookCopy.ID).
+		WillReturnResult(result)
+
+	// Tests.
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := BookCopyTestingRepository.UpdateStatus(tc.bookCopy)
+
+			if tc.err {
+				require.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+		})
+	}
+}
+
+func TestBookCopyGetStatusChanges(t *testing.T) {
+	bookCopyID := util.NewID()
+
+	rows := sqlmock.NewRows([]string{"id", "bookcopy_id", "from_status", "to_status"}).
+		AddRow(util.NewID(), bookCopyID, bookcopy.StatusAvailable, bookcopy.StatusOnLoan).
+		AddRow(util.NewID(), bookCopyID, bookcopy.StatusOnLoan, bookcopy.StatusAvailable)
+
+	Mock.ExpectQuery("SELECT (.+) FROM bookcopy_status_changes WHERE bookcopy_id=?").
+		WithArgs(bookCopyID).
+		WillReturnRows(rows)
+
+	statusChanges, err := BookCopyTestingRepository.GetStatusChanges(bookCopyID)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(statusChanges))
+	require.Equal(t, bookcopy.StatusOnLoan, statusChanges[0].ToStatus)
+}
+
+func TestBookCopyGetByBarcode(t *testing.T) {
+	validBookCopy := &bookcopy.BookCopy{
+		ID:      util.NewID(),
+		Barcode: "31234000012345",
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "barcode"}).
+		AddRow(validBookCopy.ID, validBookCopy.Barcode)
+
+	Mock.ExpectQuery("SELECT (.+) FROM bookcopies WHERE barcode=?").
+		WithArgs(validBookCopy.Barcode).
+		WillReturnRows(rows)
+
+	bookCopy, err := BookCopyTestingRepository.GetByBarcode(validBookCopy.Barcode)
+	require.Nil(t, err)
+	require.Equal(t, validBookCopy.ID, bookCopy.ID)
+
+	// No Book Copy has the barcode.
+	_, err = BookCopyTestingRepository.GetByBarcode("unknownBarcode")
+	require.NotNil(t, err)
+}
+
+func TestBookCopyNextBarcodeSequence(t *testing.T) {
+	rows := sqlmock.NewRows([]string{"nextval"}).AddRow(42)
+
+	Mock.ExpectQuery("SELECT nextval(.+)").
+		WillReturnRows(rows)
+
+	sequence, err := BookCopyTestingRepository.NextBarcodeSequence()
+	require.Nil(t, err)
+	require.Equal(t, int64(42), sequence)
+}
+
+func TestBookCopyListAddedBetween(t *testing.T) {
+	from := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"id", "barcode", "added_at"}).
+		AddRow(util.NewID(), "31234000000016", from.AddDate(0, 0, 1)).
+		AddRow(util.NewID(), "31234000000024", from.AddDate(0, 0, 2))
+
+	Mock.ExpectQuery("SELECT (.+) FROM bookcopies WHERE added_at BETWEEN (.+) ORDER BY added_at").
+		WithArgs(from, to).
+		WillReturnRows(rows)
+
+	bookCopies, err := BookCopyTestingRepository.ListAddedBetween(from, to)
+	require.Nil(t, err)
+	require.Len(t, bookCopies, 2)
+	require.Equal(t, "31234000000016", bookCopies[0].Barcode)
+}
+
+func TestBookCopyListByLocation(t *testing.T) {
+	locationID := util.NewID()
+
+	rows := sqlmock.NewRows([]string{"id", "barcode", "current_location_id"}).
+		AddRow(util.NewID(), "31234000000016", locationID).
+		AddRow(util.NewID(), "31234000000024", locationID)
+
+	Mock.ExpectQuery("SELECT (.+) FROM bookcopies WHERE current_location_id=(.+) ORDER BY barcode").
+		WithArgs(locationID).
+		WillReturnRows(rows)
+
+	bookCopies, err := BookCopyTestingRepository.ListByLocation(locationID)
+	require.Nil(t, err)
+	require.Len(t, bookCopies, 2)
+	require.Equal(t, locationID, bookCopies[1].CurrentLocationID)
+}
+
+func TestBookCopyUpdateLocation(t *testing.T) {
+	validBookCopy := &bookcopy.BookCopy{
+		ID:                util.NewID(),
+		CurrentBranchID:   util.NewID(),
+		CurrentLocationID: util.NewID(),
+	}
+
+	Mock.ExpectExec("UPDATE bookcopies SET current_branch_id").
+		WithArgs(validBookCopy.CurrentBranchID, validBookCopy.CurrentLocationID, validBookCopy.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := BookCopyTestingRepository.UpdateLocation(validBookCopy)
+	require.Nil(t, err)
+
+	// Book Copy that can not be updated.
+	err = BookCopyTestingRepository.UpdateLocation(validBookCopy)
+	require.NotNil(t, err)
+}
@@ -1,13 +1,16 @@
 package persistence
 
 import (
+	"fmt"
+
 	"github.com/jmoiron/sqlx"
 
+	util "github.com/joshuabezaleel/library-server/pkg"
 	"github.com/joshuabezaleel/library-server/pkg/borrowing"
 )
 
 type borrowRepository struct {
-	DB *sqlx.DB
+	DB executor
 }
 
 // NewBorrowRepository returns initialized implementation of the repository for
@@ -18,8 +21,20 @@ func NewBorrowRepository(DB *sqlx.DB) borrowing.Repository {
 	}
 }
 
+// Begin begins the Transaction a Borrow is closed in along with the charges against it.
+func (repo *borrowRepository) Begin() (util.Transaction, error) {
+	return begin(repo.DB)
+}
+
+// WithTransaction returns the repository running its queries in the Transaction.
+func (repo *borrowRepository) WithTransaction(tx util.Transaction) borrowing.Repository {
+	return &borrowRepository{
+		DB: inTransaction(tx),
+	}
+}
+
 func (repo *borrowRepository) Borrow(borrow *borrowing.Borrow) (*borrowing.Borrow, error) {
-	_, err := repo.DB.NamedExec("INSERT INTO borrows (id, user_id, bookcopy_id, fine, borrowed_at, due_date, returned_at) VALUES (:id, :user_id, :bookcopy_id, :fine, :borrowed_at, :due_date, :returned_at)", borrow)
+	_, err := repo.DB.NamedExec("INSERT INTO borrows (id, user_id, bookcopy_id, fine, replacement_charge, borrow_branch_id, return_branch_id, borrowed_at, due_date, returned_at) VALUES (:id, :user_id, :bookcopy_id, :fine, :replacement_charge, :borrow_branch_id, :return_branch_id, :borrowed_at, :due_date, :returned_at)", borrow)
 
 	if err != nil {
 		return nil, err
@@ -36,24 +51,28 @@ func (repo *borrowRepository) Get(borrowID string) (*borrowing.Borrow, error) {
 		return nil, err
 	}
 
+	localBorrow(&borrow)
+
 	return &borrow, nil
 }
 
 func (repo *borrowRepository) GetByUserIDAndBookCopyID(userID string, bookCopyID string) (*borrowing.Borrow, error) {
 	borrow := borrowing.Borrow{}
 
-	err := repo.DB.QueryRowx("SELECT * FROM borrows WHERE user_id=$1 AND bookcopy_id=$2", userID, bookCopyID).StructScan(&borrow)
+	err := repo.DB.QueryRowx("SELECT * FROM borrows WHERE user_id=$1 AND bookcopy_id=$2 ORDER BY borrowed_at DESC LIMIT 1", userID, bookCopyID).StructScan(&borrow)
 	if err != nil {
 		return nil, err
 	}
 
+	localBorrow(&borrow)
+
 	return &borrow, nil
 }
 
 func (repo *borrowRepository) CheckBorrowed(bookCopyID string) (bool, error) {
 	var isBorrowed bool
 
-	err := repo.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM borrows WHERE bookcopy_id=$1)", bookCopyID).Scan(&isBorrowed)
+	err := repo.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM borrows WHERE bookcopy_id=$1 AND returned_at IS NULL)", bookCopyID).Scan(&isBorrowed)
 	if err != nil {
 		return false, err
 	}
@@ -61,8 +80,62 @@ func (repo *borrowRepository) CheckBorrowed(bookCopyID string) (bool, error) {
 	return isBorrowed, nil
 }
 
+func (repo *borrowRepository) CountActiveBorrows(userID string) (int, error) {
+	var count int
+
+	err := repo.DB.QueryRow("SELECT COUNT(*) FROM borrows WHERE user_id=$1 AND returned_at IS NULL", userID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (repo *borrowRepository) GetByUserID(userID string, status string) ([]*borrowing.Borrow, error) {
+	borrows := []*borrowing.Borrow{}
+
+	err := repo.DB.Select(&borrows, fmt.Sprintf("SELECT * FROM borrows WHERE user_id=$1%s ORDER BY borrowed_at DESC", loanStatusCondition(status)), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, borrow := range borrows {
+		localBorrow(borrow)
+	}
+
+	return borrows, nil
+}
+
+func (repo *borrowRepository) GetByBookCopyID(bookCopyID string) ([]*borrowing.Borrow, error) {
+	borrows := []*borrowing.Borrow{}
+
+	err := repo.DB.Select(&borrows, "SELECT * FROM borrows WHERE bookcopy_id=$1 ORDER BY borrowed_at DESC", bookCopyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, borrow := range borrows {
+		localBorrow(borrow)
+	}
+
+	return borrows, nil
+}
+
+// loanStatusCondition returns the condition selecting the Borrows
+// of the loan status, or every Borrow for an empty status.
+func loanStatusCondition(status string) string {
+	switch status {
+	case borrowing.LoanActive:
+		return " AND returned_at IS NULL"
+	case borrowing.LoanReturned:
+		return " AND returned_at IS NOT NULL"
+	default:
+		return ""
+	}
+}
+
 func (repo *borrowRepository) Return(borrow *borrowing.Borrow) (*borrowing.Borrow, error) {
-	_, err := repo.DB.NamedExec("UPDATE borrows SET fine=:fine, returned_at=:returned_at WHERE id=:id", borrow)
+	_, err := repo.DB.NamedExec("UPDATE borrows SET fine=:fine, replacement_charge=:replacement_charge, return_branch_id=:return_branch_id, returned_at=:returned_at WHERE id=:id", borrow)
 	if err != nil {
 		return nil, err
 	}
@@ -74,3 +147,42 @@ func (repo *borrowRepository) Return(borrow *borrowing.Borrow) (*borrowing.Borro
 
 	return returnedBorrow, nil
 }
+
+func (repo *borrowRepository) Renew(borrow *borrowing.Borrow) (*borrowing.Borrow, error) {
+	_, err := repo.DB.NamedExec("UPDATE borrows SET due_date=:due_date WHERE id=:id", borrow)
+	if err != nil {
+		return nil, err
+	}
+
+	renewedBorrow, err := repo.Get(borrow.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return renewedBorrow, nil
+}
+
+func (repo *borrowRepository) SaveRenewal(renewal *borrowing.Renewal) (*borrowing.Renewal, error) {
+	_, err := repo.DB.NamedExec("INSERT INTO renewals (id, borrow_id, user_id, previous_due_date, new_due_date, renewed_at) VALUES (:id, :borrow_id, :user_id, :previous_due_date, :new_due_date, :renewed_at)", renewal)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return renewal, nil
+}
+
+func (repo *borrowRepository) GetRenewals(borrowID string) ([]*borrowing.Renewal, error) {
+	renewals := []*borrowing.Renewal{}
+
+	err := repo.DB.Select(&renewals, "SELECT * FROM renewals WHERE borrow_id=$1 ORDER BY renewed_at", borrowID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, renewal := range renewals {
+		localRenewal(renewal)
+	}
+
+	return renewals, nil
+}
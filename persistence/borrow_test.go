@@ -1,7 +1,9 @@
 package persistence
 
 import (
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/require"
@@ -42,7 +44,7 @@ func TestBorrowBorrow(t *testing.T) {
 	result := sqlmock.NewResult(1, 1)
 
 	Mock.ExpectExec("INSERT INTO borrows").
-		WithArgs(validBorrow.ID, validBorrow.UserID, validBorrow.BookCopyID, validBorrow.Fine, validBorrow.BorrowedAt, validBorrow.DueDate, validBorrow.ReturnedAt).
+		WithArgs(validBorrow.ID, validBorrow.UserID, validBorrow.BookCopyID, validBorrow.Fine, validBorrow.ReplacementCharge, validBorrow.BorrowBranchID, validBorrow.ReturnBranchID, validBorrow.BorrowedAt, validBorrow.DueDate, validBorrow.ReturnedAt).
 		WillReturnResult(result)
 
 	// Tests.
@@ -218,6 +220,84 @@ func TestBorrowCheckBorrowed(t *testing.T) {
 	}
 }
 
+func TestBorrowCountActiveBorrows(t *testing.T) {
+	userID := util.NewID()
+
+	rows := sqlmock.NewRows([]string{"count"}).
+		AddRow(3)
+
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM borrows WHERE user_id=$1 AND returned_at IS NULL")).
+		WithArgs(userID).
+		WillReturnRows(rows)
+
+	count, err := BorrowTestingRepository.CountActiveBorrows(userID)
+
+	require.Nil(t, err)
+	require.Equal(t, 3, count)
+}
+
+func TestBorrowGetByUserID(t *testing.T) {
+	userID := util.NewID()
+
+	tt := []struct {
+		name      string
+		status    string
+		condition string
+	}{
+		{
+			name:      "all loans",
+			status:    "",
+			condition: "",
+		},
+		{
+			name:      "active loans",
+			status:    borrowing.LoanActive,
+			condition: " AND returned_at IS NULL",
+		},
+		{
+			name:      "returned loans",
+			status:    borrowing.LoanReturned,
+			condition: " AND returned_at IS NOT NULL",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			borrowID := util.NewID()
+
+			rows := sqlmock.NewRows([]string{"id", "user_id"}).
+				AddRow(borrowID, userID)
+
+			Mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM borrows WHERE user_id=$1" + tc.condition + " ORDER BY borrowed_at DESC")).
+				WithArgs(userID).
+				WillReturnRows(rows)
+
+			borrows, err := BorrowTestingRepository.GetByUserID(userID, tc.status)
+
+			require.Nil(t, err)
+			require.Len(t, borrows, 1)
+			require.Equal(t, borrowID, borrows[0].ID)
+		})
+	}
+}
+
+func TestBorrowGetByBookCopyID(t *testing.T) {
+	bookCopyID := util.NewID()
+
+	rows := sqlmock.NewRows([]string{"id", "bookcopy_id"}).
+		AddRow(util.NewID(), bookCopyID).
+		AddRow(util.NewID(), bookCopyID)
+
+	Mock.ExpectQuery("SELECT (.+) FROM borrows WHERE bookcopy_id=(.+) ORDER BY borrowed_at DESC").
+		WithArgs(bookCopyID).
+		WillReturnRows(rows)
+
+	borrows, err := BorrowTestingRepository.GetByBookCopyID(bookCopyID)
+
+	require.Nil(t, err)
+	require.Len(t, borrows, 2)
+}
+
 func TestBorrowReturn(t *testing.T) {
 	tt := []struct {
 		name   string
@@ -250,7 +330,7 @@ func TestBorrowReturn(t *testing.T) {
 	result := sqlmock.NewResult(1, 1)
 
 	Mock.ExpectExec("UPDATE borrows SET").
-		WithArgs(validBorrow.Fine, validBorrow.ReturnedAt, validBorrow.ID).
+		WithArgs(validBorrow.Fine, validBorrow.ReplacementCharge, validBorrow.ReturnBranchID, validBorrow.ReturnedAt, validBorrow.ID).
 		WillReturnResult(result)
 
 	rows := sqlmock.NewRows([]string{"id", "user_id", "bookcopy_id"}).
@@ -374,3 +454,96 @@ func TestBorrowReturn(t *testing.T) {
 
 // 	repository.CleanUp()
 // }
+
+func TestBorrowRenew(t *testing.T) {
+	borrow := &borrowing.Borrow{
+		ID:      util.NewID(),
+		DueDate: time.Now().AddDate(0, 0, 14),
+	}
+
+	result := sqlmock.NewResult(1, 1)
+
+	Mock.ExpectExec("UPDATE borrows SET due_date").
+		WithArgs(borrow.DueDate, borrow.ID).
+		WillReturnResult(result)
+
+	rows := sqlmock.NewRows([]string{"id", "due_date"}).
+		AddRow(borrow.ID, storedTime(borrow.DueDate))
+
+	Mock.ExpectQuery("SELECT (.+) FROM borrows WHERE id=?").
+		WithArgs(borrow.ID).
+		WillReturnRows(rows)
+
+	renewedBorrow, err := BorrowTestingRepository.Renew(borrow)
+
+	require.Nil(t, err)
+	require.Equal(t, borrow.ID, renewedBorrow.ID)
+	require.True(t, borrow.DueDate.Equal(renewedBorrow.DueDate))
+}
+
+// storedTime returns the time as lib/pq reads it back from a column WITHOUT TIME ZONE.
+func storedTime(t time.Time) time.Time {
+	local := t.Local()
+
+	return time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), local.Nanosecond(), time.UTC)
+}
+
+func TestBorrowStoredTimestamps(t *testing.T) {
+	// Server time zones east and west of UTC read back the instant that was stored.
+	for _, location := range []*time.Location{time.FixedZone("WIB", 7*60*60), time.FixedZone("EST", -5*60*60)} {
+		local := time.Local
+		time.Local = location
+
+		borrowedAt := time.Date(2026, time.December, 24, 23, 30, 0, 0, location)
+		dueDate := borrowedAt.AddDate(0, 0, 14)
+		borrowID := util.NewID()
+
+		rows := sqlmock.NewRows([]string{"id", "borrowed_at", "due_date", "returned_at"}).
+			AddRow(borrowID, storedTime(borrowedAt), storedTime(dueDate), nil)
+
+		Mock.ExpectQuery("SELECT (.+) FROM borrows WHERE id=?").
+			WithArgs(borrowID).
+			WillReturnRows(rows)
+
+		borrow, err := BorrowTestingRepository.Get(borrowID)
+		time.Local = local
+
+		require.Nil(t, err)
+		require.True(t, borrowedAt.Equal(borrow.BorrowedAt))
+		require.True(t, dueDate.Equal(borrow.DueDate))
+		require.Nil(t, borrow.ReturnedAt)
+	}
+}
+
+func TestBorrowSaveRenewal(t *testing.T) {
+	renewal := borrowing.NewRenewal(util.NewID(), util.NewID(), util.NewID(), time.Now(), time.Now().AddDate(0, 0, 7), time.Now())
+
+	result := sqlmock.NewResult(1, 1)
+
+	Mock.ExpectExec("INSERT INTO renewals").
+		WithArgs(renewal.ID, renewal.BorrowID, renewal.UserID, renewal.PreviousDueDate, renewal.NewDueDate, renewal.RenewedAt).
+		WillReturnResult(result)
+
+	newRenewal, err := BorrowTestingRepository.SaveRenewal(renewal)
+
+	require.Nil(t, err)
+	require.Equal(t, renewal.ID, newRenewal.ID)
+}
+
+func TestBorrowGetRenewals(t *testing.T) {
+	borrowID := util.NewID()
+	renewalID := util.NewID()
+
+	rows := sqlmock.NewRows([]string{"id", "borrow_id"}).
+		AddRow(renewalID, borrowID)
+
+	Mock.ExpectQuery("SELECT (.+) FROM renewals WHERE borrow_id=?").
+		WithArgs(borrowID).
+		WillReturnRows(rows)
+
+	renewals, err := BorrowTestingRepository.GetRenewals(borrowID)
+
+	require.Nil(t, err)
+	require.Len(t, renewals, 1)
+	require.Equal(t, renewalID, renewals[0].ID)
+}
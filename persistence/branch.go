@@ -0,0 +1,133 @@
+package persistence
+
+import (
+	"github.com/jmoiron/sqlx"
+
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+)
+
+type branchRepository struct {
+	DB *sqlx.DB
+}
+
+// NewBranchRepository returns initialized implementations of the repository for
+// Branch domain model.
+func NewBranchRepository(DB *sqlx.DB) branch.Repository {
+	return &branchRepository{
+		DB: DB,
+	}
+}
+
+func (repo *branchRepository) SaveBranch(branch *branch.Branch) (*branch.Branch, error) {
+	_, err := repo.DB.NamedExec("INSERT INTO branches (id, code, name, address, added_at) VALUES (:id, :code, :name, :address, :added_at)", branch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return branch, nil
+}
+
+func (repo *branchRepository) GetBranch(branchID string) (*branch.Branch, error) {
+	branch := branch.Branch{}
+
+	err := repo.DB.QueryRowx("SELECT * FROM branches WHERE id=$1", branchID).StructScan(&branch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &branch, nil
+}
+
+func (repo *branchRepository) UpdateBranch(branch *branch.Branch) (*branch.Branch, error) {
+	_, err := repo.DB.NamedExec("UPDATE branches SET code=:code, name=:name, address=:address WHERE id=:id", branch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	updatedBranch, err := repo.GetBranch(branch.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedBranch, nil
+}
+
+func (repo *branchRepository) DeleteBranch(branchID string) error {
+	_, err := repo.DB.Exec("DELETE FROM branches WHERE id=$1", branchID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *branchRepository) GetBranches() ([]*branch.Branch, error) {
+	branches := []*branch.Branch{}
+
+	err := repo.DB.Select(&branches, "SELECT * FROM branches ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+
+	return branches, nil
+}
+
+func (repo *branchRepository) SaveLocation(location *branch.Location) (*branch.Location, error) {
+	_, err := repo.DB.NamedExec("INSERT INTO locations (id, branch_id, name, floor, room, shelf_from, shelf_to, added_at) VALUES (:id, :branch_id, :name, :floor, :room, :shelf_from, :shelf_to, :added_at)", location)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return location, nil
+}
+
+func (repo *branchRepository) GetLocation(locationID string) (*branch.Location, error) {
+	location := branch.Location{}
+
+	err := repo.DB.QueryRowx("SELECT * FROM locations WHERE id=$1", locationID).StructScan(&location)
+	if err != nil {
+		return nil, err
+	}
+
+	return &location, nil
+}
+
+func (repo *branchRepository) UpdateLocation(location *branch.Location) (*branch.Location, error) {
+	_, err := repo.DB.NamedExec("UPDATE locations SET name=:name, floor=:floor, room=:room, shelf_from=:shelf_from, shelf_to=:shelf_to WHERE id=:id", location)
+
+	if err != nil {
+		return nil, err
+	}
+
+	updatedLocation, err := repo.GetLocation(location.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedLocation, nil
+}
+
+func (repo *branchRepository) DeleteLocation(locationID string) error {
+	_, err := repo.DB.Exec("DELETE FROM locations WHERE id=$1", locationID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *branchRepository) GetLocations(branchID string) ([]*branch.Location, error) {
+	locations := []*branch.Location{}
+
+	err := repo.DB.Select(&locations, "SELECT * FROM locations WHERE branch_id=$1 ORDER BY floor, room, shelf_from", branchID)
+	if err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
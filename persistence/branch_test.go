@@ -0,0 +1,45 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+)
+
+func TestBranchSave(t *testing.T) {
+	validBranch := branch.NewBranch(util.NewID(), "MAIN", "Main Library", "1 Campus Road", time.Now())
+
+	Mock.ExpectExec("INSERT INTO branches").
+		WithArgs(validBranch.ID, validBranch.Code, validBranch.Name, validBranch.Address, validBranch.AddedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	newBranch, err := BranchTestingRepository.SaveBranch(validBranch)
+	require.Nil(t, err)
+	require.Equal(t, validBranch.ID, newBranch.ID)
+
+	// Branch with a duplicate code.
+	_, err = BranchTestingRepository.SaveBranch(validBranch)
+	require.NotNil(t, err)
+}
+
+func TestBranchGetLocations(t *testing.T) {
+	branchID := util.NewID()
+
+	rows := sqlmock.NewRows([]string{"id", "branch_id", "name", "floor", "shelf_from", "shelf_to"}).
+		AddRow(util.NewID(), branchID, "Reference", "1", "A", "AZ").
+		AddRow(util.NewID(), branchID, "Science stacks", "2", "Q", "QZ")
+
+	Mock.ExpectQuery("SELECT (.+) FROM locations WHERE branch_id=(.+) ORDER BY floor, room, shelf_from").
+		WithArgs(branchID).
+		WillReturnRows(rows)
+
+	locations, err := BranchTestingRepository.GetLocations(branchID)
+	require.Nil(t, err)
+	require.Len(t, locations, 2)
+	require.Equal(t, "Science stacks", locations[1].Name)
+}
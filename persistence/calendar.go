@@ -0,0 +1,82 @@
+package persistence
+
+import (
+	"github.com/jmoiron/sqlx"
+
+	"github.com/joshuabezaleel/library-server/pkg/calendar"
+)
+
+type calendarRepository struct {
+	DB *sqlx.DB
+}
+
+// NewCalendarRepository returns initialized implementations of the repository for
+// Calendar domain model.
+func NewCalendarRepository(DB *sqlx.DB) calendar.Repository {
+	return &calendarRepository{
+		DB: DB,
+	}
+}
+
+func (repo *calendarRepository) SaveOpeningHours(openingHours *calendar.OpeningHours) (*calendar.OpeningHours, error) {
+	_, err := repo.DB.NamedExec("INSERT INTO opening_hours (weekday, opens, closes, closed) VALUES (:weekday, :opens, :closes, :closed) ON CONFLICT (weekday) DO UPDATE SET opens=:opens, closes=:closes, closed=:closed", openingHours)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return openingHours, nil
+}
+
+func (repo *calendarRepository) GetOpeningHours() ([]*calendar.OpeningHours, error) {
+	hours := []*calendar.OpeningHours{}
+
+	err := repo.DB.Select(&hours, "SELECT * FROM opening_hours ORDER BY weekday")
+	if err != nil {
+		return nil, err
+	}
+
+	return hours, nil
+}
+
+func (repo *calendarRepository) SaveClosure(closure *calendar.Closure) (*calendar.Closure, error) {
+	_, err := repo.DB.NamedExec("INSERT INTO closures (id, kind, reason, start_date, end_date, created_at) VALUES (:id, :kind, :reason, :start_date, :end_date, :created_at)", closure)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return closure, nil
+}
+
+func (repo *calendarRepository) GetClosure(closureID string) (*calendar.Closure, error) {
+	closure := calendar.Closure{}
+
+	err := repo.DB.QueryRowx("SELECT * FROM closures WHERE id=$1", closureID).StructScan(&closure)
+	if err != nil {
+		return nil, err
+	}
+
+	return &closure, nil
+}
+
+func (repo *calendarRepository) DeleteClosure(closureID string) error {
+	_, err := repo.DB.Exec("DELETE FROM closures WHERE id=$1", closureID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *calendarRepository) GetClosures(from string, to string) ([]*calendar.Closure, error) {
+	closures := []*calendar.Closure{}
+
+	err := repo.DB.Select(&closures, "SELECT * FROM closures WHERE start_date<=$2::date AND end_date>=$1::date ORDER BY start_date", from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return closures, nil
+}
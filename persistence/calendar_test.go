@@ -0,0 +1,89 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/calendar"
+)
+
+func TestCalendarSaveOpeningHours(t *testing.T) {
+	openingHours := calendar.NewOpeningHours(time.Monday, "09:00", "17:00", false)
+
+	result := sqlmock.NewResult(1, 1)
+
+	Mock.ExpectExec("INSERT INTO opening_hours (.+) ON CONFLICT").
+		WithArgs(openingHours.Weekday, openingHours.Opens, openingHours.Closes, openingHours.Closed, openingHours.Opens, openingHours.Closes, openingHours.Closed).
+		WillReturnResult(result)
+
+	savedOpeningHours, err := CalendarTestingRepository.SaveOpeningHours(openingHours)
+
+	require.Nil(t, err)
+	require.Equal(t, openingHours, savedOpeningHours)
+}
+
+func TestCalendarSaveClosure(t *testing.T) {
+	tt := []struct {
+		name    string
+		closure *calendar.Closure
+		err     bool
+	}{
+		{
+			name:    "save a valid closure",
+			closure: calendar.NewClosure(util.NewID(), calendar.ClosureHoliday, "New Year", time.Now(), time.Now(), time.Now()),
+			err:     false,
+		},
+		{
+			name:    "save an invalid closure",
+			closure: calendar.NewClosure(util.NewID(), calendar.ClosureHoliday, "New Year", time.Now(), time.Now(), time.Now()),
+			err:     true,
+		},
+	}
+
+	// Assert a save for a valid Closure.
+	validClosure := tt[0].closure
+
+	result := sqlmock.NewResult(1, 1)
+
+	Mock.ExpectExec("INSERT INTO closures").
+		WithArgs(validClosure.ID, validClosure.Kind, validClosure.Reason, validClosure.StartDate, validClosure.EndDate, validClosure.CreatedAt).
+		WillReturnResult(result)
+
+	// Tests.
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			newClosure, err := CalendarTestingRepository.SaveClosure(tc.closure)
+
+			if tc.err {
+				require.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.closure.ID, newClosure.ID)
+		})
+	}
+}
+
+func TestCalendarGetClosures(t *testing.T) {
+	from := "2026-12-01"
+	to := "2026-12-31"
+	closureID := util.NewID()
+
+	rows := sqlmock.NewRows([]string{"id", "kind"}).
+		AddRow(closureID, calendar.ClosureAdHoc)
+
+	Mock.ExpectQuery("SELECT (.+) FROM closures WHERE (.+) ORDER BY start_date").
+		WithArgs(from, to).
+		WillReturnRows(rows)
+
+	closures, err := CalendarTestingRepository.GetClosures(from, to)
+
+	require.Nil(t, err)
+	require.Len(t, closures, 1)
+	require.Equal(t, closureID, closures[0].ID)
+}
@@ -0,0 +1,91 @@
+package persistence
+
+import (
+	"github.com/jmoiron/sqlx"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/fine"
+)
+
+// fineBalanceQuery sums the fines ledger of a User into the balance the User owes.
+const fineBalanceQuery = "SELECT COALESCE(SUM(CASE WHEN type IN ('charge', 'refund') THEN amount ELSE -amount END), 0) FROM fines WHERE user_id=$1"
+
+type fineRepository struct {
+	DB executor
+}
+
+// NewFineRepository returns initialized implementations of the repository for
+// the fines ledger.
+func NewFineRepository(DB *sqlx.DB) fine.Repository {
+	return &fineRepository{
+		DB: DB,
+	}
+}
+
+// Begin begins the Transaction an entry of the ledger is recorded in.
+func (repo *fineRepository) Begin() (util.Transaction, error) {
+	return begin(repo.DB)
+}
+
+// WithTransaction returns the repository running its queries in the Transaction.
+func (repo *fineRepository) WithTransaction(tx util.Transaction) fine.Repository {
+	return &fineRepository{
+		DB: inTransaction(tx),
+	}
+}
+
+func (repo *fineRepository) Save(transaction *fine.Transaction) (*fine.Transaction, error) {
+	_, err := repo.DB.NamedExec("INSERT INTO fines (id, user_id, borrow_id, payment_id, type, amount, balance_after, note, recorded_by, created_at) VALUES (:id, :user_id, :borrow_id, :payment_id, :type, :amount, :balance_after, :note, :recorded_by, :created_at)", transaction)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+func (repo *fineRepository) Get(transactionID string) (*fine.Transaction, error) {
+	transaction := fine.Transaction{}
+
+	err := repo.DB.QueryRowx("SELECT * FROM fines WHERE id=$1", transactionID).StructScan(&transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transaction, nil
+}
+
+func (repo *fineRepository) GetByUserID(userID string) ([]*fine.Transaction, error) {
+	transactions := []*fine.Transaction{}
+
+	err := repo.DB.Select(&transactions, "SELECT * FROM fines WHERE user_id=$1 ORDER BY created_at", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (repo *fineRepository) GetBalance(userID string) (uint32, error) {
+	var balance uint32
+
+	err := repo.DB.QueryRow(fineBalanceQuery, userID).Scan(&balance)
+	if err != nil {
+		return 0, err
+	}
+
+	return balance, nil
+}
+
+// GetBalanceForUpdate locks the row of the User before summing the ledger, so that
+// the ledger of the User is not written by anyone else until the Transaction ends.
+func (repo *fineRepository) GetBalanceForUpdate(userID string) (uint32, error) {
+	var lockedUserID string
+
+	err := repo.DB.QueryRow("SELECT id FROM users WHERE id=$1 FOR UPDATE", userID).Scan(&lockedUserID)
+	if err != nil {
+		return 0, err
+	}
+
+	return repo.GetBalance(userID)
+}
@@ -0,0 +1,129 @@
+package persistence
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/borrowing"
+	"github.com/joshuabezaleel/library-server/pkg/fine"
+)
+
+func TestFineSave(t *testing.T) {
+	tt := []struct {
+		name        string
+		transaction *fine.Transaction
+		err         bool
+	}{
+		{
+			name:        "save a valid transaction",
+			transaction: fine.NewTransaction(util.NewID(), util.NewID(), util.NewID(), "", fine.TransactionCharge, 2000, 2000, "", "", time.Now()),
+			err:         false,
+		},
+		{
+			name:        "save an invalid transaction",
+			transaction: fine.NewTransaction(util.NewID(), util.NewID(), util.NewID(), "", fine.TransactionCharge, 2000, 2000, "", "", time.Now()),
+			err:         true,
+		},
+	}
+
+	// Assert a save for a valid Transaction.
+	validTransaction := tt[0].transaction
+
+	result := sqlmock.NewResult(1, 1)
+
+	Mock.ExpectExec("INSERT INTO fines").
+		WithArgs(validTransaction.ID, validTransaction.UserID, validTransaction.BorrowID, validTransaction.PaymentID, validTransaction.Type, validTransaction.Amount, validTransaction.BalanceAfter, validTransaction.Note, validTransaction.RecordedBy, validTransaction.CreatedAt).
+		WillReturnResult(result)
+
+	// Tests.
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			newTransaction, err := FineTestingRepository.Save(tc.transaction)
+
+			if tc.err {
+				require.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.transaction.ID, newTransaction.ID)
+		})
+	}
+}
+
+func TestFineGetByUserID(t *testing.T) {
+	userID := util.NewID()
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "type", "amount"}).
+		AddRow(util.NewID(), userID, fine.TransactionCharge, 4000).
+		AddRow(util.NewID(), userID, fine.TransactionPayment, 1000)
+
+	Mock.ExpectQuery("SELECT (.+) FROM fines WHERE user_id=(.+) ORDER BY created_at").
+		WithArgs(userID).
+		WillReturnRows(rows)
+
+	transactions, err := FineTestingRepository.GetByUserID(userID)
+
+	require.Nil(t, err)
+	require.Len(t, transactions, 2)
+	require.Equal(t, fine.TransactionPayment, transactions[1].Type)
+}
+
+func TestFineGetBalance(t *testing.T) {
+	userID := util.NewID()
+
+	rows := sqlmock.NewRows([]string{"balance"}).
+		AddRow(3000)
+
+	Mock.ExpectQuery(regexp.QuoteMeta(fineBalanceQuery)).
+		WithArgs(userID).
+		WillReturnRows(rows)
+
+	balance, err := FineTestingRepository.GetBalance(userID)
+
+	require.Nil(t, err)
+	require.Equal(t, uint32(3000), balance)
+}
+
+func TestFineGetBalanceForUpdate(t *testing.T) {
+	userID := util.NewID()
+	borrow := &borrowing.Borrow{ID: util.NewID(), UserID: userID}
+
+	// The row of the User stays locked until the charge and the closed Borrow are committed.
+	Mock.ExpectBegin()
+	Mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM users WHERE id=$1 FOR UPDATE")).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
+	Mock.ExpectQuery(regexp.QuoteMeta(fineBalanceQuery)).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(3000))
+	Mock.ExpectExec("UPDATE borrows SET").WillReturnResult(sqlmock.NewResult(1, 1))
+	Mock.ExpectQuery("SELECT (.+) FROM borrows WHERE id=?").
+		WithArgs(borrow.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(borrow.ID, borrow.UserID))
+	Mock.ExpectCommit()
+
+	tx, err := BorrowTestingRepository.Begin()
+	require.Nil(t, err)
+
+	balance, err := FineTestingRepository.WithTransaction(tx).GetBalanceForUpdate(userID)
+	require.Nil(t, err)
+	require.Equal(t, uint32(3000), balance)
+
+	_, err = BorrowTestingRepository.WithTransaction(tx).Return(borrow)
+	require.Nil(t, err)
+
+	err = tx.Commit()
+	require.Nil(t, err)
+
+	require.Nil(t, Mock.ExpectationsWereMet())
+
+	// A repository already working in a Transaction does not begin another one.
+	_, err = FineTestingRepository.WithTransaction(tx).Begin()
+	require.Equal(t, errNestedTransaction, err)
+}
@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"github.com/joshuabezaleel/library-server/pkg/borrowing"
+)
+
+func (repo *borrowRepository) SaveHold(hold *borrowing.Hold) (*borrowing.Hold, error) {
+	_, err := repo.DB.NamedExec("INSERT INTO holds (id, user_id, book_id, bookcopy_id, status, placed_at, ready_at, pickup_by) VALUES (:id, :user_id, :book_id, :bookcopy_id, :status, :placed_at, :ready_at, :pickup_by)", hold)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+func (repo *borrowRepository) GetHold(holdID string) (*borrowing.Hold, error) {
+	hold := borrowing.Hold{}
+
+	err := repo.DB.QueryRowx("SELECT * FROM holds WHERE id=$1", holdID).StructScan(&hold)
+	if err != nil {
+		return nil, err
+	}
+
+	localHold(&hold)
+
+	return &hold, nil
+}
+
+func (repo *borrowRepository) UpdateHold(hold *borrowing.Hold) (*borrowing.Hold, error) {
+	_, err := repo.DB.NamedExec("UPDATE holds SET bookcopy_id=:bookcopy_id, status=:status, ready_at=:ready_at, pickup_by=:pickup_by WHERE id=:id", hold)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedHold, err := repo.GetHold(hold.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedHold, nil
+}
+
+func (repo *borrowRepository) GetActiveHolds(bookID string) ([]*borrowing.Hold, error) {
+	holds := []*borrowing.Hold{}
+
+	err := repo.DB.Select(&holds, "SELECT * FROM holds WHERE book_id=$1 AND status IN ($2, $3) ORDER BY placed_at, id", bookID, borrowing.HoldWaiting, borrowing.HoldReady)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hold := range holds {
+		localHold(hold)
+	}
+
+	return holds, nil
+}
@@ -0,0 +1,143 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/borrowing"
+)
+
+func TestHoldSave(t *testing.T) {
+	tt := []struct {
+		name string
+		hold *borrowing.Hold
+		err  bool
+	}{
+		{
+			name: "save a valid hold",
+			hold: &borrowing.Hold{
+				ID:     util.NewID(),
+				UserID: util.NewID(),
+				BookID: util.NewID(),
+				Status: borrowing.HoldWaiting,
+			},
+			err: false,
+		},
+		{
+			name: "save an invalid hold",
+			hold: &borrowing.Hold{
+				ID:     util.NewID(),
+				UserID: util.NewID(),
+				BookID: util.NewID(),
+				Status: borrowing.HoldWaiting,
+			},
+			err: true,
+		},
+	}
+
+	// Assert a save for a valid Hold.
+	validHold := tt[0].hold
+
+	result := sqlmock.NewResult(1, 1)
+
+	Mock.ExpectExec("INSERT INTO holds").
+		WithArgs(validHold.ID, validHold.UserID, validHold.BookID, validHold.BookCopyID, validHold.Status, validHold.PlacedAt, validHold.ReadyAt, validHold.PickupBy).
+		WillReturnResult(result)
+
+	// Tests.
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			newHold, err := BorrowTestingRepository.SaveHold(tc.hold)
+
+			if tc.err {
+				require.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.hold.ID, newHold.ID)
+		})
+	}
+}
+
+func TestHoldUpdate(t *testing.T) {
+	tt := []struct {
+		name string
+		hold *borrowing.Hold
+		err  bool
+	}{
+		{
+			name: "update a valid hold",
+			hold: &borrowing.Hold{
+				ID:         util.NewID(),
+				BookCopyID: util.NewID(),
+				Status:     borrowing.HoldReady,
+			},
+			err: false,
+		},
+		{
+			name: "update an invalid hold",
+			hold: &borrowing.Hold{
+				ID:     util.NewID(),
+				Status: borrowing.HoldCancelled,
+			},
+			err: true,
+		},
+	}
+
+	// Assert an update for a valid Hold.
+	validHold := tt[0].hold
+
+	result := sqlmock.NewResult(1, 1)
+
+	Mock.ExpectExec("UPDATE holds SET").
+		WithArgs(validHold.BookCopyID, validHold.Status, validHold.ReadyAt, validHold.PickupBy, validHold.ID).
+		WillReturnResult(result)
+
+	rows := sqlmock.NewRows([]string{"id", "bookcopy_id", "status"}).
+		AddRow(validHold.ID, validHold.BookCopyID, validHold.Status)
+
+	Mock.ExpectQuery("SELECT (.+) FROM holds WHERE id=?").
+		WithArgs(validHold.ID).
+		WillReturnRows(rows)
+
+	// Tests.
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			updatedHold, err := BorrowTestingRepository.UpdateHold(tc.hold)
+
+			if tc.err {
+				require.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.hold.ID, updatedHold.ID)
+			require.Equal(t, tc.hold.Status, updatedHold.Status)
+		})
+	}
+}
+
+func TestHoldGetActiveHolds(t *testing.T) {
+	bookID := util.NewID()
+	firstHoldID := util.NewID()
+	secondHoldID := util.NewID()
+
+	rows := sqlmock.NewRows([]string{"id", "book_id", "status"}).
+		AddRow(firstHoldID, bookID, borrowing.HoldReady).
+		AddRow(secondHoldID, bookID, borrowing.HoldWaiting)
+
+	Mock.ExpectQuery("SELECT (.+) FROM holds WHERE book_id=(.+) ORDER BY placed_at").
+		WithArgs(bookID, borrowing.HoldWaiting, borrowing.HoldReady).
+		WillReturnRows(rows)
+
+	holds, err := BorrowTestingRepository.GetActiveHolds(bookID)
+
+	require.Nil(t, err)
+	require.Len(t, holds, 2)
+	require.Equal(t, firstHoldID, holds[0].ID)
+	require.Equal(t, secondHoldID, holds[1].ID)
+}
@@ -0,0 +1,76 @@
+package persistence
+
+import (
+	"github.com/jmoiron/sqlx"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/importing"
+)
+
+type importingRepository struct {
+	DB *sqlx.DB
+}
+
+// NewImportingRepository returns initialized implementations of the repository for
+// import Job domain model.
+func NewImportingRepository(DB *sqlx.DB) importing.Repository {
+	return &importingRepository{
+		DB: DB,
+	}
+}
+
+// Save saves the Job along with all of its Rows, all at once or not at all.
+func (repo *importingRepository) Save(job *importing.Job) (*importing.Job, error) {
+	tx, err := repo.DB.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.NamedExec("INSERT INTO import_jobs (id, mode, dry_run, status, total_rows, imported_rows, failed_rows, created_by, created_at) VALUES (:id, :mode, :dry_run, :status, :total_rows, :imported_rows, :failed_rows, :created_by, :created_at)", job)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	for _, row := range job.Rows {
+		_, err = tx.NamedExec("INSERT INTO import_rows (job_id, number, title, isbn, call_number, status, book_id, copies_added, errors) VALUES (:job_id, :number, :title, :isbn, :call_number, :status, :book_id, :copies_added, :errors)", row)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Begin begins the Transaction the Books and Copies of an import are created in.
+func (repo *importingRepository) Begin() (util.Transaction, error) {
+	return begin(repo.DB)
+}
+
+func (repo *importingRepository) Get(jobID string) (*importing.Job, error) {
+	job := importing.Job{}
+
+	err := repo.DB.QueryRowx("SELECT * FROM import_jobs WHERE id=$1", jobID).StructScan(&job)
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (repo *importingRepository) GetRows(jobID string) ([]*importing.Row, error) {
+	rows := []*importing.Row{}
+
+	err := repo.DB.Select(&rows, "SELECT * FROM import_rows WHERE job_id=$1 ORDER BY number", jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,89 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+	"github.com/joshuabezaleel/library-server/pkg/importing"
+)
+
+func TestImportingSave(t *testing.T) {
+	job := importing.NewJob(util.NewID(), importing.ModeBestEffort, false, importing.JobPartiallyCommitted, 2, 1, 1, "librarian", time.Now())
+	job.Rows = []*importing.Row{
+		{JobID: job.ID, Number: 1, Title: "The Go Programming Language", Status: importing.RowImported, BookID: util.NewID(), CopiesAdded: 2},
+		{JobID: job.ID, Number: 2, Status: importing.RowInvalid, Errors: "Title is required"},
+	}
+
+	Mock.ExpectBegin()
+	Mock.ExpectExec("INSERT INTO import_jobs").
+		WithArgs(job.ID, job.Mode, job.DryRun, job.Status, job.TotalRows, job.ImportedRows, job.FailedRows, job.CreatedBy, job.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	for _, row := range job.Rows {
+		Mock.ExpectExec("INSERT INTO import_rows").
+			WithArgs(row.JobID, row.Number, row.Title, row.ISBN, row.CallNumber, row.Status, row.BookID, row.CopiesAdded, row.Errors).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	Mock.ExpectCommit()
+
+	savedJob, err := ImportingTestingRepository.Save(job)
+	require.Nil(t, err)
+	require.Equal(t, job.ID, savedJob.ID)
+
+	// A Row failing to be saved leaves out the Job as well.
+	Mock.ExpectBegin()
+	Mock.ExpectExec("INSERT INTO import_jobs").WillReturnResult(sqlmock.NewResult(1, 1))
+	Mock.ExpectExec("INSERT INTO import_rows").WillReturnError(errors.New("duplicate key"))
+	Mock.ExpectRollback()
+
+	_, err = ImportingTestingRepository.Save(job)
+	require.NotNil(t, err)
+}
+
+func TestImportingGetRows(t *testing.T) {
+	jobID := util.NewID()
+
+	rows := sqlmock.NewRows([]string{"job_id", "number", "title", "status", "errors"}).
+		AddRow(jobID, 1, "The Go Programming Language", importing.RowValid, "").
+		AddRow(jobID, 2, "", importing.RowInvalid, "Title is required")
+
+	Mock.ExpectQuery("SELECT (.+) FROM import_rows WHERE job_id=(.+) ORDER BY number").
+		WithArgs(jobID).
+		WillReturnRows(rows)
+
+	importRows, err := ImportingTestingRepository.GetRows(jobID)
+	require.Nil(t, err)
+	require.Len(t, importRows, 2)
+	require.Equal(t, "Title is required", importRows[1].Errors)
+}
+
+func TestImportingBegin(t *testing.T) {
+	importedBook := &book.Book{ID: util.NewID(), Title: "The Go Programming Language"}
+	importedBookCopy := &bookcopy.BookCopy{ID: util.NewID(), BookID: importedBook.ID}
+
+	// The Book and its Copy are written in the transaction, and rolled back along with it.
+	Mock.ExpectBegin()
+	Mock.ExpectExec("INSERT INTO books").WillReturnResult(sqlmock.NewResult(1, 1))
+	Mock.ExpectExec("INSERT INTO bookcopies").WillReturnResult(sqlmock.NewResult(1, 1))
+	Mock.ExpectRollback()
+
+	tx, err := ImportingTestingRepository.Begin()
+	require.Nil(t, err)
+
+	_, err = BookTestingRepository.WithTransaction(tx).Save(importedBook)
+	require.Nil(t, err)
+
+	_, err = BookCopyTestingRepository.WithTransaction(tx).Save(importedBookCopy)
+	require.Nil(t, err)
+
+	err = tx.Rollback()
+	require.Nil(t, err)
+
+	require.Nil(t, Mock.ExpectationsWereMet())
+}
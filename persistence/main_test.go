@@ -7,9 +7,18 @@ import (
 
 	"github.com/joshuabezaleel/library-server/pkg/auth"
 	"github.com/joshuabezaleel/library-server/pkg/borrowing"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+	"github.com/joshuabezaleel/library-server/pkg/calendar"
+	"github.com/joshuabezaleel/library-server/pkg/core/author"
 	"github.com/joshuabezaleel/library-server/pkg/core/book"
 	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+	"github.com/joshuabezaleel/library-server/pkg/core/subject"
 	"github.com/joshuabezaleel/library-server/pkg/core/user"
+	"github.com/joshuabezaleel/library-server/pkg/fine"
+	"github.com/joshuabezaleel/library-server/pkg/importing"
+	"github.com/joshuabezaleel/library-server/pkg/policy"
+	"github.com/joshuabezaleel/library-server/pkg/stocktake"
+	"github.com/joshuabezaleel/library-server/pkg/transfer"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
@@ -20,13 +29,22 @@ import (
 // )
 
 var (
-	DB                        *sqlx.DB
-	Mock                      sqlmock.Sqlmock
-	AuthTestingRepository     auth.Repository
-	BookTestingRepository     book.Repository
-	BookCopyTestingRepository bookcopy.Repository
-	BorrowTestingRepository   borrowing.Repository
-	UserTestingRepository     user.Repository
+	DB                         *sqlx.DB
+	Mock                       sqlmock.Sqlmock
+	AuthTestingRepository      auth.Repository
+	BookTestingRepository      book.Repository
+	BookCopyTestingRepository  bookcopy.Repository
+	BorrowTestingRepository    borrowing.Repository
+	UserTestingRepository      user.Repository
+	PolicyTestingRepository    policy.Repository
+	CalendarTestingRepository  calendar.Repository
+	FineTestingRepository      fine.Repository
+	BranchTestingRepository    branch.Repository
+	TransferTestingRepository  transfer.Repository
+	StocktakeTestingRepository stocktake.Repository
+	AuthorTestingRepository    author.Repository
+	SubjectTestingRepository   subject.Repository
+	ImportingTestingRepository importing.Repository
 )
 
 // var repository *Repository
@@ -47,6 +65,15 @@ func TestMain(m *testing.M) {
 	BookCopyTestingRepository = NewBookCopyRepository(DB)
 	BorrowTestingRepository = NewBorrowRepository(DB)
 	UserTestingRepository = NewUserRepository(DB)
+	PolicyTestingRepository = NewPolicyRepository(DB)
+	CalendarTestingRepository = NewCalendarRepository(DB)
+	FineTestingRepository = NewFineRepository(DB)
+	BranchTestingRepository = NewBranchRepository(DB)
+	TransferTestingRepository = NewTransferRepository(DB)
+	StocktakeTestingRepository = NewStocktakeRepository(DB)
+	AuthorTestingRepository = NewAuthorRepository(DB)
+	SubjectTestingRepository = NewSubjectRepository(DB)
+	ImportingTestingRepository = NewImportingRepository(DB)
 
 	code := m.Run()
 
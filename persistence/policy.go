@@ -0,0 +1,76 @@
+package persistence
+
+import (
+	"github.com/jmoiron/sqlx"
+
+	"github.com/joshuabezaleel/library-server/pkg/policy"
+)
+
+type policyRepository struct {
+	DB *sqlx.DB
+}
+
+// NewPolicyRepository returns initialized implementations of the repository for
+// Policy domain model.
+func NewPolicyRepository(DB *sqlx.DB) policy.Repository {
+	return &policyRepository{
+		DB: DB,
+	}
+}
+
+func (repo *policyRepository) Save(policy *policy.Policy) (*policy.Policy, error) {
+	_, err := repo.DB.NamedExec("INSERT INTO policies (id, role, category, loan_period_days, max_loans, max_renewals, fine_per_day, fine_cap, grace_period_days, max_fine, skip_closed_days, created_at) VALUES (:id, :role, :category, :loan_period_days, :max_loans, :max_renewals, :fine_per_day, :fine_cap, :grace_period_days, :max_fine, :skip_closed_days, :created_at)", policy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (repo *policyRepository) Get(policyID string) (*policy.Policy, error) {
+	policy := policy.Policy{}
+
+	err := repo.DB.QueryRowx("SELECT * FROM policies WHERE id=$1", policyID).StructScan(&policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (repo *policyRepository) Update(policy *policy.Policy) (*policy.Policy, error) {
+	_, err := repo.DB.NamedExec("UPDATE policies SET role=:role, category=:category, loan_period_days=:loan_period_days, max_loans=:max_loans, max_renewals=:max_renewals, fine_per_day=:fine_per_day, fine_cap=:fine_cap, grace_period_days=:grace_period_days, max_fine=:max_fine, skip_closed_days=:skip_closed_days WHERE id=:id", policy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	updatedPolicy, err := repo.Get(policy.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedPolicy, nil
+}
+
+func (repo *policyRepository) Delete(policyID string) error {
+	_, err := repo.DB.Exec("DELETE FROM policies WHERE id=$1", policyID)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *policyRepository) GetAll() ([]*policy.Policy, error) {
+	policies := []*policy.Policy{}
+
+	err := repo.DB.Select(&policies, "SELECT * FROM policies ORDER BY role, category")
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
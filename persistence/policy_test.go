@@ -0,0 +1,72 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/policy"
+)
+
+func TestPolicySave(t *testing.T) {
+	tt := []struct {
+		name   string
+		policy *policy.Policy
+		err    bool
+	}{
+		{
+			name:   "save a valid policy",
+			policy: policy.NewPolicy(util.NewID(), "student", "general", 7, 5, 2, 2000, 0, 3, 0, false, time.Now()),
+			err:    false,
+		},
+		{
+			name:   "save an invalid policy",
+			policy: policy.NewPolicy(util.NewID(), "student", "general", 7, 5, 2, 2000, 0, 3, 0, false, time.Now()),
+			err:    true,
+		},
+	}
+
+	// Assert a save for a valid Policy.
+	validPolicy := tt[0].policy
+
+	result := sqlmock.NewResult(1, 1)
+
+	Mock.ExpectExec("INSERT INTO policies").
+		WithArgs(validPolicy.ID, validPolicy.Role, validPolicy.Category, validPolicy.LoanPeriodDays, validPolicy.MaxLoans, validPolicy.MaxRenewals, validPolicy.FinePerDay, validPolicy.FineCap, validPolicy.GracePeriodDays, validPolicy.MaxFine, validPolicy.SkipClosedDays, validPolicy.CreatedAt).
+		WillReturnResult(result)
+
+	// Tests.
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			newPolicy, err := PolicyTestingRepository.Save(tc.policy)
+
+			if tc.err {
+				require.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.policy.ID, newPolicy.ID)
+		})
+	}
+}
+
+func TestPolicyGetAll(t *testing.T) {
+	studentPolicy := policy.NewPolicy(util.NewID(), "student", "general", 7, 5, 2, 2000, 0, 3, 0, false, time.Time{})
+
+	rows := sqlmock.NewRows([]string{"id", "role", "category", "loan_period_days"}).
+		AddRow(studentPolicy.ID, studentPolicy.Role, studentPolicy.Category, studentPolicy.LoanPeriodDays)
+
+	Mock.ExpectQuery("SELECT (.+) FROM policies ORDER BY role, category").
+		WillReturnRows(rows)
+
+	policies, err := PolicyTestingRepository.GetAll()
+
+	require.Nil(t, err)
+	require.Len(t, policies, 1)
+	require.Equal(t, studentPolicy.ID, policies[0].ID)
+	require.Equal(t, studentPolicy.LoanPeriodDays, policies[0].LoanPeriodDays)
+}
@@ -14,15 +14,25 @@ import (
 
 	"github.com/joshuabezaleel/library-server/pkg/auth"
 	"github.com/joshuabezaleel/library-server/pkg/borrowing"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+	"github.com/joshuabezaleel/library-server/pkg/calendar"
+	"github.com/joshuabezaleel/library-server/pkg/core/author"
 	"github.com/joshuabezaleel/library-server/pkg/core/book"
 	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+	"github.com/joshuabezaleel/library-server/pkg/core/subject"
 	"github.com/joshuabezaleel/library-server/pkg/core/user"
+	"github.com/joshuabezaleel/library-server/pkg/fine"
+	"github.com/joshuabezaleel/library-server/pkg/importing"
+	"github.com/joshuabezaleel/library-server/pkg/policy"
+	"github.com/joshuabezaleel/library-server/pkg/stocktake"
+	"github.com/joshuabezaleel/library-server/pkg/transfer"
 )
 
-var tableCreationQueries = []string{bookTable, bookCopyTable, borrowTable, userTable}
+var tableCreationQueries = []string{trigramExtension, bookTable, bookSearchIndex, bookTitleTrigramIndex, subjectTable, subjectVocabularyMigration, bookSubjectTable, subjectRelationTable, authorTable, authorDetailsMigration, authorNameTable, bookAuthorTable, bookAuthorRoleMigration, bookAuthorPositionMigration, bookAuthorRoleKeyMigration, bookCopyTable, bookCopyStatusMigration, bookCopyAcquisitionPriceMigration, bookCopyLocationMigration, bookCopyCurrentLocationMigration, bookCopyBarcodeSequence, bookCopyStatusChangeTable, borrowTable, borrowUniqueCopyMigration, borrowReturnedAtMigration, borrowBookCopyIndex, borrowUserIndex, borrowReplacementMigration, borrowBranchMigration, bookCopyOnLoanMigration, holdTable, renewalTable, policyTable, openingHoursTable, closureTable, userTable, userCardNumberMigration, userNoCardMigration, fineTable, fineUserIndex, fineOpeningBalanceMigration, branchTable, locationTable, locationBranchIndex, transferTable, transferBookCopyIndex, stocktakeSessionTable, stocktakeScanTable, stocktakeScanSessionIndex, stocktakeResultTable, stocktakeResultBookCopyMigration, stocktakeSessionResultsKeptMigration, importJobTable, importRowTable}
 
 const (
-	bookTable = `CREATE TABLE IF NOT EXISTS books (
+	trigramExtension = `CREATE EXTENSION IF NOT EXISTS pg_trgm`
+	bookTable        = `CREATE TABLE IF NOT EXISTS books (
 			id VARCHAR(27),
 			title VARCHAR,
 			publisher VARCHAR,
@@ -38,29 +48,174 @@ const (
 			author VARCHAR[],
 			quantity INT,
 			added_at TIMESTAMP WITHOUT TIME ZONE,
+			search_vector TSVECTOR,
 			CONSTRAINT books_pkey PRIMARY KEY (id)
 			)`
+	bookSearchIndex       = `CREATE INDEX IF NOT EXISTS books_search_vector_idx ON books USING GIN (search_vector)`
+	bookTitleTrigramIndex = `CREATE INDEX IF NOT EXISTS books_title_trgm_idx ON books USING GIN (title gin_trgm_ops)`
+	subjectTable          = `CREATE TABLE IF NOT EXISTS subjects (
+			id SERIAL,
+			subject VARCHAR UNIQUE,
+			scope_note TEXT DEFAULT '',
+			preferred_id INT DEFAULT 0,
+			CONSTRAINT subjects_pkey PRIMARY KEY (id)
+			)`
+	// Subjects predating the vocabulary are all preferred terms.
+	subjectVocabularyMigration = `ALTER TABLE subjects ADD COLUMN IF NOT EXISTS scope_note TEXT DEFAULT '', ADD COLUMN IF NOT EXISTS preferred_id INT DEFAULT 0`
+	bookSubjectTable           = `CREATE TABLE IF NOT EXISTS books_subjects (
+			book_id VARCHAR(27),
+			subject_id INT,
+			CONSTRAINT books_subjects_pkey PRIMARY KEY (book_id, subject_id)
+			)`
+	// Narrower terms are kept as the broader term the other way around,
+	// related terms are kept both ways.
+	subjectRelationTable = `CREATE TABLE IF NOT EXISTS subject_relations (
+			subject_id INT,
+			relation VARCHAR,
+			related_id INT,
+			CONSTRAINT subject_relations_pkey PRIMARY KEY (subject_id, relation, related_id)
+			)`
+	authorTable = `CREATE TABLE IF NOT EXISTS authors (
+			id SERIAL,
+			name VARCHAR,
+			biography TEXT DEFAULT '',
+			birth_year INT DEFAULT 0,
+			death_year INT DEFAULT 0,
+			CONSTRAINT authors_pkey PRIMARY KEY (id)
+			)`
+	authorDetailsMigration = `ALTER TABLE authors ADD COLUMN IF NOT EXISTS biography TEXT DEFAULT '', ADD COLUMN IF NOT EXISTS birth_year INT DEFAULT 0, ADD COLUMN IF NOT EXISTS death_year INT DEFAULT 0`
+	authorNameTable        = `CREATE TABLE IF NOT EXISTS author_names (
+			author_id INT,
+			name VARCHAR,
+			CONSTRAINT author_names_pkey PRIMARY KEY (author_id, name)
+			)`
+	bookAuthorTable = `CREATE TABLE IF NOT EXISTS books_authors (
+			book_id VARCHAR(27),
+			author_id INT,
+			role VARCHAR DEFAULT 'author',
+			position INT DEFAULT 0,
+			CONSTRAINT books_authors_pkey PRIMARY KEY (book_id, author_id, role)
+			)`
+	bookAuthorRoleMigration     = `ALTER TABLE books_authors ADD COLUMN IF NOT EXISTS role VARCHAR DEFAULT 'author'`
+	bookAuthorPositionMigration = `ALTER TABLE books_authors ADD COLUMN IF NOT EXISTS position INT DEFAULT 0`
+	// bookAuthorRoleKeyMigration widens the primary key of books_authors created
+	// before roles, so that an Author can have more than one role in a Book.
+	bookAuthorRoleKeyMigration = `DO $$
+		BEGIN
+			IF (SELECT array_length(conkey, 1) FROM pg_constraint WHERE conname='books_authors_pkey') < 3 THEN
+				ALTER TABLE books_authors DROP CONSTRAINT books_authors_pkey, ADD CONSTRAINT books_authors_pkey PRIMARY KEY (book_id, author_id, role);
+			END IF;
+		END $$`
 	bookCopyTable = `CREATE TABLE IF NOT EXISTS bookcopies (
 			id VARCHAR(27),
 			barcode VARCHAR UNIQUE,
 			book_id VARCHAR(27),
 			condition VARCHAR,
+			category VARCHAR,
+			status VARCHAR DEFAULT 'available',
+			acquisition_price INT DEFAULT 0,
+			home_branch_id VARCHAR(27) DEFAULT '',
+			home_location_id VARCHAR(27) DEFAULT '',
+			current_branch_id VARCHAR(27) DEFAULT '',
+			current_location_id VARCHAR(27) DEFAULT '',
 			added_at TIMESTAMP WITHOUT TIME ZONE,
 			CONSTRAINT bookcopies_pkey PRIMARY KEY (id)
 			)`
+	bookCopyStatusMigration           = `ALTER TABLE bookcopies ADD COLUMN IF NOT EXISTS status VARCHAR DEFAULT 'available'`
+	bookCopyAcquisitionPriceMigration = `ALTER TABLE bookcopies ADD COLUMN IF NOT EXISTS acquisition_price INT DEFAULT 0`
+	// Book Copies predating Branches have no home nor current Branch.
+	bookCopyLocationMigration        = `ALTER TABLE bookcopies ADD COLUMN IF NOT EXISTS home_branch_id VARCHAR(27) DEFAULT '', ADD COLUMN IF NOT EXISTS home_location_id VARCHAR(27) DEFAULT ''`
+	bookCopyCurrentLocationMigration = `ALTER TABLE bookcopies ADD COLUMN IF NOT EXISTS current_branch_id VARCHAR(27) DEFAULT '', ADD COLUMN IF NOT EXISTS current_location_id VARCHAR(27) DEFAULT ''`
+	bookCopyBarcodeSequence          = `CREATE SEQUENCE IF NOT EXISTS bookcopy_barcode_seq`
+	bookCopyStatusChangeTable        = `CREATE TABLE IF NOT EXISTS bookcopy_status_changes (
+			id VARCHAR(27),
+			bookcopy_id VARCHAR(27),
+			from_status VARCHAR,
+			to_status VARCHAR,
+			changed_by VARCHAR,
+			note TEXT,
+			changed_at TIMESTAMP WITHOUT TIME ZONE,
+			CONSTRAINT bookcopy_status_changes_pkey PRIMARY KEY (id)
+			)`
 	borrowTable = `CREATE TABLE IF NOT EXISTS borrows (
 			id VARCHAR(27),
 			user_id VARCHAR(27),
-			bookcopy_id VARCHAR(27) UNIQUE, 
+			bookcopy_id VARCHAR(27),
 			fine INT,
+			replacement_charge INT DEFAULT 0,
+			borrow_branch_id VARCHAR(27) DEFAULT '',
+			return_branch_id VARCHAR(27) DEFAULT '',
 			borrowed_at TIMESTAMP WITHOUT TIME ZONE,
 			due_date TIMESTAMP WITHOUT TIME ZONE,
 			returned_at TIMESTAMP WITHOUT TIME ZONE,
 			CONSTRAINT borrows_pkey PRIMARY KEY (id)
 			)`
+	// Borrows used to be one row per Book Copy with a zero returned_at for
+	// active loans, these bring existing tables in line with the loan history.
+	borrowUniqueCopyMigration  = `ALTER TABLE borrows DROP CONSTRAINT IF EXISTS borrows_bookcopy_id_key`
+	borrowReturnedAtMigration  = `UPDATE borrows SET returned_at=NULL WHERE returned_at='0001-01-01 00:00:00'`
+	borrowBookCopyIndex        = `CREATE INDEX IF NOT EXISTS borrows_bookcopy_id_idx ON borrows (bookcopy_id)`
+	borrowUserIndex            = `CREATE INDEX IF NOT EXISTS borrows_user_id_idx ON borrows (user_id)`
+	borrowReplacementMigration = `ALTER TABLE borrows ADD COLUMN IF NOT EXISTS replacement_charge INT DEFAULT 0`
+	borrowBranchMigration      = `ALTER TABLE borrows ADD COLUMN IF NOT EXISTS borrow_branch_id VARCHAR(27) DEFAULT '', ADD COLUMN IF NOT EXISTS return_branch_id VARCHAR(27) DEFAULT ''`
+	// Copies lent out before the status lifecycle are put on loan.
+	bookCopyOnLoanMigration = `UPDATE bookcopies SET status='on-loan' WHERE status='available' AND EXISTS(SELECT 1 FROM borrows WHERE borrows.bookcopy_id=bookcopies.id AND borrows.returned_at IS NULL)`
+	holdTable               = `CREATE TABLE IF NOT EXISTS holds (
+			id VARCHAR(27),
+			user_id VARCHAR(27),
+			book_id VARCHAR(27),
+			bookcopy_id VARCHAR(27),
+			status VARCHAR,
+			placed_at TIMESTAMP WITHOUT TIME ZONE,
+			ready_at TIMESTAMP WITHOUT TIME ZONE,
+			pickup_by TIMESTAMP WITHOUT TIME ZONE,
+			CONSTRAINT holds_pkey PRIMARY KEY (id)
+			)`
+	renewalTable = `CREATE TABLE IF NOT EXISTS renewals (
+			id VARCHAR(27),
+			borrow_id VARCHAR(27),
+			user_id VARCHAR(27),
+			previous_due_date TIMESTAMP WITHOUT TIME ZONE,
+			new_due_date TIMESTAMP WITHOUT TIME ZONE,
+			renewed_at TIMESTAMP WITHOUT TIME ZONE,
+			CONSTRAINT renewals_pkey PRIMARY KEY (id)
+			)`
+	policyTable = `CREATE TABLE IF NOT EXISTS policies (
+			id VARCHAR(27),
+			role VARCHAR,
+			category VARCHAR,
+			loan_period_days INT,
+			max_loans INT,
+			max_renewals INT,
+			fine_per_day INT,
+			fine_cap INT,
+			grace_period_days INT,
+			max_fine INT,
+			skip_closed_days BOOLEAN,
+			created_at TIMESTAMP WITHOUT TIME ZONE,
+			CONSTRAINT policies_pkey PRIMARY KEY (id),
+			CONSTRAINT policies_role_category_key UNIQUE (role, category)
+			)`
+	openingHoursTable = `CREATE TABLE IF NOT EXISTS opening_hours (
+			weekday INT,
+			opens VARCHAR(5),
+			closes VARCHAR(5),
+			closed BOOLEAN,
+			CONSTRAINT opening_hours_pkey PRIMARY KEY (weekday)
+			)`
+	closureTable = `CREATE TABLE IF NOT EXISTS closures (
+			id VARCHAR(27),
+			kind VARCHAR,
+			reason VARCHAR,
+			start_date DATE,
+			end_date DATE,
+			created_at TIMESTAMP WITHOUT TIME ZONE,
+			CONSTRAINT closures_pkey PRIMARY KEY (id)
+			)`
 	userTable = `CREATE TABLE IF NOT EXISTS users (
 			id VARCHAR(27),
 			student_id VARCHAR(8) UNIQUE,
+			card_number VARCHAR UNIQUE,
 			role VARCHAR,
 			username VARCHAR UNIQUE,
 			email VARCHAR UNIQUE,
@@ -69,15 +224,145 @@ const (
 			registered_at TIMESTAMP WITHOUT TIME ZONE,
 			CONSTRAINT users_pkey PRIMARY KEY (id)
 			)`
+	userCardNumberMigration = `ALTER TABLE users ADD COLUMN IF NOT EXISTS card_number VARCHAR UNIQUE`
+	userNoCardMigration     = `UPDATE users SET card_number=NULL WHERE card_number=''`
+	fineTable               = `CREATE TABLE IF NOT EXISTS fines (
+			id VARCHAR(27),
+			user_id VARCHAR(27),
+			borrow_id VARCHAR(27),
+			payment_id VARCHAR(27),
+			type VARCHAR,
+			amount INT,
+			balance_after INT,
+			note TEXT,
+			recorded_by VARCHAR,
+			created_at TIMESTAMP WITHOUT TIME ZONE,
+			CONSTRAINT fines_pkey PRIMARY KEY (id)
+			)`
+	fineUserIndex = `CREATE INDEX IF NOT EXISTS fines_user_id_idx ON fines (user_id)`
+	// The total fines of Users predating the ledger are carried over as an opening charge.
+	fineOpeningBalanceMigration = `INSERT INTO fines (id, user_id, borrow_id, payment_id, type, amount, balance_after, note, recorded_by, created_at)
+			SELECT LEFT(MD5(users.id), 27), users.id, '', '', 'charge', users.total_fine, users.total_fine, 'Opening balance', '', NOW()
+			FROM users WHERE users.total_fine>0 AND NOT EXISTS(SELECT 1 FROM fines WHERE fines.user_id=users.id)`
+	branchTable = `CREATE TABLE IF NOT EXISTS branches (
+			id VARCHAR(27),
+			code VARCHAR UNIQUE,
+			name VARCHAR,
+			address TEXT,
+			added_at TIMESTAMP WITHOUT TIME ZONE,
+			CONSTRAINT branches_pkey PRIMARY KEY (id)
+			)`
+	locationTable = `CREATE TABLE IF NOT EXISTS locations (
+			id VARCHAR(27),
+			branch_id VARCHAR(27),
+			name VARCHAR,
+			floor VARCHAR,
+			room VARCHAR,
+			shelf_from VARCHAR,
+			shelf_to VARCHAR,
+			added_at TIMESTAMP WITHOUT TIME ZONE,
+			CONSTRAINT locations_pkey PRIMARY KEY (id)
+			)`
+	locationBranchIndex = `CREATE INDEX IF NOT EXISTS locations_branch_id_idx ON locations (branch_id)`
+	transferTable       = `CREATE TABLE IF NOT EXISTS transfers (
+			id VARCHAR(27),
+			bookcopy_id VARCHAR(27),
+			from_branch_id VARCHAR(27),
+			to_branch_id VARCHAR(27),
+			status VARCHAR,
+			note TEXT,
+			sent_by VARCHAR,
+			received_by VARCHAR,
+			sent_at TIMESTAMP WITHOUT TIME ZONE,
+			received_at TIMESTAMP WITHOUT TIME ZONE,
+			CONSTRAINT transfers_pkey PRIMARY KEY (id)
+			)`
+	transferBookCopyIndex = `CREATE INDEX IF NOT EXISTS transfers_bookcopy_id_idx ON transfers (bookcopy_id)`
+	stocktakeSessionTable = `CREATE TABLE IF NOT EXISTS stocktake_sessions (
+			id VARCHAR(27),
+			branch_id VARCHAR(27),
+			location_id VARCHAR(27),
+			status VARCHAR,
+			opened_by VARCHAR,
+			closed_by VARCHAR,
+			opened_at TIMESTAMP WITHOUT TIME ZONE,
+			closed_at TIMESTAMP WITHOUT TIME ZONE,
+			results_kept BOOLEAN DEFAULT FALSE,
+			CONSTRAINT stocktake_sessions_pkey PRIMARY KEY (id)
+			)`
+	stocktakeScanTable = `CREATE TABLE IF NOT EXISTS stocktake_scans (
+			id VARCHAR(27),
+			session_id VARCHAR(27),
+			barcode VARCHAR,
+			bookcopy_id VARCHAR(27),
+			scanned_by VARCHAR,
+			scanned_at TIMESTAMP WITHOUT TIME ZONE,
+			CONSTRAINT stocktake_scans_pkey PRIMARY KEY (id)
+			)`
+	stocktakeScanSessionIndex = `CREATE INDEX IF NOT EXISTS stocktake_scans_session_id_idx ON stocktake_scans (session_id)`
+	stocktakeResultTable      = `CREATE TABLE IF NOT EXISTS stocktake_results (
+			session_id VARCHAR(27),
+			kind VARCHAR,
+			barcode VARCHAR,
+			bookcopy_id VARCHAR(27),
+			book_id VARCHAR(27),
+			status VARCHAR,
+			current_branch_id VARCHAR(27),
+			current_location_id VARCHAR(27),
+			CONSTRAINT stocktake_results_pkey PRIMARY KEY (session_id, kind, barcode)
+			)`
+	stocktakeResultBookCopyMigration = `ALTER TABLE stocktake_results ADD COLUMN IF NOT EXISTS book_id VARCHAR(27) DEFAULT '', ADD COLUMN IF NOT EXISTS status VARCHAR DEFAULT '', ADD COLUMN IF NOT EXISTS current_branch_id VARCHAR(27) DEFAULT '', ADD COLUMN IF NOT EXISTS current_location_id VARCHAR(27) DEFAULT ''`
+	// Sessions closed before Results were kept are marked as having none, apart
+	// from those which already have Results, as they were closed since.
+	stocktakeSessionResultsKeptMigration = `DO $$
+		BEGIN
+			IF NOT EXISTS(SELECT 1 FROM information_schema.columns WHERE table_name='stocktake_sessions' AND column_name='results_kept') THEN
+				ALTER TABLE stocktake_sessions ADD COLUMN results_kept BOOLEAN DEFAULT FALSE;
+				UPDATE stocktake_sessions SET results_kept=TRUE WHERE EXISTS(SELECT 1 FROM stocktake_results WHERE stocktake_results.session_id=stocktake_sessions.id);
+			END IF;
+		END $$`
+	importJobTable = `CREATE TABLE IF NOT EXISTS import_jobs (
+			id VARCHAR(27),
+			mode VARCHAR,
+			dry_run BOOLEAN,
+			status VARCHAR,
+			total_rows INT,
+			imported_rows INT,
+			failed_rows INT,
+			created_by VARCHAR,
+			created_at TIMESTAMP WITHOUT TIME ZONE,
+			CONSTRAINT import_jobs_pkey PRIMARY KEY (id)
+			)`
+	importRowTable = `CREATE TABLE IF NOT EXISTS import_rows (
+			job_id VARCHAR(27),
+			number INT,
+			title VARCHAR,
+			isbn VARCHAR,
+			call_number VARCHAR,
+			status VARCHAR,
+			book_id VARCHAR(27),
+			copies_added INT,
+			errors TEXT,
+			CONSTRAINT import_rows_pkey PRIMARY KEY (job_id, number)
+			)`
 )
 
 // Repository holds dependencies for the current persistence layer.
 type Repository struct {
-	AuthRepository     auth.Repository
-	BookRepository     book.Repository
-	BookCopyRepository bookcopy.Repository
-	UserRepository     user.Repository
-	BorrowRepository   borrowing.Repository
+	AuthRepository      auth.Repository
+	BookRepository      book.Repository
+	BookCopyRepository  bookcopy.Repository
+	UserRepository      user.Repository
+	BorrowRepository    borrowing.Repository
+	PolicyRepository    policy.Repository
+	CalendarRepository  calendar.Repository
+	FineRepository      fine.Repository
+	BranchRepository    branch.Repository
+	TransferRepository  transfer.Repository
+	StocktakeRepository stocktake.Repository
+	AuthorRepository    author.Repository
+	SubjectRepository   subject.Repository
+	ImportingRepository importing.Repository
 
 	DB *sqlx.DB
 }
@@ -114,14 +399,32 @@ func NewRepository(env string) *Repository {
 	bookCopyRepository := NewBookCopyRepository(DB)
 	userRepository := NewUserRepository(DB)
 	borrowRepository := NewBorrowRepository(DB)
+	policyRepository := NewPolicyRepository(DB)
+	calendarRepository := NewCalendarRepository(DB)
+	fineRepository := NewFineRepository(DB)
+	branchRepository := NewBranchRepository(DB)
+	transferRepository := NewTransferRepository(DB)
+	stocktakeRepository := NewStocktakeRepository(DB)
+	authorRepository := NewAuthorRepository(DB)
+	subjectRepository := NewSubjectRepository(DB)
+	importingRepository := NewImportingRepository(DB)
 
 	repository := &Repository{
-		AuthRepository:     authRepository,
-		BookRepository:     bookRepository,
-		BookCopyRepository: bookCopyRepository,
-		UserRepository:     userRepository,
-		BorrowRepository:   borrowRepository,
-		DB:                 DB,
+		AuthRepository:      authRepository,
+		BookRepository:      bookRepository,
+		BookCopyRepository:  bookCopyRepository,
+		UserRepository:      userRepository,
+		BorrowRepository:    borrowRepository,
+		PolicyRepository:    policyRepository,
+		CalendarRepository:  calendarRepository,
+		FineRepository:      fineRepository,
+		BranchRepository:    branchRepository,
+		TransferRepository:  transferRepository,
+		StocktakeRepository: stocktakeRepository,
+		AuthorRepository:    authorRepository,
+		SubjectRepository:   subjectRepository,
+		ImportingRepository: importingRepository,
+		DB:                  DB,
 	}
 
 	return repository
@@ -143,13 +446,38 @@ func (repo *Repository) EnsureTableExists() {
 			panic(err)
 		}
 	}
+
+	if err := normalizeISBNs(repo.DB); err != nil {
+		panic(err)
+	}
 }
 
 // CleanUp make sure that all of the data from all of the
 // tables are deleted.
 func (repo *Repository) CleanUp() {
 	repo.DB.Exec("DELETE FROM books")
+	repo.DB.Exec("DELETE FROM subjects")
+	repo.DB.Exec("DELETE FROM books_subjects")
+	repo.DB.Exec("DELETE FROM subject_relations")
+	repo.DB.Exec("DELETE FROM authors")
+	repo.DB.Exec("DELETE FROM author_names")
+	repo.DB.Exec("DELETE FROM books_authors")
 	repo.DB.Exec("DELETE FROM bookcopies")
+	repo.DB.Exec("DELETE FROM bookcopy_status_changes")
 	repo.DB.Exec("DELETE FROM users")
 	repo.DB.Exec("DELETE FROM borrows")
+	repo.DB.Exec("DELETE FROM holds")
+	repo.DB.Exec("DELETE FROM renewals")
+	repo.DB.Exec("DELETE FROM policies")
+	repo.DB.Exec("DELETE FROM opening_hours")
+	repo.DB.Exec("DELETE FROM closures")
+	repo.DB.Exec("DELETE FROM fines")
+	repo.DB.Exec("DELETE FROM branches")
+	repo.DB.Exec("DELETE FROM locations")
+	repo.DB.Exec("DELETE FROM transfers")
+	repo.DB.Exec("DELETE FROM stocktake_sessions")
+	repo.DB.Exec("DELETE FROM stocktake_scans")
+	repo.DB.Exec("DELETE FROM stocktake_results")
+	repo.DB.Exec("DELETE FROM import_jobs")
+	repo.DB.Exec("DELETE FROM import_rows")
 }
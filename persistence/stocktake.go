@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"github.com/jmoiron/sqlx"
+
+	"github.com/joshuabezaleel/library-server/pkg/stocktake"
+)
+
+type stocktakeRepository struct {
+	DB *sqlx.DB
+}
+
+// NewStocktakeRepository returns initialized implementations of the repository for
+// Stocktake domain model.
+func NewStocktakeRepository(DB *sqlx.DB) stocktake.Repository {
+	return &stocktakeRepository{
+		DB: DB,
+	}
+}
+
+func (repo *stocktakeRepository) SaveSession(session *stocktake.Session) (*stocktake.Session, error) {
+	_, err := repo.DB.NamedExec("INSERT INTO stocktake_sessions (id, branch_id, location_id, status, opened_by, closed_by, opened_at, closed_at, results_kept) VALUES (:id, :branch_id, :location_id, :status, :opened_by, :closed_by, :opened_at, :closed_at, :results_kept)", session)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (repo *stocktakeRepository) GetSession(sessionID string) (*stocktake.Session, error) {
+	session := stocktake.Session{}
+
+	err := repo.DB.QueryRowx("SELECT * FROM stocktake_sessions WHERE id=$1", sessionID).StructScan(&session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// CloseSession closes the Session along with saving its Results, all at once or not at all.
+func (repo *stocktakeRepository) CloseSession(session *stocktake.Session, results []*stocktake.Result) (*stocktake.Session, error) {
+	tx, err := repo.DB.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.NamedExec("UPDATE stocktake_sessions SET status=:status, closed_by=:closed_by, closed_at=:closed_at, results_kept=:results_kept WHERE id=:id", session)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	for _, result := range results {
+		_, err = tx.NamedExec("INSERT INTO stocktake_results (session_id, kind, barcode, bookcopy_id, book_id, status, current_branch_id, current_location_id) VALUES (:session_id, :kind, :barcode, :bookcopy_id, :book_id, :status, :current_branch_id, :current_location_id)", result)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (repo *stocktakeRepository) GetResults(sessionID string) ([]*stocktake.Result, error) {
+	results := []*stocktake.Result{}
+
+	err := repo.DB.Select(&results, "SELECT * FROM stocktake_results WHERE session_id=$1 ORDER BY kind, barcode", sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (repo *stocktakeRepository) SaveScan(scan *stocktake.Scan) (*stocktake.Scan, error) {
+	_, err := repo.DB.NamedExec("INSERT INTO stocktake_scans (id, session_id, barcode, bookcopy_id, scanned_by, scanned_at) VALUES (:id, :session_id, :barcode, :bookcopy_id, :scanned_by, :scanned_at)", scan)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scan, nil
+}
+
+func (repo *stocktakeRepository) GetScans(sessionID string) ([]*stocktake.Scan, error) {
+	scans := []*stocktake.Scan{}
+
+	err := repo.DB.Select(&scans, "SELECT * FROM stocktake_scans WHERE session_id=$1 ORDER BY scanned_at", sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scans, nil
+}
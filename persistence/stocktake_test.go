@@ -0,0 +1,99 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/stocktake"
+)
+
+func TestStocktakeSaveScan(t *testing.T) {
+	validScan := stocktake.NewScan(util.NewID(), util.NewID(), "31234000000016", util.NewID(), "librarian", time.Now())
+
+	Mock.ExpectExec("INSERT INTO stocktake_scans").
+		WithArgs(validScan.ID, validScan.SessionID, validScan.Barcode, validScan.BookCopyID, validScan.ScannedBy, validScan.ScannedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	scan, err := StocktakeTestingRepository.SaveScan(validScan)
+	require.Nil(t, err)
+	require.Equal(t, validScan.ID, scan.ID)
+}
+
+func TestStocktakeGetScans(t *testing.T) {
+	sessionID := util.NewID()
+
+	rows := sqlmock.NewRows([]string{"id", "session_id", "barcode", "bookcopy_id"}).
+		AddRow(util.NewID(), sessionID, "31234000000016", util.NewID()).
+		AddRow(util.NewID(), sessionID, "unknown", "")
+
+	Mock.ExpectQuery("SELECT (.+) FROM stocktake_scans WHERE session_id=(.+) ORDER BY scanned_at").
+		WithArgs(sessionID).
+		WillReturnRows(rows)
+
+	scans, err := StocktakeTestingRepository.GetScans(sessionID)
+	require.Nil(t, err)
+	require.Len(t, scans, 2)
+	require.Equal(t, "", scans[1].BookCopyID)
+}
+
+func TestStocktakeCloseSession(t *testing.T) {
+	closedAt := time.Now()
+	session := stocktake.NewSession(util.NewID(), util.NewID(), util.NewID(), stocktake.SessionClosed, "librarian", "librarian", time.Now(), &closedAt, true)
+	results := []*stocktake.Result{
+		stocktake.NewResult(session.ID, stocktake.ResultExpected, "31234000000016", util.NewID(), util.NewID(), "available", util.NewID(), util.NewID()),
+		stocktake.NewResult(session.ID, stocktake.ResultUnknown, "unknown", "", "", "", "", ""),
+	}
+
+	// The Session is closed along with its Results.
+	Mock.ExpectBegin()
+	Mock.ExpectExec("UPDATE stocktake_sessions SET").
+		WithArgs(session.Status, session.ClosedBy, session.ClosedAt, session.ResultsKept, session.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	for _, result := range results {
+		Mock.ExpectExec("INSERT INTO stocktake_results").
+			WithArgs(result.SessionID, result.Kind, result.Barcode, result.BookCopyID, result.BookID, result.Status, result.CurrentBranchID, result.CurrentLocationID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	Mock.ExpectCommit()
+
+	closedSession, err := StocktakeTestingRepository.CloseSession(session, results)
+	require.Nil(t, err)
+	require.Equal(t, session.ID, closedSession.ID)
+
+	// The Session stays open when its Results can not be saved.
+	Mock.ExpectBegin()
+	Mock.ExpectExec("UPDATE stocktake_sessions SET").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	Mock.ExpectExec("INSERT INTO stocktake_results").
+		WillReturnError(sqlmock.ErrCancelled)
+	Mock.ExpectRollback()
+
+	_, err = StocktakeTestingRepository.CloseSession(session, results)
+	require.NotNil(t, err)
+
+	require.Nil(t, Mock.ExpectationsWereMet())
+}
+
+func TestStocktakeGetResults(t *testing.T) {
+	sessionID := util.NewID()
+
+	locationID := util.NewID()
+	rows := sqlmock.NewRows([]string{"session_id", "kind", "barcode", "bookcopy_id", "book_id", "status", "current_branch_id", "current_location_id"}).
+		AddRow(sessionID, stocktake.ResultNotFound, "31234000000016", util.NewID(), util.NewID(), "available", util.NewID(), locationID).
+		AddRow(sessionID, stocktake.ResultUnknown, "unknown", "", "", "", "", "")
+
+	Mock.ExpectQuery("SELECT (.+) FROM stocktake_results WHERE session_id=(.+)").
+		WithArgs(sessionID).
+		WillReturnRows(rows)
+
+	results, err := StocktakeTestingRepository.GetResults(sessionID)
+	require.Nil(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, stocktake.ResultNotFound, results[0].Kind)
+	require.Equal(t, "available", results[0].Status)
+	require.Equal(t, locationID, results[0].CurrentLocationID)
+}
@@ -0,0 +1,133 @@
+package persistence
+
+import (
+	"github.com/jmoiron/sqlx"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/subject"
+)
+
+type subjectRepository struct {
+	DB *sqlx.DB
+}
+
+// NewSubjectRepository returns initialized implementations of the repository for
+// Subject domain model.
+func NewSubjectRepository(DB *sqlx.DB) subject.Repository {
+	return &subjectRepository{
+		DB: DB,
+	}
+}
+
+func (repo *subjectRepository) Save(subject *subject.Subject) (*subject.Subject, error) {
+	err := repo.DB.QueryRow("INSERT INTO subjects (subject, scope_note, preferred_id) VALUES ($1, $2, $3) RETURNING id", subject.Term, subject.ScopeNote, subject.PreferredID).Scan(&subject.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return subject, nil
+}
+
+func (repo *subjectRepository) Get(subjectID int64) (*subject.Subject, error) {
+	subject := subject.Subject{}
+
+	err := repo.DB.QueryRowx("SELECT * FROM subjects WHERE id=$1", subjectID).StructScan(&subject)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subject, nil
+}
+
+func (repo *subjectRepository) Update(subject *subject.Subject) (*subject.Subject, error) {
+	_, err := repo.DB.NamedExec("UPDATE subjects SET subject=:subject, scope_note=:scope_note, preferred_id=:preferred_id WHERE id=:id", subject)
+	if err != nil {
+		return nil, err
+	}
+
+	return subject, nil
+}
+
+// Delete deletes the Subject along with its synonyms and relations.
+func (repo *subjectRepository) Delete(subjectID int64) error {
+	_, err := repo.DB.Exec("DELETE FROM subject_relations WHERE subject_id=$1 OR related_id=$1", subjectID)
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.DB.Exec("DELETE FROM subjects WHERE id=$1 OR preferred_id=$1", subjectID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *subjectRepository) List() ([]*subject.Subject, error) {
+	subjects := []*subject.Subject{}
+
+	err := repo.DB.Select(&subjects, "SELECT * FROM subjects ORDER BY subject")
+	if err != nil {
+		return nil, err
+	}
+
+	return subjects, nil
+}
+
+func (repo *subjectRepository) GetSynonyms(subjectID int64) ([]*subject.Subject, error) {
+	synonyms := []*subject.Subject{}
+
+	err := repo.DB.Select(&synonyms, "SELECT * FROM subjects WHERE preferred_id=$1 ORDER BY subject", subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return synonyms, nil
+}
+
+func (repo *subjectRepository) SaveRelation(subjectID int64, relation string, relatedID int64) error {
+	_, err := repo.DB.Exec("INSERT INTO subject_relations (subject_id, relation, related_id) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING", subjectID, relation, relatedID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *subjectRepository) DeleteRelation(subjectID int64, relation string, relatedID int64) error {
+	_, err := repo.DB.Exec("DELETE FROM subject_relations WHERE subject_id=$1 AND relation=$2 AND related_id=$3", subjectID, relation, relatedID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetRelated retrieves the Subjects with the relation to the Subject.
+// Narrower terms are kept as the broader term the other way around.
+func (repo *subjectRepository) GetRelated(subjectID int64, relation string) ([]*subject.Subject, error) {
+	subjects := []*subject.Subject{}
+
+	query := "SELECT subjects.* FROM subject_relations INNER JOIN subjects ON subjects.id=subject_relations.related_id WHERE subject_relations.subject_id=$1 AND subject_relations.relation=$2 ORDER BY subjects.subject"
+	if relation == subject.RelationNarrower {
+		query = "SELECT subjects.* FROM subject_relations INNER JOIN subjects ON subjects.id=subject_relations.subject_id WHERE subject_relations.related_id=$1 AND subject_relations.relation=$2 ORDER BY subjects.subject"
+		relation = subject.RelationBroader
+	}
+
+	err := repo.DB.Select(&subjects, query, subjectID, relation)
+	if err != nil {
+		return nil, err
+	}
+
+	return subjects, nil
+}
+
+func (repo *subjectRepository) GetBookIDs(subjectID int64) ([]string, error) {
+	bookIDs := []string{}
+
+	err := repo.DB.Select(&bookIDs, "SELECT book_id FROM books_subjects WHERE subject_id=$1 ORDER BY book_id", subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return bookIDs, nil
+}
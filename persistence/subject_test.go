@@ -0,0 +1,45 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/subject"
+)
+
+func TestSubjectSave(t *testing.T) {
+	synonym := subject.NewSubject(0, "Maths", "", 1)
+
+	Mock.ExpectQuery("INSERT INTO subjects (.+) RETURNING id").
+		WithArgs(synonym.Term, synonym.ScopeNote, synonym.PreferredID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+
+	newSubject, err := SubjectTestingRepository.Save(synonym)
+	require.Nil(t, err)
+	require.Equal(t, int64(2), newSubject.ID)
+}
+
+func TestSubjectGetRelated(t *testing.T) {
+	subjectID := int64(1)
+	columns := []string{"id", "subject", "scope_note", "preferred_id"}
+
+	// Broader terms are looked up from the Subject.
+	Mock.ExpectQuery(`SELECT subjects.\* FROM subject_relations INNER JOIN subjects ON subjects.id=subject_relations.related_id WHERE subject_relations.subject_id=\$1`).
+		WithArgs(subjectID, subject.RelationBroader).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(3, "Science", "", 0))
+
+	broader, err := SubjectTestingRepository.GetRelated(subjectID, subject.RelationBroader)
+	require.Nil(t, err)
+	require.Equal(t, "Science", broader[0].Term)
+
+	// Narrower terms are the Subjects the Subject is broader than.
+	Mock.ExpectQuery(`SELECT subjects.\* FROM subject_relations INNER JOIN subjects ON subjects.id=subject_relations.subject_id WHERE subject_relations.related_id=\$1`).
+		WithArgs(subjectID, subject.RelationBroader).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(4, "Algebra", "", 0).AddRow(5, "Geometry", "", 0))
+
+	narrower, err := SubjectTestingRepository.GetRelated(subjectID, subject.RelationNarrower)
+	require.Nil(t, err)
+	require.Len(t, narrower, 2)
+}
@@ -0,0 +1,40 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/joshuabezaleel/library-server/pkg/borrowing"
+)
+
+// Timestamps are stored in columns WITHOUT TIME ZONE as the wall clock of the
+// server, which lib/pq reads back as if it were UTC. localTime puts a stored wall
+// clock back in the local time zone, so that it is the instant that was stored.
+func localTime(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.Local)
+}
+
+func localBorrow(borrow *borrowing.Borrow) {
+	borrow.BorrowedAt = localTime(borrow.BorrowedAt)
+	borrow.DueDate = localTime(borrow.DueDate)
+
+	if borrow.ReturnedAt != nil {
+		returnedAt := localTime(*borrow.ReturnedAt)
+		borrow.ReturnedAt = &returnedAt
+	}
+}
+
+func localHold(hold *borrowing.Hold) {
+	hold.PlacedAt = localTime(hold.PlacedAt)
+	hold.ReadyAt = localTime(hold.ReadyAt)
+	hold.PickupBy = localTime(hold.PickupBy)
+}
+
+func localRenewal(renewal *borrowing.Renewal) {
+	renewal.PreviousDueDate = localTime(renewal.PreviousDueDate)
+	renewal.NewDueDate = localTime(renewal.NewDueDate)
+	renewal.RenewedAt = localTime(renewal.RenewedAt)
+}
@@ -0,0 +1,44 @@
+package persistence
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+)
+
+// executor runs the queries of a repository, either straight on the
+// database or in a transaction of it.
+type executor interface {
+	sqlx.Ext
+	NamedExec(query string, arg interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Select(dest interface{}, query string, args ...interface{}) error
+	Get(dest interface{}, query string, args ...interface{}) error
+}
+
+// errNestedTransaction is returned when a repository already bound to
+// a Transaction begins another one.
+var errNestedTransaction = errors.New("Repository is already working in a Transaction")
+
+// begin begins a Transaction of the database.
+func begin(DB executor) (util.Transaction, error) {
+	database, ok := DB.(*sqlx.DB)
+	if !ok {
+		return nil, errNestedTransaction
+	}
+
+	tx, err := database.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// inTransaction returns the executor of a Transaction begun by begin.
+func inTransaction(tx util.Transaction) executor {
+	return tx.(*sqlx.Tx)
+}
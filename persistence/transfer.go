@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"github.com/jmoiron/sqlx"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/transfer"
+)
+
+type transferRepository struct {
+	DB executor
+}
+
+// NewTransferRepository returns initialized implementations of the repository for
+// Transfer domain model.
+func NewTransferRepository(DB *sqlx.DB) transfer.Repository {
+	return &transferRepository{
+		DB: DB,
+	}
+}
+
+// Begin begins the Transaction a Book Copy is sent in.
+func (repo *transferRepository) Begin() (util.Transaction, error) {
+	return begin(repo.DB)
+}
+
+// WithTransaction returns the repository running its queries in the Transaction.
+func (repo *transferRepository) WithTransaction(tx util.Transaction) transfer.Repository {
+	return &transferRepository{
+		DB: inTransaction(tx),
+	}
+}
+
+func (repo *transferRepository) Save(transfer *transfer.Transfer) (*transfer.Transfer, error) {
+	_, err := repo.DB.NamedExec("INSERT INTO transfers (id, bookcopy_id, from_branch_id, to_branch_id, status, note, sent_by, received_by, sent_at, received_at) VALUES (:id, :bookcopy_id, :from_branch_id, :to_branch_id, :status, :note, :sent_by, :received_by, :sent_at, :received_at)", transfer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (repo *transferRepository) Receive(transfer *transfer.Transfer) (*transfer.Transfer, error) {
+	_, err := repo.DB.NamedExec("UPDATE transfers SET status=:status, received_by=:received_by, received_at=:received_at WHERE id=:id", transfer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (repo *transferRepository) GetByBookCopyID(bookCopyID string) ([]*transfer.Transfer, error) {
+	transfers := []*transfer.Transfer{}
+
+	err := repo.DB.Select(&transfers, "SELECT * FROM transfers WHERE bookcopy_id=$1 ORDER BY sent_at", bookCopyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+func (repo *transferRepository) GetInTransitFrom(branchID string) ([]*transfer.Transfer, error) {
+	transfers := []*transfer.Transfer{}
+
+	err := repo.DB.Select(&transfers, "SELECT * FROM transfers WHERE from_branch_id=$1 AND status=$2 ORDER BY sent_at", branchID, transfer.StatusInTransit)
+	if err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+func (repo *transferRepository) GetInTransitTo(branchID string) ([]*transfer.Transfer, error) {
+	transfers := []*transfer.Transfer{}
+
+	err := repo.DB.Select(&transfers, "SELECT * FROM transfers WHERE to_branch_id=$1 AND status=$2 ORDER BY sent_at", branchID, transfer.StatusInTransit)
+	if err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
@@ -0,0 +1,42 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/transfer"
+)
+
+func TestTransferReceive(t *testing.T) {
+	receivedAt := time.Now()
+	receivedTransfer := transfer.NewTransfer(util.NewID(), util.NewID(), util.NewID(), util.NewID(), transfer.StatusReceived, "", "librarian", "librarian", time.Now(), &receivedAt)
+
+	Mock.ExpectExec("UPDATE transfers").
+		WithArgs(receivedTransfer.Status, receivedTransfer.ReceivedBy, receivedTransfer.ReceivedAt, receivedTransfer.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	transfer, err := TransferTestingRepository.Receive(receivedTransfer)
+	require.Nil(t, err)
+	require.Equal(t, receivedTransfer.ID, transfer.ID)
+}
+
+func TestTransferGetInTransitFrom(t *testing.T) {
+	branchID := util.NewID()
+
+	rows := sqlmock.NewRows([]string{"id", "bookcopy_id", "from_branch_id", "to_branch_id", "status"}).
+		AddRow(util.NewID(), util.NewID(), branchID, util.NewID(), transfer.StatusInTransit).
+		AddRow(util.NewID(), util.NewID(), branchID, util.NewID(), transfer.StatusInTransit)
+
+	Mock.ExpectQuery("SELECT (.+) FROM transfers WHERE from_branch_id=(.+) AND status=(.+) ORDER BY sent_at").
+		WithArgs(branchID, transfer.StatusInTransit).
+		WillReturnRows(rows)
+
+	transfers, err := TransferTestingRepository.GetInTransitFrom(branchID)
+	require.Nil(t, err)
+	require.Len(t, transfers, 2)
+	require.Equal(t, branchID, transfers[1].FromBranchID)
+}
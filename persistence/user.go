@@ -3,11 +3,17 @@ package persistence
 import (
 	"github.com/jmoiron/sqlx"
 
+	util "github.com/joshuabezaleel/library-server/pkg"
 	"github.com/joshuabezaleel/library-server/pkg/core/user"
 )
 
+// userColumns are the columns of users, with the card number of a User without
+// a library card, which is stored as NULL so that it does not collide with the
+// others under the UNIQUE constraint, read back as an empty string.
+const userColumns = "id, student_id, COALESCE(card_number, '') AS card_number, role, username, email, password, total_fine, registered_at"
+
 type userRepository struct {
-	DB *sqlx.DB
+	DB executor
 }
 
 // NewUserRepository returns initialized implementations of the repository for
@@ -18,8 +24,15 @@ func NewUserRepository(DB *sqlx.DB) user.Repository {
 	}
 }
 
+// WithTransaction returns the repository running its queries in the Transaction.
+func (repo *userRepository) WithTransaction(tx util.Transaction) user.Repository {
+	return &userRepository{
+		DB: inTransaction(tx),
+	}
+}
+
 func (repo *userRepository) Save(user *user.User) (*user.User, error) {
-	_, err := repo.DB.NamedExec("INSERT INTO users (id, student_id, role, username, email, password, total_fine, registered_at) VALUES (:id, :student_id, :role, :username, :email, :password, :total_fine, :registered_at)", user)
+	_, err := repo.DB.NamedExec("INSERT INTO users (id, student_id, card_number, role, username, email, password, total_fine, registered_at) VALUES (:id, :student_id, NULLIF(:card_number, ''), :role, :username, :email, :password, :total_fine, :registered_at)", user)
 
 	if err != nil {
 		return nil, err
@@ -31,7 +44,7 @@ func (repo *userRepository) Save(user *user.User) (*user.User, error) {
 func (repo *userRepository) Get(userID string) (*user.User, error) {
 	user := user.User{}
 
-	err := repo.DB.QueryRowx("SELECT * FROM users WHERE id=$1", userID).StructScan(&user)
+	err := repo.DB.QueryRowx("SELECT "+userColumns+" FROM users WHERE id=$1", userID).StructScan(&user)
 	if err != nil {
 		return nil, err
 	}
@@ -40,7 +53,7 @@ func (repo *userRepository) Get(userID string) (*user.User, error) {
 }
 
 func (repo *userRepository) Update(user *user.User) (*user.User, error) {
-	_, err := repo.DB.NamedExec("UPDATE users SET student_id=:student_id, role=:role, username=:username, email=:email, password=:password, total_fine=:total_fine WHERE id=:id", user)
+	_, err := repo.DB.NamedExec("UPDATE users SET student_id=:student_id, card_number=NULLIF(:card_number, ''), role=:role, username=:username, email=:email, password=:password WHERE id=:id", user)
 
 	if err != nil {
 		return nil, err
@@ -75,6 +88,17 @@ func (repo *userRepository) GetIDByUsername(username string) (string, error) {
 	return userID, nil
 }
 
+func (repo *userRepository) GetByIdentifier(identifier string) ([]*user.User, error) {
+	users := []*user.User{}
+
+	err := repo.DB.Select(&users, "SELECT "+userColumns+" FROM users WHERE student_id=$1 OR username=$1 OR card_number=$1 ORDER BY id", identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
 func (repo *userRepository) GetRole(userID string) (string, error) {
 	var role string
 
@@ -86,8 +110,8 @@ func (repo *userRepository) GetRole(userID string) (string, error) {
 	return role, nil
 }
 
-func (repo *userRepository) AddFine(userID string, fine uint32) error {
-	_, err := repo.DB.Exec("UPDATE users SET total_fine=$1 WHERE id=$2", fine, userID)
+func (repo *userRepository) SetTotalFine(userID string, totalFine uint32) error {
+	_, err := repo.DB.Exec("UPDATE users SET total_fine=$1 WHERE id=$2", totalFine, userID)
 	if err != nil {
 		return err
 	}
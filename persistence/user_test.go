@@ -39,8 +39,8 @@ func TestUserSave(t *testing.T) {
 
 	result := sqlmock.NewResult(1, 1)
 
-	Mock.ExpectExec("INSERT INTO users").
-		WithArgs(validUser.ID, validUser.StudentID, validUser.Role, validUser.Username, validUser.Email, validUser.Password, validUser.TotalFine, validUser.RegisteredAt).
+	Mock.ExpectExec("INSERT INTO users (.+) VALUES (.+) NULLIF").
+		WithArgs(validUser.ID, validUser.StudentID, validUser.CardNumber, validUser.Role, validUser.Username, validUser.Email, validUser.Password, validUser.TotalFine, validUser.RegisteredAt).
 		WillReturnResult(result)
 
 	// Tests.
@@ -139,8 +139,8 @@ func TestUserUpdate(t *testing.T) {
 
 	result := sqlmock.NewResult(1, 1)
 
-	Mock.ExpectExec("UPDATE users SET").
-		WithArgs(validUser.StudentID, validUser.Role, validUser.Username, validUser.Email, validUser.Password, validUser.TotalFine, validUser.ID).
+	Mock.ExpectExec("UPDATE users SET (.+) card_number=NULLIF").
+		WithArgs(validUser.StudentID, validUser.CardNumber, validUser.Role, validUser.Username, validUser.Email, validUser.Password, validUser.ID).
 		WillReturnResult(result)
 
 	rows := sqlmock.NewRows([]string{"id", "username"}).
@@ -315,7 +315,7 @@ func TestUserGetRole(t *testing.T) {
 	}
 }
 
-func TestUserAddFine(t *testing.T) {
+func TestUserSetTotalFine(t *testing.T) {
 	tt := []struct {
 		name   string
 		userID string
@@ -349,7 +349,7 @@ func TestUserAddFine(t *testing.T) {
 	// Tests.
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			err := UserTestingRepository.AddFine(tc.userID, tc.fine)
+			err := UserTestingRepository.SetTotalFine(tc.userID, tc.fine)
 
 			if tc.err {
 				require.NotNil(t, err)
@@ -516,7 +516,7 @@ func TestGetTotalFine(t *testing.T) {
 // 	repository.CleanUp()
 // }
 
-// func TestUserAddFine(t *testing.T) {
+// func TestUserSetTotalFine(t *testing.T) {
 // 	// Create a new User and save it.
 // 	user := &user.User{
 // 		ID: util.NewID(),
@@ -541,3 +541,38 @@ func TestGetTotalFine(t *testing.T) {
 // func TestUserGetTotalFine(t *testing.T) {
 
 // }
+
+func TestUserGetByIdentifier(t *testing.T) {
+	validUser := &user.User{
+		ID:         util.NewID(),
+		StudentID:  "12345678",
+		CardNumber: "LIB-0001",
+		Username:   "username",
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "student_id", "card_number", "username"}).
+		AddRow(validUser.ID, validUser.StudentID, validUser.CardNumber, validUser.Username)
+
+	Mock.ExpectQuery("SELECT (.+) COALESCE\\(card_number, ''\\) (.+) FROM users WHERE student_id=(.+) OR username=(.+) OR card_number=(.+)").
+		WithArgs(validUser.CardNumber).
+		WillReturnRows(rows)
+
+	users, err := UserTestingRepository.GetByIdentifier(validUser.CardNumber)
+	require.Nil(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, validUser.ID, users[0].ID)
+
+	// A User without a library card is read back with an empty card number.
+	rows = sqlmock.NewRows([]string{"id", "student_id", "card_number", "username"}).
+		AddRow(validUser.ID, validUser.StudentID, "", validUser.Username).
+		AddRow(util.NewID(), validUser.Username, "", "anotherUsername")
+
+	Mock.ExpectQuery("SELECT (.+) FROM users WHERE student_id=(.+) OR username=(.+) OR card_number=(.+)").
+		WithArgs(validUser.Username).
+		WillReturnRows(rows)
+
+	users, err = UserTestingRepository.GetByIdentifier(validUser.Username)
+	require.Nil(t, err)
+	require.Len(t, users, 2)
+	require.Equal(t, "", users[0].CardNumber)
+}
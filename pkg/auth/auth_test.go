@@ -1,8 +1,12 @@
 package auth
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/gorilla/mux"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/stretchr/testify/require"
@@ -42,6 +46,58 @@ func TestComparePassword(t *testing.T) {
 	require.True(t, isSamePassword)
 }
 
+func TestCheckSameUserOrLibrarian(t *testing.T) {
+	patron := &user.User{ID: util.NewID(), Username: util.NewID()}
+	otherPatron := &user.User{ID: util.NewID(), Username: util.NewID()}
+	librarian := &user.User{ID: util.NewID(), Username: util.NewID()}
+	for _, u := range []*user.User{patron, otherPatron, librarian} {
+		userRepository.On("GetIDByUsername", u.Username).Return(u.ID, nil)
+	}
+	userRepository.On("GetRole", patron.ID).Return(user.RoleStudent, nil)
+	userRepository.On("GetRole", otherPatron.ID).Return(user.RoleStudent, nil)
+	userRepository.On("GetRole", librarian.ID).Return(user.RoleLibrarian, nil)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/users/{userID}/loans", authService.CheckSameUserOrLibrarian(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tt := []struct {
+		name       string
+		username   string
+		statusCode int
+	}{
+		{
+			name:       "the User of the path",
+			username:   patron.Username,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "a librarian on behalf of the User",
+			username:   librarian.Username,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "another User",
+			username:   otherPatron.Username,
+			statusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/users/"+patron.ID+"/loans", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "username", tc.username))
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
 func hashAndSalt(password string) string {
 	pwd := []byte(password)
 	hash, err := bcrypt.GenerateFromPassword(pwd, bcrypt.MinCost)
@@ -61,6 +61,22 @@ func (_m *MockService) CheckSameUser(next http.HandlerFunc) http.HandlerFunc {
 	return r0
 }
 
+// CheckSameUserOrLibrarian provides a mock function with given fields: next
+func (_m *MockService) CheckSameUserOrLibrarian(next http.HandlerFunc) http.HandlerFunc {
+	ret := _m.Called(next)
+
+	var r0 http.HandlerFunc
+	if rf, ok := ret.Get(0).(func(http.HandlerFunc) http.HandlerFunc); ok {
+		r0 = rf(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(http.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // ComparePassword provides a mock function with given fields: incomingPassword, storedPassword
 func (_m *MockService) ComparePassword(incomingPassword string, storedPassword string) (bool, error) {
 	ret := _m.Called(incomingPassword, storedPassword)
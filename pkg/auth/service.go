@@ -26,6 +26,7 @@ type Service interface {
 	CheckLoggedInMiddleware(next http.HandlerFunc) http.HandlerFunc
 	CheckLibrarian(next http.HandlerFunc) http.HandlerFunc
 	CheckSameUser(next http.HandlerFunc) http.HandlerFunc
+	CheckSameUserOrLibrarian(next http.HandlerFunc) http.HandlerFunc
 }
 
 type service struct {
@@ -141,6 +142,27 @@ func (s *service) CheckSameUser(next http.HandlerFunc) http.HandlerFunc {
 	})
 }
 
+// CheckSameUserOrLibrarian lets the User of the path through,
+// as well as librarians acting on behalf of the User.
+func (s *service) CheckSameUserOrLibrarian(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		usernameLoggedIn := r.Context().Value("username").(string)
+
+		role, err := s.userService.GetRole(usernameLoggedIn)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if role == "librarian" {
+			next(w, r)
+			return
+		}
+
+		s.CheckSameUser(next)(w, r)
+	})
+}
+
 func respondWithError(w http.ResponseWriter, code int, message string) {
 	respondWithJSON(w, code, map[string]string{"Error": message})
 }
@@ -0,0 +1,135 @@
+package barcode
+
+import (
+	"bytes"
+	"image/png"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var scheme = NewScheme([]string{"31234"}, map[string]string{"ENG": "31235"}, DefaultLength)
+
+func TestCheckDigit(t *testing.T) {
+	require.Equal(t, 3, CheckDigit("7992739871"))
+	require.Equal(t, 0, CheckDigit("0"))
+}
+
+func TestValidate(t *testing.T) {
+	tt := []struct {
+		name    string
+		barcode string
+		err     error
+	}{
+		{
+			name:    "valid barcode",
+			barcode: "31234000000016",
+			err:     nil,
+		},
+		{
+			name:    "valid barcode of another branch",
+			barcode: "31235000000013",
+			err:     nil,
+		},
+		{
+			name:    "wrong check digit",
+			barcode: "31234000000014",
+			err:     ErrInvalidBarcode,
+		},
+		{
+			name:    "unknown prefix",
+			barcode: "31236000000011",
+			err:     ErrInvalidBarcode,
+		},
+		{
+			name:    "wrong length",
+			barcode: "3123400000013",
+			err:     ErrInvalidBarcode,
+		},
+		{
+			name:    "not only digits",
+			barcode: "3123400000001A",
+			err:     ErrInvalidBarcode,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.err, scheme.Validate(tc.barcode))
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	generated, err := scheme.Generate("31234", 1)
+
+	require.Nil(t, err)
+	require.Equal(t, "31234000000016", generated)
+	require.Nil(t, scheme.Validate(generated))
+
+	_, err = scheme.Generate("31236", 1)
+	require.Equal(t, ErrInvalidPrefix, err)
+
+	_, err = scheme.Generate("31234", 100000000)
+	require.Equal(t, ErrSequenceOverflow, err)
+}
+
+func TestValidateForBranch(t *testing.T) {
+	require.Nil(t, scheme.ValidateForBranch("31235000000013", "ENG"))
+	require.Nil(t, scheme.ValidateForBranch("31234000000016", "MAIN"))
+	require.Nil(t, scheme.ValidateForBranch("31234000000016", ""))
+	require.Equal(t, ErrOtherBranch, scheme.ValidateForBranch("31234000000016", "ENG"))
+	require.Equal(t, ErrOtherBranch, scheme.ValidateForBranch("31235000000013", "MAIN"))
+	require.Equal(t, ErrInvalidBarcode, scheme.ValidateForBranch("31235000000014", "ENG"))
+
+	require.Equal(t, "31235", scheme.BranchPrefix("ENG"))
+	require.Equal(t, "31234", scheme.BranchPrefix("MAIN"))
+}
+
+func TestNewSchemeFromEnv(t *testing.T) {
+	os.Setenv("BARCODE_PREFIXES", "31234")
+	os.Setenv("BARCODE_BRANCH_PREFIXES", "ENG:31235, SCI : 31236,malformed")
+	defer os.Unsetenv("BARCODE_PREFIXES")
+	defer os.Unsetenv("BARCODE_BRANCH_PREFIXES")
+
+	envScheme := NewSchemeFromEnv()
+	require.Equal(t, []string{"31234"}, envScheme.Prefixes)
+	require.Equal(t, map[string]string{"ENG": "31235", "SCI": "31236"}, envScheme.BranchPrefixes)
+	require.Equal(t, DefaultLength, envScheme.Length)
+}
+
+func TestEncodeCodabar(t *testing.T) {
+	modules, err := EncodeCodabar("31234000000013")
+
+	require.Nil(t, err)
+	// The start and stop characters are 10 modules wide, every digit is 9 modules
+	// wide and a narrow space separates the characters.
+	require.Len(t, modules, 10+14*9+10+15)
+	require.True(t, modules[0])
+	require.True(t, modules[len(modules)-1])
+
+	_, err = EncodeCodabar("ISBN 978")
+	require.Equal(t, ErrInvalidCodabar, err)
+
+	_, err = EncodeCodabar("")
+	require.Equal(t, ErrInvalidCodabar, err)
+}
+
+func TestRender(t *testing.T) {
+	modules, err := EncodeCodabar("31234000000013")
+	require.Nil(t, err)
+
+	svg := string(SVG(modules, DefaultModuleWidth, DefaultHeight, "31234000000013"))
+	require.True(t, strings.HasPrefix(svg, "<svg"))
+	require.Contains(t, svg, "31234000000013")
+
+	pngBytes, err := PNG(modules, DefaultModuleWidth, DefaultHeight)
+	require.Nil(t, err)
+
+	img, err := png.Decode(bytes.NewReader(pngBytes))
+	require.Nil(t, err)
+	require.Equal(t, (len(modules)+2*quietZone)*DefaultModuleWidth, img.Bounds().Dx())
+	require.Equal(t, DefaultHeight, img.Bounds().Dy())
+}
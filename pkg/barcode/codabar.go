@@ -0,0 +1,67 @@
+package barcode
+
+import (
+	"errors"
+	"strings"
+)
+
+// Start and stop characters of the Codabar barcodes of the library.
+const (
+	codabarStart = 'A'
+	codabarStop  = 'B'
+)
+
+// ErrInvalidCodabar is returned for data Codabar can not encode.
+var ErrInvalidCodabar = errors.New("Barcode can not be encoded as Codabar")
+
+// codabarPatterns holds the bars (1) and spaces (0) of each Codabar
+// character, a wide element being two modules wide.
+var codabarPatterns = map[rune]string{
+	'0': "101010011",
+	'1': "101011001",
+	'2': "101001011",
+	'3': "110010101",
+	'4': "101101001",
+	'5': "110101001",
+	'6': "100101011",
+	'7': "100101101",
+	'8': "100110101",
+	'9': "110100101",
+	'-': "101001101",
+	'$': "101100101",
+	':': "1101011011",
+	'/': "1101101011",
+	'.': "1101101101",
+	'+': "1011011011",
+	'A': "1011001001",
+	'B': "1001001011",
+	'C': "1010010011",
+	'D': "1010011001",
+}
+
+// EncodeCodabar encodes the data between the start and stop characters and
+// returns its modules, true for a bar and false for a space.
+func EncodeCodabar(data string) ([]bool, error) {
+	if data == "" {
+		return nil, ErrInvalidCodabar
+	}
+
+	patterns := []string{codabarPatterns[codabarStart]}
+	for _, r := range data {
+		pattern, ok := codabarPatterns[r]
+		if !ok || strings.ContainsRune("ABCD", r) {
+			return nil, ErrInvalidCodabar
+		}
+
+		patterns = append(patterns, pattern)
+	}
+	patterns = append(patterns, codabarPatterns[codabarStop])
+
+	// Characters are separated by a narrow space.
+	modules := []bool{}
+	for _, bit := range strings.Join(patterns, "0") {
+		modules = append(modules, bit == '1')
+	}
+
+	return modules, nil
+}
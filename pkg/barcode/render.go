@@ -0,0 +1,81 @@
+package barcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// quietZone is the number of blank modules on each side of a barcode.
+const quietZone = 10
+
+// Default dimensions of rendered barcodes, in pixels.
+const (
+	DefaultModuleWidth = 2
+	DefaultHeight      = 80
+)
+
+// SVG renders the modules as an SVG image with the text below the bars.
+func SVG(modules []bool, moduleWidth int, height int, text string) []byte {
+	width := (len(modules) + 2*quietZone) * moduleWidth
+	textHeight := 0
+	if text != "" {
+		textHeight = 20
+	}
+
+	var buffer bytes.Buffer
+
+	fmt.Fprintf(&buffer, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, width, height+textHeight, width, height+textHeight)
+	fmt.Fprintf(&buffer, `<rect width="%d" height="%d" fill="#fff"/>`, width, height+textHeight)
+
+	// Adjacent bars are drawn as one wider bar.
+	for i := 0; i < len(modules); i++ {
+		if !modules[i] {
+			continue
+		}
+
+		start := i
+		for i+1 < len(modules) && modules[i+1] {
+			i++
+		}
+
+		fmt.Fprintf(&buffer, `<rect x="%d" y="0" width="%d" height="%d" fill="#000"/>`, (quietZone+start)*moduleWidth, (i-start+1)*moduleWidth, height)
+	}
+
+	if text != "" {
+		fmt.Fprintf(&buffer, `<text x="%d" y="%d" font-family="monospace" font-size="14" text-anchor="middle">%s</text>`, width/2, height+16, text)
+	}
+
+	buffer.WriteString(`</svg>`)
+
+	return buffer.Bytes()
+}
+
+// PNG renders the modules as a PNG image.
+func PNG(modules []bool, moduleWidth int, height int) ([]byte, error) {
+	width := (len(modules) + 2*quietZone) * moduleWidth
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		module := x/moduleWidth - quietZone
+		shade := color.White
+		if module >= 0 && module < len(modules) && modules[module] {
+			shade = color.Black
+		}
+
+		for y := 0; y < height; y++ {
+			img.Set(x, y, shade)
+		}
+	}
+
+	var buffer bytes.Buffer
+
+	err := png.Encode(&buffer, img)
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,224 @@
+package barcode
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultLength is the length of a library barcode: a digit for the item type,
+// a four digit institution code, an eight digit item number and a check digit.
+const DefaultLength = 14
+
+// Errors definition.
+var (
+	ErrInvalidBarcode   = errors.New("Barcode does not match the barcode scheme")
+	ErrInvalidPrefix    = errors.New("Prefix is not one of the barcode scheme")
+	ErrOtherBranch      = errors.New("Barcode is in the range of another branch")
+	ErrSequenceOverflow = errors.New("Barcode sequence does not fit in the barcode scheme")
+)
+
+// Scheme describes the barcodes of the library. Every barcode has the same
+// length, starts with one of the prefixes and ends with a mod-10 check digit.
+// BranchPrefixes gives branches, by their code, a range of barcodes of their
+// own, while Prefixes are the ranges of the Book Copies of the other branches
+// and of those without a home branch. A Scheme without prefixes accepts any prefix.
+type Scheme struct {
+	Prefixes       []string          `json:"prefixes"`
+	BranchPrefixes map[string]string `json:"branchPrefixes"`
+	Length         int               `json:"length"`
+}
+
+// NewScheme creates a new instance of Scheme.
+func NewScheme(prefixes []string, branchPrefixes map[string]string, length int) *Scheme {
+	return &Scheme{
+		Prefixes:       prefixes,
+		BranchPrefixes: branchPrefixes,
+		Length:         length,
+	}
+}
+
+// NewSchemeFromEnv creates the Scheme configured by the comma separated
+// BARCODE_PREFIXES, by the comma separated code:prefix pairs of
+// BARCODE_BRANCH_PREFIXES and by BARCODE_LENGTH, which defaults to DefaultLength.
+func NewSchemeFromEnv() *Scheme {
+	prefixes := []string{}
+	for _, prefix := range strings.Split(os.Getenv("BARCODE_PREFIXES"), ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+
+	branchPrefixes := map[string]string{}
+	for _, pair := range strings.Split(os.Getenv("BARCODE_BRANCH_PREFIXES"), ",") {
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		code, prefix := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if code != "" && prefix != "" {
+			branchPrefixes[code] = prefix
+		}
+	}
+
+	length, err := strconv.Atoi(os.Getenv("BARCODE_LENGTH"))
+	if err != nil || length <= 1 {
+		length = DefaultLength
+	}
+
+	return NewScheme(prefixes, branchPrefixes, length)
+}
+
+// DefaultPrefix returns the prefix of the generated barcodes
+// that are not given another prefix.
+func (scheme *Scheme) DefaultPrefix() string {
+	if len(scheme.Prefixes) == 0 {
+		return ""
+	}
+
+	return scheme.Prefixes[0]
+}
+
+// BranchPrefix returns the prefix of the generated barcodes of the Book Copies
+// of the branch with the code, which is the default prefix unless the branch
+// has a range of its own.
+func (scheme *Scheme) BranchPrefix(branchCode string) string {
+	if prefix, ok := scheme.BranchPrefixes[branchCode]; ok {
+		return prefix
+	}
+
+	return scheme.DefaultPrefix()
+}
+
+// ValidateForBranch validates the barcode, like Validate does, for a Book Copy
+// of the branch with the code. It returns ErrOtherBranch when the barcode is not
+// in the range of a branch which has a range of its own, or when it is in the
+// range of another branch.
+func (scheme *Scheme) ValidateForBranch(barcode string, branchCode string) error {
+	err := scheme.Validate(barcode)
+	if err != nil {
+		return err
+	}
+
+	if prefix, ok := scheme.BranchPrefixes[branchCode]; ok {
+		if !strings.HasPrefix(barcode, prefix) {
+			return ErrOtherBranch
+		}
+
+		return nil
+	}
+
+	for code, prefix := range scheme.BranchPrefixes {
+		if code != branchCode && strings.HasPrefix(barcode, prefix) {
+			return ErrOtherBranch
+		}
+	}
+
+	return nil
+}
+
+// Validate returns ErrInvalidBarcode unless the barcode follows the Scheme.
+func (scheme *Scheme) Validate(barcode string) error {
+	if len(barcode) != scheme.Length || !isDigits(barcode) {
+		return ErrInvalidBarcode
+	}
+
+	if !scheme.hasPrefix(barcode) {
+		return ErrInvalidBarcode
+	}
+
+	payload, checkDigit := barcode[:len(barcode)-1], barcode[len(barcode)-1:]
+	if strconv.Itoa(CheckDigit(payload)) != checkDigit {
+		return ErrInvalidBarcode
+	}
+
+	return nil
+}
+
+// Generate returns the barcode with the prefix for the number of the sequence,
+// padded with zeros to the length of the Scheme and followed by its check digit.
+func (scheme *Scheme) Generate(prefix string, sequence int64) (string, error) {
+	prefixes := scheme.prefixes()
+	if len(prefixes) != 0 && !contains(prefixes, prefix) {
+		return "", ErrInvalidPrefix
+	}
+
+	width := scheme.Length - len(prefix) - 1
+	number := strconv.FormatInt(sequence, 10)
+	if sequence < 0 || len(number) > width {
+		return "", ErrSequenceOverflow
+	}
+
+	payload := prefix + fmt.Sprintf("%0*s", width, number)
+
+	return payload + strconv.Itoa(CheckDigit(payload)), nil
+}
+
+// CheckDigit returns the mod-10 check digit of the digits, which makes
+// the digits followed by the check digit pass the Luhn algorithm.
+func CheckDigit(digits string) int {
+	sum := 0
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+
+		sum += digit
+		double = !double
+	}
+
+	return (10 - sum%10) % 10
+}
+
+// prefixes returns all of the prefixes of the Scheme, those of the branches included.
+func (scheme *Scheme) prefixes() []string {
+	prefixes := append([]string{}, scheme.Prefixes...)
+	for _, prefix := range scheme.BranchPrefixes {
+		prefixes = append(prefixes, prefix)
+	}
+
+	return prefixes
+}
+
+func (scheme *Scheme) hasPrefix(barcode string) bool {
+	prefixes := scheme.prefixes()
+	if len(prefixes) == 0 {
+		return true
+	}
+
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(barcode, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return value != ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
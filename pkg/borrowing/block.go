@@ -0,0 +1,27 @@
+package borrowing
+
+// Reason codes of a BlockedError.
+const (
+	ReasonMaxLoans      = "MAX_LOANS_REACHED"
+	ReasonFineThreshold = "FINE_THRESHOLD_EXCEEDED"
+)
+
+// BlockedError is returned when a patron is not allowed to borrow.
+// Reason is a machine-readable code the desk client can act upon,
+// while Message explains the refusal to the patron.
+type BlockedError struct {
+	Reason  string
+	Message string
+}
+
+// NewBlockedError creates a new instance of BlockedError.
+func NewBlockedError(reason string, message string) *BlockedError {
+	return &BlockedError{
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+func (err *BlockedError) Error() string {
+	return err.Message
+}
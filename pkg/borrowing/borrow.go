@@ -4,26 +4,41 @@ import (
 	"time"
 )
 
-// Borrow domain model.
+// Statuses for filtering the loan history of a User.
+const (
+	LoanActive   = "active"
+	LoanReturned = "returned"
+)
+
+// Borrow domain model. A Book Copy has one Borrow for each time it is lent,
+// and the Borrow is active until it has a ReturnedAt. ReplacementCharge is
+// what the patron was charged for a Book Copy lost or damaged during the Borrow.
+// The Book Copy is lent at the Branch it is in, and can be returned at any Branch.
 type Borrow struct {
-	ID         string    `json:"id" db:"id"`
-	UserID     string    `json:"userID" db:"user_id"`
-	BookCopyID string    `json:"bookCopyID" db:"bookcopy_id"`
-	Fine       uint32    `json:"fine" db:"fine"`
-	BorrowedAt time.Time `json:"borrowedAt" db:"borrowed_at"`
-	DueDate    time.Time `json:"dueDate" db:"due_date"`
-	ReturnedAt time.Time `json:"returnedAt" db:"returned_at"`
+	ID                string     `json:"id" db:"id"`
+	UserID            string     `json:"userID" db:"user_id"`
+	BookCopyID        string     `json:"bookCopyID" db:"bookcopy_id"`
+	Fine              uint32     `json:"fine" db:"fine"`
+	ReplacementCharge uint32     `json:"replacementCharge" db:"replacement_charge"`
+	BorrowBranchID    string     `json:"borrowBranchID" db:"borrow_branch_id"`
+	ReturnBranchID    string     `json:"returnBranchID" db:"return_branch_id"`
+	BorrowedAt        time.Time  `json:"borrowedAt" db:"borrowed_at"`
+	DueDate           time.Time  `json:"dueDate" db:"due_date"`
+	ReturnedAt        *time.Time `json:"returnedAt" db:"returned_at"`
 }
 
 // NewBorrow creates a new instance of Borrow domain model.
-func NewBorrow(id string, userID string, bookCopyID string, fine uint32, borrowedAt time.Time, dueDate time.Time, returnedAt time.Time) *Borrow {
+func NewBorrow(id string, userID string, bookCopyID string, fine uint32, replacementCharge uint32, borrowBranchID string, returnBranchID string, borrowedAt time.Time, dueDate time.Time, returnedAt *time.Time) *Borrow {
 	return &Borrow{
-		ID:         id,
-		UserID:     userID,
-		BookCopyID: bookCopyID,
-		Fine:       fine,
-		BorrowedAt: borrowedAt,
-		DueDate:    dueDate,
-		ReturnedAt: returnedAt,
+		ID:                id,
+		UserID:            userID,
+		BookCopyID:        bookCopyID,
+		Fine:              fine,
+		ReplacementCharge: replacementCharge,
+		BorrowBranchID:    borrowBranchID,
+		ReturnBranchID:    returnBranchID,
+		BorrowedAt:        borrowedAt,
+		DueDate:           dueDate,
+		ReturnedAt:        returnedAt,
 	}
 }
@@ -6,23 +6,80 @@ import (
 	"time"
 
 	"github.com/bouk/monkey"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/barcode"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+	"github.com/joshuabezaleel/library-server/pkg/calendar"
 	"github.com/joshuabezaleel/library-server/pkg/core/book"
 	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
 	"github.com/joshuabezaleel/library-server/pkg/core/user"
+	"github.com/joshuabezaleel/library-server/pkg/fine"
+	"github.com/joshuabezaleel/library-server/pkg/policy"
+	"github.com/joshuabezaleel/library-server/pkg/transfer"
 )
 
 var userRepository = &user.MockRepository{}
 var bookRepository = &book.MockRepository{}
 var bookCopyRepository = &bookcopy.MockRepository{}
 var borrowRepository = &MockRepository{}
+var policyRepository = &policy.MockRepository{}
+var calendarRepository = &calendar.MockRepository{}
+var fineRepository = &fine.MockRepository{}
+var branchRepository = &branch.MockRepository{}
+var transferRepository = &transfer.MockRepository{}
 
 var userService = user.NewUserService(userRepository)
 var bookService = book.NewBookService(bookRepository)
-var bookCopyService = bookcopy.NewBookCopyService(bookCopyRepository, bookService)
-var borrowService = NewBorrowingService(borrowRepository, userService, bookCopyService)
+var branchService = branch.NewBranchService(branchRepository)
+var bookCopyService = bookcopy.NewBookCopyService(bookCopyRepository, bookService, branchService, barcode.NewScheme([]string{}, map[string]string{}, barcode.DefaultLength))
+var policyService = policy.NewPolicyService(policyRepository)
+var calendarService = calendar.NewCalendarService(calendarRepository, time.UTC)
+var fineService = fine.NewFineService(fineRepository, userService)
+var transferService = transfer.NewTransferService(transferRepository, bookCopyService, bookService, branchService)
+var borrowService = NewBorrowingService(borrowRepository, userService, bookService, bookCopyService, policyService, calendarService, fineService, transferService)
+
+// tx is the Transaction every Borrow is closed in.
+var tx = &transaction{}
+
+type transaction struct {
+	commits   int
+	rollbacks int
+}
+
+func (tx *transaction) Commit() error {
+	tx.commits++
+	return nil
+}
+
+func (tx *transaction) Rollback() error {
+	tx.rollbacks++
+	return nil
+}
+
+func init() {
+	borrowRepository.On("Begin").Return(tx, nil)
+	borrowRepository.On("WithTransaction", tx).Return(borrowRepository)
+	fineRepository.On("WithTransaction", tx).Return(fineRepository)
+	userRepository.On("WithTransaction", tx).Return(userRepository)
+	transferRepository.On("WithTransaction", tx).Return(transferRepository)
+	bookCopyRepository.On("WithTransaction", tx).Return(bookCopyRepository)
+	bookRepository.On("WithTransaction", tx).Return(bookRepository)
+
+	// Every patron borrows under the default Policy unless a test says otherwise.
+	userRepository.On("GetRole", mock.Anything).Return(user.RoleStudent, nil)
+	policyRepository.On("GetAll").Return([]*policy.Policy{}, nil)
+
+	// The library is open every day unless a test says otherwise.
+	calendarRepository.On("GetOpeningHours").Return([]*calendar.OpeningHours{}, nil)
+	calendarRepository.On("GetClosures", mock.Anything, mock.Anything).Return([]*calendar.Closure{}, nil)
+
+	// Every status change of a Book Copy is recorded.
+	bookCopyRepository.On("UpdateStatus", mock.AnythingOfType("*bookcopy.BookCopy")).Return(nil)
+	bookCopyRepository.On("SaveStatusChange", mock.AnythingOfType("*bookcopy.StatusChange")).Return(nil, nil)
+}
 
 func TestBorrow(t *testing.T) {
 	createdTime := time.Now()
@@ -38,14 +95,19 @@ func TestBorrow(t *testing.T) {
 		Username: "testUsername",
 	}
 	userRepository.On("GetIDByUsername", user.Username).Return(user.ID, nil)
+	userRepository.On("GetTotalFine", user.ID).Return(uint32(0), nil)
+	borrowRepository.On("CountActiveBorrows", user.ID).Return(0, nil)
 
 	bookCopy := &bookcopy.BookCopy{
-		ID:     util.NewID(),
-		BookID: util.NewID(),
+		ID:              util.NewID(),
+		BookID:          util.NewID(),
+		Status:          bookcopy.StatusAvailable,
+		CurrentBranchID: util.NewID(),
 	}
 	bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
 
 	borrowRepository.On("CheckBorrowed", bookCopy.ID).Return(false, nil)
+	borrowRepository.On("GetActiveHolds", bookCopy.BookID).Return([]*Hold{}, nil)
 
 	borrowID := util.NewID()
 	borrowIDPatch := monkey.Patch(util.NewID, func() string {
@@ -53,19 +115,26 @@ func TestBorrow(t *testing.T) {
 	})
 	defer borrowIDPatch.Unpatch()
 
+	// The Book Copy is lent at the Branch it is in.
 	borrow := &Borrow{
-		ID:         borrowID,
-		UserID:     user.ID,
-		BookCopyID: bookCopy.ID,
-		BorrowedAt: createdTime,
-		DueDate:    dueDate,
+		ID:             borrowID,
+		UserID:         user.ID,
+		BookCopyID:     bookCopy.ID,
+		BorrowBranchID: bookCopy.CurrentBranchID,
+		BorrowedAt:     createdTime,
+		DueDate:        dueDate,
 	}
 	borrowRepository.On("Borrow", borrow).Return(borrow, nil)
-	newBorrow, err := borrowService.Borrow(user.Username, bookCopy.ID)
+	// A librarian checks the Book Copy out on behalf of the User.
+	newBorrow, err := borrowService.Borrow(user.Username, bookCopy.ID, "librarian")
 
 	require.Nil(t, err)
 	require.Equal(t, user.ID, newBorrow.UserID)
 	require.Equal(t, bookCopy.ID, newBorrow.BookCopyID)
+	require.Equal(t, bookcopy.StatusOnLoan, bookCopy.Status)
+	bookCopyRepository.AssertCalled(t, "SaveStatusChange", mock.MatchedBy(func(statusChange *bookcopy.StatusChange) bool {
+		return statusChange.BookCopyID == bookCopy.ID && statusChange.ToStatus == bookcopy.StatusOnLoan && statusChange.ChangedBy == "librarian"
+	}))
 
 	// Check for Book that is not borrowed.
 	anotherBookCopy := &bookcopy.BookCopy{
@@ -76,7 +145,7 @@ func TestBorrow(t *testing.T) {
 
 	borrowRepository.On("CheckBorrowed", anotherBookCopy.ID).Return(true, nil)
 
-	anotherBorrow, err := borrowService.Borrow(user.Username, anotherBookCopy.ID)
+	anotherBorrow, err := borrowService.Borrow(user.Username, anotherBookCopy.ID, user.Username)
 
 	require.Nil(t, anotherBorrow)
 	require.Equal(t, err, errors.New("Book "+anotherBookCopy.ID+" is currently being borrowed"))
@@ -131,8 +200,12 @@ func TestReturn(t *testing.T) {
 	userRepository.On("GetIDByUsername", user.Username).Return(user.ID, nil)
 
 	bookCopy := &bookcopy.BookCopy{
-		ID: util.NewID(),
+		ID:     util.NewID(),
+		BookID: util.NewID(),
+		Status: bookcopy.StatusOnLoan,
 	}
+	bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+	borrowRepository.On("GetActiveHolds", bookCopy.BookID).Return([]*Hold{}, nil)
 
 	borrow := &Borrow{
 		ID:         util.NewID(),
@@ -145,13 +218,873 @@ func TestReturn(t *testing.T) {
 
 	expectedFine := uint32(14000)
 
-	userRepository.On("GetTotalFine", user.ID).Return(user.TotalFine, nil)
-	userRepository.On("AddFine", user.ID, expectedFine).Return(nil)
+	// The overdue fine is charged to the fines ledger of the User.
+	fineRepository.On("GetBalanceForUpdate", user.ID).Return(user.TotalFine, nil)
+	fineRepository.On("Save", mock.MatchedBy(func(charge *fine.Transaction) bool {
+		return charge.UserID == user.ID && charge.BorrowID == borrow.ID && charge.Amount == expectedFine && charge.Type == fine.TransactionCharge
+	})).Return(fine.NewTransaction(util.NewID(), user.ID, borrow.ID, "", fine.TransactionCharge, expectedFine, expectedFine, "", "", time.Now()), nil)
+	userRepository.On("SetTotalFine", user.ID, expectedFine).Return(nil)
 
 	borrowRepository.On("Return", borrow).Return(borrow, nil)
 
-	returnedBorrow, err := borrowService.Return(user.Username, bookCopy.ID)
+	// A librarian checks the Book Copy in on behalf of the User.
+	returnedBorrow, err := borrowService.Return(user.Username, bookCopy.ID, "", "librarian")
 
 	require.Nil(t, err)
 	require.Equal(t, borrow.ID, returnedBorrow.ID)
+	require.Equal(t, bookcopy.StatusAvailable, bookCopy.Status)
+	bookCopyRepository.AssertCalled(t, "SaveStatusChange", mock.MatchedBy(func(statusChange *bookcopy.StatusChange) bool {
+		return statusChange.BookCopyID == bookCopy.ID && statusChange.ToStatus == bookcopy.StatusAvailable && statusChange.ChangedBy == "librarian"
+	}))
+}
+
+func TestReturnRollback(t *testing.T) {
+	patron := &user.User{
+		ID:       util.NewID(),
+		Username: "rollbackUsername",
+	}
+	userRepository.On("GetIDByUsername", patron.Username).Return(patron.ID, nil)
+
+	bookCopy := &bookcopy.BookCopy{
+		ID:     util.NewID(),
+		BookID: util.NewID(),
+		Status: bookcopy.StatusOnLoan,
+	}
+	bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+
+	borrow := &Borrow{
+		ID:         util.NewID(),
+		UserID:     patron.ID,
+		BookCopyID: bookCopy.ID,
+		DueDate:    time.Now().AddDate(0, 0, -7),
+	}
+	borrowRepository.On("GetByUserIDAndBookCopyID", patron.ID, bookCopy.ID).Return(borrow, nil)
+
+	fineRepository.On("GetBalanceForUpdate", patron.ID).Return(uint32(0), nil)
+	fineRepository.On("Save", mock.MatchedBy(func(charge *fine.Transaction) bool {
+		return charge.UserID == patron.ID
+	})).Return(func(charge *fine.Transaction) *fine.Transaction {
+		return charge
+	}, nil)
+	userRepository.On("SetTotalFine", patron.ID, uint32(14000)).Return(nil)
+
+	borrowRepository.On("Return", borrow).Return(nil, errors.New("connection reset"))
+
+	commits, rollbacks := tx.commits, tx.rollbacks
+
+	// The overdue fine is rolled back along with the Borrow that failed to close.
+	returnedBorrow, err := borrowService.Return(patron.Username, bookCopy.ID, "", patron.Username)
+
+	require.Nil(t, returnedBorrow)
+	require.Equal(t, ErrCloseBorrow, err)
+	require.Equal(t, commits, tx.commits)
+	require.Equal(t, rollbacks+1, tx.rollbacks)
+	require.Equal(t, bookcopy.StatusOnLoan, bookCopy.Status)
+}
+
+func TestBorrowTrappedBookCopy(t *testing.T) {
+	holder := &user.User{
+		ID:       util.NewID(),
+		Username: "holderUsername",
+	}
+	userRepository.On("GetIDByUsername", holder.Username).Return(holder.ID, nil)
+	userRepository.On("GetTotalFine", holder.ID).Return(uint32(0), nil)
+	borrowRepository.On("CountActiveBorrows", holder.ID).Return(0, nil)
+
+	anotherUser := &user.User{
+		ID:       util.NewID(),
+		Username: "anotherUsername",
+	}
+	userRepository.On("GetIDByUsername", anotherUser.Username).Return(anotherUser.ID, nil)
+
+	bookCopy := &bookcopy.BookCopy{
+		ID:     util.NewID(),
+		BookID: util.NewID(),
+		Status: bookcopy.StatusOnHoldShelf,
+	}
+	bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+	borrowRepository.On("CheckBorrowed", bookCopy.ID).Return(false, nil)
+
+	trappedHold := NewHold(util.NewID(), holder.ID, bookCopy.BookID, bookCopy.ID, HoldReady, time.Now(), time.Now(), time.Now().AddDate(0, 0, holdPickupDays))
+	borrowRepository.On("GetActiveHolds", bookCopy.BookID).Return([]*Hold{trappedHold}, nil)
+
+	// Another patron can not borrow the copy trapped for the holder.
+	anotherBorrow, err := borrowService.Borrow(anotherUser.Username, bookCopy.ID, anotherUser.Username)
+
+	require.Nil(t, anotherBorrow)
+	require.Equal(t, ErrBookCopyOnHold, err)
+
+	// The holder picks up the copy and fulfills the Hold.
+	borrowRepository.On("Borrow", mock.AnythingOfType("*borrowing.Borrow")).Return(&Borrow{UserID: holder.ID, BookCopyID: bookCopy.ID}, nil)
+	borrowRepository.On("UpdateHold", trappedHold).Return(trappedHold, nil)
+
+	newBorrow, err := borrowService.Borrow(holder.Username, bookCopy.ID, holder.Username)
+
+	require.Nil(t, err)
+	require.Equal(t, holder.ID, newBorrow.UserID)
+	require.Equal(t, HoldFulfilled, trappedHold.Status)
+	require.Equal(t, bookcopy.StatusOnLoan, bookCopy.Status)
+}
+
+func TestBorrowFulfillsOwnHold(t *testing.T) {
+	holder := &user.User{
+		ID:       util.NewID(),
+		Username: "fulfillingUsername",
+	}
+	userRepository.On("GetIDByUsername", holder.Username).Return(holder.ID, nil)
+	userRepository.On("GetTotalFine", holder.ID).Return(uint32(0), nil)
+	borrowRepository.On("CountActiveBorrows", holder.ID).Return(0, nil)
+
+	tt := []struct {
+		name string
+		// trapped is whether another copy was trapped for the Hold of the holder.
+		trapped bool
+	}{
+		{
+			name:    "waiting Hold",
+			trapped: false,
+		},
+		{
+			name:    "Hold ready on another copy",
+			trapped: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookID := util.NewID()
+
+			bookCopy := &bookcopy.BookCopy{
+				ID:     util.NewID(),
+				BookID: bookID,
+				Status: bookcopy.StatusAvailable,
+			}
+			bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+			borrowRepository.On("CheckBorrowed", bookCopy.ID).Return(false, nil)
+
+			trappedBookCopy := &bookcopy.BookCopy{
+				ID:     util.NewID(),
+				BookID: bookID,
+				Status: bookcopy.StatusOnHoldShelf,
+			}
+			bookCopyRepository.On("Get", trappedBookCopy.ID).Return(trappedBookCopy, nil)
+
+			ownHold := NewHold(util.NewID(), holder.ID, bookID, "", HoldWaiting, time.Now().AddDate(0, 0, -2), time.Time{}, time.Time{})
+			if tc.trapped {
+				ownHold = NewHold(ownHold.ID, holder.ID, bookID, trappedBookCopy.ID, HoldReady, ownHold.PlacedAt, time.Now(), time.Now().AddDate(0, 0, holdPickupDays))
+			}
+			nextHold := NewHold(util.NewID(), util.NewID(), bookID, "", HoldWaiting, time.Now().AddDate(0, 0, -1), time.Time{}, time.Time{})
+			borrowRepository.On("GetActiveHolds", bookID).Return([]*Hold{ownHold, nextHold}, nil)
+			borrowRepository.On("UpdateHold", ownHold).Return(ownHold, nil)
+			borrowRepository.On("UpdateHold", nextHold).Return(nextHold, nil)
+			borrowRepository.On("Borrow", mock.MatchedBy(func(borrow *Borrow) bool {
+				return borrow.BookCopyID == bookCopy.ID
+			})).Return(&Borrow{UserID: holder.ID, BookCopyID: bookCopy.ID}, nil)
+
+			_, err := borrowService.Borrow(holder.Username, bookCopy.ID, holder.Username)
+
+			require.Nil(t, err)
+			require.Equal(t, HoldFulfilled, ownHold.Status)
+			require.Equal(t, bookCopy.ID, ownHold.BookCopyID)
+			require.Equal(t, bookcopy.StatusOnLoan, bookCopy.Status)
+
+			// The copy trapped for the holder is passed to the next patron in the queue.
+			if tc.trapped {
+				require.Equal(t, HoldReady, nextHold.Status)
+				require.Equal(t, trappedBookCopy.ID, nextHold.BookCopyID)
+				require.Equal(t, bookcopy.StatusOnHoldShelf, trappedBookCopy.Status)
+			} else {
+				require.Equal(t, HoldWaiting, nextHold.Status)
+			}
+		})
+	}
+}
+
+func TestReturnTrapsNextHold(t *testing.T) {
+	user := &user.User{
+		ID:       util.NewID(),
+		Username: "returningUsername",
+	}
+	userRepository.On("GetIDByUsername", user.Username).Return(user.ID, nil)
+
+	bookCopy := &bookcopy.BookCopy{
+		ID:     util.NewID(),
+		BookID: util.NewID(),
+		Status: bookcopy.StatusOnLoan,
+	}
+	bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+
+	borrow := &Borrow{
+		ID:         util.NewID(),
+		UserID:     user.ID,
+		BookCopyID: bookCopy.ID,
+		DueDate:    time.Now().AddDate(0, 0, 7),
+	}
+	borrowRepository.On("GetByUserIDAndBookCopyID", user.ID, bookCopy.ID).Return(borrow, nil)
+	borrowRepository.On("Return", borrow).Return(borrow, nil)
+
+	firstHold := NewHold(util.NewID(), util.NewID(), bookCopy.BookID, "", HoldWaiting, time.Now().AddDate(0, 0, -2), time.Time{}, time.Time{})
+	secondHold := NewHold(util.NewID(), util.NewID(), bookCopy.BookID, "", HoldWaiting, time.Now().AddDate(0, 0, -1), time.Time{}, time.Time{})
+	borrowRepository.On("GetActiveHolds", bookCopy.BookID).Return([]*Hold{firstHold, secondHold}, nil)
+	borrowRepository.On("UpdateHold", firstHold).Return(firstHold, nil)
+
+	_, err := borrowService.Return(user.Username, bookCopy.ID, "", user.Username)
+
+	require.Nil(t, err)
+	require.Equal(t, bookcopy.StatusOnHoldShelf, bookCopy.Status)
+	require.Equal(t, HoldReady, firstHold.Status)
+	require.Equal(t, bookCopy.ID, firstHold.BookCopyID)
+	require.True(t, firstHold.PickupBy.After(time.Now()))
+	require.Equal(t, HoldWaiting, secondHold.Status)
+}
+
+func TestReceiveTransfer(t *testing.T) {
+	scienceBranch := &branch.Branch{ID: util.NewID(), Code: "SCI"}
+	branchRepository.On("GetBranch", scienceBranch.ID).Return(scienceBranch, nil)
+
+	tt := []struct {
+		name   string
+		holds  func(bookID string) []*Hold
+		status string
+	}{
+		{
+			name: "Book Copy trapped for the next Hold",
+			holds: func(bookID string) []*Hold {
+				return []*Hold{NewHold(util.NewID(), util.NewID(), bookID, "", HoldWaiting, time.Now().AddDate(0, 0, -1), time.Time{}, time.Time{})}
+			},
+			status: bookcopy.StatusOnHoldShelf,
+		},
+		{
+			name: "Book Copy back on the shelf",
+			holds: func(bookID string) []*Hold {
+				return []*Hold{}
+			},
+			status: bookcopy.StatusAvailable,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookCopy := &bookcopy.BookCopy{
+				ID:              util.NewID(),
+				Barcode:         util.NewID(),
+				BookID:          util.NewID(),
+				Status:          bookcopy.StatusInTransit,
+				HomeBranchID:    scienceBranch.ID,
+				CurrentBranchID: util.NewID(),
+			}
+			bookCopyRepository.On("GetByBarcode", bookCopy.Barcode).Return(bookCopy, nil)
+			bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+			bookCopyRepository.On("UpdateLocation", bookCopy).Return(nil)
+
+			sentTransfer := transfer.NewTransfer(util.NewID(), bookCopy.ID, bookCopy.CurrentBranchID, scienceBranch.ID, transfer.StatusInTransit, "", "librarian", "", time.Now(), nil)
+			transferRepository.On("GetByBookCopyID", bookCopy.ID).Return([]*transfer.Transfer{sentTransfer}, nil)
+			transferRepository.On("Receive", sentTransfer).Return(sentTransfer, nil)
+
+			holds := tc.holds(bookCopy.BookID)
+			borrowRepository.On("GetActiveHolds", bookCopy.BookID).Return(holds, nil)
+			for _, hold := range holds {
+				borrowRepository.On("UpdateHold", hold).Return(hold, nil)
+			}
+
+			receivedTransfer, err := borrowService.ReceiveTransfer("librarian", scienceBranch.ID, bookCopy.Barcode)
+
+			require.Nil(t, err)
+			require.Equal(t, transfer.StatusReceived, receivedTransfer.Status)
+			require.Equal(t, tc.status, bookCopy.Status)
+			for _, hold := range holds {
+				require.Equal(t, HoldReady, hold.Status)
+				require.Equal(t, bookCopy.ID, hold.BookCopyID)
+			}
+		})
+	}
+}
+
+func TestPlaceHold(t *testing.T) {
+	user := &user.User{
+		ID:       util.NewID(),
+		Username: "placingUsername",
+	}
+	userRepository.On("GetIDByUsername", user.Username).Return(user.ID, nil)
+
+	bookID := util.NewID()
+	bookRepository.On("Get", bookID).Return(&book.Book{ID: bookID}, nil)
+	bookRepository.On("GetBookSubjectIDs", bookID).Return([]int64{}, nil)
+	bookRepository.On("GetSubjectsByID", []int64{}).Return([]string{}, nil)
+	bookRepository.On("GetBookAuthors", bookID).Return([]*book.BookAuthor{}, nil)
+	bookRepository.On("GetAvailability", bookID).Return([]*book.BranchAvailability{{BranchID: util.NewID(), Total: 2, Available: 0}}, nil)
+
+	existingHold := NewHold(util.NewID(), util.NewID(), bookID, "", HoldWaiting, time.Now(), time.Time{}, time.Time{})
+	borrowRepository.On("GetActiveHolds", bookID).Return([]*Hold{existingHold}, nil).Once()
+	borrowRepository.On("SaveHold", mock.AnythingOfType("*borrowing.Hold")).Return(func(hold *Hold) *Hold {
+		return hold
+	}, nil)
+
+	hold, err := borrowService.PlaceHold(user.Username, bookID)
+
+	require.Nil(t, err)
+	require.Equal(t, user.ID, hold.UserID)
+	require.Equal(t, HoldWaiting, hold.Status)
+
+	// A patron can only have one active Hold on a Book.
+	borrowRepository.On("GetActiveHolds", bookID).Return([]*Hold{existingHold, hold}, nil)
+
+	anotherHold, err := borrowService.PlaceHold(user.Username, bookID)
+
+	require.Nil(t, anotherHold)
+	require.Equal(t, ErrAlreadyOnHold, err)
+
+	// A Book with copies on the shelf is borrowed instead of put on hold.
+	availableBookID := util.NewID()
+	bookRepository.On("Get", availableBookID).Return(&book.Book{ID: availableBookID}, nil)
+	bookRepository.On("GetBookSubjectIDs", availableBookID).Return([]int64{}, nil)
+	bookRepository.On("GetBookAuthors", availableBookID).Return([]*book.BookAuthor{}, nil)
+	bookRepository.On("GetAvailability", availableBookID).Return([]*book.BranchAvailability{{BranchID: util.NewID(), Total: 2, Available: 1}}, nil)
+	borrowRepository.On("GetActiveHolds", availableBookID).Return([]*Hold{}, nil)
+
+	availableHold, err := borrowService.PlaceHold(user.Username, availableBookID)
+
+	require.Nil(t, availableHold)
+	require.Equal(t, ErrBookAvailable, err)
+}
+
+func TestCancelHold(t *testing.T) {
+	owner := &user.User{
+		ID:       util.NewID(),
+		Username: "cancellingUsername",
+	}
+	userRepository.On("GetIDByUsername", owner.Username).Return(owner.ID, nil)
+
+	anotherUser := &user.User{
+		ID:       util.NewID(),
+		Username: "anotherCancellingUsername",
+	}
+	userRepository.On("GetIDByUsername", anotherUser.Username).Return(anotherUser.ID, nil)
+	userRepository.On("GetRole", anotherUser.ID).Return("student", nil)
+
+	hold := NewHold(util.NewID(), owner.ID, util.NewID(), "", HoldWaiting, time.Now(), time.Time{}, time.Time{})
+	borrowRepository.On("GetHold", hold.ID).Return(hold, nil)
+	borrowRepository.On("UpdateHold", hold).Return(hold, nil)
+
+	// Only the owner of the Hold or a librarian can cancel it.
+	cancelledHold, err := borrowService.CancelHold(anotherUser.Username, hold.ID)
+
+	require.Nil(t, cancelledHold)
+	require.Equal(t, ErrNotHoldOwner, err)
+
+	cancelledHold, err = borrowService.CancelHold(owner.Username, hold.ID)
+
+	require.Nil(t, err)
+	require.Equal(t, HoldCancelled, cancelledHold.Status)
+
+	// A cancelled Hold can not be cancelled again.
+	cancelledHold, err = borrowService.CancelHold(owner.Username, hold.ID)
+
+	require.Nil(t, cancelledHold)
+	require.Equal(t, ErrHoldNotActive, err)
+}
+
+func TestRenew(t *testing.T) {
+	renewedTime := time.Now()
+	timePatch := monkey.Patch(time.Now, func() time.Time {
+		return renewedTime
+	})
+	defer timePatch.Unpatch()
+
+	patron := &user.User{
+		ID:       util.NewID(),
+		Username: "renewingUsername",
+	}
+	userRepository.On("GetIDByUsername", patron.Username).Return(patron.ID, nil)
+
+	newBookCopy := func(holds []*Hold) *bookcopy.BookCopy {
+		bookCopy := &bookcopy.BookCopy{
+			ID:     util.NewID(),
+			BookID: util.NewID(),
+		}
+		bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+		borrowRepository.On("GetActiveHolds", bookCopy.BookID).Return(holds, nil)
+
+		return bookCopy
+	}
+
+	tt := []struct {
+		name       string
+		bookCopy   *bookcopy.BookCopy
+		dueDate    time.Time
+		returnedAt *time.Time
+		renewals   []*Renewal
+		err        error
+	}{
+		{
+			name:     "success renewing a Borrow",
+			bookCopy: newBookCopy([]*Hold{}),
+			dueDate:  renewedTime.AddDate(0, 0, 1),
+			renewals: []*Renewal{},
+			err:      nil,
+		},
+		{
+			name:     "success renewing an overdue Borrow within the grace window",
+			bookCopy: newBookCopy([]*Hold{}),
+			dueDate:  renewedTime.AddDate(0, 0, -policy.DefaultPolicy().GracePeriodDays+1),
+			renewals: []*Renewal{{ID: util.NewID()}},
+			err:      nil,
+		},
+		{
+			name:       "returned Borrow",
+			bookCopy:   newBookCopy([]*Hold{}),
+			dueDate:    renewedTime.AddDate(0, 0, 1),
+			returnedAt: &renewedTime,
+			renewals:   []*Renewal{},
+			err:        ErrBorrowReturned,
+		},
+		{
+			name:     "overdue beyond the grace window",
+			bookCopy: newBookCopy([]*Hold{}),
+			dueDate:  renewedTime.AddDate(0, 0, -policy.DefaultPolicy().GracePeriodDays-1),
+			renewals: []*Renewal{},
+			err:      ErrRenewOverdue,
+		},
+		{
+			name:     "maximum number of renewals reached",
+			bookCopy: newBookCopy([]*Hold{}),
+			dueDate:  renewedTime.AddDate(0, 0, 1),
+			renewals: []*Renewal{{ID: util.NewID()}, {ID: util.NewID()}},
+			err:      ErrMaxRenewals,
+		},
+		{
+			name:     "other patrons are waiting for the Book",
+			bookCopy: newBookCopy([]*Hold{{ID: util.NewID(), UserID: util.NewID(), Status: HoldWaiting}}),
+			dueDate:  renewedTime.AddDate(0, 0, 1),
+			renewals: []*Renewal{},
+			err:      ErrRenewOnHold,
+		},
+		{
+			name:     "another patron's copy of the Book is ready for pickup",
+			bookCopy: newBookCopy([]*Hold{{ID: util.NewID(), UserID: util.NewID(), Status: HoldReady}}),
+			dueDate:  renewedTime.AddDate(0, 0, 1),
+			renewals: []*Renewal{},
+			err:      ErrRenewOnHold,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			borrow := &Borrow{
+				ID:         util.NewID(),
+				UserID:     patron.ID,
+				BookCopyID: tc.bookCopy.ID,
+				DueDate:    tc.dueDate,
+				ReturnedAt: tc.returnedAt,
+			}
+			borrowRepository.On("GetByUserIDAndBookCopyID", patron.ID, tc.bookCopy.ID).Return(borrow, nil)
+			borrowRepository.On("GetRenewals", borrow.ID).Return(tc.renewals, nil)
+			borrowRepository.On("Renew", borrow).Return(borrow, nil)
+			borrowRepository.On("SaveRenewal", mock.AnythingOfType("*borrowing.Renewal")).Return(nil, nil)
+
+			renewedBorrow, err := borrowService.Renew(patron.Username, tc.bookCopy.ID)
+
+			require.Equal(t, tc.err, err)
+
+			if tc.err == nil {
+				require.Equal(t, tc.dueDate.AddDate(0, 0, policy.DefaultPolicy().LoanPeriodDays), renewedBorrow.DueDate)
+			}
+		})
+	}
+}
+
+func TestBorrowBlocked(t *testing.T) {
+	defaultPolicy := policy.DefaultPolicy()
+
+	tt := []struct {
+		name          string
+		activeBorrows int
+		totalFine     uint32
+		reason        string
+	}{
+		{
+			name:          "patron holding the maximum number of loans",
+			activeBorrows: defaultPolicy.MaxLoans,
+			totalFine:     0,
+			reason:        ReasonMaxLoans,
+		},
+		{
+			name:          "patron owing more than the fine threshold",
+			activeBorrows: 0,
+			totalFine:     defaultPolicy.MaxFine + 1,
+			reason:        ReasonFineThreshold,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			patron := &user.User{
+				ID:       util.NewID(),
+				Username: util.NewID(),
+			}
+			userRepository.On("GetIDByUsername", patron.Username).Return(patron.ID, nil)
+			userRepository.On("GetTotalFine", patron.ID).Return(tc.totalFine, nil)
+			borrowRepository.On("CountActiveBorrows", patron.ID).Return(tc.activeBorrows, nil)
+
+			bookCopy := &bookcopy.BookCopy{
+				ID:     util.NewID(),
+				BookID: util.NewID(),
+				Status: bookcopy.StatusAvailable,
+			}
+			bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+			borrowRepository.On("CheckBorrowed", bookCopy.ID).Return(false, nil)
+			borrowRepository.On("GetActiveHolds", bookCopy.BookID).Return([]*Hold{}, nil)
+
+			newBorrow, err := borrowService.Borrow(patron.Username, bookCopy.ID, patron.Username)
+
+			require.Nil(t, newBorrow)
+
+			blockedErr, ok := err.(*BlockedError)
+			require.True(t, ok)
+			require.Equal(t, tc.reason, blockedErr.Reason)
+		})
+	}
+}
+
+func TestGetLoans(t *testing.T) {
+	userID := util.NewID()
+
+	returnedAt := time.Now()
+	activeBorrow := NewBorrow(util.NewID(), userID, util.NewID(), 0, 0, "", "", time.Now(), time.Now(), nil)
+	returnedBorrow := NewBorrow(util.NewID(), userID, util.NewID(), 0, 0, "", "", time.Now(), time.Now(), &returnedAt)
+
+	borrowRepository.On("GetByUserID", userID, "").Return([]*Borrow{activeBorrow, returnedBorrow}, nil)
+	borrowRepository.On("GetByUserID", userID, LoanActive).Return([]*Borrow{activeBorrow}, nil)
+	borrowRepository.On("GetByUserID", userID, LoanReturned).Return([]*Borrow{returnedBorrow}, nil)
+
+	tt := []struct {
+		name    string
+		status  string
+		borrows []*Borrow
+		err     error
+	}{
+		{
+			name:    "all loans",
+			status:  "",
+			borrows: []*Borrow{activeBorrow, returnedBorrow},
+			err:     nil,
+		},
+		{
+			name:    "active loans",
+			status:  LoanActive,
+			borrows: []*Borrow{activeBorrow},
+			err:     nil,
+		},
+		{
+			name:    "returned loans",
+			status:  LoanReturned,
+			borrows: []*Borrow{returnedBorrow},
+			err:     nil,
+		},
+		{
+			name:    "invalid status",
+			status:  "overdue",
+			borrows: nil,
+			err:     ErrInvalidLoanStatus,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			borrows, err := borrowService.GetLoans(userID, tc.status)
+
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.borrows, borrows)
+		})
+	}
+}
+
+func TestReturnAlreadyReturned(t *testing.T) {
+	patron := &user.User{
+		ID:       util.NewID(),
+		Username: util.NewID(),
+	}
+	userRepository.On("GetIDByUsername", patron.Username).Return(patron.ID, nil)
+
+	returnedAt := time.Now()
+	borrow := NewBorrow(util.NewID(), patron.ID, util.NewID(), 0, 0, "", "", time.Now(), time.Now(), &returnedAt)
+	borrowRepository.On("GetByUserIDAndBookCopyID", patron.ID, borrow.BookCopyID).Return(borrow, nil)
+
+	returnedBorrow, err := borrowService.Return(patron.Username, borrow.BookCopyID, "", patron.Username)
+
+	require.Nil(t, returnedBorrow)
+	require.Equal(t, ErrBorrowReturned, err)
+}
+
+func TestBorrowDueDateOnClosedDay(t *testing.T) {
+	createdTime := time.Now()
+	timePatch := monkey.Patch(time.Now, func() time.Time {
+		return createdTime
+	})
+	defer timePatch.Unpatch()
+
+	// The library is closed for the two days a regular loan would be due.
+	dueDate := createdTime.AddDate(0, 0, policy.DefaultPolicy().LoanPeriodDays)
+	closure := calendar.NewClosure(util.NewID(), calendar.ClosureHoliday, "Holiday", dueDate, dueDate.AddDate(0, 0, 1), createdTime)
+
+	closedCalendarRepository := &calendar.MockRepository{}
+	closedCalendarRepository.On("GetOpeningHours").Return([]*calendar.OpeningHours{}, nil)
+	closedCalendarRepository.On("GetClosures", mock.Anything, mock.Anything).Return([]*calendar.Closure{closure}, nil)
+
+	// A dedicated Borrow repository keeps the expectations of the other tests from matching.
+	closedBorrowRepository := &MockRepository{}
+	closedBorrowService := NewBorrowingService(closedBorrowRepository, userService, bookService, bookCopyService, policyService, calendar.NewCalendarService(closedCalendarRepository, time.UTC), fineService, transferService)
+
+	patron := &user.User{
+		ID:       util.NewID(),
+		Username: util.NewID(),
+	}
+	userRepository.On("GetIDByUsername", patron.Username).Return(patron.ID, nil)
+	userRepository.On("GetTotalFine", patron.ID).Return(uint32(0), nil)
+	closedBorrowRepository.On("CountActiveBorrows", patron.ID).Return(0, nil)
+
+	bookCopy := &bookcopy.BookCopy{
+		ID:     util.NewID(),
+		BookID: util.NewID(),
+		Status: bookcopy.StatusAvailable,
+	}
+	bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+	closedBorrowRepository.On("CheckBorrowed", bookCopy.ID).Return(false, nil)
+	closedBorrowRepository.On("GetActiveHolds", bookCopy.BookID).Return([]*Hold{}, nil)
+
+	borrowID := util.NewID()
+	borrowIDPatch := monkey.Patch(util.NewID, func() string {
+		return borrowID
+	})
+	defer borrowIDPatch.Unpatch()
+
+	borrow := NewBorrow(borrowID, patron.ID, bookCopy.ID, 0, 0, "", "", createdTime, dueDate.AddDate(0, 0, 2), nil)
+	closedBorrowRepository.On("Borrow", borrow).Return(borrow, nil)
+
+	newBorrow, err := closedBorrowService.Borrow(patron.Username, bookCopy.ID, patron.Username)
+
+	require.Nil(t, err)
+	require.Equal(t, dueDate.AddDate(0, 0, 2), newBorrow.DueDate)
+}
+
+func TestBorrowUnavailableBookCopy(t *testing.T) {
+	patron := &user.User{
+		ID:       util.NewID(),
+		Username: util.NewID(),
+	}
+	userRepository.On("GetIDByUsername", patron.Username).Return(patron.ID, nil)
+
+	tt := []struct {
+		name   string
+		status string
+	}{
+		{
+			name:   "copy in repair",
+			status: bookcopy.StatusInRepair,
+		},
+		{
+			name:   "lost copy",
+			status: bookcopy.StatusLost,
+		},
+		{
+			name:   "withdrawn copy",
+			status: bookcopy.StatusWithdrawn,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookCopy := &bookcopy.BookCopy{
+				ID:     util.NewID(),
+				BookID: util.NewID(),
+				Status: tc.status,
+			}
+			bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+			borrowRepository.On("CheckBorrowed", bookCopy.ID).Return(false, nil)
+			borrowRepository.On("GetActiveHolds", bookCopy.BookID).Return([]*Hold{}, nil)
+
+			newBorrow, err := borrowService.Borrow(patron.Username, bookCopy.ID, patron.Username)
+
+			require.Nil(t, newBorrow)
+			require.Equal(t, ErrBookCopyNotAvailable, err)
+		})
+	}
+}
+
+func TestReportLostOrDamaged(t *testing.T) {
+	tt := []struct {
+		name   string
+		report func(username string, bookCopyID string) (*Borrow, error)
+		status string
+	}{
+		{
+			name:   "lost Book Copy",
+			report: borrowService.ReportLost,
+			status: bookcopy.StatusLost,
+		},
+		{
+			name:   "damaged Book Copy",
+			report: borrowService.ReportDamaged,
+			status: bookcopy.StatusDamaged,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			patronID := util.NewID()
+
+			bookCopy := &bookcopy.BookCopy{
+				ID:               util.NewID(),
+				BookID:           util.NewID(),
+				Status:           bookcopy.StatusOnLoan,
+				AcquisitionPrice: 5000,
+			}
+			bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+
+			// The Borrow is overdue, but only the replacement cost is charged.
+			borrow := NewBorrow(util.NewID(), patronID, bookCopy.ID, 0, 0, "", "", time.Now().AddDate(0, 0, -30), time.Now().AddDate(0, 0, -16), nil)
+			borrowRepository.On("GetByBookCopyID", bookCopy.ID).Return([]*Borrow{borrow}, nil)
+			borrowRepository.On("Return", borrow).Return(borrow, nil)
+
+			fineRepository.On("GetBalanceForUpdate", patronID).Return(uint32(0), nil)
+			fineRepository.On("Save", mock.MatchedBy(func(charge *fine.Transaction) bool {
+				return charge.UserID == patronID && charge.BorrowID == borrow.ID && charge.Amount == bookCopy.AcquisitionPrice && charge.Type == fine.TransactionCharge
+			})).Return(fine.NewTransaction(util.NewID(), patronID, borrow.ID, "", fine.TransactionCharge, bookCopy.AcquisitionPrice, bookCopy.AcquisitionPrice, "", "", time.Now()), nil)
+			userRepository.On("SetTotalFine", patronID, bookCopy.AcquisitionPrice).Return(nil)
+
+			closedBorrow, err := tc.report("librarian", bookCopy.ID)
+
+			require.Nil(t, err)
+			require.NotNil(t, closedBorrow.ReturnedAt)
+			require.Equal(t, uint32(0), closedBorrow.Fine)
+			require.Equal(t, bookCopy.AcquisitionPrice, closedBorrow.ReplacementCharge)
+			require.Equal(t, tc.status, bookCopy.Status)
+
+			// The loan is already closed.
+			closedBorrow, err = tc.report("librarian", bookCopy.ID)
+
+			require.Nil(t, closedBorrow)
+			require.Equal(t, ErrBookCopyNotOnLoan, err)
+		})
+	}
+}
+
+func TestReportFound(t *testing.T) {
+	patronID := util.NewID()
+
+	bookCopy := &bookcopy.BookCopy{
+		ID:               util.NewID(),
+		BookID:           util.NewID(),
+		Status:           bookcopy.StatusLost,
+		AcquisitionPrice: 5000,
+	}
+	bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+	borrowRepository.On("GetActiveHolds", bookCopy.BookID).Return([]*Hold{}, nil)
+
+	returnedAt := time.Now().AddDate(0, 0, -2)
+	borrow := NewBorrow(util.NewID(), patronID, bookCopy.ID, 0, bookCopy.AcquisitionPrice, "", "", time.Now().AddDate(0, 0, -30), time.Now().AddDate(0, 0, -16), &returnedAt)
+	borrowRepository.On("GetByBookCopyID", bookCopy.ID).Return([]*Borrow{borrow}, nil)
+	borrowRepository.On("Return", borrow).Return(borrow, nil)
+
+	// The replacement charge is still owed, so it is waived.
+	fineRepository.On("GetBalanceForUpdate", patronID).Return(bookCopy.AcquisitionPrice, nil)
+	fineRepository.On("Save", mock.MatchedBy(func(waiver *fine.Transaction) bool {
+		return waiver.UserID == patronID && waiver.BorrowID == borrow.ID && waiver.Amount == bookCopy.AcquisitionPrice && waiver.Type == fine.TransactionWaiver
+	})).Return(fine.NewTransaction(util.NewID(), patronID, borrow.ID, "", fine.TransactionWaiver, bookCopy.AcquisitionPrice, 0, "", "librarian", time.Now()), nil)
+	userRepository.On("SetTotalFine", patronID, uint32(0)).Return(nil)
+
+	foundBookCopy, err := borrowService.ReportFound("librarian", bookCopy.ID)
+
+	require.Nil(t, err)
+	require.Equal(t, bookcopy.StatusAvailable, foundBookCopy.Status)
+	require.Equal(t, uint32(0), borrow.ReplacementCharge)
+
+	// A Book Copy back on the shelf can not be found again.
+	foundBookCopy, err = borrowService.ReportFound("librarian", bookCopy.ID)
+
+	require.Nil(t, foundBookCopy)
+	require.Equal(t, ErrBookCopyNotLost, err)
+}
+
+func TestReturnAtBranch(t *testing.T) {
+	mainBranch := &branch.Branch{ID: util.NewID(), Code: "MAIN"}
+	engineeringBranch := &branch.Branch{ID: util.NewID(), Code: "ENG"}
+	stacks := &branch.Location{ID: util.NewID(), BranchID: mainBranch.ID, Name: "Stacks"}
+	branchRepository.On("GetBranch", mainBranch.ID).Return(mainBranch, nil)
+	branchRepository.On("GetBranch", engineeringBranch.ID).Return(engineeringBranch, nil)
+	branchRepository.On("GetLocation", stacks.ID).Return(stacks, nil)
+
+	tt := []struct {
+		name              string
+		branchID          string
+		currentBranchID   string
+		currentLocationID string
+		status            string
+	}{
+		{
+			name:              "returned at the home Branch by default",
+			branchID:          "",
+			currentBranchID:   mainBranch.ID,
+			currentLocationID: stacks.ID,
+			status:            bookcopy.StatusAvailable,
+		},
+		{
+			name:              "returned at another Branch and sent home",
+			branchID:          engineeringBranch.ID,
+			currentBranchID:   engineeringBranch.ID,
+			currentLocationID: "",
+			status:            bookcopy.StatusInTransit,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			patron := &user.User{
+				ID:       util.NewID(),
+				Username: util.NewID(),
+			}
+			userRepository.On("GetIDByUsername", patron.Username).Return(patron.ID, nil)
+
+			bookCopy := &bookcopy.BookCopy{
+				ID:              util.NewID(),
+				BookID:          util.NewID(),
+				Status:          bookcopy.StatusOnLoan,
+				HomeBranchID:    mainBranch.ID,
+				HomeLocationID:  stacks.ID,
+				CurrentBranchID: mainBranch.ID,
+			}
+			bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+			bookCopyRepository.On("UpdateLocation", bookCopy).Return(nil)
+			borrowRepository.On("GetActiveHolds", bookCopy.BookID).Return([]*Hold{}, nil)
+
+			borrow := NewBorrow(util.NewID(), patron.ID, bookCopy.ID, 0, 0, mainBranch.ID, "", time.Now(), time.Now().AddDate(0, 0, 7), nil)
+			borrowRepository.On("GetByUserIDAndBookCopyID", patron.ID, bookCopy.ID).Return(borrow, nil)
+			borrowRepository.On("Return", borrow).Return(borrow, nil)
+			transferRepository.On("GetByBookCopyID", bookCopy.ID).Return([]*transfer.Transfer{}, nil)
+			transferRepository.On("Save", mock.MatchedBy(func(sent *transfer.Transfer) bool { return sent.BookCopyID == bookCopy.ID })).Return(func(sent *transfer.Transfer) *transfer.Transfer { return sent }, nil)
+
+			returnedBorrow, err := borrowService.Return(patron.Username, bookCopy.ID, tc.branchID, patron.Username)
+
+			require.Nil(t, err)
+			require.Equal(t, tc.currentBranchID, returnedBorrow.ReturnBranchID)
+			require.Equal(t, tc.currentBranchID, bookCopy.CurrentBranchID)
+			require.Equal(t, tc.currentLocationID, bookCopy.CurrentLocationID)
+			require.Equal(t, tc.status, bookCopy.Status)
+			if tc.status == bookcopy.StatusInTransit {
+				transferRepository.AssertCalled(t, "Save", mock.MatchedBy(func(sent *transfer.Transfer) bool {
+					return sent.BookCopyID == bookCopy.ID && sent.FromBranchID == engineeringBranch.ID && sent.ToBranchID == mainBranch.ID
+				}))
+
+				// The copy goes from the loan straight to transit, never on the shelf away from home.
+				bookCopyRepository.AssertCalled(t, "SaveStatusChange", mock.MatchedBy(func(statusChange *bookcopy.StatusChange) bool {
+					return statusChange.BookCopyID == bookCopy.ID && statusChange.FromStatus == bookcopy.StatusOnLoan && statusChange.ToStatus == bookcopy.StatusInTransit
+				}))
+				bookCopyRepository.AssertNotCalled(t, "SaveStatusChange", mock.MatchedBy(func(statusChange *bookcopy.StatusChange) bool {
+					return statusChange.BookCopyID == bookCopy.ID && statusChange.ToStatus == bookcopy.StatusAvailable
+				}))
+			} else {
+				transferRepository.AssertNotCalled(t, "Save", mock.MatchedBy(func(sent *transfer.Transfer) bool { return sent.BookCopyID == bookCopy.ID }))
+			}
+		})
+	}
 }
@@ -0,0 +1,41 @@
+package borrowing
+
+import (
+	"time"
+)
+
+// Statuses of a Hold.
+const (
+	HoldWaiting   = "waiting"
+	HoldReady     = "ready"
+	HoldFulfilled = "fulfilled"
+	HoldCancelled = "cancelled"
+	HoldExpired   = "expired"
+)
+
+// Hold domain model. A Hold is placed on a Book and waits in the queue
+// of that Book until a copy is returned and trapped for it.
+type Hold struct {
+	ID         string    `json:"id" db:"id"`
+	UserID     string    `json:"userID" db:"user_id"`
+	BookID     string    `json:"bookID" db:"book_id"`
+	BookCopyID string    `json:"bookCopyID" db:"bookcopy_id"`
+	Status     string    `json:"status" db:"status"`
+	PlacedAt   time.Time `json:"placedAt" db:"placed_at"`
+	ReadyAt    time.Time `json:"readyAt" db:"ready_at"`
+	PickupBy   time.Time `json:"pickupBy" db:"pickup_by"`
+}
+
+// NewHold creates a new instance of Hold domain model.
+func NewHold(id string, userID string, bookID string, bookCopyID string, status string, placedAt time.Time, readyAt time.Time, pickupBy time.Time) *Hold {
+	return &Hold{
+		ID:         id,
+		UserID:     userID,
+		BookID:     bookID,
+		BookCopyID: bookCopyID,
+		Status:     status,
+		PlacedAt:   placedAt,
+		ReadyAt:    readyAt,
+		PickupBy:   pickupBy,
+	}
+}
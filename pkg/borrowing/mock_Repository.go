@@ -2,13 +2,39 @@
 
 package borrowing
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	pkg "github.com/joshuabezaleel/library-server/pkg"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockRepository is an autogenerated mock type for the Repository type
 type MockRepository struct {
 	mock.Mock
 }
 
+// Begin provides a mock function with given fields:
+func (_m *MockRepository) Begin() (pkg.Transaction, error) {
+	ret := _m.Called()
+
+	var r0 pkg.Transaction
+	if rf, ok := ret.Get(0).(func() pkg.Transaction); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(pkg.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Borrow provides a mock function with given fields: borrow
 func (_m *MockRepository) Borrow(borrow *Borrow) (*Borrow, error) {
 	ret := _m.Called(borrow)
@@ -53,6 +79,27 @@ func (_m *MockRepository) CheckBorrowed(bookCopyID string) (bool, error) {
 	return r0, r1
 }
 
+// CountActiveBorrows provides a mock function with given fields: userID
+func (_m *MockRepository) CountActiveBorrows(userID string) (int, error) {
+	ret := _m.Called(userID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: borrowID
 func (_m *MockRepository) Get(borrowID string) (*Borrow, error) {
 	ret := _m.Called(borrowID)
@@ -76,6 +123,75 @@ func (_m *MockRepository) Get(borrowID string) (*Borrow, error) {
 	return r0, r1
 }
 
+// GetActiveHolds provides a mock function with given fields: bookID
+func (_m *MockRepository) GetActiveHolds(bookID string) ([]*Hold, error) {
+	ret := _m.Called(bookID)
+
+	var r0 []*Hold
+	if rf, ok := ret.Get(0).(func(string) []*Hold); ok {
+		r0 = rf(bookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Hold)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByBookCopyID provides a mock function with given fields: bookCopyID
+func (_m *MockRepository) GetByBookCopyID(bookCopyID string) ([]*Borrow, error) {
+	ret := _m.Called(bookCopyID)
+
+	var r0 []*Borrow
+	if rf, ok := ret.Get(0).(func(string) []*Borrow); ok {
+		r0 = rf(bookCopyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Borrow)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(bookCopyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserID provides a mock function with given fields: userID, status
+func (_m *MockRepository) GetByUserID(userID string, status string) ([]*Borrow, error) {
+	ret := _m.Called(userID, status)
+
+	var r0 []*Borrow
+	if rf, ok := ret.Get(0).(func(string, string) []*Borrow); ok {
+		r0 = rf(userID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Borrow)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByUserIDAndBookCopyID provides a mock function with given fields: userID, bookCopyID
 func (_m *MockRepository) GetByUserIDAndBookCopyID(userID string, bookCopyID string) (*Borrow, error) {
 	ret := _m.Called(userID, bookCopyID)
@@ -99,6 +215,75 @@ func (_m *MockRepository) GetByUserIDAndBookCopyID(userID string, bookCopyID str
 	return r0, r1
 }
 
+// GetHold provides a mock function with given fields: holdID
+func (_m *MockRepository) GetHold(holdID string) (*Hold, error) {
+	ret := _m.Called(holdID)
+
+	var r0 *Hold
+	if rf, ok := ret.Get(0).(func(string) *Hold); ok {
+		r0 = rf(holdID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Hold)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(holdID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRenewals provides a mock function with given fields: borrowID
+func (_m *MockRepository) GetRenewals(borrowID string) ([]*Renewal, error) {
+	ret := _m.Called(borrowID)
+
+	var r0 []*Renewal
+	if rf, ok := ret.Get(0).(func(string) []*Renewal); ok {
+		r0 = rf(borrowID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Renewal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(borrowID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Renew provides a mock function with given fields: borrow
+func (_m *MockRepository) Renew(borrow *Borrow) (*Borrow, error) {
+	ret := _m.Called(borrow)
+
+	var r0 *Borrow
+	if rf, ok := ret.Get(0).(func(*Borrow) *Borrow); ok {
+		r0 = rf(borrow)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Borrow)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Borrow) error); ok {
+		r1 = rf(borrow)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Return provides a mock function with given fields: borrow
 func (_m *MockRepository) Return(borrow *Borrow) (*Borrow, error) {
 	ret := _m.Called(borrow)
@@ -121,3 +306,88 @@ func (_m *MockRepository) Return(borrow *Borrow) (*Borrow, error) {
 
 	return r0, r1
 }
+
+// SaveHold provides a mock function with given fields: hold
+func (_m *MockRepository) SaveHold(hold *Hold) (*Hold, error) {
+	ret := _m.Called(hold)
+
+	var r0 *Hold
+	if rf, ok := ret.Get(0).(func(*Hold) *Hold); ok {
+		r0 = rf(hold)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Hold)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Hold) error); ok {
+		r1 = rf(hold)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveRenewal provides a mock function with given fields: renewal
+func (_m *MockRepository) SaveRenewal(renewal *Renewal) (*Renewal, error) {
+	ret := _m.Called(renewal)
+
+	var r0 *Renewal
+	if rf, ok := ret.Get(0).(func(*Renewal) *Renewal); ok {
+		r0 = rf(renewal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Renewal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Renewal) error); ok {
+		r1 = rf(renewal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateHold provides a mock function with given fields: hold
+func (_m *MockRepository) UpdateHold(hold *Hold) (*Hold, error) {
+	ret := _m.Called(hold)
+
+	var r0 *Hold
+	if rf, ok := ret.Get(0).(func(*Hold) *Hold); ok {
+		r0 = rf(hold)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Hold)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Hold) error); ok {
+		r1 = rf(hold)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithTransaction provides a mock function with given fields: tx
+func (_m *MockRepository) WithTransaction(tx pkg.Transaction) Repository {
+	ret := _m.Called(tx)
+
+	var r0 Repository
+	if rf, ok := ret.Get(0).(func(pkg.Transaction) Repository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Repository)
+		}
+	}
+
+	return r0
+}
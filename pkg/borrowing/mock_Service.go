@@ -2,29 +2,57 @@
 
 package borrowing
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	bookcopy "github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+	mock "github.com/stretchr/testify/mock"
+
+	transfer "github.com/joshuabezaleel/library-server/pkg/transfer"
+)
 
 // MockService is an autogenerated mock type for the Service type
 type MockService struct {
 	mock.Mock
 }
 
-// Borrow provides a mock function with given fields: username, bookCopyID
-func (_m *MockService) Borrow(username string, bookCopyID string) (*Borrow, error) {
-	ret := _m.Called(username, bookCopyID)
+// Borrow provides a mock function with given fields: username, bookCopyID, changedBy
+func (_m *MockService) Borrow(username string, bookCopyID string, changedBy string) (*Borrow, error) {
+	ret := _m.Called(username, bookCopyID, changedBy)
 
 	var r0 *Borrow
-	if rf, ok := ret.Get(0).(func(string, string) *Borrow); ok {
-		r0 = rf(username, bookCopyID)
+	if rf, ok := ret.Get(0).(func(string, string, string) *Borrow); ok {
+		r0 = rf(username, bookCopyID, changedBy)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*Borrow)
 		}
 	}
 
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(username, bookCopyID, changedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelHold provides a mock function with given fields: username, holdID
+func (_m *MockService) CancelHold(username string, holdID string) (*Hold, error) {
+	ret := _m.Called(username, holdID)
+
+	var r0 *Hold
+	if rf, ok := ret.Get(0).(func(string, string) *Hold); ok {
+		r0 = rf(username, holdID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Hold)
+		}
+	}
+
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(username, bookCopyID)
+		r1 = rf(username, holdID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -76,6 +104,52 @@ func (_m *MockService) Get(borrowID string) (*Borrow, error) {
 	return r0, r1
 }
 
+// GetActiveHolds provides a mock function with given fields: bookID
+func (_m *MockService) GetActiveHolds(bookID string) ([]*Hold, error) {
+	ret := _m.Called(bookID)
+
+	var r0 []*Hold
+	if rf, ok := ret.Get(0).(func(string) []*Hold); ok {
+		r0 = rf(bookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Hold)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBookCopyLoans provides a mock function with given fields: bookCopyID
+func (_m *MockService) GetBookCopyLoans(bookCopyID string) ([]*Borrow, error) {
+	ret := _m.Called(bookCopyID)
+
+	var r0 []*Borrow
+	if rf, ok := ret.Get(0).(func(string) []*Borrow); ok {
+		r0 = rf(bookCopyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Borrow)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(bookCopyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByUserIDAndBookCopyID provides a mock function with given fields: userID, bookCopyID
 func (_m *MockService) GetByUserIDAndBookCopyID(userID string, bookCopyID string) (*Borrow, error) {
 	ret := _m.Called(userID, bookCopyID)
@@ -99,8 +173,123 @@ func (_m *MockService) GetByUserIDAndBookCopyID(userID string, bookCopyID string
 	return r0, r1
 }
 
-// Return provides a mock function with given fields: username, bookCopyID
-func (_m *MockService) Return(username string, bookCopyID string) (*Borrow, error) {
+// GetLoans provides a mock function with given fields: userID, status
+func (_m *MockService) GetLoans(userID string, status string) ([]*Borrow, error) {
+	ret := _m.Called(userID, status)
+
+	var r0 []*Borrow
+	if rf, ok := ret.Get(0).(func(string, string) []*Borrow); ok {
+		r0 = rf(userID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Borrow)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRenewals provides a mock function with given fields: borrowID
+func (_m *MockService) GetRenewals(borrowID string) ([]*Renewal, error) {
+	ret := _m.Called(borrowID)
+
+	var r0 []*Renewal
+	if rf, ok := ret.Get(0).(func(string) []*Renewal); ok {
+		r0 = rf(borrowID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Renewal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(borrowID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlaceHold provides a mock function with given fields: username, bookID
+func (_m *MockService) PlaceHold(username string, bookID string) (*Hold, error) {
+	ret := _m.Called(username, bookID)
+
+	var r0 *Hold
+	if rf, ok := ret.Get(0).(func(string, string) *Hold); ok {
+		r0 = rf(username, bookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Hold)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(username, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiveTransfer provides a mock function with given fields: username, branchID, barcode
+func (_m *MockService) ReceiveTransfer(username string, branchID string, barcode string) (*transfer.Transfer, error) {
+	ret := _m.Called(username, branchID, barcode)
+
+	var r0 *transfer.Transfer
+	if rf, ok := ret.Get(0).(func(string, string, string) *transfer.Transfer); ok {
+		r0 = rf(username, branchID, barcode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*transfer.Transfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(username, branchID, barcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Renew provides a mock function with given fields: username, bookCopyID
+func (_m *MockService) Renew(username string, bookCopyID string) (*Borrow, error) {
+	ret := _m.Called(username, bookCopyID)
+
+	var r0 *Borrow
+	if rf, ok := ret.Get(0).(func(string, string) *Borrow); ok {
+		r0 = rf(username, bookCopyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Borrow)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(username, bookCopyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReportDamaged provides a mock function with given fields: username, bookCopyID
+func (_m *MockService) ReportDamaged(username string, bookCopyID string) (*Borrow, error) {
 	ret := _m.Called(username, bookCopyID)
 
 	var r0 *Borrow
@@ -121,3 +310,72 @@ func (_m *MockService) Return(username string, bookCopyID string) (*Borrow, erro
 
 	return r0, r1
 }
+
+// ReportFound provides a mock function with given fields: username, bookCopyID
+func (_m *MockService) ReportFound(username string, bookCopyID string) (*bookcopy.BookCopy, error) {
+	ret := _m.Called(username, bookCopyID)
+
+	var r0 *bookcopy.BookCopy
+	if rf, ok := ret.Get(0).(func(string, string) *bookcopy.BookCopy); ok {
+		r0 = rf(username, bookCopyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bookcopy.BookCopy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(username, bookCopyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReportLost provides a mock function with given fields: username, bookCopyID
+func (_m *MockService) ReportLost(username string, bookCopyID string) (*Borrow, error) {
+	ret := _m.Called(username, bookCopyID)
+
+	var r0 *Borrow
+	if rf, ok := ret.Get(0).(func(string, string) *Borrow); ok {
+		r0 = rf(username, bookCopyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Borrow)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(username, bookCopyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Return provides a mock function with given fields: username, bookCopyID, branchID, changedBy
+func (_m *MockService) Return(username string, bookCopyID string, branchID string, changedBy string) (*Borrow, error) {
+	ret := _m.Called(username, bookCopyID, branchID, changedBy)
+
+	var r0 *Borrow
+	if rf, ok := ret.Get(0).(func(string, string, string, string) *Borrow); ok {
+		r0 = rf(username, bookCopyID, branchID, changedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Borrow)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, string) error); ok {
+		r1 = rf(username, bookCopyID, branchID, changedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
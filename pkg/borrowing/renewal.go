@@ -0,0 +1,28 @@
+package borrowing
+
+import (
+	"time"
+)
+
+// Renewal domain model. A Renewal records each time the due date
+// of a Borrow is pushed out.
+type Renewal struct {
+	ID              string    `json:"id" db:"id"`
+	BorrowID        string    `json:"borrowID" db:"borrow_id"`
+	UserID          string    `json:"userID" db:"user_id"`
+	PreviousDueDate time.Time `json:"previousDueDate" db:"previous_due_date"`
+	NewDueDate      time.Time `json:"newDueDate" db:"new_due_date"`
+	RenewedAt       time.Time `json:"renewedAt" db:"renewed_at"`
+}
+
+// NewRenewal creates a new instance of Renewal domain model.
+func NewRenewal(id string, borrowID string, userID string, previousDueDate time.Time, newDueDate time.Time, renewedAt time.Time) *Renewal {
+	return &Renewal{
+		ID:              id,
+		BorrowID:        borrowID,
+		UserID:          userID,
+		PreviousDueDate: previousDueDate,
+		NewDueDate:      newDueDate,
+		RenewedAt:       renewedAt,
+	}
+}
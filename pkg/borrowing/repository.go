@@ -1,10 +1,31 @@
 package borrowing
 
+import util "github.com/joshuabezaleel/library-server/pkg"
+
 // Repository provides access to the Borrowing store.
 type Repository interface {
 	Borrow(borrow *Borrow) (*Borrow, error)
 	Get(borrowID string) (*Borrow, error)
 	GetByUserIDAndBookCopyID(userID string, bookCopyID string) (*Borrow, error)
 	CheckBorrowed(bookCopyID string) (bool, error)
+	CountActiveBorrows(userID string) (int, error)
+	GetByUserID(userID string, status string) ([]*Borrow, error)
+	GetByBookCopyID(bookCopyID string) ([]*Borrow, error)
 	Return(borrow *Borrow) (*Borrow, error)
+	Renew(borrow *Borrow) (*Borrow, error)
+
+	// Hold operations.
+	SaveHold(hold *Hold) (*Hold, error)
+	GetHold(holdID string) (*Hold, error)
+	UpdateHold(hold *Hold) (*Hold, error)
+	GetActiveHolds(bookID string) ([]*Hold, error)
+
+	// Renewal operations.
+	SaveRenewal(renewal *Renewal) (*Renewal, error)
+	GetRenewals(borrowID string) ([]*Renewal, error)
+
+	// Begin begins a Transaction of the Borrowing store.
+	Begin() (util.Transaction, error)
+	// WithTransaction returns the repository working in the Transaction.
+	WithTransaction(tx util.Transaction) Repository
 }
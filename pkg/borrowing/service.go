@@ -2,50 +2,118 @@ package borrowing
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/calendar"
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
 	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
 	"github.com/joshuabezaleel/library-server/pkg/core/user"
+	"github.com/joshuabezaleel/library-server/pkg/fine"
+	"github.com/joshuabezaleel/library-server/pkg/policy"
+	"github.com/joshuabezaleel/library-server/pkg/transfer"
 )
 
 const (
-	finePerDay = 2000
+	// holdPickupDays is the number of days a trapped copy waits
+	// on the hold shelf for the patron to pick it up.
+	holdPickupDays = 3
+)
+
+// Errors definition.
+var (
+	ErrPlaceHold      = errors.New("Error placing Hold")
+	ErrGetHold        = errors.New("Error retrieving Hold")
+	ErrUpdateHold     = errors.New("Error updating Hold")
+	ErrGetActiveHolds = errors.New("Error retrieving Holds of the Book")
+	ErrAlreadyOnHold  = errors.New("User already has an active Hold on this Book")
+	ErrHoldNotActive  = errors.New("Hold is no longer active")
+	ErrNotHoldOwner   = errors.New("You are not authorized to cancel this Hold")
+	ErrBookCopyOnHold = errors.New("Book copy is on hold for another patron")
+	ErrBookAvailable  = errors.New("Book has copies available on the shelf")
+
+	ErrBookCopyNotAvailable = errors.New("Book copy is not available for borrowing")
+	ErrBookCopyNotOnLoan    = errors.New("Book copy is not on loan")
+	ErrBookCopyNotLost      = errors.New("Book copy is not lost")
+	ErrCloseBorrow          = errors.New("Error closing Borrow")
+
+	ErrRenew          = errors.New("Error renewing Borrow")
+	ErrGetRenewals    = errors.New("Error retrieving Renewals of the Borrow")
+	ErrBorrowReturned = errors.New("Borrow has already been returned")
+	ErrRenewOnHold    = errors.New("Borrow can not be renewed because other patrons are waiting for the Book")
+	ErrRenewOverdue   = errors.New("Borrow is too overdue to be renewed")
+	ErrMaxRenewals    = errors.New("Borrow has reached the maximum number of renewals")
+
+	ErrCountActiveBorrows = errors.New("Error counting active Borrows of the User")
+	ErrGetLoans           = errors.New("Error retrieving loan history")
+	ErrInvalidLoanStatus  = errors.New("Invalid loan status")
 )
 
 // Service provides basic operations on Borrowing domain model.
 type Service interface {
-	Borrow(username string, bookCopyID string) (*Borrow, error)
+	Borrow(username string, bookCopyID string, changedBy string) (*Borrow, error)
 	Get(borrowID string) (*Borrow, error)
 	GetByUserIDAndBookCopyID(userID string, bookCopyID string) (*Borrow, error)
 	CheckBorrowed(bookCopyID string) (bool, error)
-	Return(username string, bookCopyID string) (*Borrow, error)
+	GetLoans(userID string, status string) ([]*Borrow, error)
+	GetBookCopyLoans(bookCopyID string) ([]*Borrow, error)
+	Return(username string, bookCopyID string, branchID string, changedBy string) (*Borrow, error)
+	Renew(username string, bookCopyID string) (*Borrow, error)
+	GetRenewals(borrowID string) ([]*Renewal, error)
+
+	// Lost and damaged operations.
+	ReportLost(username string, bookCopyID string) (*Borrow, error)
+	ReportDamaged(username string, bookCopyID string) (*Borrow, error)
+	ReportFound(username string, bookCopyID string) (*bookcopy.BookCopy, error)
+
+	// Hold operations.
+	PlaceHold(username string, bookID string) (*Hold, error)
+	CancelHold(username string, holdID string) (*Hold, error)
+	GetActiveHolds(bookID string) ([]*Hold, error)
+
+	// Transfer operations.
+	ReceiveTransfer(username string, branchID string, barcode string) (*transfer.Transfer, error)
 }
 
 type service struct {
 	borrowingRepository Repository
 	userService         user.Service
+	bookService         book.Service
 	bookCopyService     bookcopy.Service
+	policyService       policy.Service
+	calendarService     calendar.Service
+	fineService         fine.Service
+	transferService     transfer.Service
 }
 
 // NewBorrowingService creates an instance of the service for the Borrowing domain model
 // with all of the necessary dependencies.
-func NewBorrowingService(borrowingRepository Repository, userService user.Service, bookCopyService bookcopy.Service) Service {
+func NewBorrowingService(borrowingRepository Repository, userService user.Service, bookService book.Service, bookCopyService bookcopy.Service, policyService policy.Service, calendarService calendar.Service, fineService fine.Service, transferService transfer.Service) Service {
 	return &service{
 		borrowingRepository: borrowingRepository,
 		userService:         userService,
+		bookService:         bookService,
 		bookCopyService:     bookCopyService,
+		policyService:       policyService,
+		calendarService:     calendarService,
+		fineService:         fineService,
+		transferService:     transferService,
 	}
 }
 
-func (s *service) Borrow(username string, bookCopyID string) (*Borrow, error) {
+// Borrow lends the Book Copy to the User with the username. The status changes of
+// the Book Copy are recorded as made by changedBy, who is the librarian at the
+// circulation desk when the User does not borrow the Book Copy by themselves.
+func (s *service) Borrow(username string, bookCopyID string, changedBy string) (*Borrow, error) {
 	userID, err := s.userService.GetUserIDByUsername(username)
 	if err != nil {
 		return nil, err
 	}
 
 	// Check if Book Copy with the particular ID exists.
-	if _, err := s.bookCopyService.Get(bookCopyID); err != nil {
+	bookCopy, err := s.bookCopyService.Get(bookCopyID)
+	if err != nil {
 		return nil, err
 	}
 
@@ -59,9 +127,55 @@ func (s *service) Borrow(username string, bookCopyID string) (*Borrow, error) {
 		return nil, errors.New("Book " + bookCopyID + " is currently being borrowed")
 	}
 
-	newBorrow := NewBorrow(util.NewID(), userID, bookCopyID, 0, time.Now(), time.Now().AddDate(0, 0, 7), time.Time{})
+	// Check if the particular Book Copy is trapped for another patron's Hold.
+	trappedHold, err := s.getTrappedHold(bookCopy, changedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if trappedHold != nil && trappedHold.UserID != userID {
+		return nil, ErrBookCopyOnHold
+	}
+
+	// Only copies on the shelf, or waiting on the hold shelf for this patron, can be lent.
+	if trappedHold == nil && bookCopy.Status != bookcopy.StatusAvailable {
+		return nil, ErrBookCopyNotAvailable
+	}
+
+	loanPolicy, err := s.resolvePolicy(username, bookCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkBlocked(userID, loanPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	dueDate, err := s.calendarService.NextOpenDay(time.Now().AddDate(0, 0, loanPolicy.LoanPeriodDays))
+	if err != nil {
+		return nil, err
+	}
+
+	newBorrow := NewBorrow(util.NewID(), userID, bookCopyID, 0, 0, bookCopy.CurrentBranchID, "", time.Now(), dueDate, nil)
+
+	newBorrow, err = s.borrowingRepository.Borrow(newBorrow)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.bookCopyService.ChangeStatus(bookCopyID, bookcopy.StatusOnLoan, changedBy, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// The Borrow fulfills the Hold of the patron on the Book, whichever copy they borrowed.
+	err = s.fulfillHold(userID, bookCopy, changedBy)
+	if err != nil {
+		return nil, err
+	}
 
-	return s.borrowingRepository.Borrow(newBorrow)
+	return newBorrow, nil
 }
 
 func (s *service) Get(borrowID string) (*Borrow, error) {
@@ -76,7 +190,36 @@ func (s *service) CheckBorrowed(bookCopyID string) (bool, error) {
 	return s.borrowingRepository.CheckBorrowed(bookCopyID)
 }
 
-func (s *service) Return(username string, bookCopyID string) (*Borrow, error) {
+func (s *service) GetLoans(userID string, status string) ([]*Borrow, error) {
+	switch status {
+	case "", LoanActive, LoanReturned:
+	default:
+		return nil, ErrInvalidLoanStatus
+	}
+
+	borrows, err := s.borrowingRepository.GetByUserID(userID, status)
+	if err != nil {
+		return nil, ErrGetLoans
+	}
+
+	return borrows, nil
+}
+
+func (s *service) GetBookCopyLoans(bookCopyID string) ([]*Borrow, error) {
+	borrows, err := s.borrowingRepository.GetByBookCopyID(bookCopyID)
+	if err != nil {
+		return nil, ErrGetLoans
+	}
+
+	return borrows, nil
+}
+
+// Return closes the Borrow of the Book Copy at the Branch, which is the home
+// Branch of the Book Copy when none is given. A Book Copy returned at its home
+// Branch goes back to its home Location, otherwise it stays at the Branch
+// until it is sent home. The status changes of the Book Copy are recorded as made
+// by changedBy, like those of Borrow.
+func (s *service) Return(username string, bookCopyID string, branchID string, changedBy string) (*Borrow, error) {
 	userID, err := s.userService.GetUserIDByUsername(username)
 	if err != nil {
 		return nil, err
@@ -87,17 +230,618 @@ func (s *service) Return(username string, bookCopyID string) (*Borrow, error) {
 		return nil, err
 	}
 
-	borrow.ReturnedAt = time.Now()
+	if borrow.ReturnedAt != nil {
+		return nil, ErrBorrowReturned
+	}
+
+	bookCopy, err := s.bookCopyService.Get(bookCopyID)
+	if err != nil {
+		return nil, err
+	}
+
+	loanPolicy, err := s.resolvePolicy(username, bookCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	if branchID == "" {
+		branchID = bookCopy.HomeBranchID
+	}
+
+	if branchID != "" {
+		locationID := ""
+		if branchID == bookCopy.HomeBranchID {
+			locationID = bookCopy.HomeLocationID
+		}
+
+		_, err = s.bookCopyService.Relocate(bookCopyID, branchID, locationID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	returnedAt := time.Now()
+	borrow.ReturnedAt = &returnedAt
+	borrow.ReturnBranchID = branchID
 
+	// The days overdue, and the closed days among them, are both days of the calendar of the library.
+	fineNote := ""
 	if borrow.ReturnedAt.After(borrow.DueDate) {
-		diff := int(borrow.ReturnedAt.Sub(borrow.DueDate).Hours() / 24)
-		borrow.Fine = uint32(diff * finePerDay)
+		diff := s.calendarService.DaysBetween(borrow.DueDate, *borrow.ReturnedAt)
+
+		if loanPolicy.SkipClosedDays {
+			closedDays, err := s.calendarService.ClosedDaysBetween(borrow.DueDate, *borrow.ReturnedAt)
+			if err != nil {
+				return nil, err
+			}
+
+			diff -= closedDays
+		}
+
+		borrow.Fine = loanPolicy.Fine(diff)
+		fineNote = fmt.Sprintf("Returned %d days overdue", diff)
+	}
+
+	// A copy a librarian moved elsewhere while on loan keeps its status.
+	onLoan := bookCopy.Status == bookcopy.StatusOnLoan
+
+	// A copy returned at another Branch is sent home along with closing the Borrow,
+	// so that it is never on the shelf away from home, unless it waits there for
+	// the next patron in the Hold queue.
+	sendHome := false
+	if onLoan && bookCopy.HomeBranchID != "" && branchID != bookCopy.HomeBranchID {
+		waitingHold, err := s.nextWaitingHold(bookCopy.BookID)
+		if err != nil {
+			return nil, err
+		}
+
+		sendHome = waitingHold == nil
+	}
+
+	returnedBorrow, err := s.closeBorrow(borrow, func(tx util.Transaction) error {
+		if borrow.Fine != 0 {
+			_, err := s.fineService.WithTransaction(tx).Charge(userID, borrow.ID, borrow.Fine, fineNote)
+			if err != nil {
+				return err
+			}
+		}
+
+		if sendHome {
+			_, err := s.transferService.WithTransaction(tx).Send(changedBy, bookCopyID, bookCopy.HomeBranchID, "Returned at another Branch")
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Shelve the returned copy, or trap it for the next patron in the Hold queue.
+	if onLoan && !sendHome {
+		_, err = s.shelveOrTrap(bookCopy, changedBy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return returnedBorrow, nil
+}
+
+func (s *service) Renew(username string, bookCopyID string) (*Borrow, error) {
+	userID, err := s.userService.GetUserIDByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	borrow, err := s.GetByUserIDAndBookCopyID(userID, bookCopyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if borrow.ReturnedAt != nil {
+		return nil, ErrBorrowReturned
+	}
+
+	bookCopy, err := s.bookCopyService.Get(bookCopyID)
+	if err != nil {
+		return nil, err
+	}
+
+	loanPolicy, err := s.resolvePolicy(username, bookCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(borrow.DueDate.AddDate(0, 0, loanPolicy.GracePeriodDays)) {
+		return nil, ErrRenewOverdue
+	}
+
+	renewals, err := s.GetRenewals(borrow.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(renewals) >= loanPolicy.MaxRenewals {
+		return nil, ErrMaxRenewals
+	}
+
+	// Other patrons with an active Hold on the Book, whether waiting or with a copy
+	// ready for pickup, take precedence over a renewal.
+	holds, err := s.GetActiveHolds(bookCopy.BookID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hold := range holds {
+		if hold.UserID != userID && (hold.Status == HoldWaiting || hold.Status == HoldReady) {
+			return nil, ErrRenewOnHold
+		}
+	}
+
+	previousDueDate := borrow.DueDate
+
+	borrow.DueDate, err = s.calendarService.NextOpenDay(borrow.DueDate.AddDate(0, 0, loanPolicy.LoanPeriodDays))
+	if err != nil {
+		return nil, err
+	}
+
+	renewedBorrow, err := s.borrowingRepository.Renew(borrow)
+	if err != nil {
+		return nil, ErrRenew
+	}
+
+	newRenewal := NewRenewal(util.NewID(), borrow.ID, userID, previousDueDate, renewedBorrow.DueDate, time.Now())
 
-		_, err = s.userService.AddFine(userID, borrow.Fine)
+	_, err = s.borrowingRepository.SaveRenewal(newRenewal)
+	if err != nil {
+		return nil, ErrRenew
+	}
+
+	return renewedBorrow, nil
+}
+
+func (s *service) GetRenewals(borrowID string) ([]*Renewal, error) {
+	renewals, err := s.borrowingRepository.GetRenewals(borrowID)
+	if err != nil {
+		return nil, ErrGetRenewals
+	}
+
+	return renewals, nil
+}
+
+func (s *service) ReportLost(username string, bookCopyID string) (*Borrow, error) {
+	return s.closeWithReplacement(username, bookCopyID, bookcopy.StatusLost)
+}
+
+func (s *service) ReportDamaged(username string, bookCopyID string) (*Borrow, error) {
+	return s.closeWithReplacement(username, bookCopyID, bookcopy.StatusDamaged)
+}
+
+// ReportFound puts a lost Book Copy back into circulation and reverses
+// the replacement charge of the Borrow it was lost on.
+func (s *service) ReportFound(username string, bookCopyID string) (*bookcopy.BookCopy, error) {
+	bookCopy, err := s.bookCopyService.Get(bookCopyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if bookCopy.Status != bookcopy.StatusLost {
+		return nil, ErrBookCopyNotLost
+	}
+
+	borrows, err := s.GetBookCopyLoans(bookCopyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The latest Borrow is the one the Book Copy was lost on, if it was lost on loan.
+	// The ledger keeps the charge and its reversal, so the Borrow no longer holds it.
+	if len(borrows) > 0 && borrows[0].ReplacementCharge > 0 {
+		borrow := borrows[0]
+		replacementCharge := borrow.ReplacementCharge
+		borrow.ReplacementCharge = 0
+
+		_, err = s.closeBorrow(borrow, func(tx util.Transaction) error {
+			_, err := s.fineService.WithTransaction(tx).Reverse(borrow.UserID, borrow.ID, replacementCharge, "Lost Book copy found", username)
+			return err
+		})
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	return s.borrowingRepository.Return(borrow)
+	_, err = s.shelveOrTrap(bookCopy, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+func (s *service) PlaceHold(username string, bookID string) (*Hold, error) {
+	userID, err := s.userService.GetUserIDByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if Book with the particular ID exists.
+	if _, err := s.bookService.Get(bookID); err != nil {
+		return nil, err
+	}
+
+	holds, err := s.GetActiveHolds(bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hold := range holds {
+		if hold.UserID == userID {
+			return nil, ErrAlreadyOnHold
+		}
+	}
+
+	// A Hold is only trapped when a copy is returned, so a Book with copies
+	// on the shelf is borrowed instead of put on hold.
+	availability, err := s.bookService.GetAvailability(bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, branchAvailability := range availability {
+		if branchAvailability.Available > 0 {
+			return nil, ErrBookAvailable
+		}
+	}
+
+	newHold := NewHold(util.NewID(), userID, bookID, "", HoldWaiting, time.Now(), time.Time{}, time.Time{})
+
+	newHold, err = s.borrowingRepository.SaveHold(newHold)
+	if err != nil {
+		return nil, ErrPlaceHold
+	}
+
+	return newHold, nil
+}
+
+func (s *service) CancelHold(username string, holdID string) (*Hold, error) {
+	userID, err := s.userService.GetUserIDByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	hold, err := s.borrowingRepository.GetHold(holdID)
+	if err != nil {
+		return nil, ErrGetHold
+	}
+
+	// Librarians are allowed to cancel any patron's Hold.
+	if hold.UserID != userID {
+		role, err := s.userService.GetRole(username)
+		if err != nil {
+			return nil, err
+		}
+
+		if role != "librarian" {
+			return nil, ErrNotHoldOwner
+		}
+	}
+
+	if hold.Status != HoldWaiting && hold.Status != HoldReady {
+		return nil, ErrHoldNotActive
+	}
+
+	wasReady := hold.Status == HoldReady
+	hold.Status = HoldCancelled
+
+	hold, err = s.borrowingRepository.UpdateHold(hold)
+	if err != nil {
+		return nil, ErrUpdateHold
+	}
+
+	// Pass the copy that was trapped for the cancelled Hold to the next patron.
+	if wasReady {
+		bookCopy, err := s.bookCopyService.Get(hold.BookCopyID)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = s.shelveOrTrap(bookCopy, username)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return hold, nil
+}
+
+func (s *service) GetActiveHolds(bookID string) ([]*Hold, error) {
+	holds, err := s.borrowingRepository.GetActiveHolds(bookID)
+	if err != nil {
+		return nil, ErrGetActiveHolds
+	}
+
+	return holds, nil
+}
+
+// ReceiveTransfer checks in the Book Copy with the barcode at the destination
+// Branch of its Transfer, then traps it for the next patron in the Hold queue
+// of its Book or puts it back on the shelf.
+func (s *service) ReceiveTransfer(username string, branchID string, barcode string) (*transfer.Transfer, error) {
+	bookCopy, err := s.bookCopyService.GetByBarcode(barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	receivedTransfer, err := s.transferService.Receive(username, branchID, barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	// A copy a librarian moved elsewhere while in transit keeps its status.
+	if bookCopy.Status == bookcopy.StatusInTransit {
+		_, err = s.shelveOrTrap(bookCopy, username)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return receivedTransfer, nil
+}
+
+// getTrappedHold returns the Hold that the Book Copy is currently trapped for,
+// or nil if it is not trapped. A Hold whose pickup deadline has passed expires
+// and the copy is trapped for the next patron in the queue instead.
+func (s *service) getTrappedHold(bookCopy *bookcopy.BookCopy, changedBy string) (*Hold, error) {
+	holds, err := s.GetActiveHolds(bookCopy.BookID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hold := range holds {
+		if hold.Status != HoldReady || hold.BookCopyID != bookCopy.ID {
+			continue
+		}
+
+		if time.Now().Before(hold.PickupBy) {
+			return hold, nil
+		}
+
+		hold.Status = HoldExpired
+
+		_, err = s.borrowingRepository.UpdateHold(hold)
+		if err != nil {
+			return nil, ErrUpdateHold
+		}
+
+		return s.shelveOrTrap(bookCopy, changedBy)
+	}
+
+	return nil, nil
+}
+
+// fulfillHold marks the active Hold of the patron on the Book of the borrowed Book Copy
+// as fulfilled. A copy that was trapped for that Hold, other than the borrowed one,
+// is passed to the next patron in the queue or put back on the shelf.
+func (s *service) fulfillHold(userID string, bookCopy *bookcopy.BookCopy, changedBy string) error {
+	holds, err := s.GetActiveHolds(bookCopy.BookID)
+	if err != nil {
+		return err
+	}
+
+	for _, hold := range holds {
+		if hold.UserID != userID {
+			continue
+		}
+
+		releasedBookCopyID := ""
+		if hold.Status == HoldReady && hold.BookCopyID != bookCopy.ID {
+			releasedBookCopyID = hold.BookCopyID
+		}
+
+		hold.BookCopyID = bookCopy.ID
+		hold.Status = HoldFulfilled
+
+		_, err = s.borrowingRepository.UpdateHold(hold)
+		if err != nil {
+			return ErrUpdateHold
+		}
+
+		if releasedBookCopyID != "" {
+			releasedBookCopy, err := s.bookCopyService.Get(releasedBookCopyID)
+			if err != nil {
+				return err
+			}
+
+			_, err = s.shelveOrTrap(releasedBookCopy, changedBy)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// shelveOrTrap traps the Book Copy for the next waiting Hold and puts it on the
+// hold shelf, or puts it back on the shelf when nobody is waiting for it.
+func (s *service) shelveOrTrap(bookCopy *bookcopy.BookCopy, changedBy string) (*Hold, error) {
+	trappedHold, err := s.trapForNextHold(bookCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	status := bookcopy.StatusAvailable
+	if trappedHold != nil {
+		status = bookcopy.StatusOnHoldShelf
+	}
+
+	if bookCopy.Status != status {
+		_, err = s.bookCopyService.ChangeStatus(bookCopy.ID, status, changedBy, "")
+		if err != nil {
+			return nil, err
+		}
+
+		bookCopy.Status = status
+	}
+
+	return trappedHold, nil
+}
+
+// trapForNextHold traps the Book Copy for the first waiting Hold in the
+// queue of its Book and returns that Hold, or nil if nobody is waiting.
+func (s *service) trapForNextHold(bookCopy *bookcopy.BookCopy) (*Hold, error) {
+	hold, err := s.nextWaitingHold(bookCopy.BookID)
+	if err != nil || hold == nil {
+		return nil, err
+	}
+
+	hold.BookCopyID = bookCopy.ID
+	hold.Status = HoldReady
+	hold.ReadyAt = time.Now()
+	hold.PickupBy = hold.ReadyAt.AddDate(0, 0, holdPickupDays)
+
+	trappedHold, err := s.borrowingRepository.UpdateHold(hold)
+	if err != nil {
+		return nil, ErrUpdateHold
+	}
+
+	return trappedHold, nil
+}
+
+// nextWaitingHold returns the first waiting Hold in the queue of the Book,
+// or nil if nobody is waiting.
+func (s *service) nextWaitingHold(bookID string) (*Hold, error) {
+	holds, err := s.GetActiveHolds(bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hold := range holds {
+		if hold.Status == HoldWaiting {
+			return hold, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// closeWithReplacement closes the active Borrow of the Book Copy, charges the
+// patron its acquisition price as the replacement cost and moves the Book Copy
+// to the status. No overdue fine is charged on top of the replacement cost.
+func (s *service) closeWithReplacement(username string, bookCopyID string, status string) (*Borrow, error) {
+	bookCopy, err := s.bookCopyService.Get(bookCopyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if bookCopy.Status != bookcopy.StatusOnLoan {
+		return nil, ErrBookCopyNotOnLoan
+	}
+
+	borrows, err := s.GetBookCopyLoans(bookCopyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(borrows) == 0 || borrows[0].ReturnedAt != nil {
+		return nil, ErrBookCopyNotOnLoan
+	}
+
+	borrow := borrows[0]
+
+	returnedAt := time.Now()
+	borrow.ReturnedAt = &returnedAt
+	borrow.ReplacementCharge = bookCopy.AcquisitionPrice
+
+	returnedBorrow, err := s.closeBorrow(borrow, func(tx util.Transaction) error {
+		if borrow.ReplacementCharge == 0 {
+			return nil
+		}
+
+		_, err := s.fineService.WithTransaction(tx).Charge(borrow.UserID, borrow.ID, borrow.ReplacementCharge, fmt.Sprintf("Replacement cost of %s Book copy %s", status, bookCopyID))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.bookCopyService.ChangeStatus(bookCopyID, status, username, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return returnedBorrow, nil
+}
+
+// closeBorrow saves the closed Borrow along with what is recorded for it, such as
+// the charges of the ledger, in one Transaction, so that a Borrow which fails to close
+// is not charged, and is not charged again when it is closed once more.
+func (s *service) closeBorrow(borrow *Borrow, record func(tx util.Transaction) error) (*Borrow, error) {
+	tx, err := s.borrowingRepository.Begin()
+	if err != nil {
+		return nil, ErrCloseBorrow
+	}
+
+	err = record(tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	closedBorrow, err := s.borrowingRepository.WithTransaction(tx).Return(borrow)
+	if err != nil {
+		tx.Rollback()
+		return nil, ErrCloseBorrow
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, ErrCloseBorrow
+	}
+
+	return closedBorrow, nil
+}
+
+// resolvePolicy returns the lending Policy that applies to the patron
+// with the given username borrowing the particular Book Copy.
+func (s *service) resolvePolicy(username string, bookCopy *bookcopy.BookCopy) (*policy.Policy, error) {
+	role, err := s.userService.GetRole(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.policyService.Resolve(role, bookCopy.Category)
+}
+
+// checkBlocked returns a BlockedError when the patron already holds the maximum
+// number of concurrent loans or owes more than the fine threshold of the Policy.
+func (s *service) checkBlocked(userID string, loanPolicy *policy.Policy) error {
+	if loanPolicy.MaxLoans != 0 {
+		activeBorrows, err := s.borrowingRepository.CountActiveBorrows(userID)
+		if err != nil {
+			return ErrCountActiveBorrows
+		}
+
+		if activeBorrows >= loanPolicy.MaxLoans {
+			return NewBlockedError(ReasonMaxLoans, fmt.Sprintf("User already has %d active Borrows, the maximum allowed", activeBorrows))
+		}
+	}
+
+	if loanPolicy.MaxFine != 0 {
+		totalFine, err := s.userService.GetTotalFine(userID)
+		if err != nil {
+			return err
+		}
+
+		if totalFine > loanPolicy.MaxFine {
+			return NewBlockedError(ReasonFineThreshold, fmt.Sprintf("User owes %d in fines, more than the %d allowed", totalFine, loanPolicy.MaxFine))
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,53 @@
+package branch
+
+import (
+	"time"
+)
+
+// Branch domain model. A Branch is one of the libraries on the campus,
+// identified on labels and slips by its short Code.
+type Branch struct {
+	ID      string    `json:"id" db:"id"`
+	Code    string    `json:"code" db:"code"`
+	Name    string    `json:"name" db:"name"`
+	Address string    `json:"address" db:"address"`
+	AddedAt time.Time `json:"addedAt" db:"added_at"`
+}
+
+// NewBranch creates a new instance of Branch domain model.
+func NewBranch(id string, code string, name string, address string, addedAt time.Time) *Branch {
+	return &Branch{
+		ID:      id,
+		Code:    code,
+		Name:    name,
+		Address: address,
+		AddedAt: addedAt,
+	}
+}
+
+// Location domain model. A Location is where Book Copies are shelved in a Branch,
+// a range of shelves from ShelfFrom to ShelfTo in a room on a floor.
+type Location struct {
+	ID        string    `json:"id" db:"id"`
+	BranchID  string    `json:"branchID" db:"branch_id"`
+	Name      string    `json:"name" db:"name"`
+	Floor     string    `json:"floor" db:"floor"`
+	Room      string    `json:"room" db:"room"`
+	ShelfFrom string    `json:"shelfFrom" db:"shelf_from"`
+	ShelfTo   string    `json:"shelfTo" db:"shelf_to"`
+	AddedAt   time.Time `json:"addedAt" db:"added_at"`
+}
+
+// NewLocation creates a new instance of Location domain model.
+func NewLocation(id string, branchID string, name string, floor string, room string, shelfFrom string, shelfTo string, addedAt time.Time) *Location {
+	return &Location{
+		ID:        id,
+		BranchID:  branchID,
+		Name:      name,
+		Floor:     floor,
+		Room:      room,
+		ShelfFrom: shelfFrom,
+		ShelfTo:   shelfTo,
+		AddedAt:   addedAt,
+	}
+}
@@ -0,0 +1,171 @@
+package branch
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+)
+
+var branchRepository = &MockRepository{}
+
+var branchService = NewBranchService(branchRepository)
+
+func newBranch() *Branch {
+	branch := &Branch{
+		ID:   util.NewID(),
+		Code: "MAIN",
+		Name: "Main Library",
+	}
+	branchRepository.On("GetBranch", branch.ID).Return(branch, nil)
+
+	return branch
+}
+
+func newLocation(branchID string) *Location {
+	location := &Location{
+		ID:        util.NewID(),
+		BranchID:  branchID,
+		Name:      "Science stacks",
+		Floor:     "2",
+		ShelfFrom: "Q",
+		ShelfTo:   "QZ",
+	}
+	branchRepository.On("GetLocation", location.ID).Return(location, nil)
+
+	return location
+}
+
+func TestCreateBranch(t *testing.T) {
+	branchRepository.On("SaveBranch", mock.AnythingOfType("*branch.Branch")).Return(func(branch *Branch) *Branch {
+		return branch
+	}, nil)
+
+	tt := []struct {
+		name   string
+		branch *Branch
+		err    error
+	}{
+		{
+			name:   "success creating a Branch",
+			branch: &Branch{Code: "ENG", Name: "Engineering Library"},
+			err:    nil,
+		},
+		{
+			name:   "Branch without a code",
+			branch: &Branch{Name: "Engineering Library"},
+			err:    ErrInvalidBranch,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			newBranch, err := branchService.CreateBranch(tc.branch)
+
+			require.Equal(t, tc.err, err)
+
+			if tc.err == nil {
+				require.NotEmpty(t, newBranch.ID)
+				require.Equal(t, tc.branch.Code, newBranch.Code)
+			}
+		})
+	}
+}
+
+func TestDeleteBranch(t *testing.T) {
+	emptyBranch := newBranch()
+	branchRepository.On("GetLocations", emptyBranch.ID).Return([]*Location{}, nil)
+	branchRepository.On("DeleteBranch", emptyBranch.ID).Return(nil)
+
+	shelvedBranch := newBranch()
+	branchRepository.On("GetLocations", shelvedBranch.ID).Return([]*Location{newLocation(shelvedBranch.ID)}, nil)
+
+	require.Nil(t, branchService.DeleteBranch(emptyBranch.ID))
+	require.Equal(t, ErrBranchHasLocations, branchService.DeleteBranch(shelvedBranch.ID))
+	branchRepository.AssertNotCalled(t, "DeleteBranch", shelvedBranch.ID)
+}
+
+func TestCreateLocation(t *testing.T) {
+	branch := newBranch()
+	unknownBranchID := util.NewID()
+	branchRepository.On("GetBranch", unknownBranchID).Return(nil, errors.New("sql: no rows in result set"))
+	branchRepository.On("SaveLocation", mock.AnythingOfType("*branch.Location")).Return(func(location *Location) *Location {
+		return location
+	}, nil)
+
+	tt := []struct {
+		name     string
+		location *Location
+		err      error
+	}{
+		{
+			name:     "success creating a Location",
+			location: &Location{BranchID: branch.ID, Name: "Reference", Floor: "1"},
+			err:      nil,
+		},
+		{
+			name:     "Location without a name",
+			location: &Location{BranchID: branch.ID},
+			err:      ErrInvalidLocation,
+		},
+		{
+			name:     "Location in an unknown Branch",
+			location: &Location{BranchID: unknownBranchID, Name: "Reference"},
+			err:      ErrGetBranch,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			newLocation, err := branchService.CreateLocation(tc.location)
+
+			require.Equal(t, tc.err, err)
+
+			if tc.err == nil {
+				require.NotEmpty(t, newLocation.ID)
+				require.Equal(t, branch.ID, newLocation.BranchID)
+			}
+		})
+	}
+}
+
+func TestValidateLocation(t *testing.T) {
+	branch := newBranch()
+	anotherBranch := newBranch()
+	location := newLocation(branch.ID)
+
+	tt := []struct {
+		name       string
+		branchID   string
+		locationID string
+		err        error
+	}{
+		{
+			name:       "Location in the Branch",
+			branchID:   branch.ID,
+			locationID: location.ID,
+			err:        nil,
+		},
+		{
+			name:       "Branch without a Location",
+			branchID:   branch.ID,
+			locationID: "",
+			err:        nil,
+		},
+		{
+			name:       "Location in another Branch",
+			branchID:   anotherBranch.ID,
+			locationID: location.ID,
+			err:        ErrLocationNotInBranch,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.err, branchService.ValidateLocation(tc.branchID, tc.locationID))
+		})
+	}
+}
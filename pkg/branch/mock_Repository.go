@@ -0,0 +1,222 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package branch
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+// DeleteBranch provides a mock function with given fields: branchID
+func (_m *MockRepository) DeleteBranch(branchID string) error {
+	ret := _m.Called(branchID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(branchID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteLocation provides a mock function with given fields: locationID
+func (_m *MockRepository) DeleteLocation(locationID string) error {
+	ret := _m.Called(locationID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(locationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetBranch provides a mock function with given fields: branchID
+func (_m *MockRepository) GetBranch(branchID string) (*Branch, error) {
+	ret := _m.Called(branchID)
+
+	var r0 *Branch
+	if rf, ok := ret.Get(0).(func(string) *Branch); ok {
+		r0 = rf(branchID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Branch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(branchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBranches provides a mock function with given fields:
+func (_m *MockRepository) GetBranches() ([]*Branch, error) {
+	ret := _m.Called()
+
+	var r0 []*Branch
+	if rf, ok := ret.Get(0).(func() []*Branch); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Branch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLocation provides a mock function with given fields: locationID
+func (_m *MockRepository) GetLocation(locationID string) (*Location, error) {
+	ret := _m.Called(locationID)
+
+	var r0 *Location
+	if rf, ok := ret.Get(0).(func(string) *Location); ok {
+		r0 = rf(locationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Location)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(locationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLocations provides a mock function with given fields: branchID
+func (_m *MockRepository) GetLocations(branchID string) ([]*Location, error) {
+	ret := _m.Called(branchID)
+
+	var r0 []*Location
+	if rf, ok := ret.Get(0).(func(string) []*Location); ok {
+		r0 = rf(branchID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Location)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(branchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveBranch provides a mock function with given fields: branch
+func (_m *MockRepository) SaveBranch(branch *Branch) (*Branch, error) {
+	ret := _m.Called(branch)
+
+	var r0 *Branch
+	if rf, ok := ret.Get(0).(func(*Branch) *Branch); ok {
+		r0 = rf(branch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Branch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Branch) error); ok {
+		r1 = rf(branch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveLocation provides a mock function with given fields: location
+func (_m *MockRepository) SaveLocation(location *Location) (*Location, error) {
+	ret := _m.Called(location)
+
+	var r0 *Location
+	if rf, ok := ret.Get(0).(func(*Location) *Location); ok {
+		r0 = rf(location)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Location)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Location) error); ok {
+		r1 = rf(location)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateBranch provides a mock function with given fields: branch
+func (_m *MockRepository) UpdateBranch(branch *Branch) (*Branch, error) {
+	ret := _m.Called(branch)
+
+	var r0 *Branch
+	if rf, ok := ret.Get(0).(func(*Branch) *Branch); ok {
+		r0 = rf(branch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Branch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Branch) error); ok {
+		r1 = rf(branch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLocation provides a mock function with given fields: location
+func (_m *MockRepository) UpdateLocation(location *Location) (*Location, error) {
+	ret := _m.Called(location)
+
+	var r0 *Location
+	if rf, ok := ret.Get(0).(func(*Location) *Location); ok {
+		r0 = rf(location)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Location)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Location) error); ok {
+		r1 = rf(location)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
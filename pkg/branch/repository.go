@@ -0,0 +1,18 @@
+package branch
+
+// Repository provides access to the Branch store.
+type Repository interface {
+	// Branch operations.
+	SaveBranch(branch *Branch) (*Branch, error)
+	GetBranch(branchID string) (*Branch, error)
+	UpdateBranch(branch *Branch) (*Branch, error)
+	DeleteBranch(branchID string) error
+	GetBranches() ([]*Branch, error)
+
+	// Location operations.
+	SaveLocation(location *Location) (*Location, error)
+	GetLocation(locationID string) (*Location, error)
+	UpdateLocation(location *Location) (*Location, error)
+	DeleteLocation(locationID string) error
+	GetLocations(branchID string) ([]*Location, error)
+}
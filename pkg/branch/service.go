@@ -0,0 +1,216 @@
+package branch
+
+import (
+	"errors"
+	"time"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+)
+
+// Errors definition.
+var (
+	ErrCreateBranch       = errors.New("Error creating Branch")
+	ErrGetBranch          = errors.New("Error retrieving Branch")
+	ErrUpdateBranch       = errors.New("Error updating Branch")
+	ErrDeleteBranch       = errors.New("Error deleting Branch")
+	ErrGetBranches        = errors.New("Error retrieving Branches")
+	ErrInvalidBranch      = errors.New("Branch must have a code and a name")
+	ErrBranchHasLocations = errors.New("Branch can not be deleted while it has Locations")
+
+	ErrCreateLocation      = errors.New("Error creating Location")
+	ErrGetLocation         = errors.New("Error retrieving Location")
+	ErrUpdateLocation      = errors.New("Error updating Location")
+	ErrDeleteLocation      = errors.New("Error deleting Location")
+	ErrGetLocations        = errors.New("Error retrieving Locations")
+	ErrInvalidLocation     = errors.New("Location must have a name")
+	ErrLocationNotInBranch = errors.New("Location is not in the Branch")
+)
+
+// Service provides basic operations on Branch domain model.
+type Service interface {
+	// Branch operations.
+	CreateBranch(branch *Branch) (*Branch, error)
+	GetBranch(branchID string) (*Branch, error)
+	UpdateBranch(branch *Branch) (*Branch, error)
+	DeleteBranch(branchID string) error
+	GetBranches() ([]*Branch, error)
+
+	// Location operations.
+	CreateLocation(location *Location) (*Location, error)
+	GetLocation(locationID string) (*Location, error)
+	UpdateLocation(location *Location) (*Location, error)
+	DeleteLocation(locationID string) error
+	GetLocations(branchID string) ([]*Location, error)
+
+	// Other operations.
+	ValidateLocation(branchID string, locationID string) error
+}
+
+type service struct {
+	branchRepository Repository
+}
+
+// NewBranchService creates an instance of the service for the Branch domain model
+// with all of the necessary dependencies.
+func NewBranchService(branchRepository Repository) Service {
+	return &service{
+		branchRepository: branchRepository,
+	}
+}
+
+func (s *service) CreateBranch(branch *Branch) (*Branch, error) {
+	if branch.Code == "" || branch.Name == "" {
+		return nil, ErrInvalidBranch
+	}
+
+	newBranch := NewBranch(util.NewID(), branch.Code, branch.Name, branch.Address, time.Now())
+
+	newBranch, err := s.branchRepository.SaveBranch(newBranch)
+	if err != nil {
+		return nil, ErrCreateBranch
+	}
+
+	return newBranch, nil
+}
+
+func (s *service) GetBranch(branchID string) (*Branch, error) {
+	branch, err := s.branchRepository.GetBranch(branchID)
+	if err != nil {
+		return nil, ErrGetBranch
+	}
+
+	return branch, nil
+}
+
+func (s *service) UpdateBranch(branch *Branch) (*Branch, error) {
+	if branch.Code == "" || branch.Name == "" {
+		return nil, ErrInvalidBranch
+	}
+
+	branch, err := s.branchRepository.UpdateBranch(branch)
+	if err != nil {
+		return nil, ErrUpdateBranch
+	}
+
+	return branch, nil
+}
+
+// DeleteBranch deletes the Branch once all of its Locations are deleted,
+// so that no Location is left without a Branch.
+func (s *service) DeleteBranch(branchID string) error {
+	locations, err := s.GetLocations(branchID)
+	if err != nil {
+		return err
+	}
+
+	if len(locations) > 0 {
+		return ErrBranchHasLocations
+	}
+
+	err = s.branchRepository.DeleteBranch(branchID)
+	if err != nil {
+		return ErrDeleteBranch
+	}
+
+	return nil
+}
+
+func (s *service) GetBranches() ([]*Branch, error) {
+	branches, err := s.branchRepository.GetBranches()
+	if err != nil {
+		return nil, ErrGetBranches
+	}
+
+	return branches, nil
+}
+
+func (s *service) CreateLocation(location *Location) (*Location, error) {
+	if location.Name == "" {
+		return nil, ErrInvalidLocation
+	}
+
+	// Check if Branch with the particular ID exists.
+	_, err := s.GetBranch(location.BranchID)
+	if err != nil {
+		return nil, err
+	}
+
+	newLocation := NewLocation(util.NewID(), location.BranchID, location.Name, location.Floor, location.Room, location.ShelfFrom, location.ShelfTo, time.Now())
+
+	newLocation, err = s.branchRepository.SaveLocation(newLocation)
+	if err != nil {
+		return nil, ErrCreateLocation
+	}
+
+	return newLocation, nil
+}
+
+func (s *service) GetLocation(locationID string) (*Location, error) {
+	location, err := s.branchRepository.GetLocation(locationID)
+	if err != nil {
+		return nil, ErrGetLocation
+	}
+
+	return location, nil
+}
+
+// UpdateLocation updates the Location within its Branch, a Location
+// can not be moved to another Branch.
+func (s *service) UpdateLocation(location *Location) (*Location, error) {
+	if location.Name == "" {
+		return nil, ErrInvalidLocation
+	}
+
+	err := s.ValidateLocation(location.BranchID, location.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err = s.branchRepository.UpdateLocation(location)
+	if err != nil {
+		return nil, ErrUpdateLocation
+	}
+
+	return location, nil
+}
+
+func (s *service) DeleteLocation(locationID string) error {
+	err := s.branchRepository.DeleteLocation(locationID)
+	if err != nil {
+		return ErrDeleteLocation
+	}
+
+	return nil
+}
+
+func (s *service) GetLocations(branchID string) ([]*Location, error) {
+	locations, err := s.branchRepository.GetLocations(branchID)
+	if err != nil {
+		return nil, ErrGetLocations
+	}
+
+	return locations, nil
+}
+
+// ValidateLocation checks that the Branch exists and that the Location, if any, is in it.
+func (s *service) ValidateLocation(branchID string, locationID string) error {
+	_, err := s.GetBranch(branchID)
+	if err != nil {
+		return err
+	}
+
+	if locationID == "" {
+		return nil
+	}
+
+	location, err := s.GetLocation(locationID)
+	if err != nil {
+		return err
+	}
+
+	if location.BranchID != branchID {
+		return ErrLocationNotInBranch
+	}
+
+	return nil
+}
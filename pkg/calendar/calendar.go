@@ -0,0 +1,118 @@
+package calendar
+
+import (
+	"time"
+)
+
+// Kinds of Closure.
+const (
+	ClosureHoliday = "holiday"
+	ClosureAdHoc   = "closure"
+)
+
+// dateLayout is the layout used for comparing days regardless of the time of day.
+const dateLayout = "2006-01-02"
+
+// OpeningHours domain model. OpeningHours holds the time the library opens
+// and closes on a weekday, or whether it is closed for the whole weekday.
+// A weekday without OpeningHours is considered open.
+type OpeningHours struct {
+	Weekday time.Weekday `json:"weekday" db:"weekday"`
+	Opens   string       `json:"opens" db:"opens"`
+	Closes  string       `json:"closes" db:"closes"`
+	Closed  bool         `json:"closed" db:"closed"`
+}
+
+// NewOpeningHours creates a new instance of OpeningHours domain model.
+func NewOpeningHours(weekday time.Weekday, opens string, closes string, closed bool) *OpeningHours {
+	return &OpeningHours{
+		Weekday: weekday,
+		Opens:   opens,
+		Closes:  closes,
+		Closed:  closed,
+	}
+}
+
+// Closure domain model. A Closure is either a public holiday or an ad-hoc
+// closure of the library from StartDate until EndDate, both inclusive.
+type Closure struct {
+	ID        string    `json:"id" db:"id"`
+	Kind      string    `json:"kind" db:"kind"`
+	Reason    string    `json:"reason" db:"reason"`
+	StartDate time.Time `json:"startDate" db:"start_date"`
+	EndDate   time.Time `json:"endDate" db:"end_date"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
+
+// NewClosure creates a new instance of Closure domain model.
+func NewClosure(id string, kind string, reason string, startDate time.Time, endDate time.Time, createdAt time.Time) *Closure {
+	return &Closure{
+		ID:        id,
+		Kind:      kind,
+		Reason:    reason,
+		StartDate: startDate,
+		EndDate:   endDate,
+		CreatedAt: createdAt,
+	}
+}
+
+// Covers returns whether the library in the location is closed on the day of
+// the given time because of the Closure. The dates of a Closure are days of the
+// calendar of the library, which are stored without a time zone, so only the
+// given time is converted to the location.
+func (closure *Closure) Covers(day time.Time, location *time.Location) bool {
+	key := localDate(day, location)
+
+	return closure.StartDate.Format(dateLayout) <= key && key <= closure.EndDate.Format(dateLayout)
+}
+
+// localDate returns the day of the given time in the location, as a date of dateLayout.
+func localDate(day time.Time, location *time.Location) string {
+	return day.In(location).Format(dateLayout)
+}
+
+// startOfDay returns the midnight, in the location, of the day of the given time in the location.
+func startOfDay(day time.Time, location *time.Location) time.Time {
+	year, month, date := day.In(location).Date()
+
+	return time.Date(year, month, date, 0, 0, 0, 0, location)
+}
+
+// schedule holds the OpeningHours and the Closures of a period,
+// so that the open days can be looked up without querying the store.
+type schedule struct {
+	hours    map[time.Weekday]*OpeningHours
+	closures []*Closure
+	location *time.Location
+}
+
+func newSchedule(hours []*OpeningHours, closures []*Closure, location *time.Location) *schedule {
+	weekdays := make(map[time.Weekday]*OpeningHours)
+	for _, openingHours := range hours {
+		weekdays[openingHours.Weekday] = openingHours
+	}
+
+	return &schedule{
+		hours:    weekdays,
+		closures: closures,
+		location: location,
+	}
+}
+
+// isOpen returns whether the library is open on the day of the given time
+// in the location of the library.
+func (s *schedule) isOpen(day time.Time) bool {
+	day = day.In(s.location)
+
+	if openingHours, ok := s.hours[day.Weekday()]; ok && openingHours.Closed {
+		return false
+	}
+
+	for _, closure := range s.closures {
+		if closure.Covers(day, s.location) {
+			return false
+		}
+	}
+
+	return true
+}
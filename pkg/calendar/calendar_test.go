@@ -0,0 +1,339 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+)
+
+// monday is the day the calendar tests start from.
+var monday = time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+
+var calendarRepository = &MockRepository{}
+var calendarService = NewCalendarService(calendarRepository, time.UTC)
+
+func init() {
+	// The library is closed on Sundays and for a holiday on Wednesday and Thursday.
+	hours := []*OpeningHours{
+		NewOpeningHours(time.Sunday, "", "", true),
+		NewOpeningHours(time.Monday, "09:00", "17:00", false),
+	}
+	holiday := NewClosure(util.NewID(), ClosureHoliday, "Holiday", monday.AddDate(0, 0, 2), monday.AddDate(0, 0, 3), time.Now())
+
+	calendarRepository.On("GetOpeningHours").Return(hours, nil)
+	calendarRepository.On("GetClosures", mock.Anything, mock.Anything).Return([]*Closure{holiday}, nil)
+}
+
+func TestIsOpen(t *testing.T) {
+	tt := []struct {
+		name string
+		day  time.Time
+		open bool
+	}{
+		{
+			name: "open weekday",
+			day:  monday,
+			open: true,
+		},
+		{
+			name: "weekday without opening hours",
+			day:  monday.AddDate(0, 0, 1),
+			open: true,
+		},
+		{
+			name: "holiday",
+			day:  monday.AddDate(0, 0, 3),
+			open: false,
+		},
+		{
+			name: "closed weekday",
+			day:  monday.AddDate(0, 0, 6),
+			open: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			open, err := calendarService.IsOpen(tc.day)
+
+			require.Nil(t, err)
+			require.Equal(t, tc.open, open)
+		})
+	}
+}
+
+func TestNextOpenDay(t *testing.T) {
+	tt := []struct {
+		name     string
+		day      time.Time
+		expected time.Time
+	}{
+		{
+			name:     "open day",
+			day:      monday,
+			expected: monday,
+		},
+		{
+			name:     "first day of a holiday",
+			day:      monday.AddDate(0, 0, 2),
+			expected: monday.AddDate(0, 0, 4),
+		},
+		{
+			name:     "closed weekday",
+			day:      monday.AddDate(0, 0, 6),
+			expected: monday.AddDate(0, 0, 7),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			nextOpenDay, err := calendarService.NextOpenDay(tc.day)
+
+			require.Nil(t, err)
+			require.Equal(t, tc.expected, nextOpenDay)
+		})
+	}
+}
+
+func TestNextOpenDayAlwaysClosed(t *testing.T) {
+	hours := []*OpeningHours{}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		hours = append(hours, NewOpeningHours(weekday, "", "", true))
+	}
+
+	closedRepository := &MockRepository{}
+	closedRepository.On("GetOpeningHours").Return(hours, nil)
+	closedRepository.On("GetClosures", mock.Anything, mock.Anything).Return([]*Closure{}, nil)
+
+	_, err := NewCalendarService(closedRepository, time.UTC).NextOpenDay(monday)
+
+	require.Equal(t, ErrNoOpenDay, err)
+}
+
+func TestClosedDaysBetween(t *testing.T) {
+	// From Monday to the next Monday, the holiday and Sunday are closed.
+	closedDays, err := calendarService.ClosedDaysBetween(monday, monday.AddDate(0, 0, 7))
+
+	require.Nil(t, err)
+	require.Equal(t, 3, closedDays)
+
+	closedDays, err = calendarService.ClosedDaysBetween(monday, monday)
+
+	require.Nil(t, err)
+	require.Equal(t, 0, closedDays)
+}
+
+func TestSetOpeningHours(t *testing.T) {
+	validHours := NewOpeningHours(time.Saturday, "10:00", "14:00", false)
+	calendarRepository.On("SaveOpeningHours", validHours).Return(validHours, nil)
+
+	tt := []struct {
+		name  string
+		hours *OpeningHours
+		err   error
+	}{
+		{
+			name:  "valid opening hours",
+			hours: validHours,
+			err:   nil,
+		},
+		{
+			name:  "closing before opening",
+			hours: NewOpeningHours(time.Saturday, "14:00", "10:00", false),
+			err:   ErrInvalidOpeningHours,
+		},
+		{
+			name:  "malformed opening time",
+			hours: NewOpeningHours(time.Saturday, "10am", "14:00", false),
+			err:   ErrInvalidOpeningHours,
+		},
+		{
+			name:  "invalid weekday",
+			hours: NewOpeningHours(time.Weekday(7), "10:00", "14:00", false),
+			err:   ErrInvalidOpeningHours,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			hours, err := calendarService.SetOpeningHours([]*OpeningHours{tc.hours})
+
+			if tc.err != nil {
+				require.Nil(t, hours)
+				require.Equal(t, tc.err, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, []*OpeningHours{tc.hours}, hours)
+		})
+	}
+}
+
+func TestCreateClosure(t *testing.T) {
+	tt := []struct {
+		name    string
+		closure *Closure
+		err     error
+	}{
+		{
+			name:    "unknown kind",
+			closure: NewClosure("", "strike", "", monday, monday, time.Time{}),
+			err:     ErrInvalidClosure,
+		},
+		{
+			name:    "ending before starting",
+			closure: NewClosure("", ClosureAdHoc, "Flooding", monday, monday.AddDate(0, 0, -1), time.Time{}),
+			err:     ErrInvalidClosure,
+		},
+		{
+			name:    "without dates",
+			closure: NewClosure("", ClosureAdHoc, "Flooding", time.Time{}, time.Time{}, time.Time{}),
+			err:     ErrInvalidClosure,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			closure, err := calendarService.CreateClosure(tc.closure)
+
+			require.Nil(t, closure)
+			require.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func TestCovers(t *testing.T) {
+	// The Closure is stored as the days of Christmas, without a time zone.
+	christmas := time.Date(2026, time.December, 25, 0, 0, 0, 0, time.UTC)
+	closure := NewClosure(util.NewID(), ClosureHoliday, "Christmas", christmas, christmas, time.Now())
+
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	newYork := time.FixedZone("EST", -5*60*60)
+
+	tt := []struct {
+		name     string
+		day      time.Time
+		location *time.Location
+		covers   bool
+	}{
+		{
+			name:     "morning of Christmas in a library ahead of UTC",
+			day:      time.Date(2026, time.December, 24, 23, 0, 0, 0, time.UTC),
+			location: jakarta,
+			covers:   true,
+		},
+		{
+			name:     "evening of Christmas in a library behind UTC",
+			day:      time.Date(2026, time.December, 26, 1, 0, 0, 0, time.UTC),
+			location: newYork,
+			covers:   true,
+		},
+		{
+			name:     "day after Christmas in a library ahead of UTC",
+			day:      time.Date(2026, time.December, 25, 18, 0, 0, 0, time.UTC),
+			location: jakarta,
+			covers:   false,
+		},
+		{
+			name:     "day after Christmas in UTC",
+			day:      time.Date(2026, time.December, 26, 1, 0, 0, 0, time.UTC),
+			location: time.UTC,
+			covers:   false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.covers, closure.Covers(tc.day, tc.location))
+		})
+	}
+}
+
+func TestCreateClosureInLocation(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	jakartaRepository := &MockRepository{}
+	jakartaRepository.On("SaveClosure", mock.AnythingOfType("*calendar.Closure")).Return(func(closure *Closure) *Closure {
+		return closure
+	}, nil)
+
+	// Christmas in the library starts at 17:00 UTC on Christmas Eve.
+	closure, err := NewCalendarService(jakartaRepository, jakarta).CreateClosure(NewClosure("", ClosureHoliday, "Christmas", time.Date(2026, time.December, 24, 17, 0, 0, 0, time.UTC), time.Date(2026, time.December, 25, 16, 0, 0, 0, time.UTC), time.Time{}))
+
+	require.Nil(t, err)
+	require.Equal(t, "2026-12-25", closure.StartDate.Format(dateLayout))
+	require.Equal(t, "2026-12-25", closure.EndDate.Format(dateLayout))
+}
+
+func TestClosedDaysBetweenInLocation(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	// Christmas is closed on the last day of the period in the library, which is
+	// still Christmas Eve in UTC.
+	christmas := time.Date(2026, time.December, 25, 0, 0, 0, 0, time.UTC)
+	closure := NewClosure(util.NewID(), ClosureHoliday, "Christmas", christmas, christmas, time.Now())
+
+	jakartaRepository := &MockRepository{}
+	jakartaRepository.On("GetOpeningHours").Return([]*OpeningHours{}, nil)
+	jakartaRepository.On("GetClosures", "2026-12-21", "2026-12-25").Return([]*Closure{closure}, nil)
+	jakartaRepository.On("GetClosures", "2026-12-25", "2026-12-25").Return([]*Closure{closure}, nil)
+	jakartaService := NewCalendarService(jakartaRepository, jakarta)
+
+	from := time.Date(2026, time.December, 20, 18, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.December, 24, 18, 0, 0, 0, time.UTC)
+
+	closedDays, err := jakartaService.ClosedDaysBetween(from, to)
+
+	require.Nil(t, err)
+	require.Equal(t, 1, closedDays)
+	require.Equal(t, 4, jakartaService.DaysBetween(from, to))
+
+	open, err := jakartaService.IsOpen(to)
+
+	require.Nil(t, err)
+	require.False(t, open)
+}
+
+func TestDaysBetween(t *testing.T) {
+	tt := []struct {
+		name string
+		from time.Time
+		to   time.Time
+		days int
+	}{
+		{
+			name: "same day",
+			from: monday,
+			to:   monday.Add(6 * time.Hour),
+			days: 0,
+		},
+		{
+			name: "less than a day over midnight",
+			from: monday.Add(12 * time.Hour),
+			to:   monday.Add(14 * time.Hour),
+			days: 1,
+		},
+		{
+			name: "a week",
+			from: monday,
+			to:   monday.AddDate(0, 0, 7),
+			days: 7,
+		},
+		{
+			name: "backwards",
+			from: monday,
+			to:   monday.AddDate(0, 0, -1),
+			days: -1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.days, calendarService.DaysBetween(tc.from, tc.to))
+		})
+	}
+}
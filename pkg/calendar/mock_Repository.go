@@ -0,0 +1,139 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package calendar
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+// DeleteClosure provides a mock function with given fields: closureID
+func (_m *MockRepository) DeleteClosure(closureID string) error {
+	ret := _m.Called(closureID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(closureID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetClosure provides a mock function with given fields: closureID
+func (_m *MockRepository) GetClosure(closureID string) (*Closure, error) {
+	ret := _m.Called(closureID)
+
+	var r0 *Closure
+	if rf, ok := ret.Get(0).(func(string) *Closure); ok {
+		r0 = rf(closureID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Closure)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(closureID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClosures provides a mock function with given fields: from, to
+func (_m *MockRepository) GetClosures(from string, to string) ([]*Closure, error) {
+	ret := _m.Called(from, to)
+
+	var r0 []*Closure
+	if rf, ok := ret.Get(0).(func(string, string) []*Closure); ok {
+		r0 = rf(from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Closure)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpeningHours provides a mock function with given fields:
+func (_m *MockRepository) GetOpeningHours() ([]*OpeningHours, error) {
+	ret := _m.Called()
+
+	var r0 []*OpeningHours
+	if rf, ok := ret.Get(0).(func() []*OpeningHours); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*OpeningHours)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveClosure provides a mock function with given fields: closure
+func (_m *MockRepository) SaveClosure(closure *Closure) (*Closure, error) {
+	ret := _m.Called(closure)
+
+	var r0 *Closure
+	if rf, ok := ret.Get(0).(func(*Closure) *Closure); ok {
+		r0 = rf(closure)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Closure)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Closure) error); ok {
+		r1 = rf(closure)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveOpeningHours provides a mock function with given fields: openingHours
+func (_m *MockRepository) SaveOpeningHours(openingHours *OpeningHours) (*OpeningHours, error) {
+	ret := _m.Called(openingHours)
+
+	var r0 *OpeningHours
+	if rf, ok := ret.Get(0).(func(*OpeningHours) *OpeningHours); ok {
+		r0 = rf(openingHours)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*OpeningHours)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*OpeningHours) error); ok {
+		r1 = rf(openingHours)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
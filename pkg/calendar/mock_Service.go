@@ -0,0 +1,220 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package calendar
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+// ClosedDaysBetween provides a mock function with given fields: from, to
+func (_m *MockService) ClosedDaysBetween(from time.Time, to time.Time) (int, error) {
+	ret := _m.Called(from, to)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) int); ok {
+		r0 = rf(from, to)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time) error); ok {
+		r1 = rf(from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateClosure provides a mock function with given fields: closure
+func (_m *MockService) CreateClosure(closure *Closure) (*Closure, error) {
+	ret := _m.Called(closure)
+
+	var r0 *Closure
+	if rf, ok := ret.Get(0).(func(*Closure) *Closure); ok {
+		r0 = rf(closure)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Closure)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Closure) error); ok {
+		r1 = rf(closure)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DaysBetween provides a mock function with given fields: from, to
+func (_m *MockService) DaysBetween(from time.Time, to time.Time) int {
+	ret := _m.Called(from, to)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) int); ok {
+		r0 = rf(from, to)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// DeleteClosure provides a mock function with given fields: closureID
+func (_m *MockService) DeleteClosure(closureID string) error {
+	ret := _m.Called(closureID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(closureID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetClosure provides a mock function with given fields: closureID
+func (_m *MockService) GetClosure(closureID string) (*Closure, error) {
+	ret := _m.Called(closureID)
+
+	var r0 *Closure
+	if rf, ok := ret.Get(0).(func(string) *Closure); ok {
+		r0 = rf(closureID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Closure)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(closureID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClosures provides a mock function with given fields: from, to
+func (_m *MockService) GetClosures(from time.Time, to time.Time) ([]*Closure, error) {
+	ret := _m.Called(from, to)
+
+	var r0 []*Closure
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) []*Closure); ok {
+		r0 = rf(from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Closure)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time) error); ok {
+		r1 = rf(from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpeningHours provides a mock function with given fields:
+func (_m *MockService) GetOpeningHours() ([]*OpeningHours, error) {
+	ret := _m.Called()
+
+	var r0 []*OpeningHours
+	if rf, ok := ret.Get(0).(func() []*OpeningHours); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*OpeningHours)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsOpen provides a mock function with given fields: day
+func (_m *MockService) IsOpen(day time.Time) (bool, error) {
+	ret := _m.Called(day)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(time.Time) bool); ok {
+		r0 = rf(day)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(day)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NextOpenDay provides a mock function with given fields: day
+func (_m *MockService) NextOpenDay(day time.Time) (time.Time, error) {
+	ret := _m.Called(day)
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func(time.Time) time.Time); ok {
+		r0 = rf(day)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(day)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetOpeningHours provides a mock function with given fields: hours
+func (_m *MockService) SetOpeningHours(hours []*OpeningHours) ([]*OpeningHours, error) {
+	ret := _m.Called(hours)
+
+	var r0 []*OpeningHours
+	if rf, ok := ret.Get(0).(func([]*OpeningHours) []*OpeningHours); ok {
+		r0 = rf(hours)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*OpeningHours)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]*OpeningHours) error); ok {
+		r1 = rf(hours)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
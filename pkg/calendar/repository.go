@@ -0,0 +1,16 @@
+package calendar
+
+// Repository provides access to the Calendar store.
+type Repository interface {
+	// Opening hours operations.
+	SaveOpeningHours(openingHours *OpeningHours) (*OpeningHours, error)
+	GetOpeningHours() ([]*OpeningHours, error)
+
+	// Closure operations.
+	SaveClosure(closure *Closure) (*Closure, error)
+	GetClosure(closureID string) (*Closure, error)
+	DeleteClosure(closureID string) error
+	// GetClosures returns the Closures covering any day from the date from
+	// until the date to, both inclusive and both formatted as YYYY-MM-DD.
+	GetClosures(from string, to string) ([]*Closure, error)
+}
@@ -0,0 +1,272 @@
+package calendar
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+)
+
+// searchDays is the number of days NextOpenDay looks ahead for an open day.
+const searchDays = 366
+
+// hoursLayout is the layout of the opening and closing time of OpeningHours.
+const hoursLayout = "15:04"
+
+// Errors definition.
+var (
+	ErrSetOpeningHours     = errors.New("Error setting opening hours")
+	ErrGetOpeningHours     = errors.New("Error retrieving opening hours")
+	ErrInvalidOpeningHours = errors.New("Invalid opening hours")
+
+	ErrCreateClosure  = errors.New("Error creating Closure")
+	ErrGetClosure     = errors.New("Error retrieving Closure")
+	ErrDeleteClosure  = errors.New("Error deleting Closure")
+	ErrGetClosures    = errors.New("Error retrieving Closures")
+	ErrInvalidClosure = errors.New("Invalid Closure")
+
+	ErrNoOpenDay = errors.New("No open day found in the calendar")
+)
+
+// Service provides basic operations on Calendar domain model.
+type Service interface {
+	// Opening hours operations.
+	SetOpeningHours(hours []*OpeningHours) ([]*OpeningHours, error)
+	GetOpeningHours() ([]*OpeningHours, error)
+
+	// Closure operations.
+	CreateClosure(closure *Closure) (*Closure, error)
+	GetClosure(closureID string) (*Closure, error)
+	DeleteClosure(closureID string) error
+	GetClosures(from time.Time, to time.Time) ([]*Closure, error)
+
+	// Other operations.
+	IsOpen(day time.Time) (bool, error)
+	NextOpenDay(day time.Time) (time.Time, error)
+	ClosedDaysBetween(from time.Time, to time.Time) (int, error)
+	DaysBetween(from time.Time, to time.Time) int
+}
+
+type service struct {
+	calendarRepository Repository
+	location           *time.Location
+}
+
+// NewCalendarService creates an instance of the service for the Calendar domain model
+// with all of the necessary dependencies. The days of the calendar are the days
+// in the location of the library.
+func NewCalendarService(calendarRepository Repository, location *time.Location) Service {
+	return &service{
+		calendarRepository: calendarRepository,
+		location:           location,
+	}
+}
+
+// LocationFromEnv returns the location of the library named by LIBRARY_TIMEZONE,
+// such as Asia/Jakarta, which defaults to the local time zone of the server.
+func LocationFromEnv() *time.Location {
+	location, err := time.LoadLocation(os.Getenv("LIBRARY_TIMEZONE"))
+	if err != nil || os.Getenv("LIBRARY_TIMEZONE") == "" {
+		return time.Local
+	}
+
+	return location
+}
+
+func (s *service) SetOpeningHours(hours []*OpeningHours) ([]*OpeningHours, error) {
+	for _, openingHours := range hours {
+		err := validateOpeningHours(openingHours)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	savedHours := []*OpeningHours{}
+	for _, openingHours := range hours {
+		savedOpeningHours, err := s.calendarRepository.SaveOpeningHours(openingHours)
+		if err != nil {
+			return nil, ErrSetOpeningHours
+		}
+
+		savedHours = append(savedHours, savedOpeningHours)
+	}
+
+	return savedHours, nil
+}
+
+func (s *service) GetOpeningHours() ([]*OpeningHours, error) {
+	hours, err := s.calendarRepository.GetOpeningHours()
+	if err != nil {
+		return nil, ErrGetOpeningHours
+	}
+
+	return hours, nil
+}
+
+func (s *service) CreateClosure(closure *Closure) (*Closure, error) {
+	var newClosure *Closure
+
+	err := validateClosure(closure)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Closure is kept as the days it covers in the location of the library.
+	startDate := startOfDay(closure.StartDate, s.location)
+	endDate := startOfDay(closure.EndDate, s.location)
+
+	newClosure = NewClosure(util.NewID(), closure.Kind, closure.Reason, startDate, endDate, time.Now())
+
+	newClosure, err = s.calendarRepository.SaveClosure(newClosure)
+	if err != nil {
+		return nil, ErrCreateClosure
+	}
+
+	return newClosure, nil
+}
+
+func (s *service) GetClosure(closureID string) (*Closure, error) {
+	closure, err := s.calendarRepository.GetClosure(closureID)
+	if err != nil {
+		return nil, ErrGetClosure
+	}
+
+	return closure, nil
+}
+
+func (s *service) DeleteClosure(closureID string) error {
+	err := s.calendarRepository.DeleteClosure(closureID)
+	if err != nil {
+		return ErrDeleteClosure
+	}
+
+	return nil
+}
+
+// GetClosures returns the Closures covering any day of the library from the day
+// of from until the day of to.
+func (s *service) GetClosures(from time.Time, to time.Time) ([]*Closure, error) {
+	closures, err := s.calendarRepository.GetClosures(localDate(from, s.location), localDate(to, s.location))
+	if err != nil {
+		return nil, ErrGetClosures
+	}
+
+	return closures, nil
+}
+
+func (s *service) IsOpen(day time.Time) (bool, error) {
+	schedule, err := s.getSchedule(day, day)
+	if err != nil {
+		return false, err
+	}
+
+	return schedule.isOpen(day), nil
+}
+
+// NextOpenDay returns the given time moved to the first day the library is open
+// from its day on, keeping its time of day and its time zone.
+func (s *service) NextOpenDay(day time.Time) (time.Time, error) {
+	schedule, err := s.getSchedule(day, day.AddDate(0, 0, searchDays))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for i := 0; i <= searchDays; i++ {
+		if schedule.isOpen(day) {
+			return day, nil
+		}
+
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return time.Time{}, ErrNoOpenDay
+}
+
+// ClosedDaysBetween counts the days the library is closed after the day
+// of from, up to and including the day of to.
+func (s *service) ClosedDaysBetween(from time.Time, to time.Time) (int, error) {
+	days := s.DaysBetween(from, to)
+	if days <= 0 {
+		return 0, nil
+	}
+
+	schedule, err := s.getSchedule(from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	closedDays := 0
+	day := startOfDay(from, s.location)
+	for i := 0; i < days; i++ {
+		day = day.AddDate(0, 0, 1)
+
+		if !schedule.isOpen(day) {
+			closedDays++
+		}
+	}
+
+	return closedDays, nil
+}
+
+// DaysBetween counts the days of the library after the day of from, up to and
+// including the day of to, however many hours there are between the two.
+func (s *service) DaysBetween(from time.Time, to time.Time) int {
+	fromDate, _ := time.Parse(dateLayout, localDate(from, s.location))
+	toDate, _ := time.Parse(dateLayout, localDate(to, s.location))
+
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
+
+// getSchedule loads the OpeningHours and the Closures between from and to.
+func (s *service) getSchedule(from time.Time, to time.Time) (*schedule, error) {
+	hours, err := s.GetOpeningHours()
+	if err != nil {
+		return nil, err
+	}
+
+	closures, err := s.GetClosures(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSchedule(hours, closures, s.location), nil
+}
+
+func validateOpeningHours(openingHours *OpeningHours) error {
+	if openingHours.Weekday < time.Sunday || openingHours.Weekday > time.Saturday {
+		return ErrInvalidOpeningHours
+	}
+
+	if openingHours.Closed {
+		return nil
+	}
+
+	opens, err := time.Parse(hoursLayout, openingHours.Opens)
+	if err != nil {
+		return ErrInvalidOpeningHours
+	}
+
+	closes, err := time.Parse(hoursLayout, openingHours.Closes)
+	if err != nil {
+		return ErrInvalidOpeningHours
+	}
+
+	if !closes.After(opens) {
+		return ErrInvalidOpeningHours
+	}
+
+	return nil
+}
+
+func validateClosure(closure *Closure) error {
+	if closure.Kind != ClosureHoliday && closure.Kind != ClosureAdHoc {
+		return ErrInvalidClosure
+	}
+
+	if closure.StartDate.IsZero() || closure.EndDate.IsZero() || closure.EndDate.Before(closure.StartDate) {
+		return ErrInvalidClosure
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package circulation
+
+import (
+	"time"
+
+	"github.com/joshuabezaleel/library-server/pkg/borrowing"
+	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+	"github.com/joshuabezaleel/library-server/pkg/core/user"
+)
+
+// Actions done at the circulation desk.
+const (
+	ActionCheckout = "checkout"
+	ActionCheckin  = "checkin"
+)
+
+// Scan domain model. A Scan is what the circulation desk reads: the identifier
+// of the patron, which is a student ID, a username or a card number, and the
+// barcode of the Book Copy. Branch is the Branch of the desk, where
+// checked in Book Copies are returned.
+type Scan struct {
+	Patron  string `json:"patron"`
+	Barcode string `json:"barcode"`
+	Branch  string `json:"branch"`
+}
+
+// Slip domain model. A Slip sums up a checkout or a check-in for the
+// circulation desk, along with the Holds it triggered and the fines it incurred.
+type Slip struct {
+	Action         string            `json:"action"`
+	UserID         string            `json:"userID"`
+	Username       string            `json:"username"`
+	BookID         string            `json:"bookID"`
+	BookCopyID     string            `json:"bookCopyID"`
+	Barcode        string            `json:"barcode"`
+	BorrowID       string            `json:"borrowID"`
+	DueDate        time.Time         `json:"dueDate"`
+	ReturnedAt     *time.Time        `json:"returnedAt"`
+	HoldsTriggered []*borrowing.Hold `json:"holdsTriggered"`
+	FinesIncurred  uint32            `json:"finesIncurred"`
+	TotalFine      uint32            `json:"totalFine"`
+}
+
+// NewSlip creates a new instance of Slip domain model.
+func NewSlip(action string, patron *user.User, bookCopy *bookcopy.BookCopy, borrow *borrowing.Borrow, holdsTriggered []*borrowing.Hold, totalFine uint32) *Slip {
+	return &Slip{
+		Action:         action,
+		UserID:         patron.ID,
+		Username:       patron.Username,
+		BookID:         bookCopy.BookID,
+		BookCopyID:     bookCopy.ID,
+		Barcode:        bookCopy.Barcode,
+		BorrowID:       borrow.ID,
+		DueDate:        borrow.DueDate,
+		ReturnedAt:     borrow.ReturnedAt,
+		HoldsTriggered: holdsTriggered,
+		FinesIncurred:  borrow.Fine,
+		TotalFine:      totalFine,
+	}
+}
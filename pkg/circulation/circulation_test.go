@@ -0,0 +1,174 @@
+package circulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/borrowing"
+	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+	"github.com/joshuabezaleel/library-server/pkg/core/user"
+)
+
+var borrowingService = &borrowing.MockService{}
+var userService = &user.MockService{}
+var bookCopyService = &bookcopy.MockService{}
+
+var circulationService = NewCirculationService(borrowingService, userService, bookCopyService)
+
+var librarian = &user.User{
+	ID:       util.NewID(),
+	Username: "librarian",
+	Role:     user.RoleLibrarian,
+}
+
+func init() {
+	userService.On("GetRole", librarian.Username).Return(user.RoleLibrarian, nil)
+	userService.On("GetByIdentifier", librarian.Username).Return(librarian, nil)
+}
+
+func newPatron() *user.User {
+	patron := &user.User{
+		ID:         util.NewID(),
+		StudentID:  util.NewID()[:8],
+		CardNumber: util.NewID(),
+		Username:   util.NewID(),
+		Role:       user.RoleStudent,
+	}
+	userService.On("GetByIdentifier", patron.StudentID).Return(patron, nil)
+	userService.On("GetByIdentifier", patron.CardNumber).Return(patron, nil)
+	userService.On("GetByIdentifier", patron.Username).Return(patron, nil)
+	userService.On("GetRole", patron.Username).Return(user.RoleStudent, nil)
+	userService.On("GetTotalFine", patron.ID).Return(uint32(0), nil)
+
+	return patron
+}
+
+func newBookCopy() *bookcopy.BookCopy {
+	bookCopy := &bookcopy.BookCopy{
+		ID:      util.NewID(),
+		BookID:  util.NewID(),
+		Barcode: util.NewID(),
+	}
+	bookCopyService.On("GetByBarcode", bookCopy.Barcode).Return(bookCopy, nil)
+
+	return bookCopy
+}
+
+func TestCheckout(t *testing.T) {
+	patron := newPatron()
+	anotherPatron := newPatron()
+
+	tt := []struct {
+		name     string
+		username string
+		patron   string
+		err      error
+	}{
+		{
+			name:     "librarian checking out with a card number",
+			username: librarian.Username,
+			patron:   patron.CardNumber,
+			err:      nil,
+		},
+		{
+			name:     "librarian checking out with a student ID",
+			username: librarian.Username,
+			patron:   patron.StudentID,
+			err:      nil,
+		},
+		{
+			name:     "patron checking out for themselves",
+			username: patron.Username,
+			patron:   "",
+			err:      nil,
+		},
+		{
+			name:     "patron checking out on behalf of another patron",
+			username: anotherPatron.Username,
+			patron:   patron.CardNumber,
+			err:      ErrNotOnBehalf,
+		},
+		{
+			name:     "unknown patron",
+			username: librarian.Username,
+			patron:   "unknownCardNumber",
+			err:      ErrPatronNotFound,
+		},
+		{
+			name:     "identifier of more than one patron",
+			username: librarian.Username,
+			patron:   "sharedIdentifier",
+			err:      ErrAmbiguousPatron,
+		},
+	}
+
+	userService.On("GetByIdentifier", "unknownCardNumber").Return(nil, user.ErrGetByIdentifier)
+	userService.On("GetByIdentifier", "sharedIdentifier").Return(nil, user.ErrAmbiguousIdentifier)
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookCopy := newBookCopy()
+
+			dueDate := time.Now().AddDate(0, 0, 14)
+			borrow := borrowing.NewBorrow(util.NewID(), patron.ID, bookCopy.ID, 0, 0, "", "", time.Now(), dueDate, nil)
+			borrowingService.On("Borrow", patron.Username, bookCopy.ID, tc.username).Return(borrow, nil)
+
+			slip, err := circulationService.Checkout(tc.username, &Scan{Patron: tc.patron, Barcode: bookCopy.Barcode})
+
+			require.Equal(t, tc.err, err)
+
+			if tc.err == nil {
+				require.Equal(t, ActionCheckout, slip.Action)
+				require.Equal(t, patron.Username, slip.Username)
+				require.Equal(t, borrow.ID, slip.BorrowID)
+				require.Equal(t, dueDate, slip.DueDate)
+			}
+		})
+	}
+
+	// The barcode does not belong to any Book Copy.
+	bookCopyService.On("GetByBarcode", "unknownBarcode").Return(nil, bookcopy.ErrGetBookCopy)
+
+	slip, err := circulationService.Checkout(librarian.Username, &Scan{Patron: patron.CardNumber, Barcode: "unknownBarcode"})
+
+	require.Nil(t, slip)
+	require.Equal(t, ErrBarcodeNotFound, err)
+}
+
+func TestCheckin(t *testing.T) {
+	patron := newPatron()
+	bookCopy := newBookCopy()
+
+	// The patron of the active Borrow is found from the barcode alone.
+	borrow := borrowing.NewBorrow(util.NewID(), patron.ID, bookCopy.ID, 0, 0, "", "", time.Now().AddDate(0, 0, -20), time.Now().AddDate(0, 0, -6), nil)
+	borrowingService.On("GetBookCopyLoans", bookCopy.ID).Return([]*borrowing.Borrow{borrow}, nil)
+	userService.On("Get", patron.ID).Return(patron, nil)
+
+	returnedAt := time.Now()
+	returnedBorrow := borrowing.NewBorrow(borrow.ID, patron.ID, bookCopy.ID, 12000, 0, "", "", borrow.BorrowedAt, borrow.DueDate, &returnedAt)
+	borrowingService.On("Return", patron.Username, bookCopy.ID, "", librarian.Username).Return(returnedBorrow, nil)
+
+	trappedHold := borrowing.NewHold(util.NewID(), util.NewID(), bookCopy.BookID, bookCopy.ID, borrowing.HoldReady, time.Now(), time.Now(), time.Now().AddDate(0, 0, 3))
+	waitingHold := borrowing.NewHold(util.NewID(), util.NewID(), bookCopy.BookID, "", borrowing.HoldWaiting, time.Now(), time.Time{}, time.Time{})
+	borrowingService.On("GetActiveHolds", bookCopy.BookID).Return([]*borrowing.Hold{trappedHold, waitingHold}, nil)
+
+	slip, err := circulationService.Checkin(librarian.Username, &Scan{Barcode: bookCopy.Barcode})
+
+	require.Nil(t, err)
+	require.Equal(t, ActionCheckin, slip.Action)
+	require.Equal(t, patron.ID, slip.UserID)
+	require.Equal(t, uint32(12000), slip.FinesIncurred)
+	require.Equal(t, []*borrowing.Hold{trappedHold}, slip.HoldsTriggered)
+
+	// A Book Copy that is not on loan can not be checked in.
+	shelvedBookCopy := newBookCopy()
+	borrowingService.On("GetBookCopyLoans", shelvedBookCopy.ID).Return([]*borrowing.Borrow{}, nil)
+
+	slip, err = circulationService.Checkin(librarian.Username, &Scan{Barcode: shelvedBookCopy.Barcode})
+
+	require.Nil(t, slip)
+	require.Equal(t, borrowing.ErrBookCopyNotOnLoan, err)
+}
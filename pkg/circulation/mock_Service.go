@@ -0,0 +1,56 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package circulation
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+// Checkin provides a mock function with given fields: username, scan
+func (_m *MockService) Checkin(username string, scan *Scan) (*Slip, error) {
+	ret := _m.Called(username, scan)
+
+	var r0 *Slip
+	if rf, ok := ret.Get(0).(func(string, *Scan) *Slip); ok {
+		r0 = rf(username, scan)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Slip)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *Scan) error); ok {
+		r1 = rf(username, scan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Checkout provides a mock function with given fields: username, scan
+func (_m *MockService) Checkout(username string, scan *Scan) (*Slip, error) {
+	ret := _m.Called(username, scan)
+
+	var r0 *Slip
+	if rf, ok := ret.Get(0).(func(string, *Scan) *Slip); ok {
+		r0 = rf(username, scan)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Slip)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *Scan) error); ok {
+		r1 = rf(username, scan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,163 @@
+package circulation
+
+import (
+	"errors"
+
+	"github.com/joshuabezaleel/library-server/pkg/borrowing"
+	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+	"github.com/joshuabezaleel/library-server/pkg/core/user"
+)
+
+// Errors definition.
+var (
+	ErrPatronNotFound  = errors.New("No patron found with the identifier")
+	ErrAmbiguousPatron = errors.New("More than one patron has the identifier, use the card number or student ID")
+	ErrBarcodeNotFound = errors.New("No Book copy found with the barcode")
+	ErrNotOnBehalf     = errors.New("Only librarians can act on behalf of another patron")
+)
+
+// Service provides the operations of the circulation desk, which identifies
+// Book Copies by their barcode and patrons by any of their identifiers.
+type Service interface {
+	Checkout(username string, scan *Scan) (*Slip, error)
+	Checkin(username string, scan *Scan) (*Slip, error)
+}
+
+type service struct {
+	borrowingService borrowing.Service
+	userService      user.Service
+	bookCopyService  bookcopy.Service
+}
+
+// NewCirculationService creates an instance of the service for the circulation desk
+// with all of the necessary dependencies.
+func NewCirculationService(borrowingService borrowing.Service, userService user.Service, bookCopyService bookcopy.Service) Service {
+	return &service{
+		borrowingService: borrowingService,
+		userService:      userService,
+		bookCopyService:  bookCopyService,
+	}
+}
+
+// Checkout lends the Book Copy to the patron of the Scan,
+// or to the User with the username when the Scan has no patron.
+func (s *service) Checkout(username string, scan *Scan) (*Slip, error) {
+	patronIdentifier := scan.Patron
+	if patronIdentifier == "" {
+		patronIdentifier = username
+	}
+
+	patron, err := s.getPatron(username, patronIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	bookCopy, err := s.getBookCopy(scan.Barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	borrow, err := s.borrowingService.Borrow(patron.Username, bookCopy.ID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	totalFine, err := s.userService.GetTotalFine(patron.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSlip(ActionCheckout, patron, bookCopy, borrow, []*borrowing.Hold{}, totalFine), nil
+}
+
+// Checkin takes the Book Copy back from the patron of the Scan, or from
+// the patron of its active Borrow when the Scan has no patron.
+func (s *service) Checkin(username string, scan *Scan) (*Slip, error) {
+	bookCopy, err := s.getBookCopy(scan.Barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	patronIdentifier := scan.Patron
+	if patronIdentifier == "" {
+		borrows, err := s.borrowingService.GetBookCopyLoans(bookCopy.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(borrows) == 0 || borrows[0].ReturnedAt != nil {
+			return nil, borrowing.ErrBookCopyNotOnLoan
+		}
+
+		borrower, err := s.userService.Get(borrows[0].UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		patronIdentifier = borrower.Username
+	}
+
+	patron, err := s.getPatron(username, patronIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	borrow, err := s.borrowingService.Return(patron.Username, bookCopy.ID, scan.Branch, username)
+	if err != nil {
+		return nil, err
+	}
+
+	// The returned copy may have been trapped for the next patron in the Hold queue.
+	holds, err := s.borrowingService.GetActiveHolds(bookCopy.BookID)
+	if err != nil {
+		return nil, err
+	}
+
+	holdsTriggered := []*borrowing.Hold{}
+	for _, hold := range holds {
+		if hold.Status == borrowing.HoldReady && hold.BookCopyID == bookCopy.ID {
+			holdsTriggered = append(holdsTriggered, hold)
+		}
+	}
+
+	totalFine, err := s.userService.GetTotalFine(patron.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSlip(ActionCheckin, patron, bookCopy, borrow, holdsTriggered, totalFine), nil
+}
+
+// getPatron returns the patron with the identifier, provided that the User
+// with the username is that patron or a librarian acting on their behalf.
+func (s *service) getPatron(username string, patronIdentifier string) (*user.User, error) {
+	patron, err := s.userService.GetByIdentifier(patronIdentifier)
+	if err == user.ErrAmbiguousIdentifier {
+		return nil, ErrAmbiguousPatron
+	}
+	if err != nil {
+		return nil, ErrPatronNotFound
+	}
+
+	if patron.Username != username {
+		role, err := s.userService.GetRole(username)
+		if err != nil {
+			return nil, err
+		}
+
+		if role != user.RoleLibrarian {
+			return nil, ErrNotOnBehalf
+		}
+	}
+
+	return patron, nil
+}
+
+func (s *service) getBookCopy(barcode string) (*bookcopy.BookCopy, error) {
+	bookCopy, err := s.bookCopyService.GetByBarcode(barcode)
+	if err != nil {
+		return nil, ErrBarcodeNotFound
+	}
+
+	return bookCopy, nil
+}
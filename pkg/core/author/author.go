@@ -0,0 +1,25 @@
+package author
+
+// Author domain model. AlternateNames are the other forms of the name
+// of the Author, such as pseudonyms, transliterations or the names of
+// merged duplicates, which Books can be catalogued under too.
+type Author struct {
+	ID             int64    `json:"id" db:"id"`
+	Name           string   `json:"name" db:"name"`
+	Biography      string   `json:"biography" db:"biography"`
+	BirthYear      int      `json:"birthYear" db:"birth_year"`
+	DeathYear      int      `json:"deathYear" db:"death_year"`
+	AlternateNames []string `json:"alternateNames" db:"-"`
+}
+
+// NewAuthor creates a new instance of Author domain model.
+func NewAuthor(id int64, name string, biography string, birthYear int, deathYear int, alternateNames []string) *Author {
+	return &Author{
+		ID:             id,
+		Name:           name,
+		Biography:      biography,
+		BirthYear:      birthYear,
+		DeathYear:      deathYear,
+		AlternateNames: alternateNames,
+	}
+}
@@ -0,0 +1,158 @@
+package author
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+)
+
+var authorRepository = &MockRepository{}
+var bookService = &book.MockService{}
+
+var authorService = NewAuthorService(authorRepository, bookService)
+
+func TestCreate(t *testing.T) {
+	author := &Author{Name: "Mark Twain", BirthYear: 1835, DeathYear: 1910, AlternateNames: []string{"Samuel Clemens"}}
+	savedAuthor := &Author{ID: 1, Name: "Mark Twain", BirthYear: 1835, DeathYear: 1910, AlternateNames: []string{"Samuel Clemens"}}
+
+	tt := []struct {
+		name   string
+		author *Author
+		err    error
+	}{
+		{
+			name:   "success creating an Author",
+			author: author,
+			err:    nil,
+		},
+		{
+			name:   "failed creating an Author without a name",
+			author: &Author{BirthYear: 1835},
+			err:    ErrInvalidAuthor,
+		},
+		{
+			name:   "failed creating an Author who died before being born",
+			author: &Author{Name: "Anonymous", BirthYear: 1910, DeathYear: 1835},
+			err:    ErrInvalidLifespan,
+		},
+	}
+
+	authorRepository.On("Save", mock.AnythingOfType("*author.Author")).Return(savedAuthor, nil)
+	authorRepository.On("SaveAlternateNames", savedAuthor.ID, savedAuthor.AlternateNames).Return(nil)
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			newAuthor, err := authorService.Create(tc.author)
+
+			require.Equal(t, tc.err, err)
+
+			if tc.err == nil {
+				require.Equal(t, savedAuthor.ID, newAuthor.ID)
+				require.Equal(t, savedAuthor.AlternateNames, newAuthor.AlternateNames)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tt := []struct {
+		name     string
+		authorID int64
+		bookIDs  []string
+		err      error
+	}{
+		{
+			name:     "success deleting an Author without Books",
+			authorID: 10,
+			bookIDs:  []string{},
+			err:      nil,
+		},
+		{
+			name:     "failed deleting an Author with Books",
+			authorID: 11,
+			bookIDs:  []string{util.NewID()},
+			err:      ErrAuthorHasBooks,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			authorRepository.On("GetBookIDs", tc.authorID).Return(tc.bookIDs, nil)
+			authorRepository.On("Delete", tc.authorID).Return(nil)
+
+			err := authorService.Delete(tc.authorID)
+
+			require.Equal(t, tc.err, err)
+
+			if tc.err != nil {
+				authorRepository.AssertNotCalled(t, "Delete", tc.authorID)
+			}
+		})
+	}
+}
+
+func TestGetBooks(t *testing.T) {
+	author := &Author{ID: 20, Name: "Alan A. A. Donovan"}
+	goBook := &book.Book{ID: util.NewID(), Title: "The Go Programming Language"}
+
+	authorRepository.On("Get", author.ID).Return(author, nil)
+	authorRepository.On("GetAlternateNames", author.ID).Return([]string{}, nil)
+	authorRepository.On("GetBookIDs", author.ID).Return([]string{goBook.ID}, nil)
+	bookService.On("Get", goBook.ID).Return(goBook, nil)
+
+	books, err := authorService.GetBooks(author.ID)
+
+	require.Nil(t, err)
+	require.Equal(t, []*book.Book{goBook}, books)
+}
+
+func TestMerge(t *testing.T) {
+	author := &Author{ID: 30, Name: "Mark Twain"}
+	duplicate := &Author{ID: 31, Name: "Twain, Mark"}
+	bookID := util.NewID()
+
+	authorRepository.On("Get", author.ID).Return(author, nil)
+	authorRepository.On("Get", duplicate.ID).Return(duplicate, nil)
+	authorRepository.On("GetAlternateNames", author.ID).Return([]string{duplicate.Name}, nil)
+	authorRepository.On("GetAlternateNames", duplicate.ID).Return([]string{}, nil)
+	authorRepository.On("Merge", author.ID, duplicate.ID).Return(nil)
+	authorRepository.On("GetBookIDs", author.ID).Return([]string{bookID}, nil)
+	bookService.On("RefreshSearchVector", bookID).Return(nil)
+
+	tt := []struct {
+		name        string
+		authorID    int64
+		duplicateID int64
+		err         error
+	}{
+		{
+			name:        "success merging a duplicate Author",
+			authorID:    author.ID,
+			duplicateID: duplicate.ID,
+			err:         nil,
+		},
+		{
+			name:        "failed merging an Author into itself",
+			authorID:    author.ID,
+			duplicateID: author.ID,
+			err:         ErrMergeSameAuthor,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			mergedAuthor, err := authorService.Merge(tc.authorID, tc.duplicateID)
+
+			require.Equal(t, tc.err, err)
+
+			if tc.err == nil {
+				require.Equal(t, []string{duplicate.Name}, mergedAuthor.AlternateNames)
+				bookService.AssertCalled(t, "RefreshSearchVector", bookID)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package author
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: authorID
+func (_m *MockRepository) Delete(authorID int64) error {
+	ret := _m.Called(authorID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(authorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: authorID
+func (_m *MockRepository) Get(authorID int64) (*Author, error) {
+	ret := _m.Called(authorID)
+
+	var r0 *Author
+	if rf, ok := ret.Get(0).(func(int64) *Author); ok {
+		r0 = rf(authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAlternateNames provides a mock function with given fields: authorID
+func (_m *MockRepository) GetAlternateNames(authorID int64) ([]string, error) {
+	ret := _m.Called(authorID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(int64) []string); ok {
+		r0 = rf(authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBookIDs provides a mock function with given fields: authorID
+func (_m *MockRepository) GetBookIDs(authorID int64) ([]string, error) {
+	ret := _m.Called(authorID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(int64) []string); ok {
+		r0 = rf(authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields:
+func (_m *MockRepository) List() ([]*Author, error) {
+	ret := _m.Called()
+
+	var r0 []*Author
+	if rf, ok := ret.Get(0).(func() []*Author); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Merge provides a mock function with given fields: authorID, duplicateID
+func (_m *MockRepository) Merge(authorID int64, duplicateID int64) error {
+	ret := _m.Called(authorID, duplicateID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(authorID, duplicateID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: author
+func (_m *MockRepository) Save(author *Author) (*Author, error) {
+	ret := _m.Called(author)
+
+	var r0 *Author
+	if rf, ok := ret.Get(0).(func(*Author) *Author); ok {
+		r0 = rf(author)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Author) error); ok {
+		r1 = rf(author)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveAlternateNames provides a mock function with given fields: authorID, names
+func (_m *MockRepository) SaveAlternateNames(authorID int64, names []string) error {
+	ret := _m.Called(authorID, names)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, []string) error); ok {
+		r0 = rf(authorID, names)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: author
+func (_m *MockRepository) Update(author *Author) (*Author, error) {
+	ret := _m.Called(author)
+
+	var r0 *Author
+	if rf, ok := ret.Get(0).(func(*Author) *Author); ok {
+		r0 = rf(author)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Author) error); ok {
+		r1 = rf(author)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,165 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package author
+
+import (
+	book "github.com/joshuabezaleel/library-server/pkg/core/book"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: author
+func (_m *MockService) Create(author *Author) (*Author, error) {
+	ret := _m.Called(author)
+
+	var r0 *Author
+	if rf, ok := ret.Get(0).(func(*Author) *Author); ok {
+		r0 = rf(author)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Author) error); ok {
+		r1 = rf(author)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: authorID
+func (_m *MockService) Delete(authorID int64) error {
+	ret := _m.Called(authorID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(authorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: authorID
+func (_m *MockService) Get(authorID int64) (*Author, error) {
+	ret := _m.Called(authorID)
+
+	var r0 *Author
+	if rf, ok := ret.Get(0).(func(int64) *Author); ok {
+		r0 = rf(authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBooks provides a mock function with given fields: authorID
+func (_m *MockService) GetBooks(authorID int64) ([]*book.Book, error) {
+	ret := _m.Called(authorID)
+
+	var r0 []*book.Book
+	if rf, ok := ret.Get(0).(func(int64) []*book.Book); ok {
+		r0 = rf(authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*book.Book)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields:
+func (_m *MockService) List() ([]*Author, error) {
+	ret := _m.Called()
+
+	var r0 []*Author
+	if rf, ok := ret.Get(0).(func() []*Author); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Merge provides a mock function with given fields: authorID, duplicateID
+func (_m *MockService) Merge(authorID int64, duplicateID int64) (*Author, error) {
+	ret := _m.Called(authorID, duplicateID)
+
+	var r0 *Author
+	if rf, ok := ret.Get(0).(func(int64, int64) *Author); ok {
+		r0 = rf(authorID, duplicateID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(authorID, duplicateID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: author
+func (_m *MockService) Update(author *Author) (*Author, error) {
+	ret := _m.Called(author)
+
+	var r0 *Author
+	if rf, ok := ret.Get(0).(func(*Author) *Author); ok {
+		r0 = rf(author)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Author) error); ok {
+		r1 = rf(author)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,17 @@
+package author
+
+// Repository provides access to the Author store.
+type Repository interface {
+	// CRUD operations.
+	Save(author *Author) (*Author, error)
+	Get(authorID int64) (*Author, error)
+	Update(author *Author) (*Author, error)
+	Delete(authorID int64) error
+
+	// Other operations.
+	List() ([]*Author, error)
+	SaveAlternateNames(authorID int64, names []string) error
+	GetAlternateNames(authorID int64) ([]string, error)
+	GetBookIDs(authorID int64) ([]string, error)
+	Merge(authorID int64, duplicateID int64) error
+}
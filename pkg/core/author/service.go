@@ -0,0 +1,232 @@
+package author
+
+import (
+	"errors"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+)
+
+// Errors definition.
+var (
+	ErrCreateAuthor       = errors.New("Error creating Author")
+	ErrGetAuthor          = errors.New("Error retrieving Author")
+	ErrUpdateAuthor       = errors.New("Error updating Author")
+	ErrDeleteAuthor       = errors.New("Error deleting Author")
+	ErrListAuthors        = errors.New("Error listing Authors")
+	ErrSaveAlternateNames = errors.New("Error saving alternate names of Author")
+	ErrGetAlternateNames  = errors.New("Error retrieving alternate names of Author")
+	ErrGetAuthorBooks     = errors.New("Error retrieving Books of Author")
+	ErrMergeAuthors       = errors.New("Error merging Authors")
+
+	ErrInvalidAuthor   = errors.New("Author must have a name")
+	ErrInvalidLifespan = errors.New("Author's death year can not be before the birth year")
+	ErrAuthorHasBooks  = errors.New("Author can not be deleted while Books reference it")
+	ErrMergeSameAuthor = errors.New("Author can not be merged into itself")
+)
+
+// Service provides basic operations on Author domain model.
+type Service interface {
+	// CRUD operations.
+	Create(author *Author) (*Author, error)
+	Get(authorID int64) (*Author, error)
+	Update(author *Author) (*Author, error)
+	Delete(authorID int64) error
+
+	// Other operations.
+	List() ([]*Author, error)
+	GetBooks(authorID int64) ([]*book.Book, error)
+	Merge(authorID int64, duplicateID int64) (*Author, error)
+}
+
+type service struct {
+	authorRepository Repository
+	bookService      book.Service
+}
+
+// NewAuthorService creates an instance of the service for the Author domain model
+// with all of the necessary dependencies.
+func NewAuthorService(authorRepository Repository, bookService book.Service) Service {
+	return &service{
+		authorRepository: authorRepository,
+		bookService:      bookService,
+	}
+}
+
+func (s *service) Create(author *Author) (*Author, error) {
+	err := validateAuthor(author)
+	if err != nil {
+		return nil, err
+	}
+
+	newAuthor := NewAuthor(0, author.Name, author.Biography, author.BirthYear, author.DeathYear, author.AlternateNames)
+
+	newAuthor, err = s.authorRepository.Save(newAuthor)
+	if err != nil {
+		return nil, ErrCreateAuthor
+	}
+
+	err = s.authorRepository.SaveAlternateNames(newAuthor.ID, newAuthor.AlternateNames)
+	if err != nil {
+		return nil, ErrSaveAlternateNames
+	}
+
+	return newAuthor, nil
+}
+
+func (s *service) Get(authorID int64) (*Author, error) {
+	author, err := s.authorRepository.Get(authorID)
+	if err != nil {
+		return nil, ErrGetAuthor
+	}
+
+	author.AlternateNames, err = s.authorRepository.GetAlternateNames(authorID)
+	if err != nil {
+		return nil, ErrGetAlternateNames
+	}
+
+	return author, nil
+}
+
+// Update replaces the details and the alternate names of the Author
+// and reindexes its Books, which are searchable by the name of the Author.
+func (s *service) Update(author *Author) (*Author, error) {
+	err := validateAuthor(author)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.authorRepository.Update(author)
+	if err != nil {
+		return nil, ErrUpdateAuthor
+	}
+
+	err = s.authorRepository.SaveAlternateNames(author.ID, author.AlternateNames)
+	if err != nil {
+		return nil, ErrSaveAlternateNames
+	}
+
+	err = s.refreshBooks(author.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(author.ID)
+}
+
+func (s *service) Delete(authorID int64) error {
+	bookIDs, err := s.authorRepository.GetBookIDs(authorID)
+	if err != nil {
+		return ErrGetAuthorBooks
+	}
+
+	if len(bookIDs) > 0 {
+		return ErrAuthorHasBooks
+	}
+
+	err = s.authorRepository.Delete(authorID)
+	if err != nil {
+		return ErrDeleteAuthor
+	}
+
+	return nil
+}
+
+func (s *service) List() ([]*Author, error) {
+	authors, err := s.authorRepository.List()
+	if err != nil {
+		return nil, ErrListAuthors
+	}
+
+	for _, author := range authors {
+		author.AlternateNames, err = s.authorRepository.GetAlternateNames(author.ID)
+		if err != nil {
+			return nil, ErrGetAlternateNames
+		}
+	}
+
+	return authors, nil
+}
+
+func (s *service) GetBooks(authorID int64) ([]*book.Book, error) {
+	_, err := s.Get(authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookIDs, err := s.authorRepository.GetBookIDs(authorID)
+	if err != nil {
+		return nil, ErrGetAuthorBooks
+	}
+
+	books := []*book.Book{}
+	for _, bookID := range bookIDs {
+		book, err := s.bookService.Get(bookID)
+		if err != nil {
+			return nil, err
+		}
+
+		books = append(books, book)
+	}
+
+	return books, nil
+}
+
+// Merge moves the Books and the alternate names of the duplicate Author
+// to the Author, keeps the name of the duplicate as an alternate name
+// and deletes the duplicate.
+func (s *service) Merge(authorID int64, duplicateID int64) (*Author, error) {
+	if authorID == duplicateID {
+		return nil, ErrMergeSameAuthor
+	}
+
+	_, err := s.Get(authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.Get(duplicateID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.authorRepository.Merge(authorID, duplicateID)
+	if err != nil {
+		return nil, ErrMergeAuthors
+	}
+
+	err = s.refreshBooks(authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(authorID)
+}
+
+// refreshBooks reindexes the Books of the Author for searching.
+func (s *service) refreshBooks(authorID int64) error {
+	bookIDs, err := s.authorRepository.GetBookIDs(authorID)
+	if err != nil {
+		return ErrGetAuthorBooks
+	}
+
+	for _, bookID := range bookIDs {
+		err = s.bookService.RefreshSearchVector(bookID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateAuthor(author *Author) error {
+	if author.Name == "" {
+		return ErrInvalidAuthor
+	}
+
+	if author.BirthYear != 0 && author.DeathYear != 0 && author.DeathYear < author.BirthYear {
+		return ErrInvalidLifespan
+	}
+
+	return nil
+}
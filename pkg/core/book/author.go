@@ -0,0 +1,31 @@
+package book
+
+// Roles an Author can have in a Book.
+const (
+	RoleAuthor      = "author"
+	RoleEditor      = "editor"
+	RoleTranslator  = "translator"
+	RoleIllustrator = "illustrator"
+)
+
+var authorRoles = []string{RoleAuthor, RoleEditor, RoleTranslator, RoleIllustrator}
+
+// BookAuthor references an Author of a Book along with the role the
+// Author had in it. A BookAuthor with only a Name refers to the Author
+// with that name or one of its alternate names, which is created if none exists.
+type BookAuthor struct {
+	AuthorID int64  `json:"authorID" db:"author_id"`
+	Name     string `json:"name" db:"name"`
+	Role     string `json:"role" db:"role"`
+}
+
+// IsValidRole returns whether the role is one an Author can have in a Book.
+func IsValidRole(role string) bool {
+	for _, authorRole := range authorRoles {
+		if authorRole == role {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,11 @@
+package book
+
+// BranchAvailability is the number of copies of a Book in a Branch, and how
+// many of them are available on the shelf. Copies that are not in any Branch
+// are counted under an empty BranchID.
+type BranchAvailability struct {
+	BranchID   string `json:"branchID" db:"branch_id"`
+	BranchName string `json:"branchName" db:"branch_name"`
+	Total      int    `json:"total" db:"total"`
+	Available  int    `json:"available" db:"available"`
+}
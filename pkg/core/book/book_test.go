@@ -1,6 +1,7 @@
 package book
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -20,12 +21,17 @@ func TestCreate(t *testing.T) {
 
 	subjects := []string{"Mathematics", "Physics"}
 	subjectIDs := []int64{1, 2}
-	authors := []string{"author1", "author2"}
+	authorNames := []string{"author1", "author2"}
 	authorIDs := []int64{1, 2}
+	authors := []*BookAuthor{
+		{Name: "author1"},
+		{Name: "author2", Role: RoleEditor},
+	}
 
 	book := &Book{
 		ID:      ID,
 		Title:   "book",
+		ISBN:    "0-13-468599-7",
 		Subject: subjects,
 		Author:  authors,
 		AddedAt: createdTime,
@@ -39,6 +45,31 @@ func TestCreate(t *testing.T) {
 		AddedAt: createdTime,
 	}
 
+	invalidRoleBook := &Book{
+		ID:      ID,
+		Title:   "invalidRoleBook",
+		Subject: subjects,
+		Author:  []*BookAuthor{{AuthorID: 1, Role: "narrator"}},
+		AddedAt: createdTime,
+	}
+
+	unknownAuthorBook := &Book{
+		ID:      ID,
+		Title:   "unknownAuthorBook",
+		Subject: subjects,
+		Author:  []*BookAuthor{{AuthorID: 99}},
+		AddedAt: createdTime,
+	}
+
+	invalidISBNBook := &Book{
+		ID:      ID,
+		Title:   "invalidISBNBook",
+		ISBN:    "978-0-13-468599-0",
+		Subject: subjects,
+		Author:  authors,
+		AddedAt: createdTime,
+	}
+
 	tt := []struct {
 		name         string
 		book         *Book
@@ -57,16 +88,40 @@ func TestCreate(t *testing.T) {
 			returnedBook: nil,
 			err:          ErrCreateBook,
 		},
+		{
+			name:         "failed creating a Book with an invalid author role",
+			book:         invalidRoleBook,
+			returnedBook: nil,
+			err:          ErrInvalidAuthorRole,
+		},
+		{
+			name:         "failed creating a Book with an unknown author",
+			book:         unknownAuthorBook,
+			returnedBook: nil,
+			err:          ErrUnknownAuthor,
+		},
+		{
+			name:         "failed creating a Book with an invalid ISBN",
+			book:         invalidISBNBook,
+			returnedBook: nil,
+			err:          ErrInvalidISBN,
+		},
 	}
 
+	// The Authors given by name are resolved in the first test case.
+	bookRepository.On("AuthorExists", authorIDs[0]).Return(true, nil)
+	bookRepository.On("AuthorExists", authorIDs[1]).Return(true, nil)
+	bookRepository.On("AuthorExists", int64(99)).Return(false, nil)
+
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			bookRepository.On("Save", tc.book).Return(tc.returnedBook, tc.err)
 			bookRepository.On("GetSubjectIDs", tc.book.Subject).Return(subjectIDs, nil)
 			bookRepository.On("SaveBookSubjects", tc.book.ID, subjectIDs).Return(nil)
-			bookRepository.On("SaveAuthors", tc.book.Author).Return(nil)
-			bookRepository.On("GetAuthorIDs", tc.book.Author).Return(authorIDs, nil)
-			bookRepository.On("SaveBookAuthors", tc.book.ID, authorIDs).Return(nil)
+			bookRepository.On("SaveAuthors", authorNames).Return(nil)
+			bookRepository.On("GetAuthorIDs", authorNames).Return(authorIDs, nil)
+			bookRepository.On("SaveBookAuthors", tc.book.ID, tc.book.Author).Return(nil)
+			bookRepository.On("RefreshSearchVector", tc.book.ID).Return(nil)
 
 			newBook, err := bookService.Create(tc.book)
 
@@ -75,6 +130,9 @@ func TestCreate(t *testing.T) {
 			if tc.err == nil {
 				require.Equal(t, book.ID, newBook.ID)
 				require.Equal(t, book.Title, newBook.Title)
+				require.Equal(t, "9780134685991", newBook.ISBN)
+				require.Equal(t, authorIDs[1], authors[1].AuthorID)
+				require.Equal(t, RoleAuthor, authors[0].Role)
 			}
 		})
 	}
@@ -83,8 +141,10 @@ func TestCreate(t *testing.T) {
 func TestGet(t *testing.T) {
 	subjects := []string{"Mathematics", "Physics"}
 	subjectIDs := []int64{1, 2}
-	authors := []string{"author1", "author2"}
-	authorIDs := []int64{1, 2}
+	authors := []*BookAuthor{
+		{AuthorID: 1, Name: "author1", Role: RoleAuthor},
+		{AuthorID: 2, Name: "author2", Role: RoleTranslator},
+	}
 
 	book := &Book{
 		ID:      util.NewID(),
@@ -125,8 +185,7 @@ func TestGet(t *testing.T) {
 			bookRepository.On("Get", tc.book.ID).Return(tc.returnedBook, tc.err)
 			bookRepository.On("GetBookSubjectIDs", tc.book.ID).Return(subjectIDs, nil)
 			bookRepository.On("GetSubjectsByID", subjectIDs).Return(subjects, nil)
-			bookRepository.On("GetBookAuthorIDs", tc.book.ID).Return(authorIDs, nil)
-			bookRepository.On("GetAuthorsByID", authorIDs).Return(authors, nil)
+			bookRepository.On("GetBookAuthors", tc.book.ID).Return(authors, nil)
 
 			newBook, err := bookService.Get(tc.book.ID)
 
@@ -140,6 +199,262 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestList(t *testing.T) {
+	subjects := []string{"Mathematics", "Physics"}
+	subjectIDs := []int64{1, 2}
+	authors := []*BookAuthor{
+		{AuthorID: 1, Name: "author1", Role: RoleAuthor},
+		{AuthorID: 2, Name: "author2", Role: RoleTranslator},
+	}
+
+	books := []*Book{
+		{ID: util.NewID(), Title: "book"},
+		{ID: util.NewID(), Title: "another book"},
+	}
+
+	tt := []struct {
+		name          string
+		filter        *Filter
+		returnedBooks []*Book
+		total         int
+		repositoryErr error
+		err           error
+	}{
+		{
+			name:          "success listing Books",
+			filter:        &Filter{Publisher: "publisher", SortBy: SortByYearPublished, Limit: 10},
+			returnedBooks: books,
+			total:         len(books),
+			repositoryErr: nil,
+			err:           nil,
+		},
+		{
+			name:          "invalid sorting key",
+			filter:        &Filter{SortBy: "isbn"},
+			returnedBooks: nil,
+			total:         0,
+			repositoryErr: nil,
+			err:           ErrInvalidFilter,
+		},
+		{
+			name:          "invalid year range",
+			filter:        &Filter{YearFrom: 2000, YearTo: 1990},
+			returnedBooks: nil,
+			total:         0,
+			repositoryErr: nil,
+			err:           ErrInvalidFilter,
+		},
+		{
+			name:          "invalid decade",
+			filter:        &Filter{Decade: 1995},
+			returnedBooks: nil,
+			total:         0,
+			repositoryErr: nil,
+			err:           ErrInvalidFilter,
+		},
+		{
+			name:          "invalid ISBN",
+			filter:        &Filter{ISBN: "0-13-468599-8"},
+			returnedBooks: nil,
+			total:         0,
+			repositoryErr: nil,
+			err:           ErrInvalidISBN,
+		},
+		{
+			name:          "failed listing Books",
+			filter:        &Filter{Publisher: "error publisher"},
+			returnedBooks: nil,
+			total:         0,
+			repositoryErr: errors.New("query failed"),
+			err:           ErrListBooks,
+		},
+	}
+
+	for _, book := range books {
+		bookRepository.On("GetBookSubjectIDs", book.ID).Return(subjectIDs, nil)
+		bookRepository.On("GetSubjectsByID", subjectIDs).Return(subjects, nil)
+		bookRepository.On("GetBookAuthors", book.ID).Return(authors, nil)
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookRepository.On("List", tc.filter).Return(tc.returnedBooks, tc.repositoryErr)
+			bookRepository.On("Count", tc.filter).Return(tc.total, tc.repositoryErr)
+
+			returnedBooks, total, err := bookService.List(tc.filter)
+
+			require.Equal(t, tc.err, err)
+
+			if tc.err == nil {
+				require.Equal(t, tc.total, total)
+				require.Len(t, returnedBooks, len(books))
+				require.Equal(t, subjects, returnedBooks[0].Subject)
+				require.Equal(t, authors, returnedBooks[0].Author)
+			}
+		})
+	}
+}
+
+func TestListDefaults(t *testing.T) {
+	filter := &Filter{Limit: MaxLimit + 1}
+
+	bookRepository.On("List", filter).Return([]*Book{}, nil)
+	bookRepository.On("Count", filter).Return(0, nil)
+
+	_, _, err := bookService.List(filter)
+
+	require.Nil(t, err)
+	require.Equal(t, SortByTitle, filter.SortBy)
+	require.Equal(t, MaxLimit, filter.Limit)
+}
+
+func TestListByISBN(t *testing.T) {
+	filter := &Filter{ISBN: "0-262-01153-0"}
+
+	bookRepository.On("List", filter).Return([]*Book{}, nil)
+	bookRepository.On("Count", filter).Return(0, nil)
+
+	_, _, err := bookService.List(filter)
+
+	require.Nil(t, err)
+	require.Equal(t, "9780262011532", filter.ISBN)
+}
+
+func TestSearch(t *testing.T) {
+	subjects := []string{"Mathematics", "Physics"}
+	subjectIDs := []int64{1, 2}
+	authors := []*BookAuthor{
+		{AuthorID: 1, Name: "author1", Role: RoleAuthor},
+		{AuthorID: 2, Name: "author2", Role: RoleTranslator},
+	}
+
+	foundBook := &Book{
+		ID:    util.NewID(),
+		Title: "Calculus",
+	}
+	results := []*SearchResult{
+		NewSearchResult(foundBook, 0.8, "<mark>Calculus</mark>", ""),
+	}
+
+	bookRepository.On("GetBookSubjectIDs", foundBook.ID).Return(subjectIDs, nil)
+	bookRepository.On("GetSubjectsByID", subjectIDs).Return(subjects, nil)
+	bookRepository.On("GetBookAuthors", foundBook.ID).Return(authors, nil)
+
+	availability := []*BranchAvailability{
+		{BranchID: util.NewID(), BranchName: "Engineering Library", Total: 2, Available: 1},
+		{BranchID: util.NewID(), BranchName: "Main Library", Total: 1, Available: 0},
+	}
+	bookRepository.On("GetAvailability", foundBook.ID).Return(availability, nil)
+
+	tt := []struct {
+		name            string
+		query           string
+		filter          *Filter
+		returnedResults []*SearchResult
+		repositoryErr   error
+		err             error
+	}{
+		{
+			name:            "success searching Books",
+			query:           "calculus",
+			filter:          &Filter{},
+			returnedResults: results,
+			repositoryErr:   nil,
+			err:             nil,
+		},
+		{
+			name:            "empty search query",
+			query:           "   ",
+			filter:          &Filter{},
+			returnedResults: nil,
+			repositoryErr:   nil,
+			err:             ErrEmptySearchQuery,
+		},
+		{
+			name:            "failed searching Books",
+			query:           "error query",
+			filter:          &Filter{},
+			returnedResults: nil,
+			repositoryErr:   errors.New("query failed"),
+			err:             ErrSearchBooks,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookRepository.On("Search", tc.query, tc.filter).Return(tc.returnedResults, tc.repositoryErr)
+			bookRepository.On("CountSearch", tc.query, tc.filter).Return(len(tc.returnedResults), tc.repositoryErr)
+
+			returnedResults, total, err := bookService.Search(tc.query, tc.filter)
+
+			require.Equal(t, tc.err, err)
+
+			if tc.err == nil {
+				require.Equal(t, len(results), total)
+				require.Equal(t, foundBook.ID, returnedResults[0].Book.ID)
+				require.Equal(t, authors, returnedResults[0].Book.Author)
+				require.Equal(t, availability, returnedResults[0].Availability)
+			}
+		})
+	}
+}
+
+func TestGetFacets(t *testing.T) {
+	facets := &Facets{
+		LOCClassification: []*FacetValue{{Value: "Q", Count: 2}},
+		Availability:      []*FacetValue{{Value: Available, Count: 2}},
+	}
+	available := true
+
+	tt := []struct {
+		name           string
+		query          string
+		filter         *Filter
+		returnedFacets *Facets
+		repositoryErr  error
+		err            error
+	}{
+		{
+			name:           "success retrieving facets of a listing",
+			query:          "",
+			filter:         &Filter{Decade: 1990},
+			returnedFacets: facets,
+			repositoryErr:  nil,
+			err:            nil,
+		},
+		{
+			name:           "success retrieving facets of a search",
+			query:          "calculus",
+			filter:         &Filter{Available: &available},
+			returnedFacets: facets,
+			repositoryErr:  nil,
+			err:            nil,
+		},
+		{
+			name:           "failed retrieving facets",
+			query:          "error query",
+			filter:         &Filter{},
+			returnedFacets: nil,
+			repositoryErr:  errors.New("query failed"),
+			err:            ErrGetFacets,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookRepository.On("GetFacets", tc.query, tc.filter).Return(tc.returnedFacets, tc.repositoryErr)
+
+			returnedFacets, err := bookService.GetFacets(tc.query, tc.filter)
+
+			require.Equal(t, tc.err, err)
+
+			if tc.err == nil {
+				require.Equal(t, facets, returnedFacets)
+			}
+		})
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	book := &Book{
 		ID:    util.NewID(),
@@ -178,7 +493,9 @@ func TestUpdate(t *testing.T) {
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
+			bookRepository.On("Get", tc.book.ID).Return(&Book{ID: tc.book.ID, Title: "book"}, nil)
 			bookRepository.On("Update", tc.book).Return(tc.returnedBook, tc.err)
+			bookRepository.On("RefreshSearchVector", tc.book.ID).Return(nil)
 
 			updatedBook, err := bookService.Update(tc.book)
 
@@ -192,6 +509,70 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdateISBN(t *testing.T) {
+	// A Book catalogued before ISBNs were validated.
+	legacyBook := &Book{
+		ID:    util.NewID(),
+		Title: "legacy book",
+		ISBN:  "0-13-41904",
+	}
+	bookRepository.On("Get", legacyBook.ID).Return(legacyBook, nil)
+
+	tt := []struct {
+		name string
+		isbn string
+		want string
+		err  error
+	}{
+		{
+			name: "unchanged ISBN is not validated",
+			isbn: "0-13-41904",
+			want: "0-13-41904",
+			err:  nil,
+		},
+		{
+			name: "changed ISBN is normalized",
+			isbn: "0-13-419044-0",
+			want: "9780134190440",
+			err:  nil,
+		},
+		{
+			name: "changed ISBN must be valid",
+			isbn: "0-13-419044-1",
+			err:  ErrInvalidISBN,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			updatedBook := &Book{ID: legacyBook.ID, Title: "edited legacy book", ISBN: tc.isbn}
+			bookRepository.On("Update", updatedBook).Return(updatedBook, nil).Once()
+			bookRepository.On("RefreshSearchVector", legacyBook.ID).Return(nil)
+
+			returnedBook, err := bookService.Update(updatedBook)
+
+			require.Equal(t, tc.err, err)
+
+			if tc.err == nil {
+				require.Equal(t, tc.want, returnedBook.ISBN)
+			}
+		})
+	}
+}
+
+func TestAddQuantity(t *testing.T) {
+	bookID := util.NewID()
+	errorBookID := util.NewID()
+	bookRepository.On("AddQuantity", bookID, 1).Return(nil)
+	bookRepository.On("AddQuantity", errorBookID, 1).Return(errors.New("connection refused"))
+
+	err := bookService.AddQuantity(bookID, 1)
+	require.Nil(t, err)
+
+	err = bookService.AddQuantity(errorBookID, 1)
+	require.Equal(t, ErrUpdateQuantity, err)
+}
+
 func TestDelete(t *testing.T) {
 	book := &Book{
 		ID: util.NewID(),
@@ -237,32 +618,54 @@ func TestGetSubjectIDs(t *testing.T) {
 		name               string
 		subjects           []string
 		returnedSubjectIDs []int64
+		repositoryErr      error
+		subjectIDs         []int64
 		err                error
 	}{
 		{
 			name:               "success retrieving subjectIDs",
 			subjects:           subjects,
 			returnedSubjectIDs: subjectIDs,
+			repositoryErr:      nil,
+			subjectIDs:         subjectIDs,
+			err:                nil,
+		},
+		{
+			name:               "success retrieving subjectIDs of a synonym and its preferred term",
+			subjects:           []string{"Maths", "Mathematics"},
+			returnedSubjectIDs: []int64{1, 1},
+			repositoryErr:      nil,
+			subjectIDs:         []int64{1},
 			err:                nil,
 		},
+		{
+			name:               "failed retrieving subjectIDs of a subject outside of the vocabulary",
+			subjects:           []string{"Mathematics", "Alchemy"},
+			returnedSubjectIDs: []int64{1},
+			repositoryErr:      nil,
+			subjectIDs:         nil,
+			err:                ErrUnknownSubject,
+		},
 		{
 			name:               "failed retrieving subjectIDs",
 			subjects:           []string{"test"},
 			returnedSubjectIDs: nil,
+			repositoryErr:      errors.New("Error retrieving subject IDs"),
+			subjectIDs:         nil,
 			err:                ErrGetSubjectIDs,
 		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			bookRepository.On("GetSubjectIDs", tc.subjects).Return(tc.returnedSubjectIDs, tc.err)
+			bookRepository.On("GetSubjectIDs", tc.subjects).Return(tc.returnedSubjectIDs, tc.repositoryErr)
 
 			retrievedSubjectIDs, err := bookService.GetSubjectIDs(tc.subjects)
 
 			require.Equal(t, tc.err, err)
 
 			if tc.err == nil {
-				require.Equal(t, subjectIDs, retrievedSubjectIDs)
+				require.Equal(t, tc.subjectIDs, retrievedSubjectIDs)
 			}
 		})
 	}
@@ -450,111 +853,156 @@ func TestGetAuthorIDs(t *testing.T) {
 }
 
 func TestSaveBookAuthors(t *testing.T) {
-	authorIDs := []int64{1, 2}
+	authors := []*BookAuthor{
+		{AuthorID: 1, Role: RoleAuthor},
+		{AuthorID: 2, Role: RoleIllustrator},
+	}
 
 	tt := []struct {
-		name      string
-		bookID    string
-		authorIDs []int64
-		err       error
+		name    string
+		bookID  string
+		authors []*BookAuthor
+		err     error
 	}{
 		{
-			name:      "success saving book's authors",
-			bookID:    util.NewID(),
-			authorIDs: authorIDs,
-			err:       nil,
+			name:    "success saving book's authors",
+			bookID:  util.NewID(),
+			authors: authors,
+			err:     nil,
 		},
 		{
-			name:      "failed saving book's authors",
-			bookID:    util.NewID(),
-			authorIDs: authorIDs,
-			err:       ErrSaveBookAuthors,
+			name:    "failed saving book's authors",
+			bookID:  util.NewID(),
+			authors: authors,
+			err:     ErrSaveBookAuthors,
 		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			bookRepository.On("SaveBookAuthors", tc.bookID, tc.authorIDs).Return(tc.err)
+			bookRepository.On("SaveBookAuthors", tc.bookID, tc.authors).Return(tc.err)
 
-			err := bookService.SaveBookAuthors(tc.bookID, tc.authorIDs)
+			err := bookService.SaveBookAuthors(tc.bookID, tc.authors)
 
 			require.Equal(t, tc.err, err)
 		})
 	}
 }
 
-func TestGetBookAuthorIDs(t *testing.T) {
-	authorIDs := []int64{1, 2}
+func TestGetBookAuthors(t *testing.T) {
+	authors := []*BookAuthor{
+		{AuthorID: 1, Name: "author1", Role: RoleAuthor},
+		{AuthorID: 2, Name: "author2", Role: RoleEditor},
+	}
 
 	tt := []struct {
-		name              string
-		bookID            string
-		returnedAuthorIDs []int64
-		err               error
+		name            string
+		bookID          string
+		returnedAuthors []*BookAuthor
+		err             error
 	}{
 		{
-			name:              "success retrieving book's authorIDs",
-			bookID:            util.NewID(),
-			returnedAuthorIDs: authorIDs,
-			err:               nil,
+			name:            "success retrieving book's authors",
+			bookID:          util.NewID(),
+			returnedAuthors: authors,
+			err:             nil,
 		},
 		{
-			name:              "failed retrieving book's authorIDs",
-			bookID:            util.NewID(),
-			returnedAuthorIDs: nil,
-			err:               ErrGetBookAuthorIDs,
+			name:            "failed retrieving book's authors",
+			bookID:          util.NewID(),
+			returnedAuthors: nil,
+			err:             ErrGetBookAuthors,
 		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			bookRepository.On("GetBookAuthorIDs", tc.bookID).Return(tc.returnedAuthorIDs, tc.err)
+			bookRepository.On("GetBookAuthors", tc.bookID).Return(tc.returnedAuthors, tc.err)
 
-			returnedAuthorIDs, err := bookService.GetBookAuthorIDs(tc.bookID)
+			returnedAuthors, err := bookService.GetBookAuthors(tc.bookID)
 
 			require.Equal(t, tc.err, err)
 
 			if tc.err == nil {
-				require.Equal(t, authorIDs, returnedAuthorIDs)
+				require.Equal(t, authors, returnedAuthors)
 			}
 		})
 	}
 }
 
-func TestGetAuthorsByID(t *testing.T) {
-	authorIDs := []int64{1, 2}
-	authors := []string{"author1", "author2"}
+func TestLookup(t *testing.T) {
+	isbn13 := "9780134190440"
+
+	catalogueProvider := &MockMetadataProvider{}
+	catalogueProvider.On("Lookup", isbn13).Return(&Book{
+		Title:   "The Go Programming Language",
+		Author:  []*BookAuthor{{Name: "Alan A. A. Donovan"}, {Name: "Brian W. Kernighan"}},
+		Subject: []string{"Go (Computer program language)"},
+	}, nil)
+
+	openLibraryProvider := &MockMetadataProvider{}
+	openLibraryProvider.On("Lookup", isbn13).Return(&Book{
+		Title:         "The go programming language",
+		Publisher:     "Addison-Wesley",
+		YearPublished: 2016,
+		Author:        []*BookAuthor{{Name: "Donovan, Alan A. A.", Role: RoleAuthor}},
+		Subject:       []string{"go (computer program language)", "Open source software"},
+		CoverPicture:  "https://covers.openlibrary.org/b/id/8750805-L.jpg",
+	}, nil)
+
+	unknownProvider := &MockMetadataProvider{}
+	unknownProvider.On("Lookup", isbn13).Return(nil, ErrMetadataNotFound)
+
+	failingProvider := &MockMetadataProvider{}
+	failingProvider.On("Lookup", isbn13).Return(nil, errors.New("connection refused"))
 
 	tt := []struct {
-		name            string
-		authorIDs       []int64
-		returnedAuthors []string
-		err             error
+		name      string
+		value     string
+		providers []MetadataProvider
+		err       error
 	}{
 		{
-			name:            "success retrieving authors",
-			authorIDs:       authorIDs,
-			returnedAuthors: authors,
-			err:             nil,
+			name:      "success merging the metadata of the providers",
+			value:     "0-13-419044-0",
+			providers: []MetadataProvider{unknownProvider, catalogueProvider, failingProvider, openLibraryProvider},
+			err:       nil,
 		},
 		{
-			name:            "failed retrieving authors",
-			authorIDs:       []int64{},
-			returnedAuthors: nil,
-			err:             ErrGetAuthorsByID,
+			name:      "invalid ISBN",
+			value:     "0-13-419044-1",
+			providers: []MetadataProvider{catalogueProvider},
+			err:       ErrInvalidISBN,
+		},
+		{
+			name:      "no provider knows the ISBN",
+			value:     isbn13,
+			providers: []MetadataProvider{unknownProvider},
+			err:       ErrMetadataNotFound,
+		},
+		{
+			name:      "no provider could be looked up",
+			value:     isbn13,
+			providers: []MetadataProvider{unknownProvider, failingProvider},
+			err:       ErrLookupMetadata,
 		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			bookRepository.On("GetAuthorsByID", tc.authorIDs).Return(tc.returnedAuthors, tc.err)
-
-			returnedAuthors, err := bookService.GetAuthorsByID(tc.authorIDs)
-
+			draft, err := NewBookService(bookRepository, tc.providers...).Lookup(tc.value)
 			require.Equal(t, tc.err, err)
 
 			if tc.err == nil {
-				require.Equal(t, authors, returnedAuthors)
+				require.Equal(t, isbn13, draft.ISBN)
+				require.Equal(t, "The Go Programming Language", draft.Title)
+				require.Equal(t, "Addison-Wesley", draft.Publisher)
+				require.Equal(t, 2016, draft.YearPublished)
+				require.Equal(t, []*BookAuthor{
+					{Name: "Alan A. A. Donovan", Role: RoleAuthor},
+					{Name: "Brian W. Kernighan", Role: RoleAuthor},
+				}, draft.Author)
+				require.Equal(t, []string{"Go (Computer program language)", "Open source software"}, draft.Subject)
 			}
 		})
 	}
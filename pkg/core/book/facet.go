@@ -0,0 +1,25 @@
+package book
+
+// Values of the availability facet.
+const (
+	Available   = "available"
+	Unavailable = "unavailable"
+)
+
+// FacetValue is a value of a catalogue facet along with
+// the number of Books having that value.
+type FacetValue struct {
+	Value string `json:"value" db:"value"`
+	Count int    `json:"count" db:"count"`
+}
+
+// Facets holds the values of every catalogue facet of the Books
+// matching a catalogue query, used for drill-down navigation.
+type Facets struct {
+	LOCClassification []*FacetValue `json:"locClassification"`
+	Subject           []*FacetValue `json:"subject"`
+	Author            []*FacetValue `json:"author"`
+	Publisher         []*FacetValue `json:"publisher"`
+	Decade            []*FacetValue `json:"decade"`
+	Availability      []*FacetValue `json:"availability"`
+}
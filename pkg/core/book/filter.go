@@ -0,0 +1,45 @@
+package book
+
+// Sorting keys that can be used for listing Books.
+const (
+	SortByTitle         = "title"
+	SortByYearPublished = "yearPublished"
+	SortByAddedAt       = "addedAt"
+)
+
+// Pagination boundaries for listing Books.
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Filter holds the criteria, sorting and pagination used for listing
+// and searching Books. The LOCClassification criteria matches by prefix,
+// so that a classification letter selects all of its subclasses, and the
+// ISBN criteria matches either of the ISBN-10 and ISBN-13 forms. The Available
+// criteria selects the Books with copies available on the shelf when true, the
+// Books without when false, and either when it is omitted.
+type Filter struct {
+	ISBN              string `json:"isbn"`
+	Publisher         string `json:"publisher"`
+	LOCClassification string `json:"locClassification"`
+	Subject           string `json:"subject"`
+	Author            string `json:"author"`
+	YearFrom          int    `json:"yearFrom"`
+	YearTo            int    `json:"yearTo"`
+	Decade            int    `json:"decade"`
+	Available         *bool  `json:"available"`
+
+	SortBy     string `json:"sort"`
+	Descending bool   `json:"descending"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+}
+
+// NewFilter creates a new instance of Filter with the default sorting and pagination.
+func NewFilter() *Filter {
+	return &Filter{
+		SortBy: SortByTitle,
+		Limit:  DefaultLimit,
+	}
+}
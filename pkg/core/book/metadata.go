@@ -0,0 +1,68 @@
+package book
+
+import (
+	"strings"
+)
+
+// MetadataProvider looks up the bibliographic metadata of a Book by its
+// ISBN-13 in a source outside of the catalogue, such as Open Library.
+// A provider which does not know the ISBN returns ErrMetadataNotFound.
+type MetadataProvider interface {
+	Name() string
+	Lookup(isbn string) (*Book, error)
+}
+
+// mergeMetadata merges the Books found by the providers into a single draft,
+// in the order of the providers: a field is taken from the first of them
+// which has it, except subjects, which are gathered from all of them.
+func mergeMetadata(isbn string, books []*Book) *Book {
+	draft := &Book{
+		ISBN:    isbn,
+		Subject: []string{},
+		Author:  []*BookAuthor{},
+	}
+
+	subjects := map[string]bool{}
+	for _, book := range books {
+		mergeString(&draft.Title, book.Title)
+		mergeString(&draft.Publisher, book.Publisher)
+		mergeString(&draft.CallNumber, book.CallNumber)
+		mergeString(&draft.CoverPicture, book.CoverPicture)
+		mergeString(&draft.Collation, book.Collation)
+		mergeString(&draft.Description, book.Description)
+		mergeString(&draft.LOCClassification, book.LOCClassification)
+
+		if draft.YearPublished == 0 {
+			draft.YearPublished = book.YearPublished
+		}
+		if draft.Edition == 0 {
+			draft.Edition = book.Edition
+		}
+
+		if len(draft.Author) == 0 {
+			for _, author := range book.Author {
+				if author.Role == "" {
+					author.Role = RoleAuthor
+				}
+				draft.Author = append(draft.Author, author)
+			}
+		}
+
+		for _, subject := range book.Subject {
+			key := strings.ToLower(strings.TrimSpace(subject))
+			if key == "" || subjects[key] {
+				continue
+			}
+			subjects[key] = true
+			draft.Subject = append(draft.Subject, strings.TrimSpace(subject))
+		}
+	}
+
+	return draft
+}
+
+func mergeString(field *string, value string) {
+	if *field == "" {
+		*field = strings.TrimSpace(value)
+	}
+}
@@ -0,0 +1,47 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package book
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockMetadataProvider is an autogenerated mock type for the MetadataProvider type
+type MockMetadataProvider struct {
+	mock.Mock
+}
+
+// Lookup provides a mock function with given fields: isbn
+func (_m *MockMetadataProvider) Lookup(isbn string) (*Book, error) {
+	ret := _m.Called(isbn)
+
+	var r0 *Book
+	if rf, ok := ret.Get(0).(func(string) *Book); ok {
+		r0 = rf(isbn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Book)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(isbn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Name provides a mock function with given fields:
+func (_m *MockMetadataProvider) Name() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
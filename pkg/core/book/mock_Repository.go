@@ -2,13 +2,114 @@
 
 package book
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	pkg "github.com/joshuabezaleel/library-server/pkg"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockRepository is an autogenerated mock type for the Repository type
 type MockRepository struct {
 	mock.Mock
 }
 
+// AddQuantity provides a mock function with given fields: bookID, quantity
+func (_m *MockRepository) AddQuantity(bookID string, quantity int) error {
+	ret := _m.Called(bookID, quantity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int) error); ok {
+		r0 = rf(bookID, quantity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthorExists provides a mock function with given fields: authorID
+func (_m *MockRepository) AuthorExists(authorID int64) (bool, error) {
+	ret := _m.Called(authorID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int64) bool); ok {
+		r0 = rf(authorID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CallNumberExists provides a mock function with given fields: callNumber
+func (_m *MockRepository) CallNumberExists(callNumber string) (bool, error) {
+	ret := _m.Called(callNumber)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(callNumber)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(callNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Count provides a mock function with given fields: filter
+func (_m *MockRepository) Count(filter *Filter) (int, error) {
+	ret := _m.Called(filter)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*Filter) int); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Filter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountSearch provides a mock function with given fields: query, filter
+func (_m *MockRepository) CountSearch(query string, filter *Filter) (int, error) {
+	ret := _m.Called(query, filter)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(string, *Filter) int); ok {
+		r0 = rf(query, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *Filter) error); ok {
+		r1 = rf(query, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: bookID
 func (_m *MockRepository) Delete(bookID string) error {
 	ret := _m.Called(bookID)
@@ -69,22 +170,22 @@ func (_m *MockRepository) GetAuthorIDs(authors []string) ([]int64, error) {
 	return r0, r1
 }
 
-// GetAuthorsByID provides a mock function with given fields: authorIDs
-func (_m *MockRepository) GetAuthorsByID(authorIDs []int64) ([]string, error) {
-	ret := _m.Called(authorIDs)
+// GetAvailability provides a mock function with given fields: bookID
+func (_m *MockRepository) GetAvailability(bookID string) ([]*BranchAvailability, error) {
+	ret := _m.Called(bookID)
 
-	var r0 []string
-	if rf, ok := ret.Get(0).(func([]int64) []string); ok {
-		r0 = rf(authorIDs)
+	var r0 []*BranchAvailability
+	if rf, ok := ret.Get(0).(func(string) []*BranchAvailability); ok {
+		r0 = rf(bookID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]*BranchAvailability)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func([]int64) error); ok {
-		r1 = rf(authorIDs)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(bookID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -92,16 +193,16 @@ func (_m *MockRepository) GetAuthorsByID(authorIDs []int64) ([]string, error) {
 	return r0, r1
 }
 
-// GetBookAuthorIDs provides a mock function with given fields: bookID
-func (_m *MockRepository) GetBookAuthorIDs(bookID string) ([]int64, error) {
+// GetBookAuthors provides a mock function with given fields: bookID
+func (_m *MockRepository) GetBookAuthors(bookID string) ([]*BookAuthor, error) {
 	ret := _m.Called(bookID)
 
-	var r0 []int64
-	if rf, ok := ret.Get(0).(func(string) []int64); ok {
+	var r0 []*BookAuthor
+	if rf, ok := ret.Get(0).(func(string) []*BookAuthor); ok {
 		r0 = rf(bookID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]int64)
+			r0 = ret.Get(0).([]*BookAuthor)
 		}
 	}
 
@@ -138,6 +239,29 @@ func (_m *MockRepository) GetBookSubjectIDs(bookID string) ([]int64, error) {
 	return r0, r1
 }
 
+// GetFacets provides a mock function with given fields: query, filter
+func (_m *MockRepository) GetFacets(query string, filter *Filter) (*Facets, error) {
+	ret := _m.Called(query, filter)
+
+	var r0 *Facets
+	if rf, ok := ret.Get(0).(func(string, *Filter) *Facets); ok {
+		r0 = rf(query, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Facets)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *Filter) error); ok {
+		r1 = rf(query, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSubjectIDs provides a mock function with given fields: subjects
 func (_m *MockRepository) GetSubjectIDs(subjects []string) ([]int64, error) {
 	ret := _m.Called(subjects)
@@ -184,6 +308,64 @@ func (_m *MockRepository) GetSubjectsByID(subjectIDs []int64) ([]string, error)
 	return r0, r1
 }
 
+// ISBNExists provides a mock function with given fields: isbn
+func (_m *MockRepository) ISBNExists(isbn string) (bool, error) {
+	ret := _m.Called(isbn)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(isbn)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(isbn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: filter
+func (_m *MockRepository) List(filter *Filter) ([]*Book, error) {
+	ret := _m.Called(filter)
+
+	var r0 []*Book
+	if rf, ok := ret.Get(0).(func(*Filter) []*Book); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Book)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Filter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshSearchVector provides a mock function with given fields: bookID
+func (_m *MockRepository) RefreshSearchVector(bookID string) error {
+	ret := _m.Called(bookID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(bookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: book
 func (_m *MockRepository) Save(book *Book) (*Book, error) {
 	ret := _m.Called(book)
@@ -221,13 +403,13 @@ func (_m *MockRepository) SaveAuthors(authors []string) error {
 	return r0
 }
 
-// SaveBookAuthors provides a mock function with given fields: bookID, authorIDs
-func (_m *MockRepository) SaveBookAuthors(bookID string, authorIDs []int64) error {
-	ret := _m.Called(bookID, authorIDs)
+// SaveBookAuthors provides a mock function with given fields: bookID, authors
+func (_m *MockRepository) SaveBookAuthors(bookID string, authors []*BookAuthor) error {
+	ret := _m.Called(bookID, authors)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, []int64) error); ok {
-		r0 = rf(bookID, authorIDs)
+	if rf, ok := ret.Get(0).(func(string, []*BookAuthor) error); ok {
+		r0 = rf(bookID, authors)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -249,6 +431,29 @@ func (_m *MockRepository) SaveBookSubjects(bookID string, subjectIDs []int64) er
 	return r0
 }
 
+// Search provides a mock function with given fields: query, filter
+func (_m *MockRepository) Search(query string, filter *Filter) ([]*SearchResult, error) {
+	ret := _m.Called(query, filter)
+
+	var r0 []*SearchResult
+	if rf, ok := ret.Get(0).(func(string, *Filter) []*SearchResult); ok {
+		r0 = rf(query, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*SearchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *Filter) error); ok {
+		r1 = rf(query, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: book
 func (_m *MockRepository) Update(book *Book) (*Book, error) {
 	ret := _m.Called(book)
@@ -271,3 +476,19 @@ func (_m *MockRepository) Update(book *Book) (*Book, error) {
 
 	return r0, r1
 }
+
+// WithTransaction provides a mock function with given fields: tx
+func (_m *MockRepository) WithTransaction(tx pkg.Transaction) Repository {
+	ret := _m.Called(tx)
+
+	var r0 Repository
+	if rf, ok := ret.Get(0).(func(pkg.Transaction) Repository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Repository)
+		}
+	}
+
+	return r0
+}
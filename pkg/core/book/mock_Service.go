@@ -2,13 +2,51 @@
 
 package book
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	pkg "github.com/joshuabezaleel/library-server/pkg"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockService is an autogenerated mock type for the Service type
 type MockService struct {
 	mock.Mock
 }
 
+// AddQuantity provides a mock function with given fields: bookID, quantity
+func (_m *MockService) AddQuantity(bookID string, quantity int) error {
+	ret := _m.Called(bookID, quantity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int) error); ok {
+		r0 = rf(bookID, quantity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CallNumberExists provides a mock function with given fields: callNumber
+func (_m *MockService) CallNumberExists(callNumber string) (bool, error) {
+	ret := _m.Called(callNumber)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(callNumber)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(callNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: book
 func (_m *MockService) Create(book *Book) (*Book, error) {
 	ret := _m.Called(book)
@@ -92,22 +130,22 @@ func (_m *MockService) GetAuthorIDs(authors []string) ([]int64, error) {
 	return r0, r1
 }
 
-// GetAuthorsByID provides a mock function with given fields: authorIDs
-func (_m *MockService) GetAuthorsByID(authorIDs []int64) ([]string, error) {
-	ret := _m.Called(authorIDs)
+// GetAvailability provides a mock function with given fields: bookID
+func (_m *MockService) GetAvailability(bookID string) ([]*BranchAvailability, error) {
+	ret := _m.Called(bookID)
 
-	var r0 []string
-	if rf, ok := ret.Get(0).(func([]int64) []string); ok {
-		r0 = rf(authorIDs)
+	var r0 []*BranchAvailability
+	if rf, ok := ret.Get(0).(func(string) []*BranchAvailability); ok {
+		r0 = rf(bookID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]*BranchAvailability)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func([]int64) error); ok {
-		r1 = rf(authorIDs)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(bookID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -115,16 +153,16 @@ func (_m *MockService) GetAuthorsByID(authorIDs []int64) ([]string, error) {
 	return r0, r1
 }
 
-// GetBookAuthorIDs provides a mock function with given fields: bookID
-func (_m *MockService) GetBookAuthorIDs(bookID string) ([]int64, error) {
+// GetBookAuthors provides a mock function with given fields: bookID
+func (_m *MockService) GetBookAuthors(bookID string) ([]*BookAuthor, error) {
 	ret := _m.Called(bookID)
 
-	var r0 []int64
-	if rf, ok := ret.Get(0).(func(string) []int64); ok {
+	var r0 []*BookAuthor
+	if rf, ok := ret.Get(0).(func(string) []*BookAuthor); ok {
 		r0 = rf(bookID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]int64)
+			r0 = ret.Get(0).([]*BookAuthor)
 		}
 	}
 
@@ -161,6 +199,29 @@ func (_m *MockService) GetBookSubjectIDs(bookID string) ([]int64, error) {
 	return r0, r1
 }
 
+// GetFacets provides a mock function with given fields: query, filter
+func (_m *MockService) GetFacets(query string, filter *Filter) (*Facets, error) {
+	ret := _m.Called(query, filter)
+
+	var r0 *Facets
+	if rf, ok := ret.Get(0).(func(string, *Filter) *Facets); ok {
+		r0 = rf(query, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Facets)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *Filter) error); ok {
+		r1 = rf(query, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSubjectIDs provides a mock function with given fields: subjects
 func (_m *MockService) GetSubjectIDs(subjects []string) ([]int64, error) {
 	ret := _m.Called(subjects)
@@ -207,6 +268,94 @@ func (_m *MockService) GetSubjectsByID(subjectIDs []int64) ([]string, error) {
 	return r0, r1
 }
 
+// ISBNExists provides a mock function with given fields: isbn
+func (_m *MockService) ISBNExists(isbn string) (bool, error) {
+	ret := _m.Called(isbn)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(isbn)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(isbn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: filter
+func (_m *MockService) List(filter *Filter) ([]*Book, int, error) {
+	ret := _m.Called(filter)
+
+	var r0 []*Book
+	if rf, ok := ret.Get(0).(func(*Filter) []*Book); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Book)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*Filter) int); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*Filter) error); ok {
+		r2 = rf(filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Lookup provides a mock function with given fields: isbn
+func (_m *MockService) Lookup(isbn string) (*Book, error) {
+	ret := _m.Called(isbn)
+
+	var r0 *Book
+	if rf, ok := ret.Get(0).(func(string) *Book); ok {
+		r0 = rf(isbn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Book)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(isbn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshSearchVector provides a mock function with given fields: bookID
+func (_m *MockService) RefreshSearchVector(bookID string) error {
+	ret := _m.Called(bookID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(bookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveAuthors provides a mock function with given fields: authors
 func (_m *MockService) SaveAuthors(authors []string) error {
 	ret := _m.Called(authors)
@@ -221,13 +370,13 @@ func (_m *MockService) SaveAuthors(authors []string) error {
 	return r0
 }
 
-// SaveBookAuthors provides a mock function with given fields: bookID, authorIDs
-func (_m *MockService) SaveBookAuthors(bookID string, authorIDs []int64) error {
-	ret := _m.Called(bookID, authorIDs)
+// SaveBookAuthors provides a mock function with given fields: bookID, authors
+func (_m *MockService) SaveBookAuthors(bookID string, authors []*BookAuthor) error {
+	ret := _m.Called(bookID, authors)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, []int64) error); ok {
-		r0 = rf(bookID, authorIDs)
+	if rf, ok := ret.Get(0).(func(string, []*BookAuthor) error); ok {
+		r0 = rf(bookID, authors)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -249,6 +398,36 @@ func (_m *MockService) SaveBookSubjects(bookID string, subjectIDs []int64) error
 	return r0
 }
 
+// Search provides a mock function with given fields: query, filter
+func (_m *MockService) Search(query string, filter *Filter) ([]*SearchResult, int, error) {
+	ret := _m.Called(query, filter)
+
+	var r0 []*SearchResult
+	if rf, ok := ret.Get(0).(func(string, *Filter) []*SearchResult); ok {
+		r0 = rf(query, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*SearchResult)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string, *Filter) int); ok {
+		r1 = rf(query, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, *Filter) error); ok {
+		r2 = rf(query, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Update provides a mock function with given fields: book
 func (_m *MockService) Update(book *Book) (*Book, error) {
 	ret := _m.Called(book)
@@ -271,3 +450,19 @@ func (_m *MockService) Update(book *Book) (*Book, error) {
 
 	return r0, r1
 }
+
+// WithTransaction provides a mock function with given fields: tx
+func (_m *MockService) WithTransaction(tx pkg.Transaction) Service {
+	ret := _m.Called(tx)
+
+	var r0 Service
+	if rf, ok := ret.Get(0).(func(pkg.Transaction) Service); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Service)
+		}
+	}
+
+	return r0
+}
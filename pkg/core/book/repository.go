@@ -1,5 +1,7 @@
 package book
 
+import util "github.com/joshuabezaleel/library-server/pkg"
+
 // Repository provides access to the Book store.
 type Repository interface {
 	// CRUD operations.
@@ -9,6 +11,17 @@ type Repository interface {
 	Delete(bookID string) error
 
 	// Other operations.
+	List(filter *Filter) ([]*Book, error)
+	Count(filter *Filter) (int, error)
+	Search(query string, filter *Filter) ([]*SearchResult, error)
+	CountSearch(query string, filter *Filter) (int, error)
+	GetFacets(query string, filter *Filter) (*Facets, error)
+	RefreshSearchVector(bookID string) error
+	AddQuantity(bookID string, quantity int) error
+	GetAvailability(bookID string) ([]*BranchAvailability, error)
+	ISBNExists(isbn string) (bool, error)
+	CallNumberExists(callNumber string) (bool, error)
+
 	GetSubjectIDs(subjects []string) ([]int64, error)
 	SaveBookSubjects(bookID string, subjectIDs []int64) error
 	GetBookSubjectIDs(bookID string) ([]int64, error)
@@ -16,7 +29,12 @@ type Repository interface {
 
 	SaveAuthors(authors []string) error
 	GetAuthorIDs(authors []string) ([]int64, error)
-	SaveBookAuthors(bookID string, authorIDs []int64) error
-	GetBookAuthorIDs(bookID string) ([]int64, error)
-	GetAuthorsByID(authorIDs []int64) ([]string, error)
+	AuthorExists(authorID int64) (bool, error)
+	// SaveBookAuthors and GetBookAuthors keep the Authors in the order they were given,
+	// the first Author being the main entry of the Book.
+	SaveBookAuthors(bookID string, authors []*BookAuthor) error
+	GetBookAuthors(bookID string) ([]*BookAuthor, error)
+
+	// WithTransaction returns the repository working in the Transaction.
+	WithTransaction(tx util.Transaction) Repository
 }
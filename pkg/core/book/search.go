@@ -0,0 +1,22 @@
+package book
+
+// SearchResult is a Book matching a catalogue search along with
+// its relevance score, the highlighted parts that matched and where its copies are.
+// The highlighted parts are HTML-escaped text with the matching words in <mark>.
+type SearchResult struct {
+	Book           *Book                 `json:"book"`
+	Score          float64               `json:"score"`
+	TitleHighlight string                `json:"titleHighlight"`
+	Snippet        string                `json:"snippet"`
+	Availability   []*BranchAvailability `json:"availability"`
+}
+
+// NewSearchResult creates a new instance of SearchResult.
+func NewSearchResult(book *Book, score float64, titleHighlight string, snippet string) *SearchResult {
+	return &SearchResult{
+		Book:           book,
+		Score:          score,
+		TitleHighlight: titleHighlight,
+		Snippet:        snippet,
+	}
+}
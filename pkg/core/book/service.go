@@ -2,9 +2,12 @@ package book
 
 import (
 	"errors"
+	"strings"
+	"sync"
 	"time"
 
 	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/isbn"
 )
 
 // Errors definition.
@@ -13,17 +16,36 @@ var (
 	ErrGetBook    = errors.New("Error retrieving Book")
 	ErrUpdateBook = errors.New("Error updating Book")
 	ErrDeleteBook = errors.New("Error deleting Book")
+	ErrListBooks  = errors.New("Error listing Books")
+
+	ErrUpdateQuantity = errors.New("Error updating Book's quantity")
+
+	ErrInvalidFilter = errors.New("Invalid Book filter")
+	ErrInvalidISBN   = errors.New("Book's ISBN must be a valid ISBN-10 or ISBN-13")
+
+	ErrSearchBooks         = errors.New("Error searching Books")
+	ErrEmptySearchQuery    = errors.New("Search query must not be empty")
+	ErrRefreshSearchVector = errors.New("Error refreshing Book's search index")
+	ErrGetFacets           = errors.New("Error retrieving catalogue facets")
+	ErrGetAvailability     = errors.New("Error retrieving availability of Book")
+	ErrCheckDuplicate      = errors.New("Error checking the catalogue for duplicate Books")
+
+	ErrMetadataNotFound = errors.New("No metadata found for the ISBN")
+	ErrLookupMetadata   = errors.New("Error looking up metadata of the ISBN")
 
 	ErrGetSubjectIDs     = errors.New("Error retrieving subject IDs")
 	ErrSaveBookSubjects  = errors.New("Error saving Book's subjects")
 	ErrGetBookSubjectIDs = errors.New("Error retrieving Book's subjects")
 	ErrGetSubjectsByID   = errors.New("Error retrieving subjects")
-
-	ErrSaveAuthors      = errors.New("Error saving authors")
-	ErrGetAuthorIDs     = errors.New("Error retrieving author IDs")
-	ErrSaveBookAuthors  = errors.New("Error saving Book's authors")
-	ErrGetBookAuthorIDs = errors.New("Error retrieving Book's authors")
-	ErrGetAuthorsByID   = errors.New("Error retrieving authors")
+	ErrUnknownSubject    = errors.New("Book's subjects must be terms of the subject vocabulary")
+
+	ErrSaveAuthors        = errors.New("Error saving authors")
+	ErrGetAuthorIDs       = errors.New("Error retrieving author IDs")
+	ErrSaveBookAuthors    = errors.New("Error saving Book's authors")
+	ErrGetBookAuthors     = errors.New("Error retrieving Book's authors")
+	ErrInvalidAuthorRole  = errors.New("Author role must be author, editor, translator or illustrator")
+	ErrInvalidBookAuthors = errors.New("Book's authors must have an author ID or a name")
+	ErrUnknownAuthor      = errors.New("Book's authors must be existing authors")
 )
 
 // Service provides basic operations on Book domain model.
@@ -35,6 +57,16 @@ type Service interface {
 	Delete(bookID string) error
 
 	// Other operations.
+	List(filter *Filter) ([]*Book, int, error)
+	Search(query string, filter *Filter) ([]*SearchResult, int, error)
+	GetFacets(query string, filter *Filter) (*Facets, error)
+	GetAvailability(bookID string) ([]*BranchAvailability, error)
+	RefreshSearchVector(bookID string) error
+	AddQuantity(bookID string, quantity int) error
+	ISBNExists(isbn string) (bool, error)
+	CallNumberExists(callNumber string) (bool, error)
+	Lookup(isbn string) (*Book, error)
+
 	GetSubjectIDs(subjects []string) ([]int64, error)
 	SaveBookSubjects(bookID string, subjectIDs []int64) error
 	GetBookSubjectIDs(bookID string) ([]int64, error)
@@ -42,62 +74,80 @@ type Service interface {
 
 	SaveAuthors(authors []string) error
 	GetAuthorIDs(authors []string) ([]int64, error)
-	SaveBookAuthors(bookID string, authorIDs []int64) error
-	GetBookAuthorIDs(bookID string) ([]int64, error)
-	GetAuthorsByID(authorIDs []int64) ([]string, error)
+	SaveBookAuthors(bookID string, authors []*BookAuthor) error
+	GetBookAuthors(bookID string) ([]*BookAuthor, error)
+
+	WithTransaction(tx util.Transaction) Service
 }
 
 type service struct {
-	bookRepository Repository
+	bookRepository    Repository
+	metadataProviders []MetadataProvider
 }
 
 // NewBookService creates an instance of the service for the Book domain model
-// with all of the necessary dependencies.
-func NewBookService(bookRepository Repository) Service {
+// with all of the necessary dependencies. The metadata providers are looked up
+// in their order of precedence.
+func NewBookService(bookRepository Repository, metadataProviders ...MetadataProvider) Service {
+	return &service{
+		bookRepository:    bookRepository,
+		metadataProviders: metadataProviders,
+	}
+}
+
+// WithTransaction returns the service working in the Transaction, so that
+// what it writes is committed or rolled back along with the rest of it.
+func (s *service) WithTransaction(tx util.Transaction) Service {
 	return &service{
-		bookRepository: bookRepository,
+		bookRepository:    s.bookRepository.WithTransaction(tx),
+		metadataProviders: s.metadataProviders,
 	}
 }
 
 func (s *service) Create(book *Book) (*Book, error) {
 	var newBook *Book
 
-	// Create a new instance of Book.
-	newBook = NewBook(util.NewID(), book.Title, book.Publisher, book.YearPublished, book.CallNumber, book.CoverPicture, book.ISBN, book.Collation, book.Edition, book.Description, book.LOCClassification, book.Subject, book.Author, book.Quantity, time.Now())
+	err := normalizeISBN(book)
+	if err != nil {
+		return nil, err
+	}
 
-	newBook, err := s.bookRepository.Save(newBook)
+	// Resolve the Authors referenced only by name to their IDs.
+	err = s.resolveAuthors(book.Author)
 	if err != nil {
-		return nil, ErrCreateBook
+		return nil, err
 	}
 
-	// Retrieve the subjectIDs of the particular Book that want to be created.
+	// Resolve the subjects to the preferred terms of the subject vocabulary.
 	subjectIDs, err := s.GetSubjectIDs(book.Subject)
 	if err != nil {
-		return nil, ErrGetSubjectIDs
+		return nil, err
 	}
 
-	// Save the relation between this BookID with all of the subjectIDs.
-	err = s.SaveBookSubjects(newBook.ID, subjectIDs)
+	// Create a new instance of Book.
+	newBook = NewBook(util.NewID(), book.Title, book.Publisher, book.YearPublished, book.CallNumber, book.CoverPicture, book.ISBN, book.Collation, book.Edition, book.Description, book.LOCClassification, book.Subject, book.Author, book.Quantity, time.Now())
+
+	newBook, err = s.bookRepository.Save(newBook)
 	if err != nil {
-		return nil, ErrSaveBookSubjects
+		return nil, ErrCreateBook
 	}
 
-	// Save Authors of the Books.
-	err = s.SaveAuthors(book.Author)
+	// Save the relation between this BookID with all of the subjectIDs.
+	err = s.SaveBookSubjects(newBook.ID, subjectIDs)
 	if err != nil {
-		return nil, ErrSaveAuthors
+		return nil, ErrSaveBookSubjects
 	}
 
-	// Retrieve the authorIds of the particular Book that want to be created.
-	authorIDs, err := s.GetAuthorIDs(book.Author)
+	// Save the relation between this BookID with all of the Authors and their roles.
+	err = s.SaveBookAuthors(newBook.ID, book.Author)
 	if err != nil {
-		return nil, ErrGetAuthorIDs
+		return nil, ErrSaveBookAuthors
 	}
 
-	// Save the relation between this BookID with all of the authorIDs.
-	err = s.SaveBookAuthors(newBook.ID, authorIDs)
+	// Index the Book along with its subjects and authors for searching.
+	err = s.bookRepository.RefreshSearchVector(newBook.ID)
 	if err != nil {
-		return nil, ErrSaveBookAuthors
+		return nil, ErrRefreshSearchVector
 	}
 
 	return newBook, nil
@@ -109,44 +159,51 @@ func (s *service) Get(bookID string) (*Book, error) {
 		return nil, ErrGetBook
 	}
 
-	// Retrieve the IDs of the particular Book subjects that want to be retrieved.
-	subjectIDs, err := s.GetBookSubjectIDs(bookID)
+	err = s.loadSubjectsAndAuthors(book)
 	if err != nil {
-		return nil, ErrGetBookSubjectIDs
+		return nil, err
 	}
 
-	// Retrieve the Subjects by the IDs.
-	subjects, err := s.GetSubjectsByID(subjectIDs)
+	return book, nil
+}
+
+// Update validates the ISBN of the Book only when it changes, so that Books
+// saved before ISBNs were validated can still be updated.
+func (s *service) Update(book *Book) (*Book, error) {
+	existingBook, err := s.bookRepository.Get(book.ID)
 	if err != nil {
-		return nil, ErrGetSubjectsByID
+		return nil, ErrUpdateBook
 	}
 
-	book.Subject = subjects
+	if book.ISBN != existingBook.ISBN {
+		err = normalizeISBN(book)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	// Retrieve the IDs of the particular Book authors that want to be retrieved.
-	authorIDs, err := s.GetBookAuthorIDs(bookID)
+	book, err = s.bookRepository.Update(book)
 	if err != nil {
-		return nil, ErrGetBookAuthorIDs
+		return nil, ErrUpdateBook
 	}
 
-	// Retrieve the Authors by the IDs.
-	authors, err := s.GetAuthorsByID(authorIDs)
+	err = s.bookRepository.RefreshSearchVector(book.ID)
 	if err != nil {
-		return nil, ErrGetAuthorsByID
+		return nil, ErrRefreshSearchVector
 	}
 
-	book.Author = authors
-
 	return book, nil
 }
 
-func (s *service) Update(book *Book) (*Book, error) {
-	book, err := s.bookRepository.Update(book)
+// AddQuantity adds to the number of Copies of the Book, which may be negative,
+// without updating the rest of it.
+func (s *service) AddQuantity(bookID string, quantity int) error {
+	err := s.bookRepository.AddQuantity(bookID, quantity)
 	if err != nil {
-		return nil, ErrUpdateBook
+		return ErrUpdateQuantity
 	}
 
-	return book, nil
+	return nil
 }
 
 func (s *service) Delete(bookID string) error {
@@ -158,12 +215,191 @@ func (s *service) Delete(bookID string) error {
 	return nil
 }
 
+func (s *service) List(filter *Filter) ([]*Book, int, error) {
+	err := validateFilter(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	books, err := s.bookRepository.List(filter)
+	if err != nil {
+		return nil, 0, ErrListBooks
+	}
+
+	total, err := s.bookRepository.Count(filter)
+	if err != nil {
+		return nil, 0, ErrListBooks
+	}
+
+	for _, book := range books {
+		err = s.loadSubjectsAndAuthors(book)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return books, total, nil
+}
+
+func (s *service) Search(query string, filter *Filter) ([]*SearchResult, int, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, 0, ErrEmptySearchQuery
+	}
+
+	err := validateFilter(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results, err := s.bookRepository.Search(query, filter)
+	if err != nil {
+		return nil, 0, ErrSearchBooks
+	}
+
+	total, err := s.bookRepository.CountSearch(query, filter)
+	if err != nil {
+		return nil, 0, ErrSearchBooks
+	}
+
+	for _, result := range results {
+		err = s.loadSubjectsAndAuthors(result.Book)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		result.Availability, err = s.GetAvailability(result.Book.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return results, total, nil
+}
+
+func (s *service) GetFacets(query string, filter *Filter) (*Facets, error) {
+	facets, err := s.bookRepository.GetFacets(strings.TrimSpace(query), filter)
+	if err != nil {
+		return nil, ErrGetFacets
+	}
+
+	return facets, nil
+}
+
+// GetAvailability returns the copies of the Book in each Branch.
+func (s *service) GetAvailability(bookID string) ([]*BranchAvailability, error) {
+	availability, err := s.bookRepository.GetAvailability(bookID)
+	if err != nil {
+		return nil, ErrGetAvailability
+	}
+
+	return availability, nil
+}
+
+// RefreshSearchVector reindexes the Book for searching, after the
+// subjects or authors it is searchable by have changed.
+func (s *service) RefreshSearchVector(bookID string) error {
+	err := s.bookRepository.RefreshSearchVector(bookID)
+	if err != nil {
+		return ErrRefreshSearchVector
+	}
+
+	return nil
+}
+
+// ISBNExists returns whether a Book with the ISBN, in either of its
+// ISBN-10 or ISBN-13 forms, is already in the catalogue.
+func (s *service) ISBNExists(value string) (bool, error) {
+	isbn13, err := isbn.Normalize(value)
+	if err != nil {
+		return false, ErrInvalidISBN
+	}
+
+	exists, err := s.bookRepository.ISBNExists(isbn13)
+	if err != nil {
+		return false, ErrCheckDuplicate
+	}
+
+	return exists, nil
+}
+
+// CallNumberExists returns whether a Book with the call number is already in the catalogue.
+func (s *service) CallNumberExists(callNumber string) (bool, error) {
+	exists, err := s.bookRepository.CallNumberExists(callNumber)
+	if err != nil {
+		return false, ErrCheckDuplicate
+	}
+
+	return exists, nil
+}
+
+// Lookup retrieves a draft of the Book with the ISBN from the metadata providers,
+// merging what each of them found, to be reviewed before it is created. Its subjects
+// are as the providers know them, which are not necessarily in the subject vocabulary.
+func (s *service) Lookup(value string) (*Book, error) {
+	isbn13, err := isbn.Normalize(value)
+	if err != nil {
+		return nil, ErrInvalidISBN
+	}
+
+	// The providers are looked up all at once, but merged in their order.
+	books := make([]*Book, len(s.metadataProviders))
+	errs := make([]error, len(s.metadataProviders))
+
+	var wg sync.WaitGroup
+	for i, provider := range s.metadataProviders {
+		wg.Add(1)
+		go func(i int, provider MetadataProvider) {
+			defer wg.Done()
+			books[i], errs[i] = provider.Lookup(isbn13)
+		}(i, provider)
+	}
+	wg.Wait()
+
+	found := []*Book{}
+	failed := false
+	for i, book := range books {
+		switch {
+		case errs[i] == nil && book != nil:
+			found = append(found, book)
+		case errs[i] != nil && errs[i] != ErrMetadataNotFound:
+			failed = true
+		}
+	}
+
+	if len(found) == 0 {
+		if failed {
+			return nil, ErrLookupMetadata
+		}
+		return nil, ErrMetadataNotFound
+	}
+
+	return mergeMetadata(isbn13, found), nil
+}
+
+// GetSubjectIDs resolves the subjects to the IDs of their preferred terms,
+// synonyms included, without repeating a term given more than once.
 func (s *service) GetSubjectIDs(subjects []string) ([]int64, error) {
 	subjectIDs, err := s.bookRepository.GetSubjectIDs(subjects)
 	if err != nil {
 		return nil, ErrGetSubjectIDs
 	}
-	return subjectIDs, nil
+
+	// Subjects outside of the vocabulary are left out by the repository.
+	if len(subjectIDs) != len(subjects) {
+		return nil, ErrUnknownSubject
+	}
+
+	uniqueSubjectIDs := []int64{}
+	seen := map[int64]bool{}
+	for _, subjectID := range subjectIDs {
+		if !seen[subjectID] {
+			seen[subjectID] = true
+			uniqueSubjectIDs = append(uniqueSubjectIDs, subjectID)
+		}
+	}
+
+	return uniqueSubjectIDs, nil
 }
 
 func (s *service) SaveBookSubjects(bookID string, subjectIDs []int64) error {
@@ -210,8 +446,8 @@ func (s *service) GetAuthorIDs(authors []string) ([]int64, error) {
 	return authorIDs, nil
 }
 
-func (s *service) SaveBookAuthors(bookID string, authorIDs []int64) error {
-	err := s.bookRepository.SaveBookAuthors(bookID, authorIDs)
+func (s *service) SaveBookAuthors(bookID string, authors []*BookAuthor) error {
+	err := s.bookRepository.SaveBookAuthors(bookID, authors)
 	if err != nil {
 		return ErrSaveBookAuthors
 	}
@@ -219,20 +455,156 @@ func (s *service) SaveBookAuthors(bookID string, authorIDs []int64) error {
 	return nil
 }
 
-func (s *service) GetBookAuthorIDs(bookID string) ([]int64, error) {
-	bookAuthorIDs, err := s.bookRepository.GetBookAuthorIDs(bookID)
+func (s *service) GetBookAuthors(bookID string) ([]*BookAuthor, error) {
+	authors, err := s.bookRepository.GetBookAuthors(bookID)
 	if err != nil {
-		return nil, ErrGetBookAuthorIDs
+		return nil, ErrGetBookAuthors
 	}
 
-	return bookAuthorIDs, nil
+	return authors, nil
 }
 
-func (s *service) GetAuthorsByID(authorIDs []int64) ([]string, error) {
-	authors, err := s.bookRepository.GetAuthorsByID(authorIDs)
+// resolveAuthors validates the roles of the Authors of a Book, which are
+// author by default, and fills in the IDs of the Authors given only by name,
+// creating the ones that do not exist yet.
+func (s *service) resolveAuthors(authors []*BookAuthor) error {
+	var names []string
+	for _, author := range authors {
+		if author.Role == "" {
+			author.Role = RoleAuthor
+		}
+
+		if !IsValidRole(author.Role) {
+			return ErrInvalidAuthorRole
+		}
+
+		if author.AuthorID == 0 {
+			if author.Name == "" {
+				return ErrInvalidBookAuthors
+			}
+
+			names = append(names, author.Name)
+			continue
+		}
+
+		// An Author referenced by its ID must exist, or the Book would silently lose it.
+		isAuthorExists, err := s.bookRepository.AuthorExists(author.AuthorID)
+		if err != nil {
+			return ErrGetAuthorIDs
+		}
+
+		if !isAuthorExists {
+			return ErrUnknownAuthor
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	err := s.SaveAuthors(names)
 	if err != nil {
-		return nil, ErrGetAuthorsByID
+		return ErrSaveAuthors
 	}
 
-	return authors, nil
+	authorIDs, err := s.GetAuthorIDs(names)
+	if err != nil {
+		return ErrGetAuthorIDs
+	}
+
+	i := 0
+	for _, author := range authors {
+		if author.AuthorID == 0 {
+			author.AuthorID = authorIDs[i]
+			i++
+		}
+	}
+
+	return nil
+}
+
+// loadSubjectsAndAuthors fills in the subjects and authors of a Book
+// that are stored in their own tables.
+func (s *service) loadSubjectsAndAuthors(book *Book) error {
+	// Retrieve the IDs of the particular Book subjects that want to be retrieved.
+	subjectIDs, err := s.GetBookSubjectIDs(book.ID)
+	if err != nil {
+		return ErrGetBookSubjectIDs
+	}
+
+	// Retrieve the Subjects by the IDs.
+	subjects, err := s.GetSubjectsByID(subjectIDs)
+	if err != nil {
+		return ErrGetSubjectsByID
+	}
+
+	book.Subject = subjects
+
+	// Retrieve the Authors of the particular Book along with their roles.
+	authors, err := s.GetBookAuthors(book.ID)
+	if err != nil {
+		return ErrGetBookAuthors
+	}
+
+	book.Author = authors
+
+	return nil
+}
+
+// validateFilter checks the sorting and pagination of a Filter
+// and fills in the defaults for the ones that are not set.
+func validateFilter(filter *Filter) error {
+	if filter.ISBN != "" {
+		isbn13, err := isbn.Normalize(filter.ISBN)
+		if err != nil {
+			return ErrInvalidISBN
+		}
+		filter.ISBN = isbn13
+	}
+
+	switch filter.SortBy {
+	case "":
+		filter.SortBy = SortByTitle
+	case SortByTitle, SortByYearPublished, SortByAddedAt:
+	default:
+		return ErrInvalidFilter
+	}
+
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return ErrInvalidFilter
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = DefaultLimit
+	}
+
+	if filter.Limit > MaxLimit {
+		filter.Limit = MaxLimit
+	}
+
+	if filter.YearFrom != 0 && filter.YearTo != 0 && filter.YearFrom > filter.YearTo {
+		return ErrInvalidFilter
+	}
+
+	if filter.Decade%10 != 0 {
+		return ErrInvalidFilter
+	}
+
+	return nil
+}
+
+// normalizeISBN replaces the ISBN of the Book, written as either an ISBN-10
+// or an ISBN-13, with its canonical ISBN-13. Books without an ISBN are kept so.
+func normalizeISBN(book *Book) error {
+	if book.ISBN == "" {
+		return nil
+	}
+
+	isbn13, err := isbn.Normalize(book.ISBN)
+	if err != nil {
+		return ErrInvalidISBN
+	}
+	book.ISBN = isbn13
+
+	return nil
 }
@@ -4,22 +4,45 @@ import (
 	"time"
 )
 
-// BookCopy domain model.
+// DefaultCategory is the item category of a BookCopy that
+// is created without one.
+const DefaultCategory = "general"
+
+// BookCopy domain model. Status is the circulation status of the BookCopy,
+// which only changes through the transitions of the status lifecycle.
+// AcquisitionPrice is what the library paid for the BookCopy and is charged
+// to the patron as the replacement cost when it is lost or damaged.
+// The home Branch and Location are where the BookCopy belongs, and the current
+// ones are where it is, which differ while it waits to go back home.
 type BookCopy struct {
-	ID        string    `json:"id" db:"id"`
-	Barcode   string    `json:"barcode" db:"barcode"`
-	BookID    string    `json:"bookID" db:"book_id"`
-	Condition string    `json:"condition" db:"condition"`
-	AddedAt   time.Time `json:"addedAt" db:"added_at"`
+	ID                string    `json:"id" db:"id"`
+	Barcode           string    `json:"barcode" db:"barcode"`
+	BookID            string    `json:"bookID" db:"book_id"`
+	Condition         string    `json:"condition" db:"condition"`
+	Category          string    `json:"category" db:"category"`
+	Status            string    `json:"status" db:"status"`
+	AcquisitionPrice  uint32    `json:"acquisitionPrice" db:"acquisition_price"`
+	HomeBranchID      string    `json:"homeBranchID" db:"home_branch_id"`
+	HomeLocationID    string    `json:"homeLocationID" db:"home_location_id"`
+	CurrentBranchID   string    `json:"currentBranchID" db:"current_branch_id"`
+	CurrentLocationID string    `json:"currentLocationID" db:"current_location_id"`
+	AddedAt           time.Time `json:"addedAt" db:"added_at"`
 }
 
 // NewBookCopy creates a new instance of BookCopy domain model.
-func NewBookCopy(id string, barcode string, bookID string, condition string, addedAt time.Time) *BookCopy {
+func NewBookCopy(id string, barcode string, bookID string, condition string, category string, status string, acquisitionPrice uint32, homeBranchID string, homeLocationID string, currentBranchID string, currentLocationID string, addedAt time.Time) *BookCopy {
 	return &BookCopy{
-		ID:        id,
-		Barcode:   barcode,
-		BookID:    bookID,
-		Condition: condition,
-		AddedAt:   addedAt,
+		ID:                id,
+		Barcode:           barcode,
+		BookID:            bookID,
+		Condition:         condition,
+		Category:          category,
+		Status:            status,
+		AcquisitionPrice:  acquisitionPrice,
+		HomeBranchID:      homeBranchID,
+		HomeLocationID:    homeLocationID,
+		CurrentBranchID:   currentBranchID,
+		CurrentLocationID: currentLocationID,
+		AddedAt:           addedAt,
 	}
 }
@@ -1,28 +1,40 @@
 package bookcopy
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/barcode"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
 	"github.com/joshuabezaleel/library-server/pkg/core/book"
 )
 
 var bookCopyRepository = &MockRepository{}
 var bookRepository = &book.MockRepository{}
+var branchRepository = &branch.MockRepository{}
+
+var barcodeScheme = barcode.NewScheme([]string{"31234"}, map[string]string{"ENG": "31235"}, barcode.DefaultLength)
 
 var bookService = book.NewBookService(bookRepository)
+var branchService = branch.NewBranchService(branchRepository)
 var bookCopyService = service{
 	bookCopyRepository: bookCopyRepository,
 	bookService:        bookService,
+	branchService:      branchService,
+	barcodeScheme:      barcodeScheme,
 }
 
 func TestCreate(t *testing.T) {
 	subjects := []string{"Mathematics", "Physics"}
 	subjectIDs := []int64{1, 2}
-	authors := []string{"author1", "author2"}
-	authorIDs := []int64{1, 2}
+	authors := []*book.BookAuthor{
+		{AuthorID: 1, Name: "author1", Role: book.RoleAuthor},
+		{AuthorID: 2, Name: "author2", Role: book.RoleAuthor},
+	}
 
 	createdTime, createdTimePatch := util.CreatedTimePatch()
 	defer createdTimePatch.Unpatch()
@@ -38,17 +50,28 @@ func TestCreate(t *testing.T) {
 	ID, IDPatch := util.NewIDPatch()
 	defer IDPatch.Unpatch()
 
+	// Book Copies created without a barcode get the next one of the scheme.
+	bookCopyRepository.On("NextBarcodeSequence").Return(int64(1), nil)
+	generatedBarcode, err := barcodeScheme.Generate("31234", 1)
+	require.Nil(t, err)
+
 	bookCopy := &BookCopy{
 		ID:        ID,
+		Barcode:   generatedBarcode,
 		Condition: "Available",
 		BookID:    book.ID,
+		Category:  DefaultCategory,
+		Status:    StatusAvailable,
 		AddedAt:   createdTime,
 	}
 
 	errorBookCopy := &BookCopy{
 		ID:        ID,
+		Barcode:   generatedBarcode,
 		Condition: "Repaired",
 		BookID:    book.ID,
+		Category:  DefaultCategory,
+		Status:    StatusAvailable,
 		AddedAt:   createdTime,
 	}
 
@@ -78,11 +101,8 @@ func TestCreate(t *testing.T) {
 			bookRepository.On("Get", book.ID).Return(book, nil)
 			bookRepository.On("GetBookSubjectIDs", book.ID).Return(subjectIDs, nil)
 			bookRepository.On("GetSubjectsByID", subjectIDs).Return(subjects, nil)
-			bookRepository.On("GetBookAuthorIDs", book.ID).Return(authorIDs, nil)
-			bookRepository.On("GetAuthorsByID", authorIDs).Return(authors, nil)
-
-			book.Quantity++
-			bookRepository.On("Update", book).Return(book, nil)
+			bookRepository.On("GetBookAuthors", book.ID).Return(authors, nil)
+			bookRepository.On("AddQuantity", book.ID, 1).Return(nil)
 
 			returnedBookCopy, err := bookCopyService.Create(tc.bookCopy)
 
@@ -96,6 +116,23 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestCreateWithoutBook(t *testing.T) {
+	missingBookID := util.NewID()
+	bookRepository.On("Get", missingBookID).Return(nil, errors.New("sql: no rows in result set"))
+
+	bookCopy := &BookCopy{
+		Barcode:   "31234000000016",
+		Condition: "Available",
+		BookID:    missingBookID,
+	}
+
+	_, err := bookCopyService.Create(bookCopy)
+	require.Equal(t, ErrGetBookCopy, err)
+
+	// No Book Copy is left behind without its Book.
+	bookCopyRepository.AssertNotCalled(t, "Save", mock.MatchedBy(func(c *BookCopy) bool { return c.BookID == missingBookID }))
+}
+
 func TestGet(t *testing.T) {
 	bookCopy := &BookCopy{
 		ID: util.NewID(),
@@ -137,42 +174,78 @@ func TestGet(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
+	validBarcode, err := barcodeScheme.Generate("31234", 2)
+	require.Nil(t, err)
+
 	bookCopy := &BookCopy{
 		ID:        util.NewID(),
+		Barcode:   validBarcode,
 		Condition: "Repaired",
 	}
 
 	expectedBookCopy := &BookCopy{
 		ID:        bookCopy.ID,
+		Barcode:   validBarcode,
 		Condition: "Available",
 	}
 
 	errorBookCopy := &BookCopy{
-		ID: util.NewID(),
+		ID:      util.NewID(),
+		Barcode: validBarcode,
+	}
+
+	invalidBarcodeBookCopy := &BookCopy{
+		ID:      util.NewID(),
+		Barcode: "31234000000020",
+	}
+
+	// A Book Copy saved before barcodes were validated keeps its free-text barcode.
+	legacyBookCopy := &BookCopy{
+		ID:        util.NewID(),
+		Barcode:   "ACC-1998-0042",
+		Condition: "Repaired",
 	}
 
 	tt := []struct {
 		name             string
 		bookCopy         *BookCopy
+		storedBarcode    string
 		returnedBookCopy *BookCopy
 		err              error
 	}{
 		{
 			name:             "success updating a Book Copy",
 			bookCopy:         bookCopy,
+			storedBarcode:    validBarcode,
 			returnedBookCopy: expectedBookCopy,
 			err:              nil,
 		},
 		{
 			name:             "failed updating a Book Copy",
 			bookCopy:         errorBookCopy,
+			storedBarcode:    validBarcode,
 			returnedBookCopy: nil,
 			err:              ErrUpdateBookCopy,
 		},
+		{
+			name:             "invalid barcode",
+			bookCopy:         invalidBarcodeBookCopy,
+			storedBarcode:    validBarcode,
+			returnedBookCopy: nil,
+			err:              ErrInvalidBarcode,
+		},
+		{
+			name:             "success updating a Book Copy with a legacy barcode",
+			bookCopy:         legacyBookCopy,
+			storedBarcode:    legacyBookCopy.Barcode,
+			returnedBookCopy: &BookCopy{ID: legacyBookCopy.ID, Barcode: legacyBookCopy.Barcode, Condition: "Available"},
+			err:              nil,
+		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
+			bookCopyRepository.On("Get", tc.bookCopy.ID).Return(&BookCopy{ID: tc.bookCopy.ID, Barcode: tc.storedBarcode}, nil)
 			bookCopyRepository.On("Update", tc.bookCopy).Return(tc.returnedBookCopy, tc.err)
 
 			updatedBookCopy, err := bookCopyService.Update(tc.bookCopy)
@@ -180,8 +253,8 @@ func TestUpdate(t *testing.T) {
 			require.Equal(t, tc.err, err)
 
 			if tc.err == nil {
-				require.Equal(t, expectedBookCopy.ID, updatedBookCopy.ID)
-				require.Equal(t, expectedBookCopy.Condition, updatedBookCopy.Condition)
+				require.Equal(t, tc.returnedBookCopy.ID, updatedBookCopy.ID)
+				require.Equal(t, tc.returnedBookCopy.Condition, updatedBookCopy.Condition)
 			}
 		})
 	}
@@ -219,3 +292,190 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestChangeStatus(t *testing.T) {
+	librarian := "librarianUsername"
+
+	tt := []struct {
+		name string
+		from string
+		to   string
+		err  error
+	}{
+		{
+			name: "success sending an available Book Copy to repair",
+			from: StatusAvailable,
+			to:   StatusInRepair,
+			err:  nil,
+		},
+		{
+			name: "success finding a lost Book Copy",
+			from: StatusLost,
+			to:   StatusAvailable,
+			err:  nil,
+		},
+		{
+			name: "unknown status",
+			from: StatusAvailable,
+			to:   "borrowed",
+			err:  ErrInvalidStatus,
+		},
+		{
+			name: "withdrawn Book Copy can not come back",
+			from: StatusWithdrawn,
+			to:   StatusAvailable,
+			err:  ErrInvalidTransition,
+		},
+		{
+			name: "Book Copy in repair can not be lent",
+			from: StatusInRepair,
+			to:   StatusOnLoan,
+			err:  ErrInvalidTransition,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookCopy := &BookCopy{
+				ID:     util.NewID(),
+				Status: tc.from,
+			}
+			bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+			bookCopyRepository.On("UpdateStatus", bookCopy).Return(nil)
+			bookCopyRepository.On("SaveStatusChange", mock.MatchedBy(func(statusChange *StatusChange) bool {
+				return statusChange.BookCopyID == bookCopy.ID && statusChange.FromStatus == tc.from && statusChange.ToStatus == tc.to && statusChange.ChangedBy == librarian
+			})).Return(nil, nil)
+
+			changedBookCopy, err := bookCopyService.ChangeStatus(bookCopy.ID, tc.to, librarian, "")
+
+			require.Equal(t, tc.err, err)
+
+			if tc.err == nil {
+				require.Equal(t, tc.to, changedBookCopy.Status)
+			}
+		})
+	}
+}
+
+func TestRelocate(t *testing.T) {
+	mainBranch := &branch.Branch{ID: util.NewID(), Code: "MAIN"}
+	engineeringBranch := &branch.Branch{ID: util.NewID(), Code: "ENG"}
+	stacks := &branch.Location{ID: util.NewID(), BranchID: mainBranch.ID, Name: "Stacks"}
+	branchRepository.On("GetBranch", mainBranch.ID).Return(mainBranch, nil)
+	branchRepository.On("GetBranch", engineeringBranch.ID).Return(engineeringBranch, nil)
+	branchRepository.On("GetLocation", stacks.ID).Return(stacks, nil)
+
+	tt := []struct {
+		name       string
+		branchID   string
+		locationID string
+		err        error
+	}{
+		{
+			name:       "success shelving a Book Copy in a Location",
+			branchID:   mainBranch.ID,
+			locationID: stacks.ID,
+			err:        nil,
+		},
+		{
+			name:       "success moving a Book Copy to a Branch",
+			branchID:   engineeringBranch.ID,
+			locationID: "",
+			err:        nil,
+		},
+		{
+			name:       "Location in another Branch",
+			branchID:   engineeringBranch.ID,
+			locationID: stacks.ID,
+			err:        branch.ErrLocationNotInBranch,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookCopy := &BookCopy{
+				ID:              util.NewID(),
+				HomeBranchID:    mainBranch.ID,
+				CurrentBranchID: mainBranch.ID,
+			}
+			bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+			bookCopyRepository.On("UpdateLocation", bookCopy).Return(nil)
+
+			relocatedBookCopy, err := bookCopyService.Relocate(bookCopy.ID, tc.branchID, tc.locationID)
+
+			require.Equal(t, tc.err, err)
+
+			if tc.err == nil {
+				require.Equal(t, tc.branchID, relocatedBookCopy.CurrentBranchID)
+				require.Equal(t, tc.locationID, relocatedBookCopy.CurrentLocationID)
+				require.Equal(t, mainBranch.ID, relocatedBookCopy.HomeBranchID)
+			}
+		})
+	}
+}
+
+func TestBranchBarcodes(t *testing.T) {
+	mainBranch := &branch.Branch{ID: util.NewID(), Code: "MAIN"}
+	engineeringBranch := &branch.Branch{ID: util.NewID(), Code: "ENG"}
+	branchRepository.On("GetBranch", mainBranch.ID).Return(mainBranch, nil)
+	branchRepository.On("GetBranch", engineeringBranch.ID).Return(engineeringBranch, nil)
+
+	tt := []struct {
+		name         string
+		barcode      string
+		homeBranchID string
+		err          error
+	}{
+		{
+			name:         "barcode in the range of its Branch",
+			barcode:      "31235000000013",
+			homeBranchID: engineeringBranch.ID,
+			err:          nil,
+		},
+		{
+			name:         "barcode in the default range for a Branch without a range",
+			barcode:      "31234000000016",
+			homeBranchID: mainBranch.ID,
+			err:          nil,
+		},
+		{
+			name:         "barcode outside of the range of its Branch",
+			barcode:      "31234000000016",
+			homeBranchID: engineeringBranch.ID,
+			err:          ErrOtherBranch,
+		},
+		{
+			name:         "barcode in the range of another Branch",
+			barcode:      "31235000000013",
+			homeBranchID: mainBranch.ID,
+			err:          ErrOtherBranch,
+		},
+		{
+			name:         "barcode outside of the scheme",
+			barcode:      "31236000000011",
+			homeBranchID: mainBranch.ID,
+			err:          ErrInvalidBarcode,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.err, bookCopyService.ValidateBarcode(tc.barcode, tc.homeBranchID))
+		})
+	}
+
+	// Book Copies of a Branch with a range of its own get a barcode of that range.
+	bookID := util.NewID()
+	bookRepository.On("Get", bookID).Return(&book.Book{ID: bookID}, nil)
+	bookRepository.On("GetBookSubjectIDs", bookID).Return([]int64{}, nil)
+	bookRepository.On("GetSubjectsByID", []int64{}).Return([]string{}, nil)
+	bookRepository.On("GetBookAuthors", bookID).Return([]*book.BookAuthor{}, nil)
+	bookRepository.On("AddQuantity", bookID, 1).Return(nil)
+	bookCopyRepository.On("NextBarcodeSequence").Return(int64(1), nil)
+	bookCopyRepository.On("Save", mock.MatchedBy(func(c *BookCopy) bool { return c.BookID == bookID })).
+		Return(func(c *BookCopy) *BookCopy { return c }, nil)
+
+	newBookCopy, err := bookCopyService.Create(&BookCopy{BookID: bookID, HomeBranchID: engineeringBranch.ID})
+	require.Nil(t, err)
+	require.Equal(t, "31235000000013", newBookCopy.Barcode)
+}
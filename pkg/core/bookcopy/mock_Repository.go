@@ -2,7 +2,12 @@
 
 package bookcopy
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	pkg "github.com/joshuabezaleel/library-server/pkg"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
 
 // MockRepository is an autogenerated mock type for the Repository type
 type MockRepository struct {
@@ -46,6 +51,119 @@ func (_m *MockRepository) Get(bookCopyID string) (*BookCopy, error) {
 	return r0, r1
 }
 
+// GetByBarcode provides a mock function with given fields: barcode
+func (_m *MockRepository) GetByBarcode(barcode string) (*BookCopy, error) {
+	ret := _m.Called(barcode)
+
+	var r0 *BookCopy
+	if rf, ok := ret.Get(0).(func(string) *BookCopy); ok {
+		r0 = rf(barcode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BookCopy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(barcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatusChanges provides a mock function with given fields: bookCopyID
+func (_m *MockRepository) GetStatusChanges(bookCopyID string) ([]*StatusChange, error) {
+	ret := _m.Called(bookCopyID)
+
+	var r0 []*StatusChange
+	if rf, ok := ret.Get(0).(func(string) []*StatusChange); ok {
+		r0 = rf(bookCopyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*StatusChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(bookCopyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAddedBetween provides a mock function with given fields: from, to
+func (_m *MockRepository) ListAddedBetween(from time.Time, to time.Time) ([]*BookCopy, error) {
+	ret := _m.Called(from, to)
+
+	var r0 []*BookCopy
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) []*BookCopy); ok {
+		r0 = rf(from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*BookCopy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time) error); ok {
+		r1 = rf(from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByLocation provides a mock function with given fields: locationID
+func (_m *MockRepository) ListByLocation(locationID string) ([]*BookCopy, error) {
+	ret := _m.Called(locationID)
+
+	var r0 []*BookCopy
+	if rf, ok := ret.Get(0).(func(string) []*BookCopy); ok {
+		r0 = rf(locationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*BookCopy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(locationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NextBarcodeSequence provides a mock function with given fields:
+func (_m *MockRepository) NextBarcodeSequence() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: bookCopy
 func (_m *MockRepository) Save(bookCopy *BookCopy) (*BookCopy, error) {
 	ret := _m.Called(bookCopy)
@@ -69,6 +187,29 @@ func (_m *MockRepository) Save(bookCopy *BookCopy) (*BookCopy, error) {
 	return r0, r1
 }
 
+// SaveStatusChange provides a mock function with given fields: statusChange
+func (_m *MockRepository) SaveStatusChange(statusChange *StatusChange) (*StatusChange, error) {
+	ret := _m.Called(statusChange)
+
+	var r0 *StatusChange
+	if rf, ok := ret.Get(0).(func(*StatusChange) *StatusChange); ok {
+		r0 = rf(statusChange)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*StatusChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*StatusChange) error); ok {
+		r1 = rf(statusChange)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: bookCopy
 func (_m *MockRepository) Update(bookCopy *BookCopy) (*BookCopy, error) {
 	ret := _m.Called(bookCopy)
@@ -91,3 +232,47 @@ func (_m *MockRepository) Update(bookCopy *BookCopy) (*BookCopy, error) {
 
 	return r0, r1
 }
+
+// UpdateLocation provides a mock function with given fields: bookCopy
+func (_m *MockRepository) UpdateLocation(bookCopy *BookCopy) error {
+	ret := _m.Called(bookCopy)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*BookCopy) error); ok {
+		r0 = rf(bookCopy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStatus provides a mock function with given fields: bookCopy
+func (_m *MockRepository) UpdateStatus(bookCopy *BookCopy) error {
+	ret := _m.Called(bookCopy)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*BookCopy) error); ok {
+		r0 = rf(bookCopy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WithTransaction provides a mock function with given fields: tx
+func (_m *MockRepository) WithTransaction(tx pkg.Transaction) Repository {
+	ret := _m.Called(tx)
+
+	var r0 Repository
+	if rf, ok := ret.Get(0).(func(pkg.Transaction) Repository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Repository)
+		}
+	}
+
+	return r0
+}
@@ -2,13 +2,41 @@
 
 package bookcopy
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	pkg "github.com/joshuabezaleel/library-server/pkg"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
 
 // MockService is an autogenerated mock type for the Service type
 type MockService struct {
 	mock.Mock
 }
 
+// ChangeStatus provides a mock function with given fields: bookCopyID, status, changedBy, note
+func (_m *MockService) ChangeStatus(bookCopyID string, status string, changedBy string, note string) (*BookCopy, error) {
+	ret := _m.Called(bookCopyID, status, changedBy, note)
+
+	var r0 *BookCopy
+	if rf, ok := ret.Get(0).(func(string, string, string, string) *BookCopy); ok {
+		r0 = rf(bookCopyID, status, changedBy, note)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BookCopy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, string) error); ok {
+		r1 = rf(bookCopyID, status, changedBy, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: bookCopy
 func (_m *MockService) Create(bookCopy *BookCopy) (*BookCopy, error) {
 	ret := _m.Called(bookCopy)
@@ -69,6 +97,121 @@ func (_m *MockService) Get(bookID string) (*BookCopy, error) {
 	return r0, r1
 }
 
+// GetByBarcode provides a mock function with given fields: barcode
+func (_m *MockService) GetByBarcode(barcode string) (*BookCopy, error) {
+	ret := _m.Called(barcode)
+
+	var r0 *BookCopy
+	if rf, ok := ret.Get(0).(func(string) *BookCopy); ok {
+		r0 = rf(barcode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BookCopy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(barcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatusHistory provides a mock function with given fields: bookCopyID
+func (_m *MockService) GetStatusHistory(bookCopyID string) ([]*StatusChange, error) {
+	ret := _m.Called(bookCopyID)
+
+	var r0 []*StatusChange
+	if rf, ok := ret.Get(0).(func(string) []*StatusChange); ok {
+		r0 = rf(bookCopyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*StatusChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(bookCopyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAddedBetween provides a mock function with given fields: from, to
+func (_m *MockService) ListAddedBetween(from time.Time, to time.Time) ([]*BookCopy, error) {
+	ret := _m.Called(from, to)
+
+	var r0 []*BookCopy
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) []*BookCopy); ok {
+		r0 = rf(from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*BookCopy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time) error); ok {
+		r1 = rf(from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByLocation provides a mock function with given fields: locationID
+func (_m *MockService) ListByLocation(locationID string) ([]*BookCopy, error) {
+	ret := _m.Called(locationID)
+
+	var r0 []*BookCopy
+	if rf, ok := ret.Get(0).(func(string) []*BookCopy); ok {
+		r0 = rf(locationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*BookCopy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(locationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Relocate provides a mock function with given fields: bookCopyID, branchID, locationID
+func (_m *MockService) Relocate(bookCopyID string, branchID string, locationID string) (*BookCopy, error) {
+	ret := _m.Called(bookCopyID, branchID, locationID)
+
+	var r0 *BookCopy
+	if rf, ok := ret.Get(0).(func(string, string, string) *BookCopy); ok {
+		r0 = rf(bookCopyID, branchID, locationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BookCopy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(bookCopyID, branchID, locationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: bookCopy
 func (_m *MockService) Update(bookCopy *BookCopy) (*BookCopy, error) {
 	ret := _m.Called(bookCopy)
@@ -91,3 +234,33 @@ func (_m *MockService) Update(bookCopy *BookCopy) (*BookCopy, error) {
 
 	return r0, r1
 }
+
+// ValidateBarcode provides a mock function with given fields: barcode, homeBranchID
+func (_m *MockService) ValidateBarcode(barcode string, homeBranchID string) error {
+	ret := _m.Called(barcode, homeBranchID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(barcode, homeBranchID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WithTransaction provides a mock function with given fields: tx
+func (_m *MockService) WithTransaction(tx pkg.Transaction) Service {
+	ret := _m.Called(tx)
+
+	var r0 Service
+	if rf, ok := ret.Get(0).(func(pkg.Transaction) Service); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Service)
+		}
+	}
+
+	return r0
+}
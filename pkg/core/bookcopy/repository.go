@@ -1,5 +1,11 @@
 package bookcopy
 
+import (
+	"time"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+)
+
 // Repository provides access to the BookCopy store.
 type Repository interface {
 	// CRUD operations.
@@ -9,4 +15,15 @@ type Repository interface {
 	Delete(bookCopyID string) error
 
 	// Other operations.
+	GetByBarcode(barcode string) (*BookCopy, error)
+	ListAddedBetween(from time.Time, to time.Time) ([]*BookCopy, error)
+	ListByLocation(locationID string) ([]*BookCopy, error)
+	NextBarcodeSequence() (int64, error)
+	UpdateStatus(bookCopy *BookCopy) error
+	UpdateLocation(bookCopy *BookCopy) error
+	SaveStatusChange(statusChange *StatusChange) (*StatusChange, error)
+	GetStatusChanges(bookCopyID string) ([]*StatusChange, error)
+
+	// WithTransaction returns the repository working in the Transaction.
+	WithTransaction(tx util.Transaction) Repository
 }
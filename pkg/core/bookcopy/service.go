@@ -5,6 +5,8 @@ import (
 	"time"
 
 	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/barcode"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
 	"github.com/joshuabezaleel/library-server/pkg/core/book"
 )
 
@@ -14,6 +16,18 @@ var (
 	ErrGetBookCopy    = errors.New("Error retrieving Book Copy")
 	ErrUpdateBookCopy = errors.New("Error updating Book Copy")
 	ErrDeleteBookCopy = errors.New("Error deleting Book Copy")
+	ErrListBookCopies = errors.New("Error listing Book Copies")
+
+	ErrInvalidBarcode  = errors.New("Barcode does not match the barcode scheme")
+	ErrOtherBranch     = errors.New("Barcode is in the range of another Branch than the home Branch of the Book Copy")
+	ErrGenerateBarcode = errors.New("Error generating barcode")
+
+	ErrChangeStatus      = errors.New("Error changing status of Book Copy")
+	ErrGetStatusHistory  = errors.New("Error retrieving status history of Book Copy")
+	ErrInvalidStatus     = errors.New("Invalid Book Copy status")
+	ErrInvalidTransition = errors.New("Book Copy can not move to this status from its current status")
+
+	ErrRelocate = errors.New("Error relocating Book Copy")
 )
 
 // Service provides basic operations on BookCopy domain model.
@@ -25,40 +39,81 @@ type Service interface {
 	Delete(bookID string) error
 
 	// Other operations.
+	GetByBarcode(barcode string) (*BookCopy, error)
+	ValidateBarcode(barcode string, homeBranchID string) error
+	ListAddedBetween(from time.Time, to time.Time) ([]*BookCopy, error)
+	ListByLocation(locationID string) ([]*BookCopy, error)
+	ChangeStatus(bookCopyID string, status string, changedBy string, note string) (*BookCopy, error)
+	GetStatusHistory(bookCopyID string) ([]*StatusChange, error)
+	Relocate(bookCopyID string, branchID string, locationID string) (*BookCopy, error)
+
+	WithTransaction(tx util.Transaction) Service
 }
 
 type service struct {
 	bookCopyRepository Repository
 	bookService        book.Service
+	branchService      branch.Service
+	barcodeScheme      *barcode.Scheme
 }
 
 // NewBookCopyService creates an instance of the service for the BookCopy domain model
 // with all of the necessary dependencies.
-func NewBookCopyService(bookCopyRepository Repository, bookService book.Service) Service {
+func NewBookCopyService(bookCopyRepository Repository, bookService book.Service, branchService branch.Service, barcodeScheme *barcode.Scheme) Service {
 	return &service{
 		bookCopyRepository: bookCopyRepository,
 		bookService:        bookService,
+		branchService:      branchService,
+		barcodeScheme:      barcodeScheme,
+	}
+}
+
+// WithTransaction returns the service working in the Transaction, along
+// with the service of its Books, so that what they write is committed or
+// rolled back along with the rest of it.
+func (s *service) WithTransaction(tx util.Transaction) Service {
+	return &service{
+		bookCopyRepository: s.bookCopyRepository.WithTransaction(tx),
+		bookService:        s.bookService.WithTransaction(tx),
+		branchService:      s.branchService,
+		barcodeScheme:      s.barcodeScheme,
 	}
 }
 
 func (s *service) Create(bookCopy *BookCopy) (*BookCopy, error) {
 	var newBookCopy *BookCopy
 
-	newBookCopy = NewBookCopy(util.NewID(), bookCopy.Barcode, bookCopy.BookID, bookCopy.Condition, time.Now())
+	category := bookCopy.Category
+	if category == "" {
+		category = DefaultCategory
+	}
 
-	newBookCopy, err := s.bookCopyRepository.Save(newBookCopy)
+	err := s.validateHome(bookCopy)
 	if err != nil {
-		return nil, ErrCreateBookCopy
+		return nil, err
 	}
 
-	book, err := s.bookService.Get(bookCopy.BookID)
+	bookCopyBarcode, err := s.resolveBarcode(bookCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Book is checked before the Book Copy is saved, so that a Book Copy
+	// is not left behind without a Book to add it to.
+	_, err = s.bookService.Get(bookCopy.BookID)
 	if err != nil {
 		return nil, ErrGetBookCopy
 	}
 
-	book.Quantity++
+	// A new Book Copy is shelved at its home.
+	newBookCopy = NewBookCopy(util.NewID(), bookCopyBarcode, bookCopy.BookID, bookCopy.Condition, category, StatusAvailable, bookCopy.AcquisitionPrice, bookCopy.HomeBranchID, bookCopy.HomeLocationID, bookCopy.HomeBranchID, bookCopy.HomeLocationID, time.Now())
+
+	newBookCopy, err = s.bookCopyRepository.Save(newBookCopy)
+	if err != nil {
+		return nil, ErrCreateBookCopy
+	}
 
-	_, err = s.bookService.Update(book)
+	err = s.bookService.AddQuantity(bookCopy.BookID, 1)
 	if err != nil {
 		return nil, ErrUpdateBookCopy
 	}
@@ -75,8 +130,32 @@ func (s *service) Get(bookCopyID string) (*BookCopy, error) {
 	return bookCopy, nil
 }
 
+// Update keeps the barcode of the Book Copy when the update omits it. The barcode
+// is validated only when it or the home Branch changes, so that Book Copies saved
+// before barcodes were validated can still be updated.
 func (s *service) Update(bookCopy *BookCopy) (*BookCopy, error) {
-	bookCopy, err := s.bookCopyRepository.Update(bookCopy)
+	existingBookCopy, err := s.Get(bookCopy.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if bookCopy.Barcode == "" {
+		bookCopy.Barcode = existingBookCopy.Barcode
+	}
+
+	err = s.validateHome(bookCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	if bookCopy.Barcode != existingBookCopy.Barcode || bookCopy.HomeBranchID != existingBookCopy.HomeBranchID {
+		err = s.ValidateBarcode(bookCopy.Barcode, bookCopy.HomeBranchID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bookCopy, err = s.bookCopyRepository.Update(bookCopy)
 	if err != nil {
 		return nil, ErrUpdateBookCopy
 	}
@@ -92,3 +171,169 @@ func (s *service) Delete(bookCopyID string) error {
 
 	return nil
 }
+
+// validateHome checks that the home Location of the Book Copy is in its home Branch.
+// Book Copies without a home Branch are not shelved in any Branch yet.
+func (s *service) validateHome(bookCopy *BookCopy) error {
+	if bookCopy.HomeBranchID == "" && bookCopy.HomeLocationID == "" {
+		return nil
+	}
+
+	return s.branchService.ValidateLocation(bookCopy.HomeBranchID, bookCopy.HomeLocationID)
+}
+
+// resolveBarcode validates the barcode of a new Book Copy, or generates the
+// next barcode of the range of its home Branch when the barcode is omitted.
+func (s *service) resolveBarcode(bookCopy *BookCopy) (string, error) {
+	if bookCopy.Barcode != "" {
+		err := s.ValidateBarcode(bookCopy.Barcode, bookCopy.HomeBranchID)
+		if err != nil {
+			return "", err
+		}
+
+		return bookCopy.Barcode, nil
+	}
+
+	branchCode, err := s.branchCode(bookCopy.HomeBranchID)
+	if err != nil {
+		return "", err
+	}
+
+	sequence, err := s.bookCopyRepository.NextBarcodeSequence()
+	if err != nil {
+		return "", ErrGenerateBarcode
+	}
+
+	generatedBarcode, err := s.barcodeScheme.Generate(s.barcodeScheme.BranchPrefix(branchCode), sequence)
+	if err != nil {
+		return "", ErrGenerateBarcode
+	}
+
+	return generatedBarcode, nil
+}
+
+func (s *service) GetByBarcode(barcode string) (*BookCopy, error) {
+	bookCopy, err := s.bookCopyRepository.GetByBarcode(barcode)
+	if err != nil {
+		return nil, ErrGetBookCopy
+	}
+
+	return bookCopy, nil
+}
+
+// ValidateBarcode checks the barcode of a Book Copy of the home Branch
+// against the barcode scheme without creating a Book Copy.
+func (s *service) ValidateBarcode(bookCopyBarcode string, homeBranchID string) error {
+	branchCode, err := s.branchCode(homeBranchID)
+	if err != nil {
+		return err
+	}
+
+	switch s.barcodeScheme.ValidateForBranch(bookCopyBarcode, branchCode) {
+	case nil:
+		return nil
+	case barcode.ErrOtherBranch:
+		return ErrOtherBranch
+	}
+
+	return ErrInvalidBarcode
+}
+
+// branchCode returns the code of the Branch, which the barcodes of the Book Copies
+// of the Branch are in the range of. Book Copies without a home Branch have none.
+func (s *service) branchCode(branchID string) (string, error) {
+	if branchID == "" {
+		return "", nil
+	}
+
+	homeBranch, err := s.branchService.GetBranch(branchID)
+	if err != nil {
+		return "", err
+	}
+
+	return homeBranch.Code, nil
+}
+
+func (s *service) ListAddedBetween(from time.Time, to time.Time) ([]*BookCopy, error) {
+	bookCopies, err := s.bookCopyRepository.ListAddedBetween(from, to)
+	if err != nil {
+		return nil, ErrListBookCopies
+	}
+
+	return bookCopies, nil
+}
+
+// ListByLocation returns the Book Copies currently shelved at the Location.
+func (s *service) ListByLocation(locationID string) ([]*BookCopy, error) {
+	bookCopies, err := s.bookCopyRepository.ListByLocation(locationID)
+	if err != nil {
+		return nil, ErrListBookCopies
+	}
+
+	return bookCopies, nil
+}
+
+// ChangeStatus moves the Book Copy to the status if the transition is allowed
+// and records the transition along with the User who made it.
+func (s *service) ChangeStatus(bookCopyID string, status string, changedBy string, note string) (*BookCopy, error) {
+	if !IsValidStatus(status) {
+		return nil, ErrInvalidStatus
+	}
+
+	bookCopy, err := s.Get(bookCopyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !CanTransition(bookCopy.Status, status) {
+		return nil, ErrInvalidTransition
+	}
+
+	statusChange := NewStatusChange(util.NewID(), bookCopy.ID, bookCopy.Status, status, changedBy, note, time.Now())
+	bookCopy.Status = status
+
+	err = s.bookCopyRepository.UpdateStatus(bookCopy)
+	if err != nil {
+		return nil, ErrChangeStatus
+	}
+
+	_, err = s.bookCopyRepository.SaveStatusChange(statusChange)
+	if err != nil {
+		return nil, ErrChangeStatus
+	}
+
+	return bookCopy, nil
+}
+
+func (s *service) GetStatusHistory(bookCopyID string) ([]*StatusChange, error) {
+	statusChanges, err := s.bookCopyRepository.GetStatusChanges(bookCopyID)
+	if err != nil {
+		return nil, ErrGetStatusHistory
+	}
+
+	return statusChanges, nil
+}
+
+// Relocate records where the Book Copy currently is, which is
+// a Branch and optionally a Location in it.
+func (s *service) Relocate(bookCopyID string, branchID string, locationID string) (*BookCopy, error) {
+	err := s.branchService.ValidateLocation(branchID, locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookCopy, err := s.Get(bookCopyID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookCopy.CurrentBranchID = branchID
+	bookCopy.CurrentLocationID = locationID
+
+	err = s.bookCopyRepository.UpdateLocation(bookCopy)
+	if err != nil {
+		return nil, ErrRelocate
+	}
+
+	return bookCopy, nil
+}
@@ -0,0 +1,88 @@
+package bookcopy
+
+import (
+	"time"
+)
+
+// Circulation statuses of a BookCopy.
+const (
+	StatusAvailable   = "available"
+	StatusOnLoan      = "on-loan"
+	StatusOnHoldShelf = "on-hold-shelf"
+	StatusInTransit   = "in-transit"
+	StatusInRepair    = "in-repair"
+	StatusDamaged     = "damaged"
+	StatusLost        = "lost"
+	StatusMissing     = "missing"
+	StatusWithdrawn   = "withdrawn"
+)
+
+// transitions lists the statuses a BookCopy can move to from each status.
+// A withdrawn BookCopy has left the collection for good.
+var transitions = map[string][]string{
+	StatusAvailable:   {StatusOnLoan, StatusOnHoldShelf, StatusInTransit, StatusInRepair, StatusDamaged, StatusLost, StatusMissing, StatusWithdrawn},
+	StatusOnLoan:      {StatusAvailable, StatusOnHoldShelf, StatusInTransit, StatusInRepair, StatusDamaged, StatusLost},
+	StatusOnHoldShelf: {StatusOnLoan, StatusAvailable, StatusOnHoldShelf, StatusInTransit, StatusMissing},
+	StatusInTransit:   {StatusAvailable, StatusOnHoldShelf, StatusLost, StatusMissing},
+	StatusInRepair:    {StatusAvailable, StatusLost, StatusWithdrawn},
+	StatusDamaged:     {StatusAvailable, StatusInRepair, StatusWithdrawn},
+	StatusLost:        {StatusAvailable, StatusOnHoldShelf, StatusWithdrawn},
+	StatusMissing:     {StatusAvailable, StatusLost, StatusWithdrawn},
+	StatusWithdrawn:   {},
+}
+
+// librarianStatuses are the statuses librarians can set directly. Lending
+// statuses are only set by borrowing, returning and trapping copies for Holds.
+var librarianStatuses = []string{StatusAvailable, StatusInTransit, StatusInRepair, StatusDamaged, StatusLost, StatusMissing, StatusWithdrawn}
+
+// IsValidStatus returns whether the status is one of the circulation statuses.
+func IsValidStatus(status string) bool {
+	_, ok := transitions[status]
+
+	return ok
+}
+
+// CanTransition returns whether a BookCopy can move from one status to another.
+func CanTransition(from string, to string) bool {
+	return contains(transitions[from], to)
+}
+
+// IsLibrarianStatus returns whether librarians can set the status directly.
+func IsLibrarianStatus(status string) bool {
+	return contains(librarianStatuses, status)
+}
+
+func contains(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StatusChange domain model. A StatusChange records each transition of the
+// circulation status of a BookCopy and the User who made it.
+type StatusChange struct {
+	ID         string    `json:"id" db:"id"`
+	BookCopyID string    `json:"bookCopyID" db:"bookcopy_id"`
+	FromStatus string    `json:"fromStatus" db:"from_status"`
+	ToStatus   string    `json:"toStatus" db:"to_status"`
+	ChangedBy  string    `json:"changedBy" db:"changed_by"`
+	Note       string    `json:"note" db:"note"`
+	ChangedAt  time.Time `json:"changedAt" db:"changed_at"`
+}
+
+// NewStatusChange creates a new instance of StatusChange domain model.
+func NewStatusChange(id string, bookCopyID string, fromStatus string, toStatus string, changedBy string, note string, changedAt time.Time) *StatusChange {
+	return &StatusChange{
+		ID:         id,
+		BookCopyID: bookCopyID,
+		FromStatus: fromStatus,
+		ToStatus:   toStatus,
+		ChangedBy:  changedBy,
+		Note:       note,
+		ChangedAt:  changedAt,
+	}
+}
@@ -0,0 +1,213 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package subject
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: subjectID
+func (_m *MockRepository) Delete(subjectID int64) error {
+	ret := _m.Called(subjectID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(subjectID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRelation provides a mock function with given fields: subjectID, relation, relatedID
+func (_m *MockRepository) DeleteRelation(subjectID int64, relation string, relatedID int64) error {
+	ret := _m.Called(subjectID, relation, relatedID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, string, int64) error); ok {
+		r0 = rf(subjectID, relation, relatedID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: subjectID
+func (_m *MockRepository) Get(subjectID int64) (*Subject, error) {
+	ret := _m.Called(subjectID)
+
+	var r0 *Subject
+	if rf, ok := ret.Get(0).(func(int64) *Subject); ok {
+		r0 = rf(subjectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Subject)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(subjectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBookIDs provides a mock function with given fields: subjectID
+func (_m *MockRepository) GetBookIDs(subjectID int64) ([]string, error) {
+	ret := _m.Called(subjectID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(int64) []string); ok {
+		r0 = rf(subjectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(subjectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRelated provides a mock function with given fields: subjectID, relation
+func (_m *MockRepository) GetRelated(subjectID int64, relation string) ([]*Subject, error) {
+	ret := _m.Called(subjectID, relation)
+
+	var r0 []*Subject
+	if rf, ok := ret.Get(0).(func(int64, string) []*Subject); ok {
+		r0 = rf(subjectID, relation)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Subject)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
+		r1 = rf(subjectID, relation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSynonyms provides a mock function with given fields: subjectID
+func (_m *MockRepository) GetSynonyms(subjectID int64) ([]*Subject, error) {
+	ret := _m.Called(subjectID)
+
+	var r0 []*Subject
+	if rf, ok := ret.Get(0).(func(int64) []*Subject); ok {
+		r0 = rf(subjectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Subject)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(subjectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields:
+func (_m *MockRepository) List() ([]*Subject, error) {
+	ret := _m.Called()
+
+	var r0 []*Subject
+	if rf, ok := ret.Get(0).(func() []*Subject); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Subject)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: subject
+func (_m *MockRepository) Save(subject *Subject) (*Subject, error) {
+	ret := _m.Called(subject)
+
+	var r0 *Subject
+	if rf, ok := ret.Get(0).(func(*Subject) *Subject); ok {
+		r0 = rf(subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Subject)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Subject) error); ok {
+		r1 = rf(subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveRelation provides a mock function with given fields: subjectID, relation, relatedID
+func (_m *MockRepository) SaveRelation(subjectID int64, relation string, relatedID int64) error {
+	ret := _m.Called(subjectID, relation, relatedID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, string, int64) error); ok {
+		r0 = rf(subjectID, relation, relatedID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: subject
+func (_m *MockRepository) Update(subject *Subject) (*Subject, error) {
+	ret := _m.Called(subject)
+
+	var r0 *Subject
+	if rf, ok := ret.Get(0).(func(*Subject) *Subject); ok {
+		r0 = rf(subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Subject)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Subject) error); ok {
+		r1 = rf(subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,188 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package subject
+
+import (
+	book "github.com/joshuabezaleel/library-server/pkg/core/book"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+// AddRelation provides a mock function with given fields: subjectID, relation, relatedID
+func (_m *MockService) AddRelation(subjectID int64, relation string, relatedID int64) (*Subject, error) {
+	ret := _m.Called(subjectID, relation, relatedID)
+
+	var r0 *Subject
+	if rf, ok := ret.Get(0).(func(int64, string, int64) *Subject); ok {
+		r0 = rf(subjectID, relation, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Subject)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string, int64) error); ok {
+		r1 = rf(subjectID, relation, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: subject
+func (_m *MockService) Create(subject *Subject) (*Subject, error) {
+	ret := _m.Called(subject)
+
+	var r0 *Subject
+	if rf, ok := ret.Get(0).(func(*Subject) *Subject); ok {
+		r0 = rf(subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Subject)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Subject) error); ok {
+		r1 = rf(subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: subjectID
+func (_m *MockService) Delete(subjectID int64) error {
+	ret := _m.Called(subjectID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(subjectID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: subjectID
+func (_m *MockService) Get(subjectID int64) (*Subject, error) {
+	ret := _m.Called(subjectID)
+
+	var r0 *Subject
+	if rf, ok := ret.Get(0).(func(int64) *Subject); ok {
+		r0 = rf(subjectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Subject)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(subjectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBooks provides a mock function with given fields: subjectID, includeNarrower
+func (_m *MockService) GetBooks(subjectID int64, includeNarrower bool) ([]*book.Book, error) {
+	ret := _m.Called(subjectID, includeNarrower)
+
+	var r0 []*book.Book
+	if rf, ok := ret.Get(0).(func(int64, bool) []*book.Book); ok {
+		r0 = rf(subjectID, includeNarrower)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*book.Book)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, bool) error); ok {
+		r1 = rf(subjectID, includeNarrower)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields:
+func (_m *MockService) List() ([]*Subject, error) {
+	ret := _m.Called()
+
+	var r0 []*Subject
+	if rf, ok := ret.Get(0).(func() []*Subject); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Subject)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveRelation provides a mock function with given fields: subjectID, relation, relatedID
+func (_m *MockService) RemoveRelation(subjectID int64, relation string, relatedID int64) (*Subject, error) {
+	ret := _m.Called(subjectID, relation, relatedID)
+
+	var r0 *Subject
+	if rf, ok := ret.Get(0).(func(int64, string, int64) *Subject); ok {
+		r0 = rf(subjectID, relation, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Subject)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string, int64) error); ok {
+		r1 = rf(subjectID, relation, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: subject
+func (_m *MockService) Update(subject *Subject) (*Subject, error) {
+	ret := _m.Called(subject)
+
+	var r0 *Subject
+	if rf, ok := ret.Get(0).(func(*Subject) *Subject); ok {
+		r0 = rf(subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Subject)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Subject) error); ok {
+		r1 = rf(subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,18 @@
+package subject
+
+// Repository provides access to the Subject store.
+type Repository interface {
+	// CRUD operations.
+	Save(subject *Subject) (*Subject, error)
+	Get(subjectID int64) (*Subject, error)
+	Update(subject *Subject) (*Subject, error)
+	Delete(subjectID int64) error
+
+	// Other operations.
+	List() ([]*Subject, error)
+	GetSynonyms(subjectID int64) ([]*Subject, error)
+	SaveRelation(subjectID int64, relation string, relatedID int64) error
+	DeleteRelation(subjectID int64, relation string, relatedID int64) error
+	GetRelated(subjectID int64, relation string) ([]*Subject, error)
+	GetBookIDs(subjectID int64) ([]string, error)
+}
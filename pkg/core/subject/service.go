@@ -0,0 +1,370 @@
+package subject
+
+import (
+	"errors"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+)
+
+// Errors definition.
+var (
+	ErrCreateSubject      = errors.New("Error creating Subject")
+	ErrGetSubject         = errors.New("Error retrieving Subject")
+	ErrUpdateSubject      = errors.New("Error updating Subject")
+	ErrDeleteSubject      = errors.New("Error deleting Subject")
+	ErrListSubjects       = errors.New("Error listing Subjects")
+	ErrGetSynonyms        = errors.New("Error retrieving synonyms of Subject")
+	ErrSaveRelation       = errors.New("Error saving relation between Subjects")
+	ErrDeleteRelation     = errors.New("Error deleting relation between Subjects")
+	ErrGetRelatedSubjects = errors.New("Error retrieving related Subjects")
+	ErrGetSubjectBooks    = errors.New("Error retrieving Books of Subject")
+
+	ErrInvalidSubject       = errors.New("Subject must have a term")
+	ErrInvalidPreferredTerm = errors.New("Synonym must refer to another preferred term")
+	ErrInvalidRelation      = errors.New("Subject relation must be broader, narrower or related")
+	ErrSelfRelation         = errors.New("Subject can not be related to itself")
+	ErrRelationToSynonym    = errors.New("Only preferred terms can be related to each other")
+	ErrRelationCycle        = errors.New("Subject can not be broader than its own broader term")
+	ErrSubjectHasBooks      = errors.New("Subject can not be deleted while Books reference it")
+	ErrSynonymInUse         = errors.New("Subject with Books, synonyms or relations can not become a synonym")
+)
+
+// Service provides basic operations on Subject domain model.
+type Service interface {
+	// CRUD operations.
+	Create(subject *Subject) (*Subject, error)
+	Get(subjectID int64) (*Subject, error)
+	Update(subject *Subject) (*Subject, error)
+	Delete(subjectID int64) error
+
+	// Other operations.
+	List() ([]*Subject, error)
+	AddRelation(subjectID int64, relation string, relatedID int64) (*Subject, error)
+	RemoveRelation(subjectID int64, relation string, relatedID int64) (*Subject, error)
+	GetBooks(subjectID int64, includeNarrower bool) ([]*book.Book, error)
+}
+
+type service struct {
+	subjectRepository Repository
+	bookService       book.Service
+}
+
+// NewSubjectService creates an instance of the service for the Subject domain model
+// with all of the necessary dependencies.
+func NewSubjectService(subjectRepository Repository, bookService book.Service) Service {
+	return &service{
+		subjectRepository: subjectRepository,
+		bookService:       bookService,
+	}
+}
+
+func (s *service) Create(subject *Subject) (*Subject, error) {
+	err := s.validateSubject(subject)
+	if err != nil {
+		return nil, err
+	}
+
+	newSubject := NewSubject(0, subject.Term, subject.ScopeNote, subject.PreferredID)
+
+	newSubject, err = s.subjectRepository.Save(newSubject)
+	if err != nil {
+		return nil, ErrCreateSubject
+	}
+
+	return newSubject, nil
+}
+
+// Get retrieves the Subject along with its broader, narrower and related
+// terms and its synonyms. A synonym is retrieved as it is, so that it can
+// be redirected to its preferred term.
+func (s *service) Get(subjectID int64) (*Subject, error) {
+	subject, err := s.subjectRepository.Get(subjectID)
+	if err != nil {
+		return nil, ErrGetSubject
+	}
+
+	if subject.IsSynonym() {
+		return subject, nil
+	}
+
+	subject.Broader, err = s.subjectRepository.GetRelated(subjectID, RelationBroader)
+	if err != nil {
+		return nil, ErrGetRelatedSubjects
+	}
+
+	subject.Narrower, err = s.subjectRepository.GetRelated(subjectID, RelationNarrower)
+	if err != nil {
+		return nil, ErrGetRelatedSubjects
+	}
+
+	subject.Related, err = s.subjectRepository.GetRelated(subjectID, RelationRelated)
+	if err != nil {
+		return nil, ErrGetRelatedSubjects
+	}
+
+	subject.Synonyms, err = s.subjectRepository.GetSynonyms(subjectID)
+	if err != nil {
+		return nil, ErrGetSynonyms
+	}
+
+	return subject, nil
+}
+
+// Update changes the term, the scope note or the preferred term of the Subject
+// and reindexes its Books, which are searchable by their subjects.
+func (s *service) Update(subject *Subject) (*Subject, error) {
+	err := s.validateSubject(subject)
+	if err != nil {
+		return nil, err
+	}
+
+	existingSubject, err := s.Get(subject.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookIDs, err := s.subjectRepository.GetBookIDs(subject.ID)
+	if err != nil {
+		return nil, ErrGetSubjectBooks
+	}
+
+	// Books, synonyms and relations are only ever kept on preferred terms.
+	if subject.IsSynonym() && !existingSubject.IsSynonym() {
+		if len(bookIDs) > 0 || len(existingSubject.Synonyms) > 0 || len(existingSubject.Broader) > 0 || len(existingSubject.Narrower) > 0 || len(existingSubject.Related) > 0 {
+			return nil, ErrSynonymInUse
+		}
+	}
+
+	_, err = s.subjectRepository.Update(subject)
+	if err != nil {
+		return nil, ErrUpdateSubject
+	}
+
+	for _, bookID := range bookIDs {
+		err = s.bookService.RefreshSearchVector(bookID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.Get(subject.ID)
+}
+
+// Delete deletes the Subject along with its synonyms and relations.
+func (s *service) Delete(subjectID int64) error {
+	bookIDs, err := s.subjectRepository.GetBookIDs(subjectID)
+	if err != nil {
+		return ErrGetSubjectBooks
+	}
+
+	if len(bookIDs) > 0 {
+		return ErrSubjectHasBooks
+	}
+
+	err = s.subjectRepository.Delete(subjectID)
+	if err != nil {
+		return ErrDeleteSubject
+	}
+
+	return nil
+}
+
+func (s *service) List() ([]*Subject, error) {
+	subjects, err := s.subjectRepository.List()
+	if err != nil {
+		return nil, ErrListSubjects
+	}
+
+	return subjects, nil
+}
+
+// AddRelation relates the Subject to another preferred term. Related terms
+// are related both ways and a narrower term is kept as the broader term
+// the other way around.
+func (s *service) AddRelation(subjectID int64, relation string, relatedID int64) (*Subject, error) {
+	err := s.validateRelation(subjectID, relation, relatedID)
+	if err != nil {
+		return nil, err
+	}
+
+	narrowerID, broaderID := subjectID, relatedID
+	if relation == RelationNarrower {
+		narrowerID, broaderID = relatedID, subjectID
+	}
+
+	switch relation {
+	case RelationBroader, RelationNarrower:
+		// The broader term can not already be one of the narrower terms.
+		narrowerIDs, err := s.getNarrowerIDs(narrowerID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range narrowerIDs {
+			if id == broaderID {
+				return nil, ErrRelationCycle
+			}
+		}
+
+		err = s.subjectRepository.SaveRelation(narrowerID, RelationBroader, broaderID)
+		if err != nil {
+			return nil, ErrSaveRelation
+		}
+	case RelationRelated:
+		err = s.subjectRepository.SaveRelation(subjectID, RelationRelated, relatedID)
+		if err != nil {
+			return nil, ErrSaveRelation
+		}
+
+		err = s.subjectRepository.SaveRelation(relatedID, RelationRelated, subjectID)
+		if err != nil {
+			return nil, ErrSaveRelation
+		}
+	}
+
+	return s.Get(subjectID)
+}
+
+func (s *service) RemoveRelation(subjectID int64, relation string, relatedID int64) (*Subject, error) {
+	if !IsValidRelation(relation) {
+		return nil, ErrInvalidRelation
+	}
+
+	var err error
+	switch relation {
+	case RelationBroader:
+		err = s.subjectRepository.DeleteRelation(subjectID, RelationBroader, relatedID)
+	case RelationNarrower:
+		err = s.subjectRepository.DeleteRelation(relatedID, RelationBroader, subjectID)
+	case RelationRelated:
+		err = s.subjectRepository.DeleteRelation(subjectID, RelationRelated, relatedID)
+		if err == nil {
+			err = s.subjectRepository.DeleteRelation(relatedID, RelationRelated, subjectID)
+		}
+	}
+	if err != nil {
+		return nil, ErrDeleteRelation
+	}
+
+	return s.Get(subjectID)
+}
+
+// GetBooks retrieves the Books catalogued under the preferred term of the
+// Subject and, optionally, under all of its narrower terms.
+func (s *service) GetBooks(subjectID int64, includeNarrower bool) ([]*book.Book, error) {
+	subject, err := s.subjectRepository.Get(subjectID)
+	if err != nil {
+		return nil, ErrGetSubject
+	}
+
+	if subject.IsSynonym() {
+		subjectID = subject.PreferredID
+	}
+
+	subjectIDs := []int64{subjectID}
+	if includeNarrower {
+		narrowerIDs, err := s.getNarrowerIDs(subjectID)
+		if err != nil {
+			return nil, err
+		}
+
+		subjectIDs = append(subjectIDs, narrowerIDs...)
+	}
+
+	books := []*book.Book{}
+	seen := map[string]bool{}
+	for _, id := range subjectIDs {
+		bookIDs, err := s.subjectRepository.GetBookIDs(id)
+		if err != nil {
+			return nil, ErrGetSubjectBooks
+		}
+
+		for _, bookID := range bookIDs {
+			if seen[bookID] {
+				continue
+			}
+			seen[bookID] = true
+
+			book, err := s.bookService.Get(bookID)
+			if err != nil {
+				return nil, err
+			}
+
+			books = append(books, book)
+		}
+	}
+
+	return books, nil
+}
+
+// getNarrowerIDs retrieves the IDs of all of the narrower terms of the Subject,
+// the narrower terms of those included.
+func (s *service) getNarrowerIDs(subjectID int64) ([]int64, error) {
+	narrowerIDs := []int64{}
+	seen := map[int64]bool{subjectID: true}
+	queue := []int64{subjectID}
+
+	for len(queue) > 0 {
+		narrowerSubjects, err := s.subjectRepository.GetRelated(queue[0], RelationNarrower)
+		if err != nil {
+			return nil, ErrGetRelatedSubjects
+		}
+		queue = queue[1:]
+
+		for _, narrowerSubject := range narrowerSubjects {
+			if seen[narrowerSubject.ID] {
+				continue
+			}
+			seen[narrowerSubject.ID] = true
+
+			narrowerIDs = append(narrowerIDs, narrowerSubject.ID)
+			queue = append(queue, narrowerSubject.ID)
+		}
+	}
+
+	return narrowerIDs, nil
+}
+
+func (s *service) validateSubject(subject *Subject) error {
+	if subject.Term == "" {
+		return ErrInvalidSubject
+	}
+
+	if !subject.IsSynonym() {
+		return nil
+	}
+
+	if subject.PreferredID == subject.ID {
+		return ErrInvalidPreferredTerm
+	}
+
+	preferredSubject, err := s.subjectRepository.Get(subject.PreferredID)
+	if err != nil || preferredSubject.IsSynonym() {
+		return ErrInvalidPreferredTerm
+	}
+
+	return nil
+}
+
+func (s *service) validateRelation(subjectID int64, relation string, relatedID int64) error {
+	if !IsValidRelation(relation) {
+		return ErrInvalidRelation
+	}
+
+	if subjectID == relatedID {
+		return ErrSelfRelation
+	}
+
+	for _, id := range []int64{subjectID, relatedID} {
+		subject, err := s.subjectRepository.Get(id)
+		if err != nil {
+			return ErrGetSubject
+		}
+
+		if subject.IsSynonym() {
+			return ErrRelationToSynonym
+		}
+	}
+
+	return nil
+}
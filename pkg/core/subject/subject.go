@@ -0,0 +1,55 @@
+package subject
+
+// Relations between the terms of the subject vocabulary.
+const (
+	RelationBroader  = "broader"
+	RelationNarrower = "narrower"
+	RelationRelated  = "related"
+)
+
+var relations = []string{RelationBroader, RelationNarrower, RelationRelated}
+
+// Subject domain model is a term of the controlled subject vocabulary.
+// A synonym has the ID of its preferred term as PreferredID and is
+// catalogued under that term, a preferred term has none.
+//
+// Broader, Narrower, Related and Synonyms are only filled in
+// for preferred terms and are not filled in recursively.
+type Subject struct {
+	ID          int64  `json:"id" db:"id"`
+	Term        string `json:"term" db:"subject"`
+	ScopeNote   string `json:"scopeNote" db:"scope_note"`
+	PreferredID int64  `json:"preferredID,omitempty" db:"preferred_id"`
+
+	Broader  []*Subject `json:"broader,omitempty" db:"-"`
+	Narrower []*Subject `json:"narrower,omitempty" db:"-"`
+	Related  []*Subject `json:"related,omitempty" db:"-"`
+	Synonyms []*Subject `json:"synonyms,omitempty" db:"-"`
+}
+
+// NewSubject creates a new instance of Subject domain model.
+func NewSubject(id int64, term string, scopeNote string, preferredID int64) *Subject {
+	return &Subject{
+		ID:          id,
+		Term:        term,
+		ScopeNote:   scopeNote,
+		PreferredID: preferredID,
+	}
+}
+
+// IsSynonym returns whether the Subject is a synonym of a preferred term.
+func (subject *Subject) IsSynonym() bool {
+	return subject.PreferredID != 0
+}
+
+// IsValidRelation returns whether the relation is one the terms
+// of the subject vocabulary can have with each other.
+func IsValidRelation(relation string) bool {
+	for _, subjectRelation := range relations {
+		if subjectRelation == relation {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,180 @@
+package subject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+)
+
+var subjectRepository = &MockRepository{}
+var bookService = &book.MockService{}
+
+var subjectService = NewSubjectService(subjectRepository, bookService)
+
+// A small vocabulary: Science is broader than Mathematics, which is broader
+// than Algebra, and Maths is a synonym of Mathematics.
+var science = &Subject{ID: 1, Term: "Science"}
+var mathematics = &Subject{ID: 2, Term: "Mathematics"}
+var algebra = &Subject{ID: 3, Term: "Algebra"}
+var maths = &Subject{ID: 4, Term: "Maths", PreferredID: mathematics.ID}
+
+func init() {
+	for _, subject := range []*Subject{science, mathematics, algebra, maths} {
+		subjectRepository.On("Get", subject.ID).Return(subject, nil)
+	}
+
+	subjectRepository.On("GetRelated", science.ID, RelationNarrower).Return([]*Subject{mathematics}, nil)
+	subjectRepository.On("GetRelated", mathematics.ID, RelationNarrower).Return([]*Subject{algebra}, nil)
+	subjectRepository.On("GetRelated", algebra.ID, RelationNarrower).Return([]*Subject{}, nil)
+	subjectRepository.On("GetRelated", mock.AnythingOfType("int64"), mock.AnythingOfType("string")).Return([]*Subject{}, nil)
+	subjectRepository.On("GetSynonyms", mock.AnythingOfType("int64")).Return([]*Subject{}, nil)
+}
+
+func TestCreate(t *testing.T) {
+	tt := []struct {
+		name    string
+		subject *Subject
+		err     error
+	}{
+		{
+			name:    "success creating a preferred term",
+			subject: &Subject{Term: "Physics"},
+			err:     nil,
+		},
+		{
+			name:    "success creating a synonym",
+			subject: &Subject{Term: "Maths", PreferredID: mathematics.ID},
+			err:     nil,
+		},
+		{
+			name:    "failed creating a Subject without a term",
+			subject: &Subject{ScopeNote: "Nothing in particular"},
+			err:     ErrInvalidSubject,
+		},
+		{
+			name:    "failed creating a synonym of a synonym",
+			subject: &Subject{Term: "Math", PreferredID: maths.ID},
+			err:     ErrInvalidPreferredTerm,
+		},
+	}
+
+	subjectRepository.On("Save", mock.AnythingOfType("*subject.Subject")).Return(&Subject{ID: 10}, nil)
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := subjectService.Create(tc.subject)
+
+			require.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func TestAddRelation(t *testing.T) {
+	tt := []struct {
+		name      string
+		subjectID int64
+		relation  string
+		relatedID int64
+		err       error
+	}{
+		{
+			name:      "success relating related terms",
+			subjectID: mathematics.ID,
+			relation:  RelationRelated,
+			relatedID: science.ID,
+			err:       nil,
+		},
+		{
+			name:      "failed relating a Subject with an invalid relation",
+			subjectID: mathematics.ID,
+			relation:  "sibling",
+			relatedID: science.ID,
+			err:       ErrInvalidRelation,
+		},
+		{
+			name:      "failed relating a Subject to itself",
+			subjectID: mathematics.ID,
+			relation:  RelationBroader,
+			relatedID: mathematics.ID,
+			err:       ErrSelfRelation,
+		},
+		{
+			name:      "failed relating a Subject to a synonym",
+			subjectID: algebra.ID,
+			relation:  RelationBroader,
+			relatedID: maths.ID,
+			err:       ErrRelationToSynonym,
+		},
+		{
+			name:      "failed making a narrower term broader",
+			subjectID: science.ID,
+			relation:  RelationBroader,
+			relatedID: algebra.ID,
+			err:       ErrRelationCycle,
+		},
+	}
+
+	subjectRepository.On("SaveRelation", mock.AnythingOfType("int64"), mock.AnythingOfType("string"), mock.AnythingOfType("int64")).Return(nil)
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := subjectService.AddRelation(tc.subjectID, tc.relation, tc.relatedID)
+
+			require.Equal(t, tc.err, err)
+		})
+	}
+
+	// Related terms are related both ways.
+	subjectRepository.AssertCalled(t, "SaveRelation", mathematics.ID, RelationRelated, science.ID)
+	subjectRepository.AssertCalled(t, "SaveRelation", science.ID, RelationRelated, mathematics.ID)
+	subjectRepository.AssertNotCalled(t, "SaveRelation", science.ID, RelationBroader, algebra.ID)
+}
+
+func TestGetBooks(t *testing.T) {
+	mathematicsBook := &book.Book{ID: util.NewID(), Title: "Concrete Mathematics"}
+	algebraBook := &book.Book{ID: util.NewID(), Title: "Linear Algebra Done Right"}
+
+	subjectRepository.On("GetBookIDs", mathematics.ID).Return([]string{mathematicsBook.ID}, nil)
+	subjectRepository.On("GetBookIDs", algebra.ID).Return([]string{algebraBook.ID, mathematicsBook.ID}, nil)
+	bookService.On("Get", mathematicsBook.ID).Return(mathematicsBook, nil)
+	bookService.On("Get", algebraBook.ID).Return(algebraBook, nil)
+
+	tt := []struct {
+		name            string
+		subjectID       int64
+		includeNarrower bool
+		books           []*book.Book
+	}{
+		{
+			name:            "Books of the term only",
+			subjectID:       mathematics.ID,
+			includeNarrower: false,
+			books:           []*book.Book{mathematicsBook},
+		},
+		{
+			name:            "Books of the term and its narrower terms",
+			subjectID:       mathematics.ID,
+			includeNarrower: true,
+			books:           []*book.Book{mathematicsBook, algebraBook},
+		},
+		{
+			name:            "Books of the preferred term of a synonym",
+			subjectID:       maths.ID,
+			includeNarrower: false,
+			books:           []*book.Book{mathematicsBook},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			books, err := subjectService.GetBooks(tc.subjectID, tc.includeNarrower)
+
+			require.Nil(t, err)
+			require.Equal(t, tc.books, books)
+		})
+	}
+}
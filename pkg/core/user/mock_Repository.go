@@ -2,27 +2,16 @@
 
 package user
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	pkg "github.com/joshuabezaleel/library-server/pkg"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockRepository is an autogenerated mock type for the Repository type
 type MockRepository struct {
 	mock.Mock
 }
 
-// AddFine provides a mock function with given fields: userID, fine
-func (_m *MockRepository) AddFine(userID string, fine uint32) error {
-	ret := _m.Called(userID, fine)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, uint32) error); ok {
-		r0 = rf(userID, fine)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // Delete provides a mock function with given fields: userID
 func (_m *MockRepository) Delete(userID string) error {
 	ret := _m.Called(userID)
@@ -60,6 +49,29 @@ func (_m *MockRepository) Get(userID string) (*User, error) {
 	return r0, r1
 }
 
+// GetByIdentifier provides a mock function with given fields: identifier
+func (_m *MockRepository) GetByIdentifier(identifier string) ([]*User, error) {
+	ret := _m.Called(identifier)
+
+	var r0 []*User
+	if rf, ok := ret.Get(0).(func(string) []*User); ok {
+		r0 = rf(identifier)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(identifier)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetIDByUsername provides a mock function with given fields: username
 func (_m *MockRepository) GetIDByUsername(username string) (string, error) {
 	ret := _m.Called(username)
@@ -146,6 +158,20 @@ func (_m *MockRepository) Save(user *User) (*User, error) {
 	return r0, r1
 }
 
+// SetTotalFine provides a mock function with given fields: userID, totalFine
+func (_m *MockRepository) SetTotalFine(userID string, totalFine uint32) error {
+	ret := _m.Called(userID, totalFine)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, uint32) error); ok {
+		r0 = rf(userID, totalFine)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: user
 func (_m *MockRepository) Update(user *User) (*User, error) {
 	ret := _m.Called(user)
@@ -168,3 +194,19 @@ func (_m *MockRepository) Update(user *User) (*User, error) {
 
 	return r0, r1
 }
+
+// WithTransaction provides a mock function with given fields: tx
+func (_m *MockRepository) WithTransaction(tx pkg.Transaction) Repository {
+	ret := _m.Called(tx)
+
+	var r0 Repository
+	if rf, ok := ret.Get(0).(func(pkg.Transaction) Repository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Repository)
+		}
+	}
+
+	return r0
+}
@@ -2,34 +2,16 @@
 
 package user
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	pkg "github.com/joshuabezaleel/library-server/pkg"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockService is an autogenerated mock type for the Service type
 type MockService struct {
 	mock.Mock
 }
 
-// AddFine provides a mock function with given fields: userID, fine
-func (_m *MockService) AddFine(userID string, fine uint32) (uint32, error) {
-	ret := _m.Called(userID, fine)
-
-	var r0 uint32
-	if rf, ok := ret.Get(0).(func(string, uint32) uint32); ok {
-		r0 = rf(userID, fine)
-	} else {
-		r0 = ret.Get(0).(uint32)
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(string, uint32) error); ok {
-		r1 = rf(userID, fine)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // Create provides a mock function with given fields: user
 func (_m *MockService) Create(user *User) (*User, error) {
 	ret := _m.Called(user)
@@ -90,6 +72,29 @@ func (_m *MockService) Get(userID string) (*User, error) {
 	return r0, r1
 }
 
+// GetByIdentifier provides a mock function with given fields: identifier
+func (_m *MockService) GetByIdentifier(identifier string) (*User, error) {
+	ret := _m.Called(identifier)
+
+	var r0 *User
+	if rf, ok := ret.Get(0).(func(string) *User); ok {
+		r0 = rf(identifier)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(identifier)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRole provides a mock function with given fields: username
 func (_m *MockService) GetRole(username string) (string, error) {
 	ret := _m.Called(username)
@@ -153,6 +158,20 @@ func (_m *MockService) GetUserIDByUsername(username string) (string, error) {
 	return r0, r1
 }
 
+// SetTotalFine provides a mock function with given fields: userID, totalFine
+func (_m *MockService) SetTotalFine(userID string, totalFine uint32) error {
+	ret := _m.Called(userID, totalFine)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, uint32) error); ok {
+		r0 = rf(userID, totalFine)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: user
 func (_m *MockService) Update(user *User) (*User, error) {
 	ret := _m.Called(user)
@@ -175,3 +194,19 @@ func (_m *MockService) Update(user *User) (*User, error) {
 
 	return r0, r1
 }
+
+// WithTransaction provides a mock function with given fields: tx
+func (_m *MockService) WithTransaction(tx pkg.Transaction) Service {
+	ret := _m.Called(tx)
+
+	var r0 Service
+	if rf, ok := ret.Get(0).(func(pkg.Transaction) Service); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Service)
+		}
+	}
+
+	return r0
+}
@@ -1,5 +1,7 @@
 package user
 
+import util "github.com/joshuabezaleel/library-server/pkg"
+
 // Repository provides access to the User store.
 type Repository interface {
 	// CRUD operations.
@@ -10,7 +12,12 @@ type Repository interface {
 
 	// Other operations.
 	GetIDByUsername(username string) (string, error)
+	// GetByIdentifier returns every User whose student ID, username or card number is the identifier.
+	GetByIdentifier(identifier string) ([]*User, error)
 	GetRole(userID string) (string, error)
-	AddFine(userID string, fine uint32) error
+	SetTotalFine(userID string, totalFine uint32) error
 	GetTotalFine(userID string) (uint32, error)
+
+	// WithTransaction returns the repository working in the Transaction.
+	WithTransaction(tx util.Transaction) Repository
 }
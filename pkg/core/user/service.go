@@ -17,8 +17,10 @@ var (
 	ErrDeleteUser = errors.New("Error deleting User")
 
 	ErrGetUserIDByUsername = errors.New("Error retrieving User ID")
+	ErrGetByIdentifier     = errors.New("Error retrieving User by identifier")
+	ErrAmbiguousIdentifier = errors.New("The identifier is the student ID, username or card number of more than one User")
 	ErrGetRole             = errors.New("Error retrieving User's role")
-	ErrAddFine             = errors.New("Error adding fine to User")
+	ErrSetTotalFine        = errors.New("Error setting User's total fine")
 	ErrGetTotalFine        = errors.New("Error retrieving User's total fine")
 )
 
@@ -32,9 +34,12 @@ type Service interface {
 
 	// Other operations.
 	GetUserIDByUsername(username string) (string, error)
+	GetByIdentifier(identifier string) (*User, error)
 	GetRole(username string) (string, error)
-	AddFine(userID string, fine uint32) (uint32, error)
+	SetTotalFine(userID string, totalFine uint32) error
 	GetTotalFine(userID string) (uint32, error)
+
+	WithTransaction(tx util.Transaction) Service
 }
 
 type service struct {
@@ -49,13 +54,21 @@ func NewUserService(userRepository Repository) Service {
 	}
 }
 
+// WithTransaction returns the service working in the Transaction, so that
+// what it writes is committed or rolled back along with the rest of it.
+func (s *service) WithTransaction(tx util.Transaction) Service {
+	return &service{
+		userRepository: s.userRepository.WithTransaction(tx),
+	}
+}
+
 func (s *service) Create(user *User) (*User, error) {
 	var newUser *User
 
 	if user.ID == "" {
-		newUser = NewUser(util.NewID(), user.StudentID, user.Role, user.Username, user.Email, hashAndSalt(user.Password), user.TotalFine, time.Now())
+		newUser = NewUser(util.NewID(), user.StudentID, user.CardNumber, user.Role, user.Username, user.Email, hashAndSalt(user.Password), 0, time.Now())
 	} else {
-		newUser = NewUser(user.ID, user.StudentID, user.Role, user.Username, user.Email, hashAndSalt(user.Password), user.TotalFine, time.Now())
+		newUser = NewUser(user.ID, user.StudentID, user.CardNumber, user.Role, user.Username, user.Email, hashAndSalt(user.Password), 0, time.Now())
 	}
 
 	newUser, err := s.userRepository.Save(newUser)
@@ -104,6 +117,23 @@ func (s *service) GetUserIDByUsername(username string) (string, error) {
 	return userID, nil
 }
 
+// GetByIdentifier returns the User whose student ID, username
+// or card number is the identifier. An identifier of more than one User,
+// such as a username which is also the student ID of another, is refused
+// rather than resolved to either of them.
+func (s *service) GetByIdentifier(identifier string) (*User, error) {
+	users, err := s.userRepository.GetByIdentifier(identifier)
+	if err != nil || len(users) == 0 {
+		return nil, ErrGetByIdentifier
+	}
+
+	if len(users) > 1 {
+		return nil, ErrAmbiguousIdentifier
+	}
+
+	return users[0], nil
+}
+
 func (s *service) GetRole(username string) (string, error) {
 	userID, err := s.GetUserIDByUsername(username)
 	if err != nil {
@@ -118,20 +148,15 @@ func (s *service) GetRole(username string) (string, error) {
 	return role, nil
 }
 
-func (s *service) AddFine(userID string, fine uint32) (uint32, error) {
-	currentTotalFine, err := s.GetTotalFine(userID)
-	if err != nil {
-		return 0, ErrGetTotalFine
-	}
-
-	totalAddedFine := currentTotalFine + fine
-
-	err = s.userRepository.AddFine(userID, totalAddedFine)
+// SetTotalFine records the balance of the fines ledger of the User,
+// from which the TotalFine of the User is derived.
+func (s *service) SetTotalFine(userID string, totalFine uint32) error {
+	err := s.userRepository.SetTotalFine(userID, totalFine)
 	if err != nil {
-		return 0, ErrAddFine
+		return ErrSetTotalFine
 	}
 
-	return totalAddedFine, nil
+	return nil
 }
 
 func (s *service) GetTotalFine(userID string) (uint32, error) {
@@ -2,10 +2,19 @@ package user
 
 import "time"
 
-// User domain model.
+// Roles of a User.
+const (
+	RoleStudent   = "student"
+	RoleStaff     = "staff"
+	RoleLibrarian = "librarian"
+)
+
+// User domain model. CardNumber is the number printed on the library card
+// of the User, which identifies the User at the circulation desk.
 type User struct {
 	ID           string    `json:"id" db:"id"`
 	StudentID    string    `json:"studentID" db:"student_id"`
+	CardNumber   string    `json:"cardNumber" db:"card_number"`
 	Role         string    `json:"role" db:"role"`
 	Username     string    `json:"username" db:"username"`
 	Email        string    `json:"email" db:"email"`
@@ -15,10 +24,11 @@ type User struct {
 }
 
 // NewUser creates a new instance of User domain model.
-func NewUser(id string, studentID string, role string, username string, email string, password string, totalFine uint32, registeredAt time.Time) *User {
+func NewUser(id string, studentID string, cardNumber string, role string, username string, email string, password string, totalFine uint32, registeredAt time.Time) *User {
 	return &User{
 		ID:           id,
 		StudentID:    studentID,
+		CardNumber:   cardNumber,
 		Role:         role,
 		Username:     username,
 		Email:        email,
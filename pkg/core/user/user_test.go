@@ -331,66 +331,103 @@ func TestGetTotalFine(t *testing.T) {
 	}
 }
 
-func TestAddFine(t *testing.T) {
+func TestSetTotalFine(t *testing.T) {
 	user := &User{
-		ID:        util.NewID(),
-		TotalFine: 2000,
+		ID: util.NewID(),
 	}
 
 	errorUser := &User{
-		ID:        util.NewID(),
-		TotalFine: 0,
+		ID: util.NewID(),
 	}
 
-	var fine uint32 = 7000
+	var totalFine uint32 = 7000
 
 	tt := []struct {
-		name string
-		user *User
-		fine uint32
-		err  error
+		name      string
+		user      *User
+		totalFine uint32
+		err       error
 	}{
 		{
-			name: "success adding fine to User",
-			user: user,
-			fine: fine,
-			err:  nil,
+			name:      "success setting the total fine of User",
+			user:      user,
+			totalFine: totalFine,
+			err:       nil,
 		},
 		{
-			name: "failed adding fine to User",
-			user: errorUser,
-			fine: fine,
-			err:  ErrAddFine,
+			name:      "failed setting the total fine of User",
+			user:      errorUser,
+			totalFine: totalFine,
+			err:       ErrSetTotalFine,
 		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			userRepository.On("GetTotalFine", tc.user.ID).Return(tc.user.TotalFine, nil)
-
-			userRepository.On("AddFine", tc.user.ID, tc.user.TotalFine+tc.fine).Return(tc.err)
+			userRepository.On("SetTotalFine", tc.user.ID, tc.totalFine).Return(tc.err)
 
-			totalFine, err := userService.AddFine(tc.user.ID, tc.fine)
+			err := userService.SetTotalFine(tc.user.ID, tc.totalFine)
 
 			require.Equal(t, tc.err, err)
-
-			if tc.err == nil {
-				require.Equal(t, tc.user.TotalFine+fine, totalFine)
-			}
 		})
 	}
-	// userRepository.On("GetTotalFine", user.ID).Return(user.TotalFine, nil)
+}
 
-	// userRepository.On("AddFine", user.ID, user.TotalFine+fine).Return(nil)
+func TestGetByIdentifier(t *testing.T) {
+	patron := &User{
+		ID:         util.NewID(),
+		StudentID:  "12345678",
+		CardNumber: "LIB-0001",
+		Username:   "patron",
+	}
 
-	// addedFine, err := userService.AddFine(user.ID, fine)
-	// require.Nil(t, err)
-	// require.Equal(t, user.TotalFine+fine, addedFine)
+	// The username of another User which is also the student ID of the patron.
+	namesake := &User{
+		ID:       util.NewID(),
+		Username: "87654321",
+	}
+	sharingPatron := &User{
+		ID:        util.NewID(),
+		StudentID: "87654321",
+		Username:  "sharingPatron",
+	}
+
+	userRepository.On("GetByIdentifier", patron.CardNumber).Return([]*User{patron}, nil)
+	userRepository.On("GetByIdentifier", namesake.Username).Return([]*User{sharingPatron, namesake}, nil)
+	userRepository.On("GetByIdentifier", "unknownIdentifier").Return([]*User{}, nil)
+
+	tt := []struct {
+		name       string
+		identifier string
+		user       *User
+		err        error
+	}{
+		{
+			name:       "success retrieving User by card number",
+			identifier: patron.CardNumber,
+			user:       patron,
+			err:        nil,
+		},
+		{
+			name:       "identifier of more than one User",
+			identifier: namesake.Username,
+			user:       nil,
+			err:        ErrAmbiguousIdentifier,
+		},
+		{
+			name:       "identifier of no User",
+			identifier: "unknownIdentifier",
+			user:       nil,
+			err:        ErrGetByIdentifier,
+		},
+	}
 
-	// // Error on GetTotalFine
-	// userRepository.On("GetTotalFine", "another username").Return(uint32(0), errors.New("another error"))
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			user, err := userService.GetByIdentifier(tc.identifier)
 
-	// addedFine, err = userService.AddFine("another username", fine)
-	// require.NotNil(t, err)
-	// require.Equal(t, uint32(0), addedFine)
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.user, user)
+		})
+	}
 }
@@ -0,0 +1,200 @@
+package cover
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/storage"
+)
+
+var bookService = &book.MockService{}
+var blobStore = &storage.MockBlobStore{}
+
+var coverService = NewCoverService(blobStore, bookService)
+
+// newPNG returns a PNG image of the dimensions.
+func newPNG(t *testing.T, width int, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+
+	var buffer bytes.Buffer
+	err := png.Encode(&buffer, img)
+	require.Nil(t, err)
+
+	return buffer.Bytes()
+}
+
+func TestUpload(t *testing.T) {
+	cover := newPNG(t, 600, 900)
+
+	coverBook := &book.Book{ID: util.NewID(), Title: "The Go Programming Language"}
+	bookService.On("Get", coverBook.ID).Return(coverBook, nil)
+	bookService.On("Update", mock.MatchedBy(func(updated *book.Book) bool { return updated.ID == coverBook.ID })).Return(coverBook, nil)
+
+	thumbnails := map[string][]byte{}
+	blobStore.On("Put", "covers/"+coverBook.ID+"/original", cover, "image/png").Return(nil)
+	blobStore.On("Put", mock.MatchedBy(func(key string) bool { return strings.HasPrefix(key, "covers/"+coverBook.ID+"/") }), mock.Anything, "image/jpeg").
+		Run(func(args mock.Arguments) {
+			thumbnails[args.String(0)] = args.Get(1).([]byte)
+		}).
+		Return(nil)
+
+	uploadedBook, err := coverService.Upload(coverBook.ID, cover)
+	require.Nil(t, err)
+	require.True(t, strings.HasPrefix(uploadedBook.CoverPicture, "/books/"+coverBook.ID+"/cover?v="))
+
+	require.Len(t, thumbnails, len(Widths))
+	for size, width := range Widths {
+		thumbnail, err := jpeg.Decode(bytes.NewReader(thumbnails["covers/"+coverBook.ID+"/"+size]))
+		require.Nil(t, err)
+		require.Equal(t, width, thumbnail.Bounds().Dx())
+		require.Equal(t, width*3/2, thumbnail.Bounds().Dy())
+	}
+}
+
+func TestUploadSmallCover(t *testing.T) {
+	cover := newPNG(t, 100, 150)
+
+	coverBook := &book.Book{ID: util.NewID(), Title: "The C Programming Language"}
+	bookService.On("Get", coverBook.ID).Return(coverBook, nil)
+	bookService.On("Update", mock.MatchedBy(func(updated *book.Book) bool { return updated.ID == coverBook.ID })).Return(coverBook, nil)
+
+	thumbnails := map[string][]byte{}
+	blobStore.On("Put", "covers/"+coverBook.ID+"/original", cover, "image/png").Return(nil)
+	blobStore.On("Put", mock.MatchedBy(func(key string) bool { return strings.HasPrefix(key, "covers/"+coverBook.ID+"/") }), mock.Anything, "image/jpeg").
+		Run(func(args mock.Arguments) {
+			thumbnails[args.String(0)] = args.Get(1).([]byte)
+		}).
+		Return(nil)
+
+	_, err := coverService.Upload(coverBook.ID, cover)
+	require.Nil(t, err)
+
+	// Covers narrower than a thumbnail are not scaled up.
+	large, err := jpeg.Decode(bytes.NewReader(thumbnails["covers/"+coverBook.ID+"/"+SizeLarge]))
+	require.Nil(t, err)
+	require.Equal(t, 100, large.Bounds().Dx())
+
+	small, err := jpeg.Decode(bytes.NewReader(thumbnails["covers/"+coverBook.ID+"/"+SizeSmall]))
+	require.Nil(t, err)
+	require.Equal(t, 90, small.Bounds().Dx())
+}
+
+func TestUploadInvalid(t *testing.T) {
+	missingBookID := util.NewID()
+	bookService.On("Get", missingBookID).Return(nil, book.ErrGetBook)
+
+	tt := []struct {
+		name   string
+		bookID string
+		data   []byte
+		err    error
+	}{
+		{
+			name:   "too large",
+			bookID: util.NewID(),
+			data:   make([]byte, MaxSize+1),
+			err:    ErrCoverTooLarge,
+		},
+		{
+			name:   "not an image",
+			bookID: util.NewID(),
+			data:   []byte("%PDF-1.4 not a cover"),
+			err:    ErrUnsupportedImage,
+		},
+		{
+			name:   "truncated image",
+			bookID: util.NewID(),
+			data:   newPNG(t, 60, 90)[:40],
+			err:    ErrInvalidImage,
+		},
+		{
+			name:   "missing Book",
+			bookID: missingBookID,
+			data:   newPNG(t, 60, 90),
+			err:    book.ErrGetBook,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := coverService.Upload(tc.bookID, tc.data)
+			require.Equal(t, tc.err, err)
+		})
+	}
+
+	blobStore.AssertNotCalled(t, "Put", "covers/"+missingBookID+"/original", mock.Anything, mock.Anything)
+}
+
+func TestGet(t *testing.T) {
+	bookID := util.NewID()
+	thumbnail := &storage.Blob{Data: []byte("thumbnail"), ContentType: "image/jpeg"}
+	original := &storage.Blob{Data: []byte("original"), ContentType: "image/png"}
+	blobStore.On("Get", "covers/"+bookID+"/small").Return(thumbnail, nil)
+	blobStore.On("Get", "covers/"+bookID+"/original").Return(original, nil)
+
+	noCoverBookID := util.NewID()
+	blobStore.On("Get", "covers/"+noCoverBookID+"/original").Return(nil, storage.ErrBlobNotFound)
+
+	brokenBookID := util.NewID()
+	blobStore.On("Get", "covers/"+brokenBookID+"/original").Return(nil, errors.New("connection refused"))
+
+	tt := []struct {
+		name   string
+		bookID string
+		size   string
+		blob   *storage.Blob
+		err    error
+	}{
+		{
+			name:   "thumbnail",
+			bookID: bookID,
+			size:   SizeSmall,
+			blob:   thumbnail,
+		},
+		{
+			name:   "original by default",
+			bookID: bookID,
+			blob:   original,
+		},
+		{
+			name:   "invalid size",
+			bookID: bookID,
+			size:   "huge",
+			err:    ErrInvalidSize,
+		},
+		{
+			name:   "no cover",
+			bookID: noCoverBookID,
+			err:    ErrCoverNotFound,
+		},
+		{
+			name:   "store failure",
+			bookID: brokenBookID,
+			err:    ErrGetCover,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			blob, err := coverService.Get(tc.bookID, tc.size)
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.blob, blob)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package cover
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"net/http"
+
+	// Decoders of the supported formats of covers.
+	_ "image/gif"
+	_ "image/png"
+)
+
+// thumbnailQuality is the JPEG quality the thumbnails are encoded with.
+const thumbnailQuality = 85
+
+// supportedTypes are the media types of the images accepted as covers.
+var supportedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// decode sniffs the media type of the data and decodes it as an image of one
+// of the supported types.
+func decode(data []byte) (image.Image, string, error) {
+	contentType := http.DetectContentType(data)
+	if !supportedTypes[contentType] {
+		return nil, "", ErrUnsupportedImage
+	}
+
+	// The dimensions are checked before decoding, so that a small file of a huge
+	// image is not decoded into a huge amount of memory.
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width == 0 || config.Height == 0 || config.Width > MaxDimension || config.Height > MaxDimension {
+		return nil, "", ErrInvalidImage
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", ErrInvalidImage
+	}
+
+	return img, contentType, nil
+}
+
+// thumbnail scales the image down to the width, keeping its aspect ratio, and
+// encodes it as a JPEG. Images already narrower than the width are not scaled up.
+// Transparent areas are flattened on white, as JPEG has no alpha channel.
+func thumbnail(img image.Image, width int) ([]byte, error) {
+	bounds := img.Bounds()
+
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.ZP, draw.Src)
+	draw.Draw(flat, flat.Bounds(), img, bounds.Min, draw.Over)
+
+	scaled := flat
+	if bounds.Dx() > width {
+		height := bounds.Dy() * width / bounds.Dx()
+		if height == 0 {
+			height = 1
+		}
+		scaled = scale(flat, width, height)
+	}
+
+	var buffer bytes.Buffer
+	err := jpeg.Encode(&buffer, scaled, &jpeg.Options{Quality: thumbnailQuality})
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// scale scales the image down to the width and height, each pixel being the
+// average of the pixels of the source it covers.
+func scale(src *image.RGBA, width int, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	srcWidth, srcHeight := src.Bounds().Dx(), src.Bounds().Dy()
+
+	for y := 0; y < height; y++ {
+		y0 := y * srcHeight / height
+		y1 := (y + 1) * srcHeight / height
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+
+		for x := 0; x < width; x++ {
+			x0 := x * srcWidth / width
+			x1 := (x + 1) * srcWidth / width
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					offset := src.PixOffset(sx, sy)
+					r += uint32(src.Pix[offset])
+					g += uint32(src.Pix[offset+1])
+					b += uint32(src.Pix[offset+2])
+					a += uint32(src.Pix[offset+3])
+					n++
+				}
+			}
+
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / n)
+			dst.Pix[offset+1] = uint8(g / n)
+			dst.Pix[offset+2] = uint8(b / n)
+			dst.Pix[offset+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,61 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package cover
+
+import (
+	book "github.com/joshuabezaleel/library-server/pkg/core/book"
+	mock "github.com/stretchr/testify/mock"
+
+	storage "github.com/joshuabezaleel/library-server/pkg/storage"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: bookID, size
+func (_m *MockService) Get(bookID string, size string) (*storage.Blob, error) {
+	ret := _m.Called(bookID, size)
+
+	var r0 *storage.Blob
+	if rf, ok := ret.Get(0).(func(string, string) *storage.Blob); ok {
+		r0 = rf(bookID, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.Blob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(bookID, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upload provides a mock function with given fields: bookID, data
+func (_m *MockService) Upload(bookID string, data []byte) (*book.Book, error) {
+	ret := _m.Called(bookID, data)
+
+	var r0 *book.Book
+	if rf, ok := ret.Get(0).(func(string, []byte) *book.Book); ok {
+		r0 = rf(bookID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*book.Book)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte) error); ok {
+		r1 = rf(bookID, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
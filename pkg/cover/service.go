@@ -0,0 +1,133 @@
+package cover
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/storage"
+)
+
+// Limits of the covers accepted for upload.
+const (
+	MaxSize      = 5 << 20
+	MaxDimension = 8000
+)
+
+// Sizes a cover is available in. The original is the uploaded image as is,
+// the others are JPEG thumbnails of the width in Widths.
+const (
+	SizeOriginal = "original"
+	SizeSmall    = "small"
+	SizeMedium   = "medium"
+	SizeLarge    = "large"
+)
+
+// Widths of the thumbnails of a cover, in pixels.
+var Widths = map[string]int{
+	SizeSmall:  90,
+	SizeMedium: 240,
+	SizeLarge:  480,
+}
+
+// Errors definition.
+var (
+	ErrCoverTooLarge    = errors.New("Cover must not be larger than 5 MB")
+	ErrUnsupportedImage = errors.New("Cover must be a JPEG, PNG or GIF image")
+	ErrInvalidImage     = errors.New("Cover is not a valid image")
+	ErrInvalidSize      = errors.New("Cover size must be original, small, medium or large")
+	ErrCoverNotFound    = errors.New("Book has no cover")
+	ErrStoreCover       = errors.New("Error storing cover")
+	ErrGetCover         = errors.New("Error retrieving cover")
+)
+
+// Service provides the cover images of Books.
+type Service interface {
+	Upload(bookID string, data []byte) (*book.Book, error)
+	Get(bookID string, size string) (*storage.Blob, error)
+}
+
+type service struct {
+	blobStore   storage.BlobStore
+	bookService book.Service
+}
+
+// NewCoverService creates an instance of the service for covers
+// with all of the necessary dependencies.
+func NewCoverService(blobStore storage.BlobStore, bookService book.Service) Service {
+	return &service{
+		blobStore:   blobStore,
+		bookService: bookService,
+	}
+}
+
+// Upload stores the image as the cover of the Book along with its thumbnails and
+// points the Book's CoverPicture at it. The URL carries a digest of the image, so
+// that a new cover is not hidden by the cached old one.
+func (s *service) Upload(bookID string, data []byte) (*book.Book, error) {
+	if len(data) > MaxSize {
+		return nil, ErrCoverTooLarge
+	}
+
+	img, contentType, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	coverBook, err := s.bookService.Get(bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnails := map[string][]byte{}
+	for size, width := range Widths {
+		thumbnails[size], err = thumbnail(img, width)
+		if err != nil {
+			return nil, ErrStoreCover
+		}
+	}
+
+	err = s.blobStore.Put(key(bookID, SizeOriginal), data, contentType)
+	if err != nil {
+		return nil, ErrStoreCover
+	}
+
+	for size, thumbnail := range thumbnails {
+		err = s.blobStore.Put(key(bookID, size), thumbnail, "image/jpeg")
+		if err != nil {
+			return nil, ErrStoreCover
+		}
+	}
+
+	digest := sha1.Sum(data)
+	coverBook.CoverPicture = fmt.Sprintf("/books/%s/cover?v=%s", bookID, hex.EncodeToString(digest[:])[:12])
+
+	return s.bookService.Update(coverBook)
+}
+
+// Get returns the cover of the Book in the size, which defaults to the original.
+func (s *service) Get(bookID string, size string) (*storage.Blob, error) {
+	if size == "" {
+		size = SizeOriginal
+	}
+
+	if _, ok := Widths[size]; !ok && size != SizeOriginal {
+		return nil, ErrInvalidSize
+	}
+
+	blob, err := s.blobStore.Get(key(bookID, size))
+	if err == storage.ErrBlobNotFound {
+		return nil, ErrCoverNotFound
+	}
+	if err != nil {
+		return nil, ErrGetCover
+	}
+
+	return blob, nil
+}
+
+func key(bookID string, size string) string {
+	return "covers/" + bookID + "/" + size
+}
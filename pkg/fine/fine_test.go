@@ -0,0 +1,276 @@
+package fine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bouk/monkey"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/core/user"
+)
+
+var userRepository = &user.MockRepository{}
+var fineRepository = &MockRepository{}
+
+var userService = user.NewUserService(userRepository)
+var fineService = NewFineService(fineRepository, userService)
+
+// tx is the Transaction every Transaction of the ledger is recorded in.
+var tx = &transaction{}
+
+type transaction struct {
+	commits   int
+	rollbacks int
+}
+
+func (tx *transaction) Commit() error {
+	tx.commits++
+	return nil
+}
+
+func (tx *transaction) Rollback() error {
+	tx.rollbacks++
+	return nil
+}
+
+func init() {
+	fineRepository.On("Begin").Return(tx, nil)
+	fineRepository.On("WithTransaction", tx).Return(fineRepository)
+	userRepository.On("WithTransaction", tx).Return(userRepository)
+
+	// Every transaction saved in the ledger is returned as is.
+	fineRepository.On("Save", mock.AnythingOfType("*fine.Transaction")).Return(func(transaction *Transaction) *Transaction {
+		return transaction
+	}, nil)
+}
+
+func TestCharge(t *testing.T) {
+	userID := util.NewID()
+	borrowID := util.NewID()
+
+	fineRepository.On("GetBalanceForUpdate", userID).Return(uint32(2000), nil)
+	userRepository.On("SetTotalFine", userID, uint32(6000)).Return(nil)
+
+	charge, err := fineService.Charge(userID, borrowID, 4000, "Returned 2 days overdue")
+
+	require.Nil(t, err)
+	require.Equal(t, TransactionCharge, charge.Type)
+	require.Equal(t, borrowID, charge.BorrowID)
+	require.Equal(t, uint32(6000), charge.BalanceAfter)
+
+	charge, err = fineService.Charge(userID, borrowID, 0, "")
+
+	require.Nil(t, charge)
+	require.Equal(t, ErrNothingToCharge, err)
+}
+
+func TestChargeRollback(t *testing.T) {
+	userID := util.NewID()
+
+	fineRepository.On("GetBalanceForUpdate", userID).Return(uint32(2000), nil)
+	userRepository.On("SetTotalFine", userID, uint32(6000)).Return(errors.New("connection reset"))
+
+	commits, rollbacks := tx.commits, tx.rollbacks
+
+	charge, err := fineService.Charge(userID, util.NewID(), 4000, "Returned 2 days overdue")
+
+	require.Nil(t, charge)
+	require.Equal(t, user.ErrSetTotalFine, err)
+	require.Equal(t, commits, tx.commits)
+	require.Equal(t, rollbacks+1, tx.rollbacks)
+}
+
+func TestPay(t *testing.T) {
+	paidTime := time.Now()
+	timePatch := monkey.Patch(time.Now, func() time.Time {
+		return paidTime
+	})
+	defer timePatch.Unpatch()
+
+	userID := util.NewID()
+
+	fineRepository.On("GetBalanceForUpdate", userID).Return(uint32(10000), nil)
+	userRepository.On("SetTotalFine", userID, uint32(4000)).Return(nil)
+
+	tt := []struct {
+		name          string
+		amount        uint32
+		balanceBefore uint32
+		balanceAfter  uint32
+		err           error
+	}{
+		{
+			name:          "partial payment",
+			amount:        6000,
+			balanceBefore: 10000,
+			balanceAfter:  4000,
+			err:           nil,
+		},
+		{
+			name:   "payment without an amount",
+			amount: 0,
+			err:    ErrInvalidAmount,
+		},
+		{
+			name:   "payment over the balance",
+			amount: 12000,
+			err:    ErrExceedsBalance,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			receipt, err := fineService.Pay(userID, tc.amount, "Cash at the desk", "librarian")
+
+			if tc.err != nil {
+				require.Nil(t, receipt)
+				require.Equal(t, tc.err, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.amount, receipt.Amount)
+			require.Equal(t, tc.balanceBefore, receipt.BalanceBefore)
+			require.Equal(t, tc.balanceAfter, receipt.BalanceAfter)
+			require.Equal(t, "librarian", receipt.RecordedBy)
+			require.Equal(t, paidTime, receipt.PaidAt)
+		})
+	}
+}
+
+func TestWaive(t *testing.T) {
+	userID := util.NewID()
+
+	fineRepository.On("GetBalanceForUpdate", userID).Return(uint32(3000), nil)
+	userRepository.On("SetTotalFine", userID, uint32(0)).Return(nil)
+
+	waiver, err := fineService.Waive(userID, util.NewID(), 3000, "Hospitalised", "librarian")
+
+	require.Nil(t, err)
+	require.Equal(t, TransactionWaiver, waiver.Type)
+	require.Equal(t, uint32(0), waiver.BalanceAfter)
+
+	waiver, err = fineService.Waive(userID, util.NewID(), 3001, "Hospitalised", "librarian")
+
+	require.Nil(t, waiver)
+	require.Equal(t, ErrExceedsBalance, err)
+}
+
+func TestRefund(t *testing.T) {
+	userID := util.NewID()
+
+	payment := NewTransaction(util.NewID(), userID, "", "", TransactionPayment, 5000, 0, "", "librarian", time.Now())
+	refund := NewTransaction(util.NewID(), userID, "", payment.ID, TransactionRefund, 2000, 2000, "", "librarian", time.Now())
+	charge := NewTransaction(util.NewID(), userID, util.NewID(), "", TransactionCharge, 5000, 5000, "", "", time.Now())
+
+	fineRepository.On("GetByUserID", userID).Return([]*Transaction{charge, payment, refund}, nil)
+	fineRepository.On("GetBalanceForUpdate", userID).Return(uint32(2000), nil)
+	userRepository.On("SetTotalFine", userID, uint32(5000)).Return(nil)
+
+	tt := []struct {
+		name      string
+		paymentID string
+		amount    uint32
+		err       error
+	}{
+		{
+			name:      "refund of the rest of the payment",
+			paymentID: payment.ID,
+			amount:    3000,
+			err:       nil,
+		},
+		{
+			name:      "refund over the rest of the payment",
+			paymentID: payment.ID,
+			amount:    3001,
+			err:       ErrExceedsPayment,
+		},
+		{
+			name:      "refund of a charge",
+			paymentID: charge.ID,
+			amount:    1000,
+			err:       ErrNotPayment,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			newRefund, err := fineService.Refund(userID, tc.paymentID, tc.amount, "Paid twice", "librarian")
+
+			if tc.err != nil {
+				require.Nil(t, newRefund)
+				require.Equal(t, tc.err, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.paymentID, newRefund.PaymentID)
+			require.Equal(t, uint32(5000), newRefund.BalanceAfter)
+		})
+	}
+}
+
+func TestReverse(t *testing.T) {
+	borrowID := util.NewID()
+
+	// The replacement charge is still owed in full.
+	owingUserID := util.NewID()
+
+	fineRepository.On("GetBalanceForUpdate", owingUserID).Return(uint32(5000), nil)
+	userRepository.On("SetTotalFine", owingUserID, uint32(0)).Return(nil)
+
+	waiver, err := fineService.Reverse(owingUserID, borrowID, 5000, "Lost Book copy found", "librarian")
+
+	require.Nil(t, err)
+	require.Equal(t, TransactionWaiver, waiver.Type)
+	require.Equal(t, uint32(0), waiver.BalanceAfter)
+
+	// The replacement charge was mostly paid, so the payment is refunded before the waiver.
+	payingUserID := util.NewID()
+
+	charge := NewTransaction(util.NewID(), payingUserID, borrowID, "", TransactionCharge, 5000, 5000, "", "", time.Now())
+	payment := NewTransaction(util.NewID(), payingUserID, "", "", TransactionPayment, 4000, 1000, "", "librarian", time.Now())
+
+	fineRepository.On("GetByUserID", payingUserID).Return([]*Transaction{charge, payment}, nil)
+	fineRepository.On("GetBalanceForUpdate", payingUserID).Return(uint32(1000), nil).Twice()
+	fineRepository.On("GetBalanceForUpdate", payingUserID).Return(uint32(5000), nil).Once()
+	userRepository.On("SetTotalFine", payingUserID, uint32(5000)).Return(nil)
+	userRepository.On("SetTotalFine", payingUserID, uint32(0)).Return(nil)
+
+	waiver, err = fineService.Reverse(payingUserID, borrowID, 5000, "Lost Book copy found", "librarian")
+
+	require.Nil(t, err)
+	require.Equal(t, borrowID, waiver.BorrowID)
+	require.Equal(t, uint32(0), waiver.BalanceAfter)
+	fineRepository.AssertCalled(t, "Save", mock.MatchedBy(func(refund *Transaction) bool {
+		return refund.Type == TransactionRefund && refund.PaymentID == payment.ID && refund.Amount == 4000
+	}))
+
+	waiver, err = fineService.Reverse(payingUserID, borrowID, 0, "", "librarian")
+
+	require.Nil(t, waiver)
+	require.Equal(t, ErrInvalidAmount, err)
+}
+
+func TestGetReceipt(t *testing.T) {
+	userID := util.NewID()
+
+	payment := NewTransaction(util.NewID(), userID, "", "", TransactionPayment, 5000, 1000, "", "librarian", time.Now())
+	fineRepository.On("Get", payment.ID).Return(payment, nil)
+
+	receipt, err := fineService.GetReceipt(userID, payment.ID)
+
+	require.Nil(t, err)
+	require.Equal(t, payment.ID, receipt.Number)
+	require.Equal(t, uint32(6000), receipt.BalanceBefore)
+
+	// The receipt of another User's payment is not handed out.
+	receipt, err = fineService.GetReceipt(util.NewID(), payment.ID)
+
+	require.Nil(t, receipt)
+	require.Equal(t, ErrNotPayment, err)
+}
@@ -0,0 +1,163 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package fine
+
+import (
+	pkg "github.com/joshuabezaleel/library-server/pkg"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+// Begin provides a mock function with given fields:
+func (_m *MockRepository) Begin() (pkg.Transaction, error) {
+	ret := _m.Called()
+
+	var r0 pkg.Transaction
+	if rf, ok := ret.Get(0).(func() pkg.Transaction); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(pkg.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: transactionID
+func (_m *MockRepository) Get(transactionID string) (*Transaction, error) {
+	ret := _m.Called(transactionID)
+
+	var r0 *Transaction
+	if rf, ok := ret.Get(0).(func(string) *Transaction); ok {
+		r0 = rf(transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBalance provides a mock function with given fields: userID
+func (_m *MockRepository) GetBalance(userID string) (uint32, error) {
+	ret := _m.Called(userID)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(string) uint32); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBalanceForUpdate provides a mock function with given fields: userID
+func (_m *MockRepository) GetBalanceForUpdate(userID string) (uint32, error) {
+	ret := _m.Called(userID)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(string) uint32); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserID provides a mock function with given fields: userID
+func (_m *MockRepository) GetByUserID(userID string) ([]*Transaction, error) {
+	ret := _m.Called(userID)
+
+	var r0 []*Transaction
+	if rf, ok := ret.Get(0).(func(string) []*Transaction); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: transaction
+func (_m *MockRepository) Save(transaction *Transaction) (*Transaction, error) {
+	ret := _m.Called(transaction)
+
+	var r0 *Transaction
+	if rf, ok := ret.Get(0).(func(*Transaction) *Transaction); ok {
+		r0 = rf(transaction)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Transaction) error); ok {
+		r1 = rf(transaction)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithTransaction provides a mock function with given fields: tx
+func (_m *MockRepository) WithTransaction(tx pkg.Transaction) Repository {
+	ret := _m.Called(tx)
+
+	var r0 Repository
+	if rf, ok := ret.Get(0).(func(pkg.Transaction) Repository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Repository)
+		}
+	}
+
+	return r0
+}
@@ -0,0 +1,211 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package fine
+
+import (
+	pkg "github.com/joshuabezaleel/library-server/pkg"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+// Charge provides a mock function with given fields: userID, borrowID, amount, note
+func (_m *MockService) Charge(userID string, borrowID string, amount uint32, note string) (*Transaction, error) {
+	ret := _m.Called(userID, borrowID, amount, note)
+
+	var r0 *Transaction
+	if rf, ok := ret.Get(0).(func(string, string, uint32, string) *Transaction); ok {
+		r0 = rf(userID, borrowID, amount, note)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, uint32, string) error); ok {
+		r1 = rf(userID, borrowID, amount, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBalance provides a mock function with given fields: userID
+func (_m *MockService) GetBalance(userID string) (uint32, error) {
+	ret := _m.Called(userID)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(string) uint32); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceipt provides a mock function with given fields: userID, paymentID
+func (_m *MockService) GetReceipt(userID string, paymentID string) (*Receipt, error) {
+	ret := _m.Called(userID, paymentID)
+
+	var r0 *Receipt
+	if rf, ok := ret.Get(0).(func(string, string) *Receipt); ok {
+		r0 = rf(userID, paymentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Receipt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, paymentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatement provides a mock function with given fields: userID
+func (_m *MockService) GetStatement(userID string) (*Statement, error) {
+	ret := _m.Called(userID)
+
+	var r0 *Statement
+	if rf, ok := ret.Get(0).(func(string) *Statement); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Statement)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Pay provides a mock function with given fields: userID, amount, note, recordedBy
+func (_m *MockService) Pay(userID string, amount uint32, note string, recordedBy string) (*Receipt, error) {
+	ret := _m.Called(userID, amount, note, recordedBy)
+
+	var r0 *Receipt
+	if rf, ok := ret.Get(0).(func(string, uint32, string, string) *Receipt); ok {
+		r0 = rf(userID, amount, note, recordedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Receipt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, uint32, string, string) error); ok {
+		r1 = rf(userID, amount, note, recordedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Refund provides a mock function with given fields: userID, paymentID, amount, note, recordedBy
+func (_m *MockService) Refund(userID string, paymentID string, amount uint32, note string, recordedBy string) (*Transaction, error) {
+	ret := _m.Called(userID, paymentID, amount, note, recordedBy)
+
+	var r0 *Transaction
+	if rf, ok := ret.Get(0).(func(string, string, uint32, string, string) *Transaction); ok {
+		r0 = rf(userID, paymentID, amount, note, recordedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, uint32, string, string) error); ok {
+		r1 = rf(userID, paymentID, amount, note, recordedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reverse provides a mock function with given fields: userID, borrowID, amount, note, recordedBy
+func (_m *MockService) Reverse(userID string, borrowID string, amount uint32, note string, recordedBy string) (*Transaction, error) {
+	ret := _m.Called(userID, borrowID, amount, note, recordedBy)
+
+	var r0 *Transaction
+	if rf, ok := ret.Get(0).(func(string, string, uint32, string, string) *Transaction); ok {
+		r0 = rf(userID, borrowID, amount, note, recordedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, uint32, string, string) error); ok {
+		r1 = rf(userID, borrowID, amount, note, recordedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Waive provides a mock function with given fields: userID, borrowID, amount, note, recordedBy
+func (_m *MockService) Waive(userID string, borrowID string, amount uint32, note string, recordedBy string) (*Transaction, error) {
+	ret := _m.Called(userID, borrowID, amount, note, recordedBy)
+
+	var r0 *Transaction
+	if rf, ok := ret.Get(0).(func(string, string, uint32, string, string) *Transaction); ok {
+		r0 = rf(userID, borrowID, amount, note, recordedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, uint32, string, string) error); ok {
+		r1 = rf(userID, borrowID, amount, note, recordedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithTransaction provides a mock function with given fields: tx
+func (_m *MockService) WithTransaction(tx pkg.Transaction) Service {
+	ret := _m.Called(tx)
+
+	var r0 Service
+	if rf, ok := ret.Get(0).(func(pkg.Transaction) Service); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Service)
+		}
+	}
+
+	return r0
+}
@@ -0,0 +1,19 @@
+package fine
+
+import util "github.com/joshuabezaleel/library-server/pkg"
+
+// Repository provides access to the fines ledger store.
+type Repository interface {
+	Save(transaction *Transaction) (*Transaction, error)
+	Get(transactionID string) (*Transaction, error)
+	GetByUserID(userID string) ([]*Transaction, error)
+	GetBalance(userID string) (uint32, error)
+	// GetBalanceForUpdate returns the balance of the User and keeps anyone else
+	// from writing the ledger of the User until the Transaction ends.
+	GetBalanceForUpdate(userID string) (uint32, error)
+
+	// Begin begins a Transaction of the fines ledger.
+	Begin() (util.Transaction, error)
+	// WithTransaction returns the repository working in the Transaction.
+	WithTransaction(tx util.Transaction) Repository
+}
@@ -0,0 +1,366 @@
+package fine
+
+import (
+	"errors"
+	"time"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/core/user"
+)
+
+// Errors definition.
+var (
+	ErrCharge       = errors.New("Error charging fine")
+	ErrPay          = errors.New("Error recording payment")
+	ErrWaive        = errors.New("Error waiving fine")
+	ErrRefund       = errors.New("Error refunding payment")
+	ErrGetStatement = errors.New("Error retrieving fines statement")
+	ErrGetBalance   = errors.New("Error retrieving fines balance")
+	ErrGetPayment   = errors.New("Error retrieving payment")
+
+	ErrInvalidAmount   = errors.New("Amount must be greater than zero")
+	ErrExceedsBalance  = errors.New("Amount exceeds the outstanding balance")
+	ErrExceedsPayment  = errors.New("Amount exceeds the refundable part of the payment")
+	ErrNotPayment      = errors.New("Transaction is not a payment of the User")
+	ErrNothingToCharge = errors.New("Nothing to charge")
+)
+
+// Service provides basic operations on the fines ledger.
+type Service interface {
+	Charge(userID string, borrowID string, amount uint32, note string) (*Transaction, error)
+	Pay(userID string, amount uint32, note string, recordedBy string) (*Receipt, error)
+	Waive(userID string, borrowID string, amount uint32, note string, recordedBy string) (*Transaction, error)
+	Refund(userID string, paymentID string, amount uint32, note string, recordedBy string) (*Transaction, error)
+	Reverse(userID string, borrowID string, amount uint32, note string, recordedBy string) (*Transaction, error)
+
+	GetStatement(userID string) (*Statement, error)
+	GetReceipt(userID string, paymentID string) (*Receipt, error)
+	GetBalance(userID string) (uint32, error)
+
+	WithTransaction(tx util.Transaction) Service
+}
+
+type service struct {
+	fineRepository Repository
+	userService    user.Service
+	tx             util.Transaction
+}
+
+// NewFineService creates an instance of the service for the fines ledger
+// with all of the necessary dependencies.
+func NewFineService(fineRepository Repository, userService user.Service) Service {
+	return &service{
+		fineRepository: fineRepository,
+		userService:    userService,
+	}
+}
+
+// WithTransaction returns the service recording in the Transaction, so that the
+// Transactions of the ledger are committed or rolled back along with the rest of it.
+func (s *service) WithTransaction(tx util.Transaction) Service {
+	return &service{
+		fineRepository: s.fineRepository.WithTransaction(tx),
+		userService:    s.userService.WithTransaction(tx),
+		tx:             tx,
+	}
+}
+
+func (s *service) Charge(userID string, borrowID string, amount uint32, note string) (*Transaction, error) {
+	if amount == 0 {
+		return nil, ErrNothingToCharge
+	}
+
+	var charge *Transaction
+
+	err := s.inTransaction(ErrCharge, func(s *service) error {
+		balance, err := s.lockBalance(userID)
+		if err != nil {
+			return err
+		}
+
+		charge = NewTransaction(util.NewID(), userID, borrowID, "", TransactionCharge, amount, balance+amount, note, "", time.Now())
+
+		charge, err = s.record(charge, ErrCharge)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return charge, nil
+}
+
+func (s *service) Pay(userID string, amount uint32, note string, recordedBy string) (*Receipt, error) {
+	if amount == 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	var payment *Transaction
+
+	err := s.inTransaction(ErrPay, func(s *service) error {
+		balance, err := s.lockBalance(userID)
+		if err != nil {
+			return err
+		}
+
+		// A payment lower than the balance is a partial payment.
+		if amount > balance {
+			return ErrExceedsBalance
+		}
+
+		payment = NewTransaction(util.NewID(), userID, "", "", TransactionPayment, amount, balance-amount, note, recordedBy, time.Now())
+
+		payment, err = s.record(payment, ErrPay)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReceipt(payment), nil
+}
+
+func (s *service) Waive(userID string, borrowID string, amount uint32, note string, recordedBy string) (*Transaction, error) {
+	if amount == 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	var waiver *Transaction
+
+	err := s.inTransaction(ErrWaive, func(s *service) error {
+		balance, err := s.lockBalance(userID)
+		if err != nil {
+			return err
+		}
+
+		if amount > balance {
+			return ErrExceedsBalance
+		}
+
+		waiver = NewTransaction(util.NewID(), userID, borrowID, "", TransactionWaiver, amount, balance-amount, note, recordedBy, time.Now())
+
+		waiver, err = s.record(waiver, ErrWaive)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return waiver, nil
+}
+
+func (s *service) Refund(userID string, paymentID string, amount uint32, note string, recordedBy string) (*Transaction, error) {
+	if amount == 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	var refund *Transaction
+
+	err := s.inTransaction(ErrRefund, func(s *service) error {
+		// The ledger is locked before the refunds of the payment are summed,
+		// so that the payment is not refunded twice at the same time.
+		balance, err := s.lockBalance(userID)
+		if err != nil {
+			return err
+		}
+
+		transactions, err := s.fineRepository.GetByUserID(userID)
+		if err != nil {
+			return ErrGetStatement
+		}
+
+		// Only the part of the payment that has not been refunded yet can be refunded.
+		var payment *Transaction
+		var refunded uint32
+		for _, transaction := range transactions {
+			switch {
+			case transaction.ID == paymentID && transaction.Type == TransactionPayment:
+				payment = transaction
+			case transaction.PaymentID == paymentID && transaction.Type == TransactionRefund:
+				refunded += transaction.Amount
+			}
+		}
+
+		if payment == nil {
+			return ErrNotPayment
+		}
+
+		if amount > payment.Amount-refunded {
+			return ErrExceedsPayment
+		}
+
+		refund = NewTransaction(util.NewID(), userID, "", paymentID, TransactionRefund, amount, balance+amount, note, recordedBy, time.Now())
+
+		refund, err = s.record(refund, ErrRefund)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+// Reverse takes back a charge against the Borrow. The part of the charge
+// the User already paid is refunded first, then the charge is waived.
+func (s *service) Reverse(userID string, borrowID string, amount uint32, note string, recordedBy string) (*Transaction, error) {
+	if amount == 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	var waiver *Transaction
+
+	// The refunds and the waiver are recorded all together or not at all.
+	err := s.inTransaction(ErrWaive, func(s *service) error {
+		balance, err := s.lockBalance(userID)
+		if err != nil {
+			return err
+		}
+
+		if amount > balance {
+			err = s.refundPayments(userID, amount-balance, note, recordedBy)
+			if err != nil {
+				return err
+			}
+		}
+
+		waiver, err = s.Waive(userID, borrowID, amount, note, recordedBy)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return waiver, nil
+}
+
+func (s *service) GetStatement(userID string) (*Statement, error) {
+	transactions, err := s.fineRepository.GetByUserID(userID)
+	if err != nil {
+		return nil, ErrGetStatement
+	}
+
+	balance, err := s.GetBalance(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStatement(userID, balance, transactions), nil
+}
+
+func (s *service) GetReceipt(userID string, paymentID string) (*Receipt, error) {
+	payment, err := s.fineRepository.Get(paymentID)
+	if err != nil {
+		return nil, ErrGetPayment
+	}
+
+	if payment.UserID != userID || payment.Type != TransactionPayment {
+		return nil, ErrNotPayment
+	}
+
+	return NewReceipt(payment), nil
+}
+
+func (s *service) GetBalance(userID string) (uint32, error) {
+	balance, err := s.fineRepository.GetBalance(userID)
+	if err != nil {
+		return 0, ErrGetBalance
+	}
+
+	return balance, nil
+}
+
+// inTransaction runs the operation on the service recording in a Transaction, which
+// is committed when the operation succeeds and rolled back otherwise. A service
+// already recording in a Transaction runs the operation in that Transaction.
+func (s *service) inTransaction(errRecord error, operation func(s *service) error) error {
+	if s.tx != nil {
+		return operation(s)
+	}
+
+	tx, err := s.fineRepository.Begin()
+	if err != nil {
+		return errRecord
+	}
+
+	err = operation(s.WithTransaction(tx).(*service))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errRecord
+	}
+
+	return nil
+}
+
+// lockBalance returns the balance of the User, whose ledger is not written
+// by anyone else until the Transaction of the service ends.
+func (s *service) lockBalance(userID string) (uint32, error) {
+	balance, err := s.fineRepository.GetBalanceForUpdate(userID)
+	if err != nil {
+		return 0, ErrGetBalance
+	}
+
+	return balance, nil
+}
+
+// refundPayments refunds the amount from the payments of the User,
+// starting from the latest payment.
+func (s *service) refundPayments(userID string, amount uint32, note string, recordedBy string) error {
+	transactions, err := s.fineRepository.GetByUserID(userID)
+	if err != nil {
+		return ErrGetStatement
+	}
+
+	refunded := map[string]uint32{}
+	for _, transaction := range transactions {
+		if transaction.Type == TransactionRefund {
+			refunded[transaction.PaymentID] += transaction.Amount
+		}
+	}
+
+	for i := len(transactions) - 1; i >= 0 && amount > 0; i-- {
+		payment := transactions[i]
+		if payment.Type != TransactionPayment || payment.Amount == refunded[payment.ID] {
+			continue
+		}
+
+		refund := payment.Amount - refunded[payment.ID]
+		if refund > amount {
+			refund = amount
+		}
+
+		_, err = s.Refund(userID, payment.ID, refund, note, recordedBy)
+		if err != nil {
+			return err
+		}
+
+		amount -= refund
+	}
+
+	if amount > 0 {
+		return ErrExceedsPayment
+	}
+
+	return nil
+}
+
+// record saves the Transaction in the ledger and derives
+// the TotalFine of the User from the new balance.
+func (s *service) record(transaction *Transaction, errRecord error) (*Transaction, error) {
+	transaction, err := s.fineRepository.Save(transaction)
+	if err != nil {
+		return nil, errRecord
+	}
+
+	err = s.userService.SetTotalFine(transaction.UserID, transaction.BalanceAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
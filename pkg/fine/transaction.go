@@ -0,0 +1,87 @@
+package fine
+
+import (
+	"time"
+)
+
+// Types of Transaction.
+const (
+	TransactionCharge  = "charge"
+	TransactionPayment = "payment"
+	TransactionWaiver  = "waiver"
+	TransactionRefund  = "refund"
+)
+
+// Transaction domain model. A Transaction is one entry of the fines ledger
+// of a User. Charges and refunds raise the balance the User owes, while
+// payments and waivers lower it. BalanceAfter is the balance of the ledger
+// right after the Transaction. BorrowID links a charge or a waiver to the
+// Borrow that caused it, and PaymentID links a refund to its payment.
+type Transaction struct {
+	ID           string    `json:"id" db:"id"`
+	UserID       string    `json:"userID" db:"user_id"`
+	BorrowID     string    `json:"borrowID" db:"borrow_id"`
+	PaymentID    string    `json:"paymentID" db:"payment_id"`
+	Type         string    `json:"type" db:"type"`
+	Amount       uint32    `json:"amount" db:"amount"`
+	BalanceAfter uint32    `json:"balanceAfter" db:"balance_after"`
+	Note         string    `json:"note" db:"note"`
+	RecordedBy   string    `json:"recordedBy" db:"recorded_by"`
+	CreatedAt    time.Time `json:"createdAt" db:"created_at"`
+}
+
+// NewTransaction creates a new instance of Transaction domain model.
+func NewTransaction(id string, userID string, borrowID string, paymentID string, transactionType string, amount uint32, balanceAfter uint32, note string, recordedBy string, createdAt time.Time) *Transaction {
+	return &Transaction{
+		ID:           id,
+		UserID:       userID,
+		BorrowID:     borrowID,
+		PaymentID:    paymentID,
+		Type:         transactionType,
+		Amount:       amount,
+		BalanceAfter: balanceAfter,
+		Note:         note,
+		RecordedBy:   recordedBy,
+		CreatedAt:    createdAt,
+	}
+}
+
+// Statement is the itemised fines ledger of a User.
+type Statement struct {
+	UserID       string         `json:"userID"`
+	Balance      uint32         `json:"balance"`
+	Transactions []*Transaction `json:"transactions"`
+}
+
+// NewStatement creates a new instance of Statement.
+func NewStatement(userID string, balance uint32, transactions []*Transaction) *Statement {
+	return &Statement{
+		UserID:       userID,
+		Balance:      balance,
+		Transactions: transactions,
+	}
+}
+
+// Receipt acknowledges a payment towards the fines of a User.
+type Receipt struct {
+	Number        string    `json:"number"`
+	UserID        string    `json:"userID"`
+	Amount        uint32    `json:"amount"`
+	BalanceBefore uint32    `json:"balanceBefore"`
+	BalanceAfter  uint32    `json:"balanceAfter"`
+	RecordedBy    string    `json:"recordedBy"`
+	PaidAt        time.Time `json:"paidAt"`
+}
+
+// NewReceipt creates the Receipt of a payment Transaction.
+func NewReceipt(payment *Transaction) *Receipt {
+	return &Receipt{
+		Number:        payment.ID,
+		UserID:        payment.UserID,
+		Amount:        payment.Amount,
+		BalanceBefore: payment.BalanceAfter + payment.Amount,
+		BalanceAfter:  payment.BalanceAfter,
+		RecordedBy:    payment.RecordedBy,
+		PaidAt:        payment.CreatedAt,
+	}
+}
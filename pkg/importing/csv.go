@@ -0,0 +1,239 @@
+package importing
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+)
+
+// Columns of the CSV, named in its header in any order. Only title
+// is required. Authors, subjects and barcodes hold several values
+// separated by semicolons, and an author can be followed by its role
+// in parentheses, as in "Kernighan, Brian W. (editor)".
+const (
+	ColumnTitle             = "title"
+	ColumnISBN              = "isbn"
+	ColumnCallNumber        = "call_number"
+	ColumnPublisher         = "publisher"
+	ColumnYearPublished     = "year_published"
+	ColumnEdition           = "edition"
+	ColumnCollation         = "collation"
+	ColumnDescription       = "description"
+	ColumnLOCClassification = "loc_classification"
+	ColumnAuthors           = "authors"
+	ColumnSubjects          = "subjects"
+	ColumnCopies            = "copies"
+	ColumnBarcodes          = "barcodes"
+	ColumnCondition         = "condition"
+	ColumnCategory          = "category"
+	ColumnAcquisitionPrice  = "acquisition_price"
+	ColumnHomeBranchID      = "home_branch_id"
+	ColumnHomeLocationID    = "home_location_id"
+)
+
+var columns = []string{ColumnTitle, ColumnISBN, ColumnCallNumber, ColumnPublisher, ColumnYearPublished, ColumnEdition, ColumnCollation, ColumnDescription, ColumnLOCClassification, ColumnAuthors, ColumnSubjects, ColumnCopies, ColumnBarcodes, ColumnCondition, ColumnCategory, ColumnAcquisitionPrice, ColumnHomeBranchID, ColumnHomeLocationID}
+
+// reportHeader is the header of the CSV report of a Job.
+var reportHeader = []string{"row", ColumnTitle, ColumnISBN, ColumnCallNumber, "status", "book_id", "copies_added", "errors"}
+
+var authorRolePattern = regexp.MustCompile(`^(.+?)\s*\(([^()]*)\)$`)
+
+// record is a line of the CSV parsed into the Book and the Copies it adds,
+// along with the errors found in it so far.
+type record struct {
+	number   int
+	book     *book.Book
+	bookCopy *bookcopy.BookCopy
+	copies   int
+	barcodes []string
+	errors   []string
+}
+
+func (r *record) addError(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// parse reads the lines of the CSV into records. Errors within a line are
+// kept on its record, so that they can be reported along with the others.
+func parse(data []byte) ([]*record, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	lines, err := reader.ReadAll()
+	if err != nil {
+		return nil, ErrInvalidCSV
+	}
+
+	if len(lines) == 0 {
+		return nil, ErrNoRows
+	}
+
+	header, err := parseHeader(lines[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 1 {
+		return nil, ErrNoRows
+	}
+
+	records := []*record{}
+	for i, line := range lines[1:] {
+		records = append(records, parseLine(i+1, header, line))
+	}
+
+	return records, nil
+}
+
+// parseHeader maps the columns of the header to their position in the lines.
+func parseHeader(line []string) (map[string]int, error) {
+	header := map[string]int{}
+	for i, name := range line {
+		column := strings.ToLower(strings.TrimSpace(name))
+		if !isColumn(column) {
+			return nil, ErrInvalidHeader
+		}
+
+		header[column] = i
+	}
+
+	if _, ok := header[ColumnTitle]; !ok {
+		return nil, ErrInvalidHeader
+	}
+
+	return header, nil
+}
+
+func isColumn(name string) bool {
+	for _, column := range columns {
+		if column == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseLine(number int, header map[string]int, line []string) *record {
+	value := func(column string) string {
+		i, ok := header[column]
+		if !ok || i >= len(line) {
+			return ""
+		}
+
+		return strings.TrimSpace(line[i])
+	}
+
+	r := &record{
+		number: number,
+		book: &book.Book{
+			Title:             value(ColumnTitle),
+			ISBN:              value(ColumnISBN),
+			CallNumber:        value(ColumnCallNumber),
+			Publisher:         value(ColumnPublisher),
+			Collation:         value(ColumnCollation),
+			Description:       value(ColumnDescription),
+			LOCClassification: value(ColumnLOCClassification),
+			Subject:           splitValues(value(ColumnSubjects)),
+			Author:            []*book.BookAuthor{},
+		},
+		bookCopy: &bookcopy.BookCopy{
+			Condition:      value(ColumnCondition),
+			Category:       value(ColumnCategory),
+			HomeBranchID:   value(ColumnHomeBranchID),
+			HomeLocationID: value(ColumnHomeLocationID),
+		},
+		barcodes: splitValues(value(ColumnBarcodes)),
+	}
+
+	if r.book.Title == "" {
+		r.addError("Title is required")
+	}
+
+	r.book.YearPublished = r.parseNumber(ColumnYearPublished, value(ColumnYearPublished))
+	r.book.Edition = r.parseNumber(ColumnEdition, value(ColumnEdition))
+
+	if price := value(ColumnAcquisitionPrice); price != "" {
+		acquisitionPrice, err := strconv.ParseUint(price, 10, 32)
+		if err != nil {
+			r.addError("%s must be a whole number", ColumnAcquisitionPrice)
+		}
+		r.bookCopy.AcquisitionPrice = uint32(acquisitionPrice)
+	}
+
+	// Without a number of copies, there is a copy for each of the barcodes.
+	r.copies = len(r.barcodes)
+	if copies := value(ColumnCopies); copies != "" {
+		r.copies = r.parseNumber(ColumnCopies, copies)
+		if r.copies < len(r.barcodes) {
+			r.addError("%s must not be fewer than the barcodes", ColumnCopies)
+		}
+	}
+
+	for _, author := range splitValues(value(ColumnAuthors)) {
+		bookAuthor := &book.BookAuthor{Name: author, Role: book.RoleAuthor}
+		if match := authorRolePattern.FindStringSubmatch(author); match != nil {
+			bookAuthor.Name = match[1]
+			bookAuthor.Role = strings.ToLower(strings.TrimSpace(match[2]))
+		}
+
+		if !book.IsValidRole(bookAuthor.Role) {
+			r.addError("Author %q: %s", bookAuthor.Name, book.ErrInvalidAuthorRole.Error())
+		}
+
+		r.book.Author = append(r.book.Author, bookAuthor)
+	}
+
+	return r
+}
+
+// parseNumber parses a non-negative whole number, which is 0 when the value is empty.
+func (r *record) parseNumber(column string, value string) int {
+	if value == "" {
+		return 0
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		r.addError("%s must be a whole number", column)
+		return 0
+	}
+
+	return number
+}
+
+func splitValues(value string) []string {
+	values := []string{}
+	for _, v := range strings.Split(value, ";") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// writeReport writes the Rows of a Job as CSV, one line per line of the imported CSV.
+func writeReport(rows []*Row) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	writer := csv.NewWriter(&buffer)
+	writer.Write(reportHeader)
+	for _, row := range rows {
+		writer.Write([]string{strconv.Itoa(row.Number), row.Title, row.ISBN, row.CallNumber, row.Status, row.BookID, strconv.Itoa(row.CopiesAdded), row.Errors})
+	}
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,86 @@
+package importing
+
+import (
+	"time"
+)
+
+// Commit modes of a Job.
+const (
+	// ModeAllOrNothing imports none of the rows unless all of them are valid.
+	ModeAllOrNothing = "all-or-nothing"
+	// ModeBestEffort imports the valid rows and leaves out the invalid ones.
+	ModeBestEffort = "best-effort"
+)
+
+// Statuses of a Job.
+const (
+	JobValidated          = "validated"
+	JobCommitted          = "committed"
+	JobPartiallyCommitted = "partially-committed"
+	JobRejected           = "rejected"
+)
+
+// Statuses of a Row.
+const (
+	RowValid    = "valid"
+	RowInvalid  = "invalid"
+	RowImported = "imported"
+	RowFailed   = "failed"
+	RowSkipped  = "skipped"
+)
+
+// Job domain model. A Job is one upload of a CSV of Books and their Copies
+// into the catalogue. A dry-run Job only validates the rows, which leaves
+// it validated instead of committed.
+type Job struct {
+	ID           string    `json:"id" db:"id"`
+	Mode         string    `json:"mode" db:"mode"`
+	DryRun       bool      `json:"dryRun" db:"dry_run"`
+	Status       string    `json:"status" db:"status"`
+	TotalRows    int       `json:"totalRows" db:"total_rows"`
+	ImportedRows int       `json:"importedRows" db:"imported_rows"`
+	FailedRows   int       `json:"failedRows" db:"failed_rows"`
+	CreatedBy    string    `json:"createdBy" db:"created_by"`
+	CreatedAt    time.Time `json:"createdAt" db:"created_at"`
+	Rows         []*Row    `json:"rows,omitempty" db:"-"`
+}
+
+// NewJob creates a new instance of Job domain model.
+func NewJob(id string, mode string, dryRun bool, status string, totalRows int, importedRows int, failedRows int, createdBy string, createdAt time.Time) *Job {
+	return &Job{
+		ID:           id,
+		Mode:         mode,
+		DryRun:       dryRun,
+		Status:       status,
+		TotalRows:    totalRows,
+		ImportedRows: importedRows,
+		FailedRows:   failedRows,
+		CreatedBy:    createdBy,
+		CreatedAt:    createdAt,
+	}
+}
+
+// Row domain model. A Row is the outcome of one line of the CSV of a Job,
+// numbered from 1 after the header. Errors are the validation errors of
+// an invalid Row or the reason a valid Row failed to be imported.
+type Row struct {
+	JobID       string `json:"jobID" db:"job_id"`
+	Number      int    `json:"number" db:"number"`
+	Title       string `json:"title" db:"title"`
+	ISBN        string `json:"isbn" db:"isbn"`
+	CallNumber  string `json:"callNumber" db:"call_number"`
+	Status      string `json:"status" db:"status"`
+	BookID      string `json:"bookID" db:"book_id"`
+	CopiesAdded int    `json:"copiesAdded" db:"copies_added"`
+	Errors      string `json:"errors" db:"errors"`
+}
+
+// IsFailed returns whether the Row is invalid or failed to be imported.
+func (row *Row) IsFailed() bool {
+	return row.Status == RowInvalid || row.Status == RowFailed
+}
+
+// IsValidMode returns whether the mode is one a Job can be committed in.
+func IsValidMode(mode string) bool {
+	return mode == ModeAllOrNothing || mode == ModeBestEffort
+}
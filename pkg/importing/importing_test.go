@@ -0,0 +1,323 @@
+package importing
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+)
+
+// transaction is a Transaction counting the times it is committed and rolled back.
+type transaction struct {
+	commits   int
+	rollbacks int
+}
+
+func (tx *transaction) Commit() error {
+	tx.commits++
+	return nil
+}
+
+func (tx *transaction) Rollback() error {
+	tx.rollbacks++
+	return nil
+}
+
+type testingService struct {
+	Service
+
+	tx                  *transaction
+	importingRepository *MockRepository
+	bookService         *book.MockService
+	bookCopyService     *bookcopy.MockService
+	branchService       *branch.MockService
+}
+
+// newTestingService returns a service with mocks of its dependencies.
+func newTestingService() *testingService {
+	s := &testingService{
+		tx:                  &transaction{},
+		importingRepository: &MockRepository{},
+		bookService:         &book.MockService{},
+		bookCopyService:     &bookcopy.MockService{},
+		branchService:       &branch.MockService{},
+	}
+	s.Service = NewImportingService(s.importingRepository, s.bookService, s.bookCopyService, s.branchService)
+
+	return s
+}
+
+// emptyCatalogue makes the mocks answer as an empty catalogue does. As mocks
+// match the first expectation set up, it comes after those a test is about.
+func (s *testingService) emptyCatalogue() {
+	s.importingRepository.On("Save", mock.AnythingOfType("*importing.Job")).Return(func(job *Job) *Job { return job }, nil)
+	s.importingRepository.On("Begin").Return(s.tx, nil)
+	s.bookService.On("WithTransaction", s.tx).Return(s.bookService)
+	s.bookCopyService.On("WithTransaction", s.tx).Return(s.bookCopyService)
+	s.bookService.On("ISBNExists", mock.AnythingOfType("string")).Return(false, nil)
+	s.bookService.On("CallNumberExists", mock.AnythingOfType("string")).Return(false, nil)
+	s.bookService.On("GetSubjectIDs", mock.Anything).Return([]int64{1}, nil)
+	s.bookCopyService.On("ValidateBarcode", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+	s.bookCopyService.On("GetByBarcode", mock.AnythingOfType("string")).Return(nil, bookcopy.ErrGetBookCopy)
+}
+
+func csvOf(lines ...string) []byte {
+	return []byte(strings.Join(lines, "\n"))
+}
+
+func TestParse(t *testing.T) {
+	tt := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{
+			name: "success parsing the CSV",
+			data: csvOf("Title,ISBN,Authors", "The Go Programming Language,9780134190440,Donovan; Kernighan (Editor)"),
+			err:  nil,
+		},
+		{
+			name: "unknown column",
+			data: csvOf("title,isbn13", "The Go Programming Language,9780134190440"),
+			err:  ErrInvalidHeader,
+		},
+		{
+			name: "no title column",
+			data: csvOf("isbn", "9780134190440"),
+			err:  ErrInvalidHeader,
+		},
+		{
+			name: "header only",
+			data: csvOf("title,isbn"),
+			err:  ErrNoRows,
+		},
+		{
+			name: "unterminated quote",
+			data: csvOf("title", `"The Go Programming Language`),
+			err:  ErrInvalidCSV,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parse(tc.data)
+			require.Equal(t, tc.err, err)
+		})
+	}
+
+	records, err := parse(csvOf(
+		"title,authors,year_published,copies,barcodes",
+		"The Go Programming Language,Donovan; Kernighan (Editor),2015,3,31234000000016;31234000000024",
+		",Anonymous (narrator),MMXV,1,31234000000032;31234000000040",
+	))
+	require.Nil(t, err)
+	require.Len(t, records, 2)
+
+	require.Empty(t, records[0].errors)
+	require.Equal(t, []*book.BookAuthor{
+		{Name: "Donovan", Role: book.RoleAuthor},
+		{Name: "Kernighan", Role: book.RoleEditor},
+	}, records[0].book.Author)
+	require.Equal(t, 3, records[0].copies)
+	require.Equal(t, 2015, records[0].book.YearPublished)
+
+	require.Len(t, records[1].errors, 4)
+}
+
+func TestImportDryRun(t *testing.T) {
+	s := newTestingService()
+	s.bookService.On("ISBNExists", "9780262011532").Return(true, nil)
+	s.bookService.On("GetSubjectIDs", []string{"Alchemy"}).Return(nil, book.ErrUnknownSubject)
+
+	s.emptyCatalogue()
+
+	job, err := s.Import("librarian", csvOf(
+		"title,isbn,subjects",
+		"The Go Programming Language,9780134190440,Computer programming",
+		"The Go Programming Language,0-13-419044-0,",
+		"Structure and Interpretation of Computer Programs,9780262011532,",
+		"The Alchemist,,Alchemy",
+	), ModeAllOrNothing, true)
+	require.Nil(t, err)
+
+	require.Equal(t, JobValidated, job.Status)
+	require.Equal(t, 4, job.TotalRows)
+	require.Equal(t, 3, job.FailedRows)
+	require.Equal(t, RowValid, job.Rows[0].Status)
+	require.Equal(t, "ISBN 9780134190440 is duplicated in row 1", job.Rows[1].Errors)
+	require.Equal(t, "ISBN 9780262011532 is already in the catalogue", job.Rows[2].Errors)
+	require.Equal(t, `Unknown subject "Alchemy"`, job.Rows[3].Errors)
+
+	s.bookService.AssertNotCalled(t, "Create", mock.Anything)
+	s.importingRepository.AssertCalled(t, "Save", job)
+}
+
+func TestImportAllOrNothing(t *testing.T) {
+	t.Run("invalid row rejects the Job", func(t *testing.T) {
+		s := newTestingService()
+
+		s.emptyCatalogue()
+
+		job, err := s.Import("librarian", csvOf("title,call_number", "The Go Programming Language,QA76.73", ",QA76.6"), ModeAllOrNothing, false)
+		require.Nil(t, err)
+
+		require.Equal(t, JobRejected, job.Status)
+		require.Equal(t, RowSkipped, job.Rows[0].Status)
+		require.Equal(t, RowInvalid, job.Rows[1].Status)
+		s.bookService.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("failing row rolls back the imported rows", func(t *testing.T) {
+		s := newTestingService()
+
+		importedBook := &book.Book{ID: util.NewID()}
+		importedBookCopy := &bookcopy.BookCopy{ID: util.NewID()}
+		s.bookService.On("Create", mock.MatchedBy(func(b *book.Book) bool { return b.Title == "The Go Programming Language" })).Return(importedBook, nil)
+		s.bookService.On("Create", mock.MatchedBy(func(b *book.Book) bool { return b.Title == "SICP" })).Return(nil, book.ErrCreateBook)
+		s.bookCopyService.On("Create", mock.AnythingOfType("*bookcopy.BookCopy")).Return(importedBookCopy, nil)
+
+		s.emptyCatalogue()
+
+		job, err := s.Import("librarian", csvOf("title,copies", "The Go Programming Language,1", "SICP,1", "The Alchemist,1"), ModeAllOrNothing, false)
+		require.Nil(t, err)
+
+		require.Equal(t, JobRejected, job.Status)
+		require.Equal(t, 0, job.ImportedRows)
+		require.Equal(t, RowSkipped, job.Rows[0].Status)
+		require.Equal(t, RowFailed, job.Rows[1].Status)
+		require.Equal(t, book.ErrCreateBook.Error(), job.Rows[1].Errors)
+		require.Equal(t, RowSkipped, job.Rows[2].Status)
+
+		// The rows are imported in a single transaction, which is rolled back.
+		require.Equal(t, 0, s.tx.commits)
+		require.Equal(t, 1, s.tx.rollbacks)
+		s.importingRepository.AssertNumberOfCalls(t, "Begin", 1)
+		s.bookService.AssertNotCalled(t, "Delete", mock.Anything)
+		s.bookService.AssertNotCalled(t, "Create", mock.MatchedBy(func(b *book.Book) bool { return b.Title == "The Alchemist" }))
+	})
+}
+
+func TestImportBestEffort(t *testing.T) {
+	s := newTestingService()
+
+	importedBook := &book.Book{ID: util.NewID()}
+	mainBranchID := util.NewID()
+	s.bookService.On("Create", mock.AnythingOfType("*book.Book")).Return(importedBook, nil)
+	s.bookCopyService.On("Create", mock.AnythingOfType("*bookcopy.BookCopy")).Return(&bookcopy.BookCopy{ID: util.NewID()}, nil)
+	s.bookCopyService.On("ValidateBarcode", "1234", mainBranchID).Return(bookcopy.ErrInvalidBarcode)
+	s.branchService.On("ValidateLocation", mainBranchID, "").Return(nil)
+
+	s.emptyCatalogue()
+
+	job, err := s.Import("librarian", csvOf(
+		"title,copies,barcodes,home_branch_id",
+		"The Go Programming Language,3,31234000000016;31234000000024,"+mainBranchID,
+		"SICP,1,1234,"+mainBranchID,
+	), ModeBestEffort, false)
+	require.Nil(t, err)
+
+	require.Equal(t, JobPartiallyCommitted, job.Status)
+	require.Equal(t, 1, job.ImportedRows)
+	require.Equal(t, 1, job.FailedRows)
+	require.Equal(t, importedBook.ID, job.Rows[0].BookID)
+	require.Equal(t, 3, job.Rows[0].CopiesAdded)
+	require.Equal(t, "Barcode 1234: "+bookcopy.ErrInvalidBarcode.Error(), job.Rows[1].Errors)
+	require.Equal(t, 1, s.tx.commits)
+
+	// The copies without a barcode of their own get one generated.
+	s.bookCopyService.AssertCalled(t, "Create", mock.MatchedBy(func(c *bookcopy.BookCopy) bool {
+		return c.BookID == importedBook.ID && c.Barcode == "31234000000024" && c.HomeBranchID == mainBranchID
+	}))
+	s.bookCopyService.AssertCalled(t, "Create", mock.MatchedBy(func(c *bookcopy.BookCopy) bool { return c.Barcode == "" }))
+}
+
+func TestImportBestEffortFailingRow(t *testing.T) {
+	s := newTestingService()
+
+	importedBook := &book.Book{ID: util.NewID()}
+	s.bookService.On("Create", mock.MatchedBy(func(b *book.Book) bool { return b.Title == "SICP" })).Return(nil, book.ErrSaveBookAuthors)
+	s.bookService.On("Create", mock.AnythingOfType("*book.Book")).Return(importedBook, nil)
+
+	s.emptyCatalogue()
+
+	job, err := s.Import("librarian", csvOf("title", "SICP", "The Go Programming Language", "The Alchemist"), ModeBestEffort, false)
+	require.Nil(t, err)
+
+	require.Equal(t, JobPartiallyCommitted, job.Status)
+	require.Equal(t, RowFailed, job.Rows[0].Status)
+	require.Equal(t, RowImported, job.Rows[1].Status)
+	require.Equal(t, RowImported, job.Rows[2].Status)
+
+	// Each of the rows is imported in a transaction of its own.
+	s.importingRepository.AssertNumberOfCalls(t, "Begin", 3)
+	require.Equal(t, 2, s.tx.commits)
+	require.Equal(t, 1, s.tx.rollbacks)
+}
+
+func TestImportBeginFails(t *testing.T) {
+	s := newTestingService()
+	s.importingRepository.On("Begin").Return(nil, errors.New("connection refused"))
+
+	s.emptyCatalogue()
+
+	job, err := s.Import("librarian", csvOf("title", "SICP"), ModeAllOrNothing, false)
+	require.Nil(t, err)
+
+	require.Equal(t, JobRejected, job.Status)
+	require.Equal(t, RowFailed, job.Rows[0].Status)
+	require.Equal(t, ErrBeginImport.Error(), job.Rows[0].Errors)
+	s.bookService.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestImportInvalidMode(t *testing.T) {
+	s := newTestingService()
+
+	s.emptyCatalogue()
+
+	_, err := s.Import("librarian", csvOf("title", "SICP"), "some", false)
+	require.Equal(t, ErrInvalidMode, err)
+}
+
+func TestImportCheckDuplicateFails(t *testing.T) {
+	s := newTestingService()
+	s.bookService.On("ISBNExists", "9780134190440").Return(false, book.ErrCheckDuplicate)
+
+	s.emptyCatalogue()
+
+	_, err := s.Import("librarian", csvOf("isbn,title", "9780134190440,The Go Programming Language"), ModeBestEffort, false)
+	require.Equal(t, book.ErrCheckDuplicate, err)
+	s.importingRepository.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestReport(t *testing.T) {
+	s := newTestingService()
+
+	jobID := util.NewID()
+	bookID := util.NewID()
+	s.importingRepository.On("Get", jobID).Return(&Job{ID: jobID}, nil)
+	s.importingRepository.On("GetRows", jobID).Return([]*Row{
+		{JobID: jobID, Number: 1, Title: "The Go Programming Language", Status: RowImported, BookID: bookID, CopiesAdded: 2},
+		{JobID: jobID, Number: 2, Title: "SICP, 2nd edition", Status: RowInvalid, Errors: "Barcode 1234: " + bookcopy.ErrInvalidBarcode.Error()},
+	}, nil)
+
+	missingJobID := util.NewID()
+	s.importingRepository.On("Get", missingJobID).Return(nil, errors.New("sql: no rows in result set"))
+
+	s.emptyCatalogue()
+
+	report, err := s.Report(jobID)
+	require.Nil(t, err)
+	require.Equal(t, "row,title,isbn,call_number,status,book_id,copies_added,errors\n"+
+		"1,The Go Programming Language,,,imported,"+bookID+",2,\n"+
+		`2,"SICP, 2nd edition",,,invalid,,0,Barcode 1234: Barcode does not match the barcode scheme`+"\n", string(report))
+
+	_, err = s.Report(missingJobID)
+	require.Equal(t, ErrGetJob, err)
+}
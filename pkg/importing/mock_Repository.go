@@ -0,0 +1,105 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package importing
+
+import (
+	pkg "github.com/joshuabezaleel/library-server/pkg"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+// Begin provides a mock function with given fields:
+func (_m *MockRepository) Begin() (pkg.Transaction, error) {
+	ret := _m.Called()
+
+	var r0 pkg.Transaction
+	if rf, ok := ret.Get(0).(func() pkg.Transaction); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(pkg.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: jobID
+func (_m *MockRepository) Get(jobID string) (*Job, error) {
+	ret := _m.Called(jobID)
+
+	var r0 *Job
+	if rf, ok := ret.Get(0).(func(string) *Job); ok {
+		r0 = rf(jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRows provides a mock function with given fields: jobID
+func (_m *MockRepository) GetRows(jobID string) ([]*Row, error) {
+	ret := _m.Called(jobID)
+
+	var r0 []*Row
+	if rf, ok := ret.Get(0).(func(string) []*Row); ok {
+		r0 = rf(jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Row)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: job
+func (_m *MockRepository) Save(job *Job) (*Job, error) {
+	ret := _m.Called(job)
+
+	var r0 *Job
+	if rf, ok := ret.Get(0).(func(*Job) *Job); ok {
+		r0 = rf(job)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Job) error); ok {
+		r1 = rf(job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,79 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package importing
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: jobID
+func (_m *MockService) Get(jobID string) (*Job, error) {
+	ret := _m.Called(jobID)
+
+	var r0 *Job
+	if rf, ok := ret.Get(0).(func(string) *Job); ok {
+		r0 = rf(jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Import provides a mock function with given fields: username, data, mode, dryRun
+func (_m *MockService) Import(username string, data []byte, mode string, dryRun bool) (*Job, error) {
+	ret := _m.Called(username, data, mode, dryRun)
+
+	var r0 *Job
+	if rf, ok := ret.Get(0).(func(string, []byte, string, bool) *Job); ok {
+		r0 = rf(username, data, mode, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte, string, bool) error); ok {
+		r1 = rf(username, data, mode, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Report provides a mock function with given fields: jobID
+func (_m *MockService) Report(jobID string) ([]byte, error) {
+	ret := _m.Called(jobID)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(string) []byte); ok {
+		r0 = rf(jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
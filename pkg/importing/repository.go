@@ -0,0 +1,13 @@
+package importing
+
+import util "github.com/joshuabezaleel/library-server/pkg"
+
+// Repository provides access to the import Job store.
+type Repository interface {
+	Save(job *Job) (*Job, error)
+	Get(jobID string) (*Job, error)
+	GetRows(jobID string) ([]*Row, error)
+
+	// Begin begins a Transaction of the catalogue.
+	Begin() (util.Transaction, error)
+}
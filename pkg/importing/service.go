@@ -0,0 +1,351 @@
+package importing
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+	"github.com/joshuabezaleel/library-server/pkg/isbn"
+)
+
+// Errors definition.
+var (
+	ErrSaveJob       = errors.New("Error saving import Job")
+	ErrGetJob        = errors.New("Error retrieving import Job")
+	ErrGetRows       = errors.New("Error retrieving rows of import Job")
+	ErrWriteReport   = errors.New("Error writing report of import Job")
+	ErrInvalidMode   = errors.New("Import mode must be all-or-nothing or best-effort")
+	ErrInvalidCSV    = errors.New("Invalid CSV")
+	ErrInvalidHeader = errors.New("CSV header must name the title column and only known columns")
+	ErrNoRows        = errors.New("No rows to import")
+	ErrBeginImport   = errors.New("Error beginning the transaction of the import")
+	ErrCommitImport  = errors.New("Error committing the transaction of the import")
+)
+
+// Service provides bulk import of Books and their Copies into the catalogue.
+type Service interface {
+	Import(username string, data []byte, mode string, dryRun bool) (*Job, error)
+	Get(jobID string) (*Job, error)
+	Report(jobID string) ([]byte, error)
+}
+
+type service struct {
+	importingRepository Repository
+	bookService         book.Service
+	bookCopyService     bookcopy.Service
+	branchService       branch.Service
+}
+
+// NewImportingService creates an instance of the service for bulk imports
+// with all of the necessary dependencies.
+func NewImportingService(importingRepository Repository, bookService book.Service, bookCopyService bookcopy.Service, branchService branch.Service) Service {
+	return &service{
+		importingRepository: importingRepository,
+		bookService:         bookService,
+		bookCopyService:     bookCopyService,
+		branchService:       branchService,
+	}
+}
+
+// Import validates each of the rows of the CSV and, unless it is a dry run,
+// creates the Book and Copies of the valid ones. In the all-or-nothing mode
+// a single invalid row, or a row failing to be imported, rolls the whole
+// import back; in the best-effort mode the other rows are imported regardless.
+func (s *service) Import(username string, data []byte, mode string, dryRun bool) (*Job, error) {
+	if !IsValidMode(mode) {
+		return nil, ErrInvalidMode
+	}
+
+	records, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.validate(records)
+	if err != nil {
+		return nil, err
+	}
+
+	job := NewJob(util.NewID(), mode, dryRun, JobValidated, len(records), 0, 0, username, time.Now())
+
+	invalid := false
+	for _, record := range records {
+		row := &Row{
+			JobID:      job.ID,
+			Number:     record.number,
+			Title:      record.book.Title,
+			ISBN:       record.book.ISBN,
+			CallNumber: record.book.CallNumber,
+			Status:     RowValid,
+		}
+
+		if len(record.errors) > 0 {
+			row.Status = RowInvalid
+			row.Errors = strings.Join(record.errors, "; ")
+			invalid = true
+		}
+
+		job.Rows = append(job.Rows, row)
+	}
+
+	switch {
+	case dryRun:
+	case invalid && mode == ModeAllOrNothing:
+		job.Status = JobRejected
+		skipRows(job.Rows)
+	default:
+		s.commit(job, records)
+	}
+
+	for _, row := range job.Rows {
+		if row.Status == RowImported {
+			job.ImportedRows++
+		}
+		if row.IsFailed() {
+			job.FailedRows++
+		}
+	}
+
+	if !dryRun && job.Status != JobRejected {
+		switch job.ImportedRows {
+		case job.TotalRows:
+			job.Status = JobCommitted
+		case 0:
+			job.Status = JobRejected
+		default:
+			job.Status = JobPartiallyCommitted
+		}
+	}
+
+	job, err = s.importingRepository.Save(job)
+	if err != nil {
+		return nil, ErrSaveJob
+	}
+
+	return job, nil
+}
+
+func (s *service) Get(jobID string) (*Job, error) {
+	job, err := s.importingRepository.Get(jobID)
+	if err != nil {
+		return nil, ErrGetJob
+	}
+
+	job.Rows, err = s.importingRepository.GetRows(jobID)
+	if err != nil {
+		return nil, ErrGetRows
+	}
+
+	return job, nil
+}
+
+// Report retrieves the outcome of each of the rows of the Job as CSV.
+func (s *service) Report(jobID string) ([]byte, error) {
+	job, err := s.Get(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := writeReport(job.Rows)
+	if err != nil {
+		return nil, ErrWriteReport
+	}
+
+	return report, nil
+}
+
+// validate checks the records against each other and against the catalogue:
+// ISBNs, call numbers and barcodes must be new, subjects must be terms of the
+// subject vocabulary and the home of the Copies must be a Location of the Branch.
+func (s *service) validate(records []*record) error {
+	isbns := map[string]int{}
+	callNumbers := map[string]int{}
+	barcodes := map[string]int{}
+
+	for _, record := range records {
+		if record.book.ISBN != "" {
+			// ISBNs are compared in their canonical form, whichever form they are written in.
+			isbn13, err := isbn.Normalize(record.book.ISBN)
+			if err != nil {
+				record.addError("ISBN %s: %s", record.book.ISBN, book.ErrInvalidISBN.Error())
+			} else if number, ok := isbns[isbn13]; ok {
+				record.book.ISBN = isbn13
+				record.addError("ISBN %s is duplicated in row %d", isbn13, number)
+			} else {
+				record.book.ISBN = isbn13
+				isbns[isbn13] = record.number
+
+				exists, err := s.bookService.ISBNExists(isbn13)
+				if err != nil {
+					return err
+				}
+				if exists {
+					record.addError("ISBN %s is already in the catalogue", isbn13)
+				}
+			}
+		}
+
+		if callNumber := record.book.CallNumber; callNumber != "" {
+			if number, ok := callNumbers[callNumber]; ok {
+				record.addError("Call number %s is duplicated in row %d", callNumber, number)
+			} else {
+				callNumbers[callNumber] = record.number
+
+				exists, err := s.bookService.CallNumberExists(callNumber)
+				if err != nil {
+					return err
+				}
+				if exists {
+					record.addError("Call number %s is already in the catalogue", callNumber)
+				}
+			}
+		}
+
+		for _, subject := range record.book.Subject {
+			_, err := s.bookService.GetSubjectIDs([]string{subject})
+			if err == book.ErrUnknownSubject {
+				record.addError("Unknown subject %q", subject)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, barcode := range record.barcodes {
+			if number, ok := barcodes[barcode]; ok {
+				record.addError("Barcode %s is duplicated in row %d", barcode, number)
+				continue
+			}
+			barcodes[barcode] = record.number
+
+			if err := s.bookCopyService.ValidateBarcode(barcode, record.bookCopy.HomeBranchID); err != nil {
+				record.addError("Barcode %s: %s", barcode, err.Error())
+				continue
+			}
+
+			if _, err := s.bookCopyService.GetByBarcode(barcode); err == nil {
+				record.addError("Barcode %s is already in use", barcode)
+			}
+		}
+
+		bookCopy := record.bookCopy
+		if record.copies > 0 && (bookCopy.HomeBranchID != "" || bookCopy.HomeLocationID != "") {
+			if err := s.branchService.ValidateLocation(bookCopy.HomeBranchID, bookCopy.HomeLocationID); err != nil {
+				record.addError("Home of the copies: %s", err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+// commit imports the valid rows of the Job. In the all-or-nothing mode all of
+// the rows are imported in a single transaction, which is rolled back as soon
+// as one of them fails. In the best-effort mode each of the rows is imported in
+// a transaction of its own, so that a failing row leaves nothing of it behind,
+// such as the authors created for it, while the other rows are imported regardless.
+func (s *service) commit(job *Job, records []*record) {
+	if job.Mode == ModeAllOrNothing {
+		s.commitRows(job.Rows, records)
+		return
+	}
+
+	for i := range records {
+		if job.Rows[i].Status == RowValid {
+			s.commitRows(job.Rows[i:i+1], records[i:i+1])
+		}
+	}
+}
+
+// commitRows imports the valid rows in a single transaction. When one of them
+// fails, the transaction is rolled back and the other rows are skipped.
+func (s *service) commitRows(rows []*Row, records []*record) {
+	tx, err := s.importingRepository.Begin()
+	if err != nil {
+		failRows(rows, ErrBeginImport)
+		return
+	}
+
+	bookService := s.bookService.WithTransaction(tx)
+	bookCopyService := s.bookCopyService.WithTransaction(tx)
+
+	for i, record := range records {
+		row := rows[i]
+		if row.Status != RowValid {
+			continue
+		}
+
+		bookID, copiesAdded, err := importRecord(bookService, bookCopyService, record)
+		if err != nil {
+			tx.Rollback()
+
+			row.Status = RowFailed
+			row.Errors = err.Error()
+			skipRows(rows)
+			return
+		}
+
+		row.Status = RowImported
+		row.BookID = bookID
+		row.CopiesAdded = copiesAdded
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		failRows(rows, ErrCommitImport)
+	}
+}
+
+// importRecord creates the Book of the record and its Copies with the services
+// of the transaction of the import.
+func importRecord(bookService book.Service, bookCopyService bookcopy.Service, record *record) (string, int, error) {
+	createdBook, err := bookService.Create(record.book)
+	if err != nil {
+		return "", 0, err
+	}
+
+	for i := 0; i < record.copies; i++ {
+		bookCopy := *record.bookCopy
+		bookCopy.BookID = createdBook.ID
+		if i < len(record.barcodes) {
+			bookCopy.Barcode = record.barcodes[i]
+		}
+
+		_, err := bookCopyService.Create(&bookCopy)
+		if err != nil {
+			return "", 0, err
+		}
+	}
+
+	return createdBook.ID, record.copies, nil
+}
+
+// failRows marks the valid and imported Rows as failed with the error,
+// when their transaction could not be begun or committed.
+func failRows(rows []*Row, err error) {
+	for _, row := range rows {
+		if row.Status == RowValid || row.Status == RowImported {
+			row.Status = RowFailed
+			row.Errors = err.Error()
+			row.BookID = ""
+			row.CopiesAdded = 0
+		}
+	}
+}
+
+// skipRows marks the valid Rows, and the Rows whose import was rolled back,
+// as not imported.
+func skipRows(rows []*Row) {
+	for _, row := range rows {
+		if row.Status == RowValid || row.Status == RowImported {
+			row.Status = RowSkipped
+			row.BookID = ""
+			row.CopiesAdded = 0
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package isbn
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Errors definition.
+var (
+	ErrInvalidISBN = errors.New("ISBN must be a valid ISBN-10 or ISBN-13")
+	ErrNoISBN10    = errors.New("ISBN-13 has no ISBN-10 form unless it starts with 978")
+)
+
+// bookland is the prefix of the ISBN-13 of every ISBN-10.
+const bookland = "978"
+
+// Normalize parses an ISBN-10 or ISBN-13, written with or without
+// hyphens, spaces or an "ISBN" label, and returns its canonical
+// ISBN-13 of digits only.
+func Normalize(value string) (string, error) {
+	digits := clean(value)
+
+	switch len(digits) {
+	case 10:
+		if !IsValid10(digits) {
+			return "", ErrInvalidISBN
+		}
+		return To13(digits)
+	case 13:
+		if !IsValid13(digits) {
+			return "", ErrInvalidISBN
+		}
+		return digits, nil
+	}
+
+	return "", ErrInvalidISBN
+}
+
+// To13 converts an ISBN-10 to its ISBN-13.
+func To13(isbn10 string) (string, error) {
+	digits := clean(isbn10)
+	if !IsValid10(digits) {
+		return "", ErrInvalidISBN
+	}
+
+	payload := bookland + digits[:9]
+
+	return payload + strconv.Itoa(checkDigit13(payload)), nil
+}
+
+// To10 converts an ISBN-13 starting with 978 to its ISBN-10.
+func To10(isbn13 string) (string, error) {
+	digits := clean(isbn13)
+	if !IsValid13(digits) {
+		return "", ErrInvalidISBN
+	}
+
+	if !strings.HasPrefix(digits, bookland) {
+		return "", ErrNoISBN10
+	}
+
+	payload := digits[3:12]
+
+	return payload + checkDigit10(payload), nil
+}
+
+// IsValid10 returns whether the value is ten characters, nine digits
+// and a check digit which can be X, with a valid mod-11 checksum.
+func IsValid10(value string) bool {
+	if len(value) != 10 || !isDigits(value[:9]) {
+		return false
+	}
+
+	return checkDigit10(value[:9]) == strings.ToUpper(value[9:])
+}
+
+// IsValid13 returns whether the value is thirteen digits with a valid
+// mod-10 checksum, starting with one of the 978 and 979 prefixes.
+func IsValid13(value string) bool {
+	if len(value) != 13 || !isDigits(value) {
+		return false
+	}
+
+	if !strings.HasPrefix(value, "978") && !strings.HasPrefix(value, "979") {
+		return false
+	}
+
+	return strconv.Itoa(checkDigit13(value[:12])) == value[12:]
+}
+
+// checkDigit10 computes the check digit of the nine digits of an ISBN-10,
+// where 10 is written as X.
+func checkDigit10(payload string) string {
+	sum := 0
+	for i, digit := range payload {
+		sum += (10 - i) * int(digit-'0')
+	}
+
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return "X"
+	}
+
+	return strconv.Itoa(check)
+}
+
+// checkDigit13 computes the check digit of the twelve digits of an ISBN-13,
+// whose digits are weighted alternately by 1 and 3.
+func checkDigit13(payload string) int {
+	sum := 0
+	for i, digit := range payload {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += weight * int(digit-'0')
+	}
+
+	return (10 - sum%10) % 10
+}
+
+// clean strips the label, hyphens and spaces ISBNs are written with.
+func clean(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	for _, label := range []string{"ISBN-13", "ISBN-10", "ISBN"} {
+		if strings.HasPrefix(value, label) {
+			value = strings.TrimLeft(value[len(label):], ": ")
+			break
+		}
+	}
+
+	return strings.NewReplacer("-", "", " ", "").Replace(value)
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
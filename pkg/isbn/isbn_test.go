@@ -0,0 +1,102 @@
+package isbn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tt := []struct {
+		name  string
+		value string
+		isbn  string
+		err   error
+	}{
+		{
+			name:  "ISBN-13 with hyphens",
+			value: "978-0-13-468599-1",
+			isbn:  "9780134685991",
+			err:   nil,
+		},
+		{
+			name:  "ISBN-13 with a label",
+			value: "ISBN-13: 978 0 13 468599 1",
+			isbn:  "9780134685991",
+			err:   nil,
+		},
+		{
+			name:  "ISBN-10",
+			value: "0-262-01153-0",
+			isbn:  "9780262011532",
+			err:   nil,
+		},
+		{
+			name:  "ISBN-10 with check digit X",
+			value: "080442957x",
+			isbn:  "9780804429573",
+			err:   nil,
+		},
+		{
+			name:  "ISBN-13 starting with 979",
+			value: "979-10-90636-07-1",
+			isbn:  "9791090636071",
+			err:   nil,
+		},
+		{
+			name:  "wrong ISBN-13 check digit",
+			value: "9780134685990",
+			err:   ErrInvalidISBN,
+		},
+		{
+			name:  "wrong ISBN-10 check digit",
+			value: "0262011531",
+			err:   ErrInvalidISBN,
+		},
+		{
+			name:  "ISBN-13 without a Bookland prefix",
+			value: "1234567890128",
+			err:   ErrInvalidISBN,
+		},
+		{
+			name:  "wrong length",
+			value: "97801346859",
+			err:   ErrInvalidISBN,
+		},
+		{
+			name:  "not an ISBN",
+			value: "isbn1",
+			err:   ErrInvalidISBN,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			isbn, err := Normalize(tc.value)
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.isbn, isbn)
+		})
+	}
+}
+
+func TestTo10(t *testing.T) {
+	isbn10, err := To10("978-0-262-01153-2")
+	require.Nil(t, err)
+	require.Equal(t, "0262011530", isbn10)
+
+	isbn10, err = To10("9780804429573")
+	require.Nil(t, err)
+	require.Equal(t, "080442957X", isbn10)
+
+	_, err = To10("9791090636071")
+	require.Equal(t, ErrNoISBN10, err)
+}
+
+func TestTo13(t *testing.T) {
+	isbn13, err := To13("0134685997")
+	require.Nil(t, err)
+	require.Equal(t, "9780134685991", isbn13)
+
+	_, err = To13("0134685998")
+	require.Equal(t, ErrInvalidISBN, err)
+}
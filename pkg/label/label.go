@@ -0,0 +1,62 @@
+package label
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Label is what is printed for a Book Copy: its call number
+// split onto spine lines, the title of its Book and its barcode.
+type Label struct {
+	SpineLines []string `json:"spineLines"`
+	Title      string   `json:"title"`
+	Barcode    string   `json:"barcode"`
+}
+
+// NewLabel creates a new instance of Label with the call number split onto spine lines.
+func NewLabel(callNumber string, title string, barcode string) *Label {
+	return &Label{
+		SpineLines: SpineLines(callNumber),
+		Title:      title,
+		Barcode:    barcode,
+	}
+}
+
+// SpineLines splits a call number the way it is read on the spine, one part per line.
+// The class letters of an LC call number are separated from its class number and
+// each cutter starts a new line, so QA76.73.G63 D66 2016 becomes QA, 76.73, .G63,
+// D66 and 2016.
+func SpineLines(callNumber string) []string {
+	lines := []string{}
+
+	for i, field := range strings.Fields(callNumber) {
+		if i == 0 {
+			letters := strings.IndexFunc(field, func(r rune) bool {
+				return !unicode.IsLetter(r)
+			})
+			if letters > 0 && unicode.IsDigit(rune(field[letters])) {
+				lines = append(lines, field[:letters])
+				field = field[letters:]
+			}
+		}
+
+		lines = append(lines, splitCutters(field)...)
+	}
+
+	return lines
+}
+
+// splitCutters splits the field before every period that is followed by a letter.
+func splitCutters(field string) []string {
+	parts := []string{}
+
+	start := 0
+	for i := 1; i < len(field)-1; i++ {
+		if field[i] == '.' && unicode.IsLetter(rune(field[i+1])) {
+			parts = append(parts, field[start:i])
+			start = i
+		}
+	}
+
+	return append(parts, field[start:])
+}
@@ -0,0 +1,177 @@
+package label
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+)
+
+var bookCopyService = &bookcopy.MockService{}
+var bookService = &book.MockService{}
+
+var labelService = NewLabelService(bookCopyService, bookService)
+
+func newBookCopy() *bookcopy.BookCopy {
+	bookCopyBook := &book.Book{
+		ID:         util.NewID(),
+		Title:      "The Go Programming Language",
+		CallNumber: "QA76.73.G63 D66 2016",
+	}
+	bookService.On("Get", bookCopyBook.ID).Return(bookCopyBook, nil)
+
+	bookCopy := &bookcopy.BookCopy{
+		ID:      util.NewID(),
+		BookID:  bookCopyBook.ID,
+		Barcode: "31234000000016",
+	}
+	bookCopyService.On("Get", bookCopy.ID).Return(bookCopy, nil)
+
+	return bookCopy
+}
+
+func TestSpineLines(t *testing.T) {
+	tt := []struct {
+		name       string
+		callNumber string
+		lines      []string
+	}{
+		{
+			name:       "LC call number",
+			callNumber: "QA76.73.G63 D66 2016",
+			lines:      []string{"QA", "76.73", ".G63", "D66", "2016"},
+		},
+		{
+			name:       "LC call number with two cutters in one field",
+			callNumber: "PS3545.I345Z5.B3 1990",
+			lines:      []string{"PS", "3545", ".I345Z5", ".B3", "1990"},
+		},
+		{
+			name:       "Dewey call number",
+			callNumber: "005.133 KNU",
+			lines:      []string{"005.133", "KNU"},
+		},
+		{
+			name:       "empty call number",
+			callNumber: "",
+			lines:      []string{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.lines, SpineLines(tc.callNumber))
+		})
+	}
+}
+
+func TestGetLayout(t *testing.T) {
+	layout, err := GetLayout("")
+	require.Nil(t, err)
+	require.Equal(t, DefaultLayout, layout.Name)
+	require.Equal(t, 30, layout.PerPage())
+
+	_, err = GetLayout("avery-0000")
+	require.Equal(t, ErrUnknownLayout, err)
+
+	// Every label of every layout fits on its page.
+	for _, layout := range Layouts() {
+		right := layout.LeftMargin + float64(layout.Columns-1)*layout.HorizontalPitch + layout.LabelWidth
+		bottom := layout.TopMargin + float64(layout.Rows-1)*layout.VerticalPitch + layout.LabelHeight
+
+		require.True(t, right <= layout.PageWidth, layout.Name)
+		require.True(t, bottom <= layout.PageHeight, layout.Name)
+	}
+}
+
+func TestSheet(t *testing.T) {
+	layout, _ := GetLayout("avery-5163")
+
+	labels := []*Label{}
+	for i := 0; i < layout.PerPage(); i++ {
+		labels = append(labels, NewLabel("QA76.73.G63 D66 2016", "Programming (2nd ed.)", "31234000000016"))
+	}
+
+	// Skipping labels pushes the last ones onto a second page.
+	sheet, err := Sheet(layout, labels, 3)
+	require.Nil(t, err)
+	require.True(t, bytes.HasPrefix(sheet, []byte("%PDF-1.4")))
+	require.True(t, bytes.HasSuffix(sheet, []byte("%%EOF\n")))
+	require.Contains(t, string(sheet), "/Count 2")
+	require.Contains(t, string(sheet), `(Programming \(2nd ed.\)) Tj`)
+	require.Contains(t, string(sheet), "(.G63) Tj")
+
+	// Barcodes Codabar can not encode.
+	_, err = Sheet(layout, []*Label{NewLabel("QA76", "Title", "3123A")}, 0)
+	require.NotNil(t, err)
+}
+
+func TestLabelSheet(t *testing.T) {
+	bookCopy := newBookCopy()
+	anotherBookCopy := newBookCopy()
+
+	addedFrom := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	addedTo := time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC)
+	bookCopyService.On("ListAddedBetween", addedFrom, addedTo).Return([]*bookcopy.BookCopy{bookCopy, anotherBookCopy}, nil)
+	bookCopyService.On("ListAddedBetween", addedTo, mock.AnythingOfType("time.Time")).Return([]*bookcopy.BookCopy{}, nil)
+
+	tt := []struct {
+		name    string
+		request *Request
+		err     error
+	}{
+		{
+			name:    "labels of Book Copies by ID",
+			request: &Request{BookCopyIDs: []string{bookCopy.ID, anotherBookCopy.ID}},
+			err:     nil,
+		},
+		{
+			name:    "labels of Book Copies added in a date range",
+			request: &Request{AddedFrom: addedFrom, AddedTo: addedTo, Layout: "avery-l7160"},
+			err:     nil,
+		},
+		{
+			name:    "no Book Copies added since the start of the date range",
+			request: &Request{AddedFrom: addedTo},
+			err:     ErrNoBookCopies,
+		},
+		{
+			name:    "neither Book Copy IDs nor a date range",
+			request: &Request{},
+			err:     ErrNoSelection,
+		},
+		{
+			name:    "date range ending before it starts",
+			request: &Request{AddedFrom: addedTo, AddedTo: addedFrom},
+			err:     ErrInvalidDateRange,
+		},
+		{
+			name:    "unknown layout",
+			request: &Request{BookCopyIDs: []string{bookCopy.ID}, Layout: "avery-0000"},
+			err:     ErrUnknownLayout,
+		},
+		{
+			name:    "skipping a whole sheet",
+			request: &Request{BookCopyIDs: []string{bookCopy.ID}, Skip: 30},
+			err:     ErrInvalidSkip,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			sheet, err := labelService.Sheet(tc.request)
+
+			require.Equal(t, tc.err, err)
+
+			if tc.err == nil {
+				require.True(t, bytes.HasPrefix(sheet, []byte("%PDF-1.4")))
+			}
+		})
+	}
+}
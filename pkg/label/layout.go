@@ -0,0 +1,111 @@
+package label
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrUnknownLayout is returned for a label sheet layout that is not supported.
+var ErrUnknownLayout = errors.New("Unknown label sheet layout")
+
+// DefaultLayout is the layout used when none is requested.
+const DefaultLayout = "avery-5160"
+
+// Layout describes a sheet of labels. All of the dimensions are in
+// points (1/72 inch), measured from the top left corner of the page.
+// The pitch is the distance from the start of a label to the start of the next one.
+type Layout struct {
+	Name            string  `json:"name"`
+	PageWidth       float64 `json:"pageWidth"`
+	PageHeight      float64 `json:"pageHeight"`
+	TopMargin       float64 `json:"topMargin"`
+	LeftMargin      float64 `json:"leftMargin"`
+	LabelWidth      float64 `json:"labelWidth"`
+	LabelHeight     float64 `json:"labelHeight"`
+	HorizontalPitch float64 `json:"horizontalPitch"`
+	VerticalPitch   float64 `json:"verticalPitch"`
+	Columns         int     `json:"columns"`
+	Rows            int     `json:"rows"`
+}
+
+// PerPage returns the number of labels on a sheet.
+func (layout *Layout) PerPage() int {
+	return layout.Columns * layout.Rows
+}
+
+// layouts holds the supported label sheets.
+var layouts = map[string]*Layout{
+	// 1" x 2-5/8" labels, 30 per US Letter sheet.
+	"avery-5160": {
+		Name:            "avery-5160",
+		PageWidth:       612,
+		PageHeight:      792,
+		TopMargin:       36,
+		LeftMargin:      13.5,
+		LabelWidth:      189,
+		LabelHeight:     72,
+		HorizontalPitch: 198,
+		VerticalPitch:   72,
+		Columns:         3,
+		Rows:            10,
+	},
+	// 2" x 4" labels, 10 per US Letter sheet.
+	"avery-5163": {
+		Name:            "avery-5163",
+		PageWidth:       612,
+		PageHeight:      792,
+		TopMargin:       36,
+		LeftMargin:      11.25,
+		LabelWidth:      288,
+		LabelHeight:     144,
+		HorizontalPitch: 297,
+		VerticalPitch:   144,
+		Columns:         2,
+		Rows:            5,
+	},
+	// 63.5mm x 38.1mm labels, 21 per A4 sheet.
+	"avery-l7160": {
+		Name:            "avery-l7160",
+		PageWidth:       595.28,
+		PageHeight:      841.89,
+		TopMargin:       42.8,
+		LeftMargin:      20.4,
+		LabelWidth:      180,
+		LabelHeight:     108,
+		HorizontalPitch: 187.1,
+		VerticalPitch:   108,
+		Columns:         3,
+		Rows:            7,
+	},
+}
+
+// GetLayout returns the label sheet layout with the name,
+// or the default layout when the name is empty.
+func GetLayout(name string) (*Layout, error) {
+	if name == "" {
+		name = DefaultLayout
+	}
+
+	layout, ok := layouts[name]
+	if !ok {
+		return nil, ErrUnknownLayout
+	}
+
+	return layout, nil
+}
+
+// Layouts returns the supported label sheet layouts sorted by name.
+func Layouts() []*Layout {
+	names := make([]string, 0, len(layouts))
+	for name := range layouts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sortedLayouts := make([]*Layout, 0, len(names))
+	for _, name := range names {
+		sortedLayouts = append(sortedLayouts, layouts[name])
+	}
+
+	return sortedLayouts
+}
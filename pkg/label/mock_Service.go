@@ -0,0 +1,33 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package label
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+// Sheet provides a mock function with given fields: request
+func (_m *MockService) Sheet(request *Request) ([]byte, error) {
+	ret := _m.Called(request)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(*Request) []byte); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Request) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,103 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// document is a minimal PDF writer for label sheets. It only draws filled
+// rectangles and single lines of text in Helvetica, which every PDF viewer
+// provides, so that no font has to be embedded.
+type document struct {
+	width  float64
+	height float64
+	pages  []*bytes.Buffer
+}
+
+func newDocument(width float64, height float64) *document {
+	return &document{
+		width:  width,
+		height: height,
+	}
+}
+
+// addPage starts a new page, which the following drawing goes to.
+func (doc *document) addPage() {
+	doc.pages = append(doc.pages, &bytes.Buffer{})
+}
+
+func (doc *document) page() *bytes.Buffer {
+	return doc.pages[len(doc.pages)-1]
+}
+
+// rect fills the rectangle in black. The coordinates are PDF coordinates,
+// with the origin at the bottom left corner of the page.
+func (doc *document) rect(x float64, y float64, width float64, height float64) {
+	fmt.Fprintf(doc.page(), "%.2f %.2f %.2f %.2f re f\n", x, y, width, height)
+}
+
+// text writes the text with its baseline starting at x and y.
+func (doc *document) text(x float64, y float64, size float64, text string) {
+	fmt.Fprintf(doc.page(), "BT /F1 %.1f Tf %.2f %.2f Td (%s) Tj ET\n", size, x, y, escapeText(text))
+}
+
+// bytes writes out the document with its cross-reference table.
+func (doc *document) bytes() []byte {
+	var buffer bytes.Buffer
+	offsets := []int{}
+
+	object := func(body string) {
+		offsets = append(offsets, buffer.Len())
+		fmt.Fprintf(&buffer, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buffer.WriteString("%PDF-1.4\n")
+
+	// The catalog, the page tree and the font come first,
+	// followed by a page and its content stream for each page.
+	kids := []string{}
+	for i := range doc.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 4+2*i))
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(doc.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range doc.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", doc.width, doc.height, 5+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := buffer.Len()
+	fmt.Fprintf(&buffer, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buffer, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buffer, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buffer.Bytes()
+}
+
+// escapeText escapes the text for a PDF string in WinAnsiEncoding,
+// replacing the characters the encoding does not have.
+func escapeText(text string) string {
+	var builder strings.Builder
+
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			builder.WriteRune('\\')
+			builder.WriteRune(r)
+		case r >= 0x20 && r <= 0x7e:
+			builder.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&builder, "\\%03o", r)
+		default:
+			builder.WriteRune('?')
+		}
+	}
+
+	return builder.String()
+}
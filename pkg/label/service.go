@@ -0,0 +1,120 @@
+package label
+
+import (
+	"errors"
+	"time"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+)
+
+// Errors definition.
+var (
+	ErrNoSelection      = errors.New("Labels need either Book Copy IDs or a range of dates the Book Copies were added")
+	ErrInvalidDateRange = errors.New("The end of the date range must not be before its start")
+	ErrInvalidSkip      = errors.New("The number of labels to skip must be less than the labels on a sheet")
+	ErrNoBookCopies     = errors.New("No Book Copies to print labels for")
+)
+
+// Request selects the Book Copies to print labels for, either by their IDs or by
+// the range of dates they were added, and the sheet to print them on.
+// Skip is the number of labels already used on the first sheet.
+type Request struct {
+	BookCopyIDs []string  `json:"bookCopyIDs"`
+	AddedFrom   time.Time `json:"addedFrom"`
+	AddedTo     time.Time `json:"addedTo"`
+	Layout      string    `json:"layout"`
+	Skip        int       `json:"skip"`
+}
+
+// Service provides printable label sheets for Book Copies.
+type Service interface {
+	Sheet(request *Request) ([]byte, error)
+}
+
+type service struct {
+	bookCopyService bookcopy.Service
+	bookService     book.Service
+}
+
+// NewLabelService creates an instance of the service for label sheets
+// with all of the necessary dependencies.
+func NewLabelService(bookCopyService bookcopy.Service, bookService book.Service) Service {
+	return &service{
+		bookCopyService: bookCopyService,
+		bookService:     bookService,
+	}
+}
+
+// Sheet renders the labels of the Book Copies of the request as a PDF.
+// A date range without an end runs until now.
+func (s *service) Sheet(request *Request) ([]byte, error) {
+	layout, err := GetLayout(request.Layout)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Skip < 0 || request.Skip >= layout.PerPage() {
+		return nil, ErrInvalidSkip
+	}
+
+	bookCopies, err := s.getBookCopies(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bookCopies) == 0 {
+		return nil, ErrNoBookCopies
+	}
+
+	labels := []*Label{}
+	books := map[string]*book.Book{}
+
+	for _, bookCopy := range bookCopies {
+		bookCopyBook, ok := books[bookCopy.BookID]
+		if !ok {
+			bookCopyBook, err = s.bookService.Get(bookCopy.BookID)
+			if err != nil {
+				return nil, err
+			}
+
+			books[bookCopy.BookID] = bookCopyBook
+		}
+
+		labels = append(labels, NewLabel(bookCopyBook.CallNumber, bookCopyBook.Title, bookCopy.Barcode))
+	}
+
+	return Sheet(layout, labels, request.Skip)
+}
+
+func (s *service) getBookCopies(request *Request) ([]*bookcopy.BookCopy, error) {
+	if len(request.BookCopyIDs) > 0 {
+		bookCopies := []*bookcopy.BookCopy{}
+
+		for _, bookCopyID := range request.BookCopyIDs {
+			bookCopy, err := s.bookCopyService.Get(bookCopyID)
+			if err != nil {
+				return nil, err
+			}
+
+			bookCopies = append(bookCopies, bookCopy)
+		}
+
+		return bookCopies, nil
+	}
+
+	if request.AddedFrom.IsZero() {
+		return nil, ErrNoSelection
+	}
+
+	addedTo := request.AddedTo
+	if addedTo.IsZero() {
+		addedTo = time.Now()
+	}
+
+	if addedTo.Before(request.AddedFrom) {
+		return nil, ErrInvalidDateRange
+	}
+
+	return s.bookCopyService.ListAddedBetween(request.AddedFrom, addedTo)
+}
@@ -0,0 +1,106 @@
+package label
+
+import (
+	"github.com/joshuabezaleel/library-server/pkg/barcode"
+)
+
+// Dimensions of the content of a label, in points.
+const (
+	padding        = 4
+	spineRatio     = 0.25
+	maxSpineSize   = 9
+	titleSize      = 7
+	barcodeSize    = 6
+	charWidthRatio = 0.55
+)
+
+// Sheet renders the labels as a PDF in the layout, starting after the number
+// of positions to skip so that partly used sheets can be printed on again.
+func Sheet(layout *Layout, labels []*Label, skip int) ([]byte, error) {
+	doc := newDocument(layout.PageWidth, layout.PageHeight)
+	doc.addPage()
+
+	for i, label := range labels {
+		position := (skip + i) % layout.PerPage()
+		if position == 0 && skip+i > 0 {
+			doc.addPage()
+		}
+
+		column := position % layout.Columns
+		row := position / layout.Columns
+
+		x := layout.LeftMargin + float64(column)*layout.HorizontalPitch
+		y := layout.PageHeight - layout.TopMargin - float64(row)*layout.VerticalPitch - layout.LabelHeight
+
+		err := drawLabel(doc, label, x, y, layout.LabelWidth, layout.LabelHeight)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return doc.bytes(), nil
+}
+
+// drawLabel draws the spine lines on the left of the label, and the title
+// above the barcode and its text on the right. x and y are the bottom left corner.
+func drawLabel(doc *document, label *Label, x float64, y float64, width float64, height float64) error {
+	modules, err := barcode.EncodeCodabar(label.Barcode)
+	if err != nil {
+		return err
+	}
+
+	spineWidth := width * spineRatio
+	if len(label.SpineLines) > 0 {
+		size := (height - 2*padding) / float64(len(label.SpineLines)) / 1.2
+		if size > maxSpineSize {
+			size = maxSpineSize
+		}
+
+		for i, line := range label.SpineLines {
+			doc.text(x+padding, y+height-padding-float64(i+1)*size*1.2, size, fit(line, spineWidth-padding, size))
+		}
+	}
+
+	contentX := x + spineWidth
+	contentWidth := width - spineWidth - padding
+
+	doc.text(contentX, y+height-padding-titleSize, titleSize, fit(label.Title, contentWidth, titleSize))
+
+	barsBottom := y + padding + barcodeSize + 2
+	barsHeight := height - 2*padding - titleSize - barcodeSize - 6
+	moduleWidth := contentWidth / float64(len(modules))
+
+	// Adjacent bars are drawn as one wider bar.
+	for i := 0; i < len(modules); i++ {
+		if !modules[i] {
+			continue
+		}
+
+		start := i
+		for i+1 < len(modules) && modules[i+1] {
+			i++
+		}
+
+		doc.rect(contentX+float64(start)*moduleWidth, barsBottom, float64(i-start+1)*moduleWidth, barsHeight)
+	}
+
+	doc.text(contentX, y+padding, barcodeSize, label.Barcode)
+
+	return nil
+}
+
+// fit truncates the text to about the width at the font size,
+// estimating the width of the characters of Helvetica.
+func fit(text string, width float64, size float64) string {
+	runes := []rune(text)
+	maxChars := int(width / (size * charWidthRatio))
+
+	if len(runes) <= maxChars {
+		return text
+	}
+	if maxChars <= 3 {
+		return string(runes[:maxChars])
+	}
+
+	return string(runes[:maxChars-3]) + "..."
+}
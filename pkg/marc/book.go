@@ -0,0 +1,197 @@
+package marc
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+)
+
+// bookLeader is the leader of exported records: a new record of
+// a language material monograph with ISBD punctuation.
+const bookLeader = "     nam a22     7i 4500"
+
+// relatorRoles maps the relator terms ($e) and codes ($4) of added
+// entries to the roles an Author can have in a Book.
+var relatorRoles = map[string]string{
+	"author":      book.RoleAuthor,
+	"aut":         book.RoleAuthor,
+	"editor":      book.RoleEditor,
+	"edt":         book.RoleEditor,
+	"translator":  book.RoleTranslator,
+	"trl":         book.RoleTranslator,
+	"illustrator": book.RoleIllustrator,
+	"ill":         book.RoleIllustrator,
+}
+
+var (
+	yearPattern    = regexp.MustCompile(`\d{4}`)
+	numberPattern  = regexp.MustCompile(`^\d+`)
+	classesPattern = regexp.MustCompile(`^[A-Z]+`)
+)
+
+// ToBook maps the fields of a MARC21 bibliographic record onto a Book,
+// with its authors referenced by name and its subjects by term.
+func ToBook(record *Record) *book.Book {
+	newBook := &book.Book{
+		Subject: []string{},
+		Author:  []*book.BookAuthor{},
+	}
+
+	if field := record.Field("020"); field != nil {
+		// The ISBN can be followed by a qualifier, as in "9780134190440 (pbk.)".
+		isbn := strings.Fields(field.Subfield("a"))
+		if len(isbn) > 0 {
+			newBook.ISBN = isbn[0]
+		}
+	}
+
+	if field := record.Field("050"); field != nil {
+		newBook.CallNumber = strings.Join(field.SubfieldValues("ab"), " ")
+
+		classes := classesPattern.FindString(field.Subfield("a"))
+		if len(classes) > 2 {
+			classes = classes[:2]
+		}
+		newBook.LOCClassification = classes
+	}
+
+	if field := record.Field("100"); field != nil {
+		newBook.Author = append(newBook.Author, &book.BookAuthor{Name: trimPunctuation(field.Subfield("a")), Role: book.RoleAuthor})
+	}
+
+	for _, field := range record.Fields("700") {
+		role := book.RoleAuthor
+		for _, relator := range field.SubfieldValues("e4") {
+			if relatorRole, ok := relatorRoles[strings.ToLower(strings.Trim(relator, " .,"))]; ok {
+				role = relatorRole
+				break
+			}
+		}
+
+		newBook.Author = append(newBook.Author, &book.BookAuthor{Name: trimPunctuation(field.Subfield("a")), Role: role})
+	}
+
+	if field := record.Field("245"); field != nil {
+		newBook.Title = trimPunctuation(field.Subfield("a"))
+		if subtitle := trimPunctuation(field.Subfield("b")); subtitle != "" {
+			newBook.Title += ": " + subtitle
+		}
+	}
+
+	if field := record.Field("250"); field != nil {
+		newBook.Edition, _ = strconv.Atoi(numberPattern.FindString(field.Subfield("a")))
+	}
+
+	// The publication statement (264 with the second indicator 1)
+	// superseded 260 in RDA records.
+	publication := record.Field("260")
+	for _, field := range record.Fields("264") {
+		if field.Ind2 == "1" {
+			publication = field
+			break
+		}
+	}
+
+	if publication != nil {
+		newBook.Publisher = trimPunctuation(publication.Subfield("b"))
+		newBook.YearPublished, _ = strconv.Atoi(yearPattern.FindString(publication.Subfield("c")))
+	}
+
+	if field := record.Field("300"); field != nil {
+		newBook.Collation = trimPunctuation(strings.Join(field.SubfieldValues("abc"), " "))
+	}
+
+	if field := record.Field("520"); field != nil {
+		newBook.Description = field.Subfield("a")
+	}
+
+	for _, field := range record.Fields("650") {
+		if subject := trimPunctuation(field.Subfield("a")); subject != "" {
+			newBook.Subject = append(newBook.Subject, subject)
+		}
+	}
+
+	return newBook
+}
+
+// FromBook maps a Book onto a MARC21 bibliographic record. The first
+// of its authors in the author role is the main entry, the others
+// are added entries along with their role.
+func FromBook(exportedBook *book.Book) *Record {
+	record := &Record{Leader: bookLeader}
+	record.AddControlField("001", exportedBook.ID)
+
+	record.AddDataField("020", " ", " ", "a", exportedBook.ISBN)
+
+	callNumber := strings.SplitN(exportedBook.CallNumber, " ", 2)
+	if len(callNumber) == 2 {
+		record.AddDataField("050", " ", "4", "a", callNumber[0], "b", callNumber[1])
+	} else {
+		record.AddDataField("050", " ", "4", "a", callNumber[0])
+	}
+
+	mainEntry := -1
+	for i, author := range exportedBook.Author {
+		if author.Role == book.RoleAuthor || author.Role == "" {
+			mainEntry = i
+			record.AddDataField("100", "1", " ", "a", author.Name, "e", book.RoleAuthor)
+			break
+		}
+	}
+
+	title := strings.SplitN(exportedBook.Title, ": ", 2)
+	if len(title) == 2 {
+		record.AddDataField("245", "1", "0", "a", title[0], "b", title[1])
+	} else {
+		record.AddDataField("245", "1", "0", "a", title[0])
+	}
+
+	if exportedBook.Edition > 0 {
+		record.AddDataField("250", " ", " ", "a", ordinal(exportedBook.Edition)+" ed.")
+	}
+
+	year := ""
+	if exportedBook.YearPublished > 0 {
+		year = strconv.Itoa(exportedBook.YearPublished)
+	}
+	record.AddDataField("264", " ", "1", "b", exportedBook.Publisher, "c", year)
+
+	record.AddDataField("300", " ", " ", "a", exportedBook.Collation)
+	record.AddDataField("520", " ", " ", "a", exportedBook.Description)
+
+	for _, subject := range exportedBook.Subject {
+		record.AddDataField("650", " ", "4", "a", subject)
+	}
+
+	for i, author := range exportedBook.Author {
+		if i != mainEntry {
+			record.AddDataField("700", "1", " ", "a", author.Name, "e", author.Role)
+		}
+	}
+
+	return record
+}
+
+// trimPunctuation removes the ISBD punctuation MARC21 subfields end with,
+// such as the " /" before a statement of responsibility.
+func trimPunctuation(value string) string {
+	return strings.TrimRight(strings.TrimSpace(value), " /:;,=")
+}
+
+func ordinal(number int) string {
+	suffix := "th"
+	switch {
+	case number%100 >= 11 && number%100 <= 13:
+	case number%10 == 1:
+		suffix = "st"
+	case number%10 == 2:
+		suffix = "nd"
+	case number%10 == 3:
+		suffix = "rd"
+	}
+
+	return fmt.Sprintf("%d%s", number, suffix)
+}
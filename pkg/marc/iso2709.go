@@ -0,0 +1,160 @@
+package marc
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// Delimiters of the ISO 2709 exchange format.
+const (
+	subfieldDelimiter = 0x1F
+	fieldTerminator   = 0x1E
+	recordTerminator  = 0x1D
+
+	leaderLength         = 24
+	directoryEntryLength = 12
+)
+
+// ReadISO2709 parses the records of ISO 2709 data, the binary format
+// MARC21 records are exchanged in.
+func ReadISO2709(data []byte) ([]*Record, error) {
+	records := []*Record{}
+
+	for _, recordData := range bytes.Split(data, []byte{recordTerminator}) {
+		// Files are often split into lines between records.
+		recordData = bytes.TrimLeft(recordData, "\r\n")
+		if len(bytes.TrimSpace(recordData)) == 0 {
+			continue
+		}
+
+		record, err := readISO2709Record(recordData)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func readISO2709Record(data []byte) (*Record, error) {
+	if len(data) < leaderLength {
+		return nil, ErrInvalidRecord
+	}
+
+	record := &Record{Leader: string(data[:leaderLength])}
+
+	baseAddress, err := strconv.Atoi(record.Leader[12:17])
+	if err != nil || baseAddress <= leaderLength || baseAddress > len(data) {
+		return nil, ErrInvalidRecord
+	}
+
+	// The directory ends with a field terminator right before the base address.
+	directory := data[leaderLength : baseAddress-1]
+	if len(directory)%directoryEntryLength != 0 {
+		return nil, ErrInvalidRecord
+	}
+
+	for i := 0; i < len(directory); i += directoryEntryLength {
+		entry := string(directory[i : i+directoryEntryLength])
+
+		length, err := strconv.Atoi(entry[3:7])
+		if err != nil {
+			return nil, ErrInvalidRecord
+		}
+
+		start, err := strconv.Atoi(entry[7:12])
+		if err != nil {
+			return nil, ErrInvalidRecord
+		}
+
+		if baseAddress+start+length > len(data) {
+			return nil, ErrInvalidRecord
+		}
+
+		tag := entry[:3]
+		field := bytes.TrimRight(data[baseAddress+start:baseAddress+start+length], string([]byte{fieldTerminator}))
+
+		if isControlTag(tag) {
+			record.AddControlField(tag, string(field))
+			continue
+		}
+
+		if len(field) < 2 {
+			return nil, ErrInvalidRecord
+		}
+
+		dataField := &DataField{Tag: tag, Ind1: string(field[0]), Ind2: string(field[1])}
+		for _, subfield := range bytes.Split(field[2:], []byte{subfieldDelimiter}) {
+			if len(subfield) == 0 {
+				continue
+			}
+
+			dataField.Subfields = append(dataField.Subfields, &Subfield{Code: string(subfield[0]), Value: string(subfield[1:])})
+		}
+
+		record.DataFields = append(record.DataFields, dataField)
+	}
+
+	return record, nil
+}
+
+// WriteISO2709 serializes the records into ISO 2709 data, with the
+// lengths and addresses of the leader and the directory computed anew.
+func WriteISO2709(records []*Record) []byte {
+	var data bytes.Buffer
+
+	for _, record := range records {
+		var directory, fields bytes.Buffer
+
+		addField := func(tag string, field []byte) {
+			field = append(field, fieldTerminator)
+			fmt.Fprintf(&directory, "%s%04d%05d", tag, len(field), fields.Len())
+			fields.Write(field)
+		}
+
+		for _, controlField := range record.ControlFields {
+			addField(controlField.Tag, []byte(controlField.Value))
+		}
+
+		for _, dataField := range record.DataFields {
+			field := []byte(indicator(dataField.Ind1) + indicator(dataField.Ind2))
+			for _, subfield := range dataField.Subfields {
+				field = append(field, subfieldDelimiter)
+				field = append(field, subfield.Code+subfield.Value...)
+			}
+
+			addField(dataField.Tag, field)
+		}
+		directory.WriteByte(fieldTerminator)
+
+		baseAddress := leaderLength + directory.Len()
+		recordLength := baseAddress + fields.Len() + 1
+
+		leader := []byte(fmt.Sprintf("%-24s", record.Leader)[:leaderLength])
+		copy(leader[0:5], fmt.Sprintf("%05d", recordLength))
+		// The record is encoded in UTF-8.
+		leader[9] = 'a'
+		copy(leader[10:12], "22")
+		copy(leader[12:17], fmt.Sprintf("%05d", baseAddress))
+		copy(leader[20:24], "4500")
+
+		data.Write(leader)
+		data.Write(directory.Bytes())
+		data.Write(fields.Bytes())
+		data.WriteByte(recordTerminator)
+	}
+
+	return data.Bytes()
+}
+
+// indicator returns the indicator, blank if it is not given.
+func indicator(value string) string {
+	if value == "" {
+		return " "
+	}
+
+	return value[:1]
+}
@@ -0,0 +1,159 @@
+package marc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+)
+
+var bookService = &book.MockService{}
+
+var marcService = NewMARCService(bookService)
+
+const marcXMLRecord = `<?xml version="1.0" encoding="UTF-8"?>
+<marc:collection xmlns:marc="http://www.loc.gov/MARC21/slim">
+  <marc:record>
+    <marc:leader>01142cam  2200301 i 4500</marc:leader>
+    <marc:controlfield tag="001">2015950709</marc:controlfield>
+    <marc:datafield tag="020" ind1=" " ind2=" ">
+      <marc:subfield code="a">9780134190440 (pbk.)</marc:subfield>
+    </marc:datafield>
+    <marc:datafield tag="050" ind1="0" ind2="0">
+      <marc:subfield code="a">QA76.73.G63</marc:subfield>
+      <marc:subfield code="b">D66 2016</marc:subfield>
+    </marc:datafield>
+    <marc:datafield tag="100" ind1="1" ind2=" ">
+      <marc:subfield code="a">Donovan, Alan A. A.,</marc:subfield>
+      <marc:subfield code="e">author.</marc:subfield>
+    </marc:datafield>
+    <marc:datafield tag="245" ind1="1" ind2="4">
+      <marc:subfield code="a">The Go programming language /</marc:subfield>
+      <marc:subfield code="c">Alan A.A. Donovan, Brian W. Kernighan.</marc:subfield>
+    </marc:datafield>
+    <marc:datafield tag="250" ind1=" " ind2=" ">
+      <marc:subfield code="a">1st ed.</marc:subfield>
+    </marc:datafield>
+    <marc:datafield tag="264" ind1=" " ind2="1">
+      <marc:subfield code="a">New York :</marc:subfield>
+      <marc:subfield code="b">Addison-Wesley,</marc:subfield>
+      <marc:subfield code="c">[2016]</marc:subfield>
+    </marc:datafield>
+    <marc:datafield tag="264" ind1=" " ind2="4">
+      <marc:subfield code="c">©2016</marc:subfield>
+    </marc:datafield>
+    <marc:datafield tag="300" ind1=" " ind2=" ">
+      <marc:subfield code="a">xvii, 380 pages :</marc:subfield>
+      <marc:subfield code="b">illustrations ;</marc:subfield>
+      <marc:subfield code="c">24 cm</marc:subfield>
+    </marc:datafield>
+    <marc:datafield tag="520" ind1=" " ind2=" ">
+      <marc:subfield code="a">The authoritative resource to writing clear and idiomatic Go.</marc:subfield>
+    </marc:datafield>
+    <marc:datafield tag="650" ind1=" " ind2="0">
+      <marc:subfield code="a">Go (Computer program language)</marc:subfield>
+    </marc:datafield>
+    <marc:datafield tag="650" ind1=" " ind2="0">
+      <marc:subfield code="a">Open source software.</marc:subfield>
+    </marc:datafield>
+    <marc:datafield tag="700" ind1="1" ind2=" ">
+      <marc:subfield code="a">Kernighan, Brian W.,</marc:subfield>
+      <marc:subfield code="4">edt</marc:subfield>
+    </marc:datafield>
+  </marc:record>
+</marc:collection>`
+
+func TestReadMARCXML(t *testing.T) {
+	records, err := Read([]byte(marcXMLRecord))
+	require.Nil(t, err)
+	require.Len(t, records, 1)
+
+	readBook := ToBook(records[0])
+
+	require.Equal(t, "9780134190440", readBook.ISBN)
+	require.Equal(t, "QA76.73.G63 D66 2016", readBook.CallNumber)
+	require.Equal(t, "QA", readBook.LOCClassification)
+	require.Equal(t, "The Go programming language", readBook.Title)
+	require.Equal(t, 1, readBook.Edition)
+	require.Equal(t, "Addison-Wesley", readBook.Publisher)
+	require.Equal(t, 2016, readBook.YearPublished)
+	require.Equal(t, "xvii, 380 pages : illustrations ; 24 cm", readBook.Collation)
+	require.Equal(t, []string{"Go (Computer program language)", "Open source software."}, readBook.Subject)
+	require.Equal(t, []*book.BookAuthor{
+		{Name: "Donovan, Alan A. A.", Role: book.RoleAuthor},
+		{Name: "Kernighan, Brian W.", Role: book.RoleEditor},
+	}, readBook.Author)
+}
+
+func TestISO2709RoundTrip(t *testing.T) {
+	exportedBook := &book.Book{
+		ID:            util.NewID(),
+		Title:         "Structure and Interpretation of Computer Programs: Second Edition",
+		Publisher:     "MIT Press",
+		YearPublished: 1996,
+		CallNumber:    "QA76.6 .A255 1996",
+		ISBN:          "0262011530",
+		Collation:     "xxiii, 657 p.",
+		Edition:       2,
+		Description:   "Über das Programmieren.",
+		Subject:       []string{"Computer programming", "LISP (Computer program language)"},
+		Author: []*book.BookAuthor{
+			{AuthorID: 1, Name: "Abelson, Harold", Role: book.RoleAuthor},
+			{AuthorID: 2, Name: "Sussman, Gerald Jay", Role: book.RoleAuthor},
+			{AuthorID: 3, Name: "Sussman, Julie", Role: book.RoleTranslator},
+		},
+	}
+
+	data, err := Write([]*Record{FromBook(exportedBook), FromBook(exportedBook)}, FormatISO2709)
+	require.Nil(t, err)
+
+	records, err := Read(data)
+	require.Nil(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, exportedBook.ID, records[1].ControlField("001"))
+
+	readBook := ToBook(records[0])
+
+	require.Equal(t, exportedBook.Title, readBook.Title)
+	require.Equal(t, exportedBook.Publisher, readBook.Publisher)
+	require.Equal(t, exportedBook.YearPublished, readBook.YearPublished)
+	require.Equal(t, exportedBook.CallNumber, readBook.CallNumber)
+	require.Equal(t, exportedBook.ISBN, readBook.ISBN)
+	require.Equal(t, exportedBook.Edition, readBook.Edition)
+	require.Equal(t, exportedBook.Description, readBook.Description)
+	require.Equal(t, exportedBook.Subject, readBook.Subject)
+	require.Len(t, readBook.Author, 3)
+	require.Equal(t, book.RoleTranslator, readBook.Author[2].Role)
+}
+
+func TestReadInvalidISO2709(t *testing.T) {
+	_, err := Read([]byte("00042nam a2200025 i 4500\x1d"))
+	require.Equal(t, ErrInvalidRecord, err)
+}
+
+func TestImport(t *testing.T) {
+	bookService.On("GetSubjectIDs", []string{"Go (Computer program language)"}).Return([]int64{1}, nil)
+	bookService.On("GetSubjectIDs", []string{"Open source software."}).Return(nil, book.ErrUnknownSubject)
+	bookService.On("Create", mock.MatchedBy(func(b *book.Book) bool { return b.ISBN == "9780134190440" })).Return(&book.Book{ID: util.NewID()}, nil)
+
+	results, err := marcService.Import([]byte(marcXMLRecord))
+	require.Nil(t, err)
+	require.Len(t, results, 1)
+	require.Empty(t, results[0].Error)
+	require.Equal(t, []string{"Open source software."}, results[0].SkippedSubjects)
+
+	bookService.AssertCalled(t, "Create", mock.MatchedBy(func(b *book.Book) bool {
+		return len(b.Subject) == 1 && b.Subject[0] == "Go (Computer program language)"
+	}))
+
+	_, err = marcService.Import([]byte("  "))
+	require.Equal(t, ErrNoRecords, err)
+}
+
+func TestExport(t *testing.T) {
+	_, err := marcService.Export(util.NewID(), "bibtex")
+	require.Equal(t, ErrUnknownFormat, err)
+}
@@ -0,0 +1,113 @@
+package marc
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+)
+
+// xmlCollection is the root of MARCXML documents, in the MARC21 slim namespace.
+type xmlCollection struct {
+	XMLName xml.Name     `xml:"http://www.loc.gov/MARC21/slim collection"`
+	Records []*xmlRecord `xml:"record"`
+}
+
+type xmlRecord struct {
+	XMLName       xml.Name           `xml:"record"`
+	Leader        string             `xml:"leader"`
+	ControlFields []*xmlControlField `xml:"controlfield"`
+	DataFields    []*xmlDataField    `xml:"datafield"`
+}
+
+type xmlControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlDataField struct {
+	Tag       string         `xml:"tag,attr"`
+	Ind1      string         `xml:"ind1,attr"`
+	Ind2      string         `xml:"ind2,attr"`
+	Subfields []*xmlSubfield `xml:"subfield"`
+}
+
+type xmlSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// ReadMARCXML parses the records of a MARCXML document, which is either
+// a collection of records or a single record.
+func ReadMARCXML(data []byte) ([]*Record, error) {
+	records := []*Record{}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidRecord
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "record" {
+			continue
+		}
+
+		marcXMLRecord := xmlRecord{}
+		err = decoder.DecodeElement(&marcXMLRecord, &start)
+		if err != nil {
+			return nil, ErrInvalidRecord
+		}
+
+		record := &Record{Leader: marcXMLRecord.Leader}
+		for _, controlField := range marcXMLRecord.ControlFields {
+			record.AddControlField(controlField.Tag, controlField.Value)
+		}
+
+		for _, marcXMLDataField := range marcXMLRecord.DataFields {
+			dataField := &DataField{Tag: marcXMLDataField.Tag, Ind1: marcXMLDataField.Ind1, Ind2: marcXMLDataField.Ind2}
+			for _, subfield := range marcXMLDataField.Subfields {
+				dataField.Subfields = append(dataField.Subfields, &Subfield{Code: subfield.Code, Value: subfield.Value})
+			}
+
+			record.DataFields = append(record.DataFields, dataField)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// WriteMARCXML serializes the records into a MARCXML collection.
+func WriteMARCXML(records []*Record) ([]byte, error) {
+	collection := xmlCollection{}
+
+	for _, record := range records {
+		marcXMLRecord := &xmlRecord{Leader: record.Leader}
+		for _, controlField := range record.ControlFields {
+			marcXMLRecord.ControlFields = append(marcXMLRecord.ControlFields, &xmlControlField{Tag: controlField.Tag, Value: controlField.Value})
+		}
+
+		for _, dataField := range record.DataFields {
+			marcXMLDataField := &xmlDataField{Tag: dataField.Tag, Ind1: indicator(dataField.Ind1), Ind2: indicator(dataField.Ind2)}
+			for _, subfield := range dataField.Subfields {
+				marcXMLDataField.Subfields = append(marcXMLDataField.Subfields, &xmlSubfield{Code: subfield.Code, Value: subfield.Value})
+			}
+
+			marcXMLRecord.DataFields = append(marcXMLRecord.DataFields, marcXMLDataField)
+		}
+
+		collection.Records = append(collection.Records, marcXMLRecord)
+	}
+
+	data, err := xml.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
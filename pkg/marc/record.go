@@ -0,0 +1,114 @@
+package marc
+
+import "strings"
+
+// Record is a MARC21 bibliographic record. Control fields (tags 001 to 009)
+// only have a value, data fields have two indicators and subfields.
+type Record struct {
+	Leader        string
+	ControlFields []*ControlField
+	DataFields    []*DataField
+}
+
+// ControlField is a MARC21 field without indicators nor subfields.
+type ControlField struct {
+	Tag   string
+	Value string
+}
+
+// DataField is a MARC21 field with two indicators and subfields.
+type DataField struct {
+	Tag       string
+	Ind1      string
+	Ind2      string
+	Subfields []*Subfield
+}
+
+// Subfield is a coded part of a data field.
+type Subfield struct {
+	Code  string
+	Value string
+}
+
+// isControlTag returns whether the tag is one of a control field.
+func isControlTag(tag string) bool {
+	return strings.HasPrefix(tag, "00")
+}
+
+// ControlField returns the value of the first control field with the tag.
+func (record *Record) ControlField(tag string) string {
+	for _, field := range record.ControlFields {
+		if field.Tag == tag {
+			return field.Value
+		}
+	}
+
+	return ""
+}
+
+// Fields returns all of the data fields with the tag, in the order of the record.
+func (record *Record) Fields(tag string) []*DataField {
+	fields := []*DataField{}
+	for _, field := range record.DataFields {
+		if field.Tag == tag {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// Field returns the first data field with the tag, or nil if there is none.
+func (record *Record) Field(tag string) *DataField {
+	for _, field := range record.DataFields {
+		if field.Tag == tag {
+			return field
+		}
+	}
+
+	return nil
+}
+
+// AddControlField appends a control field to the record.
+func (record *Record) AddControlField(tag string, value string) {
+	record.ControlFields = append(record.ControlFields, &ControlField{Tag: tag, Value: value})
+}
+
+// AddDataField appends a data field with the subfields given as pairs of
+// code and value, leaving out the subfields without a value.
+func (record *Record) AddDataField(tag string, ind1 string, ind2 string, subfields ...string) {
+	field := &DataField{Tag: tag, Ind1: ind1, Ind2: ind2}
+	for i := 0; i+1 < len(subfields); i += 2 {
+		if subfields[i+1] != "" {
+			field.Subfields = append(field.Subfields, &Subfield{Code: subfields[i], Value: subfields[i+1]})
+		}
+	}
+
+	if len(field.Subfields) > 0 {
+		record.DataFields = append(record.DataFields, field)
+	}
+}
+
+// Subfield returns the value of the first subfield with the code.
+func (field *DataField) Subfield(code string) string {
+	for _, subfield := range field.Subfields {
+		if subfield.Code == code {
+			return subfield.Value
+		}
+	}
+
+	return ""
+}
+
+// SubfieldValues returns the values of all of the subfields with any of the codes,
+// in the order of the field.
+func (field *DataField) SubfieldValues(codes string) []string {
+	values := []string{}
+	for _, subfield := range field.Subfields {
+		if strings.Contains(codes, subfield.Code) {
+			values = append(values, subfield.Value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,142 @@
+package marc
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+)
+
+// Formats MARC21 records are exchanged in.
+const (
+	FormatISO2709 = "marc"
+	FormatMARCXML = "marcxml"
+)
+
+// Errors definition.
+var (
+	ErrInvalidRecord = errors.New("Invalid MARC21 record")
+	ErrNoRecords     = errors.New("No MARC21 records to import")
+	ErrUnknownFormat = errors.New("MARC21 format must be marc or marcxml")
+	ErrNoTitle       = errors.New("MARC21 record has no title")
+)
+
+// Result is the outcome of importing one of the records, in the order of the
+// imported data. Subjects outside of the subject vocabulary are left out.
+type Result struct {
+	Index           int        `json:"index"`
+	Book            *book.Book `json:"book,omitempty"`
+	SkippedSubjects []string   `json:"skippedSubjects,omitempty"`
+	Error           string     `json:"error,omitempty"`
+}
+
+// Service provides import and export of Books as MARC21 bibliographic records.
+type Service interface {
+	Import(data []byte) ([]*Result, error)
+	Export(bookID string, format string) ([]byte, error)
+}
+
+type service struct {
+	bookService book.Service
+}
+
+// NewMARCService creates an instance of the service for MARC21 records
+// with all of the necessary dependencies.
+func NewMARCService(bookService book.Service) Service {
+	return &service{
+		bookService: bookService,
+	}
+}
+
+// Import creates a Book of each of the records of ISO 2709 or MARCXML data.
+// A record which can not be imported does not stop the others from being imported.
+func (s *service) Import(data []byte) ([]*Result, error) {
+	records, err := Read(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, ErrNoRecords
+	}
+
+	results := []*Result{}
+	for i, record := range records {
+		results = append(results, s.importRecord(i, record))
+	}
+
+	return results, nil
+}
+
+func (s *service) importRecord(index int, record *Record) *Result {
+	result := &Result{Index: index}
+
+	newBook := ToBook(record)
+	if newBook.Title == "" {
+		result.Error = ErrNoTitle.Error()
+		return result
+	}
+
+	subjects := []string{}
+	for _, subject := range newBook.Subject {
+		_, err := s.bookService.GetSubjectIDs([]string{subject})
+		if err == book.ErrUnknownSubject {
+			result.SkippedSubjects = append(result.SkippedSubjects, subject)
+			continue
+		}
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+
+		subjects = append(subjects, subject)
+	}
+	newBook.Subject = subjects
+
+	createdBook, err := s.bookService.Create(newBook)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Book = createdBook
+
+	return result
+}
+
+// Export retrieves the Book as a MARC21 record in the format.
+func (s *service) Export(bookID string, format string) ([]byte, error) {
+	if format != FormatISO2709 && format != FormatMARCXML {
+		return nil, ErrUnknownFormat
+	}
+
+	exportedBook, err := s.bookService.Get(bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return Write([]*Record{FromBook(exportedBook)}, format)
+}
+
+// Read parses ISO 2709 or MARCXML data, telling them apart by
+// MARCXML starting with markup.
+func Read(data []byte) ([]*Record, error) {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if bytes.HasPrefix(trimmed, []byte("<")) {
+		return ReadMARCXML(trimmed)
+	}
+
+	return ReadISO2709(data)
+}
+
+// Write serializes the records in the format.
+func Write(records []*Record, format string) ([]byte, error) {
+	switch format {
+	case FormatISO2709:
+		return WriteISO2709(records), nil
+	case FormatMARCXML:
+		return WriteMARCXML(records)
+	}
+
+	return nil, ErrUnknownFormat
+}
@@ -0,0 +1,48 @@
+package metadata
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/isbn"
+)
+
+type fileProvider struct {
+	path string
+}
+
+// NewFileProvider returns a provider looking up a JSON file of Books keyed
+// by their ISBN, written as either an ISBN-10 or an ISBN-13. The file is read
+// on every lookup, so that it can be edited while the server runs.
+func NewFileProvider(path string) book.MetadataProvider {
+	return &fileProvider{
+		path: path,
+	}
+}
+
+func (provider *fileProvider) Name() string {
+	return ProviderFile
+}
+
+func (provider *fileProvider) Lookup(isbn13 string) (*book.Book, error) {
+	data, err := ioutil.ReadFile(provider.path)
+	if err != nil {
+		return nil, err
+	}
+
+	books := map[string]*book.Book{}
+	err = json.Unmarshal(data, &books)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, draft := range books {
+		normalized, err := isbn.Normalize(key)
+		if err == nil && normalized == isbn13 {
+			return draft, nil
+		}
+	}
+
+	return nil, book.ErrMetadataNotFound
+}
@@ -0,0 +1,118 @@
+package metadata
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+)
+
+// openLibraryResponse is an edition as the Books API answers with jscmd=data.
+const openLibraryResponse = `{
+  "ISBN:9780134190440": {
+    "title": "The Go Programming Language",
+    "authors": [
+      {"url": "https://openlibrary.org/authors/OL7407040A/Alan_A._A._Donovan", "name": "Alan A. A. Donovan"},
+      {"url": "https://openlibrary.org/authors/OL234664A/Brian_W._Kernighan", "name": "Brian W. Kernighan"}
+    ],
+    "number_of_pages": 380,
+    "publishers": [{"name": "Addison-Wesley"}],
+    "publish_date": "Nov 05, 2015",
+    "subjects": [
+      {"name": "Go (Computer program language)", "url": "https://openlibrary.org/subjects/go_(computer_program_language)"},
+      {"name": "Open source software", "url": "https://openlibrary.org/subjects/open_source_software"}
+    ],
+    "classifications": {"lc_classifications": ["QA76.73.G63 D66 2015"]},
+    "notes": {"type": "/type/text", "value": "Includes index."},
+    "cover": {
+      "small": "https://covers.openlibrary.org/b/id/8750805-S.jpg",
+      "medium": "https://covers.openlibrary.org/b/id/8750805-M.jpg",
+      "large": "https://covers.openlibrary.org/b/id/8750805-L.jpg"
+    }
+  }
+}`
+
+func TestOpenLibraryLookup(t *testing.T) {
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("bibkeys") {
+		case "ISBN:9780134190440":
+			w.Write([]byte(openLibraryResponse))
+		case "ISBN:9780262011532":
+			w.Write([]byte("{}"))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer stub.Close()
+
+	provider := NewOpenLibraryProvider(stub.URL + "/")
+
+	draft, err := provider.Lookup("9780134190440")
+	require.Nil(t, err)
+	require.Equal(t, "The Go Programming Language", draft.Title)
+	require.Equal(t, []*book.BookAuthor{
+		{Name: "Alan A. A. Donovan", Role: book.RoleAuthor},
+		{Name: "Brian W. Kernighan", Role: book.RoleAuthor},
+	}, draft.Author)
+	require.Equal(t, "Addison-Wesley", draft.Publisher)
+	require.Equal(t, 2015, draft.YearPublished)
+	require.Equal(t, []string{"Go (Computer program language)", "Open source software"}, draft.Subject)
+	require.Equal(t, "https://covers.openlibrary.org/b/id/8750805-L.jpg", draft.CoverPicture)
+	require.Equal(t, "380 pages", draft.Collation)
+	require.Equal(t, "QA76.73.G63 D66 2015", draft.CallNumber)
+	require.Equal(t, "QA", draft.LOCClassification)
+	require.Equal(t, "Includes index.", draft.Description)
+
+	_, err = provider.Lookup("9780262011532")
+	require.Equal(t, book.ErrMetadataNotFound, err)
+
+	_, err = provider.Lookup("9780804429573")
+	require.NotNil(t, err)
+	require.NotEqual(t, book.ErrMetadataNotFound, err)
+}
+
+func TestFileLookup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "metadata")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "metadata.json")
+	err = ioutil.WriteFile(path, []byte(`{
+		"0-262-01153-0": {
+			"title": "Structure and Interpretation of Computer Programs",
+			"publisher": "MIT Press",
+			"yearPublished": 1996,
+			"author": [{"name": "Harold Abelson"}, {"name": "Gerald Jay Sussman"}]
+		}
+	}`), 0644)
+	require.Nil(t, err)
+
+	provider := NewFileProvider(path)
+
+	draft, err := provider.Lookup("9780262011532")
+	require.Nil(t, err)
+	require.Equal(t, "MIT Press", draft.Publisher)
+	require.Len(t, draft.Author, 2)
+
+	_, err = provider.Lookup("9780134190440")
+	require.Equal(t, book.ErrMetadataNotFound, err)
+
+	_, err = NewFileProvider(filepath.Join(dir, "missing.json")).Lookup("9780262011532")
+	require.NotNil(t, err)
+}
+
+func TestNewProvidersFromEnv(t *testing.T) {
+	os.Setenv("METADATA_PROVIDERS", "file, openlibrary,worldcat")
+	defer os.Unsetenv("METADATA_PROVIDERS")
+
+	providers := NewProvidersFromEnv()
+	require.Len(t, providers, 2)
+	require.Equal(t, ProviderFile, providers[0].Name())
+	require.Equal(t, ProviderOpenLibrary, providers[1].Name())
+}
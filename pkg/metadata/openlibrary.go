@@ -0,0 +1,158 @@
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+)
+
+// DefaultOpenLibraryURL is the base URL of the Open Library Books API.
+const DefaultOpenLibraryURL = "https://openlibrary.org"
+
+// lookupTimeout bounds how long cataloguing waits for a provider.
+const lookupTimeout = 10 * time.Second
+
+var (
+	yearPattern    = regexp.MustCompile(`\d{4}`)
+	classesPattern = regexp.MustCompile(`^[A-Z]+`)
+)
+
+// openLibraryBook is the data of an edition in the Books API, of which
+// only what maps onto a Book is read.
+type openLibraryBook struct {
+	Title         string `json:"title"`
+	Subtitle      string `json:"subtitle"`
+	NumberOfPages int    `json:"number_of_pages"`
+	PublishDate   string `json:"publish_date"`
+	Authors       []struct {
+		Name string `json:"name"`
+	} `json:"authors"`
+	Publishers []struct {
+		Name string `json:"name"`
+	} `json:"publishers"`
+	Subjects []struct {
+		Name string `json:"name"`
+	} `json:"subjects"`
+	Cover struct {
+		Medium string `json:"medium"`
+		Large  string `json:"large"`
+	} `json:"cover"`
+	Classifications struct {
+		LCClassifications []string `json:"lc_classifications"`
+	} `json:"classifications"`
+	Notes interface{} `json:"notes"`
+}
+
+type openLibraryProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewOpenLibraryProvider returns a provider looking up the Books API of
+// Open Library, or of a server answering the same way, at the base URL.
+func NewOpenLibraryProvider(baseURL string) book.MetadataProvider {
+	return &openLibraryProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: lookupTimeout},
+	}
+}
+
+func (provider *openLibraryProvider) Name() string {
+	return ProviderOpenLibrary
+}
+
+func (provider *openLibraryProvider) Lookup(isbn string) (*book.Book, error) {
+	bibkey := "ISBN:" + isbn
+
+	query := url.Values{}
+	query.Set("bibkeys", bibkey)
+	query.Set("format", "json")
+	query.Set("jscmd", "data")
+
+	response, err := provider.client.Get(provider.baseURL + "/api/books?" + query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Open Library responded with status %d", response.StatusCode)
+	}
+
+	// The editions are keyed by the bibkeys they were looked up by,
+	// and an unknown bibkey is left out.
+	editions := map[string]*openLibraryBook{}
+	err = json.NewDecoder(response.Body).Decode(&editions)
+	if err != nil {
+		return nil, err
+	}
+
+	edition, ok := editions[bibkey]
+	if !ok {
+		return nil, book.ErrMetadataNotFound
+	}
+
+	return edition.toBook(), nil
+}
+
+func (edition *openLibraryBook) toBook() *book.Book {
+	draft := &book.Book{
+		Title:   edition.Title,
+		Subject: []string{},
+		Author:  []*book.BookAuthor{},
+	}
+
+	if edition.Subtitle != "" {
+		draft.Title += ": " + edition.Subtitle
+	}
+
+	for _, author := range edition.Authors {
+		draft.Author = append(draft.Author, &book.BookAuthor{Name: author.Name, Role: book.RoleAuthor})
+	}
+
+	if len(edition.Publishers) > 0 {
+		draft.Publisher = edition.Publishers[0].Name
+	}
+
+	draft.YearPublished, _ = strconv.Atoi(yearPattern.FindString(edition.PublishDate))
+
+	for _, subject := range edition.Subjects {
+		draft.Subject = append(draft.Subject, subject.Name)
+	}
+
+	draft.CoverPicture = edition.Cover.Large
+	if draft.CoverPicture == "" {
+		draft.CoverPicture = edition.Cover.Medium
+	}
+
+	if edition.NumberOfPages > 0 {
+		draft.Collation = strconv.Itoa(edition.NumberOfPages) + " pages"
+	}
+
+	if len(edition.Classifications.LCClassifications) > 0 {
+		draft.CallNumber = edition.Classifications.LCClassifications[0]
+
+		classes := classesPattern.FindString(draft.CallNumber)
+		if len(classes) > 2 {
+			classes = classes[:2]
+		}
+		draft.LOCClassification = classes
+	}
+
+	// Notes are either plain text or a typed text value.
+	switch notes := edition.Notes.(type) {
+	case string:
+		draft.Description = notes
+	case map[string]interface{}:
+		draft.Description, _ = notes["value"].(string)
+	}
+
+	return draft
+}
@@ -0,0 +1,37 @@
+package metadata
+
+import (
+	"os"
+	"strings"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+)
+
+// Names of the providers, as configured in METADATA_PROVIDERS.
+const (
+	ProviderOpenLibrary = "openlibrary"
+	ProviderFile        = "file"
+)
+
+// NewProvidersFromEnv creates the providers named in the comma separated
+// METADATA_PROVIDERS, in their order of precedence. The Open Library provider
+// looks up OPENLIBRARY_URL, which defaults to DefaultOpenLibraryURL, and the
+// file provider looks up the file at METADATA_FILE. Unknown names are left out.
+func NewProvidersFromEnv() []book.MetadataProvider {
+	providers := []book.MetadataProvider{}
+
+	for _, name := range strings.Split(os.Getenv("METADATA_PROVIDERS"), ",") {
+		switch strings.TrimSpace(name) {
+		case ProviderOpenLibrary:
+			baseURL := os.Getenv("OPENLIBRARY_URL")
+			if baseURL == "" {
+				baseURL = DefaultOpenLibraryURL
+			}
+			providers = append(providers, NewOpenLibraryProvider(baseURL))
+		case ProviderFile:
+			providers = append(providers, NewFileProvider(os.Getenv("METADATA_FILE")))
+		}
+	}
+
+	return providers
+}
@@ -0,0 +1,116 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package policy
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: policyID
+func (_m *MockRepository) Delete(policyID string) error {
+	ret := _m.Called(policyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(policyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: policyID
+func (_m *MockRepository) Get(policyID string) (*Policy, error) {
+	ret := _m.Called(policyID)
+
+	var r0 *Policy
+	if rf, ok := ret.Get(0).(func(string) *Policy); ok {
+		r0 = rf(policyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Policy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(policyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields:
+func (_m *MockRepository) GetAll() ([]*Policy, error) {
+	ret := _m.Called()
+
+	var r0 []*Policy
+	if rf, ok := ret.Get(0).(func() []*Policy); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Policy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: policy
+func (_m *MockRepository) Save(policy *Policy) (*Policy, error) {
+	ret := _m.Called(policy)
+
+	var r0 *Policy
+	if rf, ok := ret.Get(0).(func(*Policy) *Policy); ok {
+		r0 = rf(policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Policy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Policy) error); ok {
+		r1 = rf(policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: policy
+func (_m *MockRepository) Update(policy *Policy) (*Policy, error) {
+	ret := _m.Called(policy)
+
+	var r0 *Policy
+	if rf, ok := ret.Get(0).(func(*Policy) *Policy); ok {
+		r0 = rf(policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Policy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Policy) error); ok {
+		r1 = rf(policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
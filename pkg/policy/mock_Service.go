@@ -0,0 +1,139 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package policy
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: policy
+func (_m *MockService) Create(policy *Policy) (*Policy, error) {
+	ret := _m.Called(policy)
+
+	var r0 *Policy
+	if rf, ok := ret.Get(0).(func(*Policy) *Policy); ok {
+		r0 = rf(policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Policy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Policy) error); ok {
+		r1 = rf(policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: policyID
+func (_m *MockService) Delete(policyID string) error {
+	ret := _m.Called(policyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(policyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: policyID
+func (_m *MockService) Get(policyID string) (*Policy, error) {
+	ret := _m.Called(policyID)
+
+	var r0 *Policy
+	if rf, ok := ret.Get(0).(func(string) *Policy); ok {
+		r0 = rf(policyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Policy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(policyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields:
+func (_m *MockService) GetAll() ([]*Policy, error) {
+	ret := _m.Called()
+
+	var r0 []*Policy
+	if rf, ok := ret.Get(0).(func() []*Policy); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Policy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Resolve provides a mock function with given fields: role, category
+func (_m *MockService) Resolve(role string, category string) (*Policy, error) {
+	ret := _m.Called(role, category)
+
+	var r0 *Policy
+	if rf, ok := ret.Get(0).(func(string, string) *Policy); ok {
+		r0 = rf(role, category)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Policy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(role, category)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: policy
+func (_m *MockService) Update(policy *Policy) (*Policy, error) {
+	ret := _m.Called(policy)
+
+	var r0 *Policy
+	if rf, ok := ret.Get(0).(func(*Policy) *Policy); ok {
+		r0 = rf(policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Policy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Policy) error); ok {
+		r1 = rf(policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,70 @@
+package policy
+
+import (
+	"time"
+)
+
+// Any matches every role or every item category in a Policy.
+const Any = "*"
+
+// Policy domain model. A Policy holds the lending rules for the patrons of
+// a role borrowing copies of an item category. GracePeriodDays is the number
+// of days after the due date in which a Borrow is not fined when returned and
+// can still be renewed. A patron owing more than MaxFine is blocked from
+// borrowing. A FineCap of zero means the fine is not capped, a MaxFine of zero
+// means the patron is never blocked for their fines, and a MaxLoans of zero
+// means the number of concurrent loans is not limited. With SkipClosedDays
+// the days the library is closed are not fined.
+type Policy struct {
+	ID              string    `json:"id" db:"id"`
+	Role            string    `json:"role" db:"role"`
+	Category        string    `json:"category" db:"category"`
+	LoanPeriodDays  int       `json:"loanPeriodDays" db:"loan_period_days"`
+	MaxLoans        int       `json:"maxLoans" db:"max_loans"`
+	MaxRenewals     int       `json:"maxRenewals" db:"max_renewals"`
+	FinePerDay      uint32    `json:"finePerDay" db:"fine_per_day"`
+	FineCap         uint32    `json:"fineCap" db:"fine_cap"`
+	GracePeriodDays int       `json:"gracePeriodDays" db:"grace_period_days"`
+	MaxFine         uint32    `json:"maxFine" db:"max_fine"`
+	SkipClosedDays  bool      `json:"skipClosedDays" db:"skip_closed_days"`
+	CreatedAt       time.Time `json:"createdAt" db:"created_at"`
+}
+
+// NewPolicy creates a new instance of Policy domain model.
+func NewPolicy(id string, role string, category string, loanPeriodDays int, maxLoans int, maxRenewals int, finePerDay uint32, fineCap uint32, gracePeriodDays int, maxFine uint32, skipClosedDays bool, createdAt time.Time) *Policy {
+	return &Policy{
+		ID:              id,
+		Role:            role,
+		Category:        category,
+		LoanPeriodDays:  loanPeriodDays,
+		MaxLoans:        maxLoans,
+		MaxRenewals:     maxRenewals,
+		FinePerDay:      finePerDay,
+		FineCap:         fineCap,
+		GracePeriodDays: gracePeriodDays,
+		MaxFine:         maxFine,
+		SkipClosedDays:  skipClosedDays,
+		CreatedAt:       createdAt,
+	}
+}
+
+// DefaultPolicy returns the Policy that applies when no Policy
+// has been defined for a role and an item category.
+func DefaultPolicy() *Policy {
+	return NewPolicy("", Any, Any, 7, 5, 2, 2000, 0, 3, 10000, false, time.Time{})
+}
+
+// Fine returns the fine of a Borrow returned the given number
+// of days after its due date under this Policy.
+func (policy *Policy) Fine(overdueDays int) uint32 {
+	if overdueDays <= policy.GracePeriodDays {
+		return 0
+	}
+
+	fine := uint32(overdueDays) * policy.FinePerDay
+	if policy.FineCap != 0 && fine > policy.FineCap {
+		fine = policy.FineCap
+	}
+
+	return fine
+}
@@ -0,0 +1,177 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bouk/monkey"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/core/user"
+)
+
+var policyRepository = &MockRepository{}
+var policyService = NewPolicyService(policyRepository)
+
+func TestCreate(t *testing.T) {
+	createdTime := time.Now()
+	timePatch := monkey.Patch(time.Now, func() time.Time {
+		return createdTime
+	})
+	defer timePatch.Unpatch()
+
+	policyID := util.NewID()
+	policyIDPatch := monkey.Patch(util.NewID, func() string {
+		return policyID
+	})
+	defer policyIDPatch.Unpatch()
+
+	tt := []struct {
+		name   string
+		policy *Policy
+		err    error
+	}{
+		{
+			name:   "create a valid policy",
+			policy: NewPolicy(policyID, user.RoleStaff, "reference", 1, 2, 0, 5000, 20000, 0, 0, false, createdTime),
+			err:    nil,
+		},
+		{
+			name:   "create a policy with an unknown role",
+			policy: NewPolicy(policyID, "visitor", "reference", 1, 2, 0, 5000, 20000, 0, 0, false, createdTime),
+			err:    ErrInvalidPolicy,
+		},
+		{
+			name:   "create a policy without a category",
+			policy: NewPolicy(policyID, user.RoleStaff, "", 1, 2, 0, 5000, 20000, 0, 0, false, createdTime),
+			err:    ErrInvalidPolicy,
+		},
+		{
+			name:   "create a policy without a loan period",
+			policy: NewPolicy(policyID, user.RoleStaff, "reference", 0, 2, 0, 5000, 20000, 0, 0, false, createdTime),
+			err:    ErrInvalidPolicy,
+		},
+	}
+
+	policyRepository.On("Save", tt[0].policy).Return(tt[0].policy, nil)
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			newPolicy, err := policyService.Create(tc.policy)
+
+			if tc.err != nil {
+				require.Nil(t, newPolicy)
+				require.Equal(t, tc.err, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.policy.ID, newPolicy.ID)
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	anyPolicy := NewPolicy(util.NewID(), Any, Any, 14, 5, 2, 1000, 0, 0, 0, false, time.Now())
+	referencePolicy := NewPolicy(util.NewID(), Any, "reference", 1, 1, 0, 5000, 0, 0, 0, false, time.Now())
+	staffPolicy := NewPolicy(util.NewID(), user.RoleStaff, Any, 28, 10, 3, 1000, 0, 0, 0, false, time.Now())
+	staffReferencePolicy := NewPolicy(util.NewID(), user.RoleStaff, "reference", 3, 2, 1, 5000, 0, 0, 0, false, time.Now())
+
+	policies := []*Policy{anyPolicy, referencePolicy, staffPolicy, staffReferencePolicy}
+
+	tt := []struct {
+		name     string
+		policies []*Policy
+		role     string
+		category string
+		expected *Policy
+	}{
+		{
+			name:     "exact role and category",
+			policies: policies,
+			role:     user.RoleStaff,
+			category: "reference",
+			expected: staffReferencePolicy,
+		},
+		{
+			name:     "role and any category",
+			policies: policies,
+			role:     user.RoleStaff,
+			category: "general",
+			expected: staffPolicy,
+		},
+		{
+			name:     "any role and category",
+			policies: policies,
+			role:     user.RoleStudent,
+			category: "reference",
+			expected: referencePolicy,
+		},
+		{
+			name:     "any role and any category",
+			policies: policies,
+			role:     user.RoleStudent,
+			category: "general",
+			expected: anyPolicy,
+		},
+		{
+			name:     "no matching policy",
+			policies: []*Policy{staffReferencePolicy},
+			role:     user.RoleStudent,
+			category: "general",
+			expected: DefaultPolicy(),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, resolve(tc.policies, tc.role, tc.category))
+		})
+	}
+}
+
+func TestResolveError(t *testing.T) {
+	failingRepository := &MockRepository{}
+	failingRepository.On("GetAll").Return(nil, errors.New("connection refused"))
+
+	resolvedPolicy, err := NewPolicyService(failingRepository).Resolve(user.RoleStudent, "general")
+
+	require.Nil(t, resolvedPolicy)
+	require.Equal(t, ErrResolvePolicy, err)
+}
+
+func TestFine(t *testing.T) {
+	tt := []struct {
+		name        string
+		policy      *Policy
+		overdueDays int
+		fine        uint32
+	}{
+		{
+			name:        "overdue within the grace period",
+			policy:      NewPolicy("", Any, Any, 7, 5, 2, 2000, 0, 3, 0, false, time.Time{}),
+			overdueDays: 3,
+			fine:        0,
+		},
+		{
+			name:        "overdue after the grace period",
+			policy:      NewPolicy("", Any, Any, 7, 5, 2, 2000, 0, 3, 0, false, time.Time{}),
+			overdueDays: 4,
+			fine:        8000,
+		},
+		{
+			name:        "fine reaching the cap",
+			policy:      NewPolicy("", Any, Any, 7, 5, 2, 2000, 10000, 0, 0, false, time.Time{}),
+			overdueDays: 10,
+			fine:        10000,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.fine, tc.policy.Fine(tc.overdueDays))
+		})
+	}
+}
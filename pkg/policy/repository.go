@@ -0,0 +1,13 @@
+package policy
+
+// Repository provides access to the Policy store.
+type Repository interface {
+	// CRUD operations.
+	Save(policy *Policy) (*Policy, error)
+	Get(policyID string) (*Policy, error)
+	Update(policy *Policy) (*Policy, error)
+	Delete(policyID string) error
+
+	// Other operations.
+	GetAll() ([]*Policy, error)
+}
@@ -0,0 +1,155 @@
+package policy
+
+import (
+	"errors"
+	"time"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/core/user"
+)
+
+// Errors definition.
+var (
+	ErrCreatePolicy = errors.New("Error creating Policy")
+	ErrGetPolicy    = errors.New("Error retrieving Policy")
+	ErrUpdatePolicy = errors.New("Error updating Policy")
+	ErrDeletePolicy = errors.New("Error deleting Policy")
+
+	ErrGetPolicies   = errors.New("Error retrieving Policies")
+	ErrInvalidPolicy = errors.New("Invalid Policy")
+	ErrResolvePolicy = errors.New("Error resolving Policy")
+)
+
+// Service provides basic operations on Policy domain model.
+type Service interface {
+	// CRUD operations.
+	Create(policy *Policy) (*Policy, error)
+	Get(policyID string) (*Policy, error)
+	Update(policy *Policy) (*Policy, error)
+	Delete(policyID string) error
+
+	// Other operations.
+	GetAll() ([]*Policy, error)
+	Resolve(role string, category string) (*Policy, error)
+}
+
+type service struct {
+	policyRepository Repository
+}
+
+// NewPolicyService creates an instance of the service for the Policy domain model
+// with all of the necessary dependencies.
+func NewPolicyService(policyRepository Repository) Service {
+	return &service{
+		policyRepository: policyRepository,
+	}
+}
+
+func (s *service) Create(policy *Policy) (*Policy, error) {
+	var newPolicy *Policy
+
+	err := validatePolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	newPolicy = NewPolicy(util.NewID(), policy.Role, policy.Category, policy.LoanPeriodDays, policy.MaxLoans, policy.MaxRenewals, policy.FinePerDay, policy.FineCap, policy.GracePeriodDays, policy.MaxFine, policy.SkipClosedDays, time.Now())
+
+	newPolicy, err = s.policyRepository.Save(newPolicy)
+	if err != nil {
+		return nil, ErrCreatePolicy
+	}
+
+	return newPolicy, nil
+}
+
+func (s *service) Get(policyID string) (*Policy, error) {
+	policy, err := s.policyRepository.Get(policyID)
+	if err != nil {
+		return nil, ErrGetPolicy
+	}
+
+	return policy, nil
+}
+
+func (s *service) Update(policy *Policy) (*Policy, error) {
+	err := validatePolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err = s.policyRepository.Update(policy)
+	if err != nil {
+		return nil, ErrUpdatePolicy
+	}
+
+	return policy, nil
+}
+
+func (s *service) Delete(policyID string) error {
+	err := s.policyRepository.Delete(policyID)
+	if err != nil {
+		return ErrDeletePolicy
+	}
+
+	return nil
+}
+
+func (s *service) GetAll() ([]*Policy, error) {
+	policies, err := s.policyRepository.GetAll()
+	if err != nil {
+		return nil, ErrGetPolicies
+	}
+
+	return policies, nil
+}
+
+func (s *service) Resolve(role string, category string) (*Policy, error) {
+	policies, err := s.GetAll()
+	if err != nil {
+		return nil, ErrResolvePolicy
+	}
+
+	return resolve(policies, role, category), nil
+}
+
+// resolve picks the most specific Policy for the role and the item category.
+// A Policy for the exact role and category wins over one for the role and any
+// category, which wins over one for any role and the category, which wins over
+// one for any role and any category. The default Policy applies otherwise.
+func resolve(policies []*Policy, role string, category string) *Policy {
+	candidates := [][2]string{
+		{role, category},
+		{role, Any},
+		{Any, category},
+		{Any, Any},
+	}
+
+	for _, candidate := range candidates {
+		for _, policy := range policies {
+			if policy.Role == candidate[0] && policy.Category == candidate[1] {
+				return policy
+			}
+		}
+	}
+
+	return DefaultPolicy()
+}
+
+func validatePolicy(policy *Policy) error {
+	switch policy.Role {
+	case user.RoleStudent, user.RoleStaff, user.RoleLibrarian, Any:
+	default:
+		return ErrInvalidPolicy
+	}
+
+	if policy.Category == "" {
+		return ErrInvalidPolicy
+	}
+
+	if policy.LoanPeriodDays <= 0 || policy.MaxLoans < 0 || policy.MaxRenewals < 0 || policy.GracePeriodDays < 0 {
+		return ErrInvalidPolicy
+	}
+
+	return nil
+}
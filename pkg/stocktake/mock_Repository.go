@@ -0,0 +1,148 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package stocktake
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+// CloseSession provides a mock function with given fields: session, results
+func (_m *MockRepository) CloseSession(session *Session, results []*Result) (*Session, error) {
+	ret := _m.Called(session, results)
+
+	var r0 *Session
+	if rf, ok := ret.Get(0).(func(*Session, []*Result) *Session); ok {
+		r0 = rf(session, results)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Session, []*Result) error); ok {
+		r1 = rf(session, results)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetResults provides a mock function with given fields: sessionID
+func (_m *MockRepository) GetResults(sessionID string) ([]*Result, error) {
+	ret := _m.Called(sessionID)
+
+	var r0 []*Result
+	if rf, ok := ret.Get(0).(func(string) []*Result); ok {
+		r0 = rf(sessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Result)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetScans provides a mock function with given fields: sessionID
+func (_m *MockRepository) GetScans(sessionID string) ([]*Scan, error) {
+	ret := _m.Called(sessionID)
+
+	var r0 []*Scan
+	if rf, ok := ret.Get(0).(func(string) []*Scan); ok {
+		r0 = rf(sessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Scan)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSession provides a mock function with given fields: sessionID
+func (_m *MockRepository) GetSession(sessionID string) (*Session, error) {
+	ret := _m.Called(sessionID)
+
+	var r0 *Session
+	if rf, ok := ret.Get(0).(func(string) *Session); ok {
+		r0 = rf(sessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveScan provides a mock function with given fields: scan
+func (_m *MockRepository) SaveScan(scan *Scan) (*Scan, error) {
+	ret := _m.Called(scan)
+
+	var r0 *Scan
+	if rf, ok := ret.Get(0).(func(*Scan) *Scan); ok {
+		r0 = rf(scan)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Scan)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Scan) error); ok {
+		r1 = rf(scan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveSession provides a mock function with given fields: session
+func (_m *MockRepository) SaveSession(session *Session) (*Session, error) {
+	ret := _m.Called(session)
+
+	var r0 *Session
+	if rf, ok := ret.Get(0).(func(*Session) *Session); ok {
+		r0 = rf(session)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Session) error); ok {
+		r1 = rf(session)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,125 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package stocktake
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+// Close provides a mock function with given fields: username, sessionID
+func (_m *MockService) Close(username string, sessionID string) (*Report, error) {
+	ret := _m.Called(username, sessionID)
+
+	var r0 *Report
+	if rf, ok := ret.Get(0).(func(string, string) *Report); ok {
+		r0 = rf(username, sessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Report)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(username, sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSession provides a mock function with given fields: sessionID
+func (_m *MockService) GetSession(sessionID string) (*Session, error) {
+	ret := _m.Called(sessionID)
+
+	var r0 *Session
+	if rf, ok := ret.Get(0).(func(string) *Session); ok {
+		r0 = rf(sessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Open provides a mock function with given fields: username, branchID, locationID
+func (_m *MockService) Open(username string, branchID string, locationID string) (*Session, error) {
+	ret := _m.Called(username, branchID, locationID)
+
+	var r0 *Session
+	if rf, ok := ret.Get(0).(func(string, string, string) *Session); ok {
+		r0 = rf(username, branchID, locationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Session)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(username, branchID, locationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Report provides a mock function with given fields: sessionID
+func (_m *MockService) Report(sessionID string) (*Report, error) {
+	ret := _m.Called(sessionID)
+
+	var r0 *Report
+	if rf, ok := ret.Get(0).(func(string) *Report); ok {
+		r0 = rf(sessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Report)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Scan provides a mock function with given fields: username, sessionID, barcodes
+func (_m *MockService) Scan(username string, sessionID string, barcodes []string) ([]*Scan, error) {
+	ret := _m.Called(username, sessionID, barcodes)
+
+	var r0 []*Scan
+	if rf, ok := ret.Get(0).(func(string, string, []string) []*Scan); ok {
+		r0 = rf(username, sessionID, barcodes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Scan)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, []string) error); ok {
+		r1 = rf(username, sessionID, barcodes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,15 @@
+package stocktake
+
+// Repository provides access to the Stocktake store.
+type Repository interface {
+	// Session operations.
+	SaveSession(session *Session) (*Session, error)
+	GetSession(sessionID string) (*Session, error)
+	// CloseSession closes the Session along with saving its Results, all at once or not at all.
+	CloseSession(session *Session, results []*Result) (*Session, error)
+	GetResults(sessionID string) ([]*Result, error)
+
+	// Scan operations.
+	SaveScan(scan *Scan) (*Scan, error)
+	GetScans(sessionID string) ([]*Scan, error)
+}
@@ -0,0 +1,281 @@
+package stocktake
+
+import (
+	"errors"
+	"time"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+)
+
+// Errors definition.
+var (
+	ErrOpenSession   = errors.New("Error opening stocktake Session")
+	ErrGetSession    = errors.New("Error retrieving stocktake Session")
+	ErrCloseSession  = errors.New("Error closing stocktake Session")
+	ErrSaveScan      = errors.New("Error saving stocktake Scan")
+	ErrGetScans      = errors.New("Error retrieving stocktake Scans")
+	ErrGetResults    = errors.New("Error retrieving stocktake Results")
+	ErrNoLocation    = errors.New("Stocktake Session needs a Location")
+	ErrNoBarcodes    = errors.New("No barcodes were scanned")
+	ErrSessionClosed = errors.New("Stocktake Session is already closed")
+)
+
+// Service provides basic operations on Stocktake domain model.
+type Service interface {
+	Open(username string, branchID string, locationID string) (*Session, error)
+	GetSession(sessionID string) (*Session, error)
+	Scan(username string, sessionID string, barcodes []string) ([]*Scan, error)
+	Close(username string, sessionID string) (*Report, error)
+	Report(sessionID string) (*Report, error)
+}
+
+type service struct {
+	stocktakeRepository Repository
+	bookCopyService     bookcopy.Service
+	branchService       branch.Service
+}
+
+// NewStocktakeService creates an instance of the service for the Stocktake domain model
+// with all of the necessary dependencies.
+func NewStocktakeService(stocktakeRepository Repository, bookCopyService bookcopy.Service, branchService branch.Service) Service {
+	return &service{
+		stocktakeRepository: stocktakeRepository,
+		bookCopyService:     bookCopyService,
+		branchService:       branchService,
+	}
+}
+
+func (s *service) Open(username string, branchID string, locationID string) (*Session, error) {
+	if locationID == "" {
+		return nil, ErrNoLocation
+	}
+
+	err := s.branchService.ValidateLocation(branchID, locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	newSession := NewSession(util.NewID(), branchID, locationID, SessionOpen, username, "", time.Now(), nil, false)
+
+	newSession, err = s.stocktakeRepository.SaveSession(newSession)
+	if err != nil {
+		return nil, ErrOpenSession
+	}
+
+	return newSession, nil
+}
+
+func (s *service) GetSession(sessionID string) (*Session, error) {
+	session, err := s.stocktakeRepository.GetSession(sessionID)
+	if err != nil {
+		return nil, ErrGetSession
+	}
+
+	return session, nil
+}
+
+// Scan records the barcodes read on the shelves of the Location of an open Session.
+// Barcodes that are not of any Book Copy are recorded too and reported as unknown.
+func (s *service) Scan(username string, sessionID string, barcodes []string) ([]*Scan, error) {
+	if len(barcodes) == 0 {
+		return nil, ErrNoBarcodes
+	}
+
+	session, err := s.GetSession(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Status != SessionOpen {
+		return nil, ErrSessionClosed
+	}
+
+	scans := []*Scan{}
+	for _, barcode := range barcodes {
+		if barcode == "" {
+			continue
+		}
+
+		bookCopyID := ""
+		bookCopy, err := s.bookCopyService.GetByBarcode(barcode)
+		if err == nil {
+			bookCopyID = bookCopy.ID
+		}
+
+		scan, err := s.stocktakeRepository.SaveScan(NewScan(util.NewID(), session.ID, barcode, bookCopyID, username, time.Now()))
+		if err != nil {
+			return nil, ErrSaveScan
+		}
+
+		scans = append(scans, scan)
+	}
+
+	return scans, nil
+}
+
+// Close closes the Session so no more barcodes can be scanned
+// and returns its Report, which is kept as it is at the close.
+func (s *service) Close(username string, sessionID string) (*Report, error) {
+	session, err := s.GetSession(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Status != SessionOpen {
+		return nil, ErrSessionClosed
+	}
+
+	report, results, err := s.report(session)
+	if err != nil {
+		return nil, err
+	}
+
+	closedAt := time.Now()
+	session.Status = SessionClosed
+	session.ClosedBy = username
+	session.ClosedAt = &closedAt
+	session.ResultsKept = true
+
+	_, err = s.stocktakeRepository.CloseSession(session, results)
+	if err != nil {
+		return nil, ErrCloseSession
+	}
+
+	return report, nil
+}
+
+// Report returns the Report of the Session. The Report of a closed Session
+// is the one kept at the close. Sessions closed before Reports were kept
+// are compared with the current Book Copies instead.
+func (s *service) Report(sessionID string) (*Report, error) {
+	session, err := s.GetSession(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Status == SessionClosed && session.ResultsKept {
+		results, err := s.stocktakeRepository.GetResults(session.ID)
+		if err != nil {
+			return nil, ErrGetResults
+		}
+
+		return s.closedReport(session, results)
+	}
+
+	report, _, err := s.report(session)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// report compares the Book Copies scanned during the Session with the
+// available Book Copies expected on the shelves of its Location, and
+// returns the Report along with the Results it is kept as.
+func (s *service) report(session *Session) (*Report, []*Result, error) {
+	scans, err := s.stocktakeRepository.GetScans(session.ID)
+	if err != nil {
+		return nil, nil, ErrGetScans
+	}
+
+	expectedBookCopies, err := s.bookCopyService.ListByLocation(session.LocationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := newReport(session, scans)
+	results := []*Result{}
+
+	scanned := map[string]bool{}
+	for _, scan := range scans {
+		if scanned[scan.Barcode] {
+			continue
+		}
+		scanned[scan.Barcode] = true
+
+		if scan.BookCopyID == "" {
+			report.UnknownBarcodes = append(report.UnknownBarcodes, scan.Barcode)
+			results = append(results, NewResult(session.ID, ResultUnknown, scan.Barcode, "", "", "", "", ""))
+			continue
+		}
+
+		bookCopy, err := s.bookCopyService.Get(scan.BookCopyID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if bookCopy.Status == bookcopy.StatusOnLoan {
+			report.OnLoan = append(report.OnLoan, bookCopy)
+			results = append(results, bookCopyResult(session.ID, ResultOnLoan, bookCopy))
+		} else if bookCopy.CurrentLocationID != session.LocationID {
+			report.Misplaced = append(report.Misplaced, bookCopy)
+			results = append(results, bookCopyResult(session.ID, ResultMisplaced, bookCopy))
+		}
+	}
+
+	for _, bookCopy := range expectedBookCopies {
+		if bookCopy.Status != bookcopy.StatusAvailable {
+			continue
+		}
+
+		report.Expected++
+		results = append(results, bookCopyResult(session.ID, ResultExpected, bookCopy))
+
+		if !scanned[bookCopy.Barcode] {
+			report.NotFound = append(report.NotFound, bookCopy)
+			results = append(results, bookCopyResult(session.ID, ResultNotFound, bookCopy))
+		}
+	}
+
+	return report, results, nil
+}
+
+// closedReport returns the Report of the closed Session from the Results kept at
+// its close. The Book Copies are listed as the Report found them at the close,
+// even those deleted since.
+func (s *service) closedReport(session *Session, results []*Result) (*Report, error) {
+	scans, err := s.stocktakeRepository.GetScans(session.ID)
+	if err != nil {
+		return nil, ErrGetScans
+	}
+
+	report := newReport(session, scans)
+
+	for _, result := range results {
+		switch result.Kind {
+		case ResultExpected:
+			report.Expected++
+		case ResultUnknown:
+			report.UnknownBarcodes = append(report.UnknownBarcodes, result.Barcode)
+		case ResultMisplaced:
+			report.Misplaced = append(report.Misplaced, result.BookCopy())
+		case ResultNotFound:
+			report.NotFound = append(report.NotFound, result.BookCopy())
+		case ResultOnLoan:
+			report.OnLoan = append(report.OnLoan, result.BookCopy())
+		}
+	}
+
+	return report, nil
+}
+
+// newReport returns an empty Report of the Session with the number
+// of distinct barcodes scanned during it.
+func newReport(session *Session, scans []*Scan) *Report {
+	scanned := map[string]bool{}
+	for _, scan := range scans {
+		scanned[scan.Barcode] = true
+	}
+
+	return &Report{
+		Session:         session,
+		Scanned:         len(scanned),
+		Misplaced:       []*bookcopy.BookCopy{},
+		NotFound:        []*bookcopy.BookCopy{},
+		OnLoan:          []*bookcopy.BookCopy{},
+		UnknownBarcodes: []string{},
+	}
+}
@@ -0,0 +1,143 @@
+package stocktake
+
+import (
+	"time"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+)
+
+// Statuses of a Session.
+const (
+	SessionOpen   = "open"
+	SessionClosed = "closed"
+)
+
+// Session domain model. A Session is the stocktake of one Location,
+// from the moment a librarian opens it, through the scanning of its
+// shelves, until it is closed. ResultsKept tells that the Results of the
+// Session were kept at its close, which Sessions closed before Results
+// were kept lack.
+type Session struct {
+	ID          string     `json:"id" db:"id"`
+	BranchID    string     `json:"branchID" db:"branch_id"`
+	LocationID  string     `json:"locationID" db:"location_id"`
+	Status      string     `json:"status" db:"status"`
+	OpenedBy    string     `json:"openedBy" db:"opened_by"`
+	ClosedBy    string     `json:"closedBy" db:"closed_by"`
+	OpenedAt    time.Time  `json:"openedAt" db:"opened_at"`
+	ClosedAt    *time.Time `json:"closedAt" db:"closed_at"`
+	ResultsKept bool       `json:"resultsKept" db:"results_kept"`
+}
+
+// NewSession creates a new instance of Session domain model.
+func NewSession(id string, branchID string, locationID string, status string, openedBy string, closedBy string, openedAt time.Time, closedAt *time.Time, resultsKept bool) *Session {
+	return &Session{
+		ID:          id,
+		BranchID:    branchID,
+		LocationID:  locationID,
+		Status:      status,
+		OpenedBy:    openedBy,
+		ClosedBy:    closedBy,
+		OpenedAt:    openedAt,
+		ClosedAt:    closedAt,
+		ResultsKept: resultsKept,
+	}
+}
+
+// Scan domain model. A Scan is a barcode read on the shelves during a Session.
+// BookCopyID is empty when the barcode is not one of any Book Copy.
+type Scan struct {
+	ID         string    `json:"id" db:"id"`
+	SessionID  string    `json:"sessionID" db:"session_id"`
+	Barcode    string    `json:"barcode" db:"barcode"`
+	BookCopyID string    `json:"bookCopyID" db:"bookcopy_id"`
+	ScannedBy  string    `json:"scannedBy" db:"scanned_by"`
+	ScannedAt  time.Time `json:"scannedAt" db:"scanned_at"`
+}
+
+// NewScan creates a new instance of Scan domain model.
+func NewScan(id string, sessionID string, barcode string, bookCopyID string, scannedBy string, scannedAt time.Time) *Scan {
+	return &Scan{
+		ID:         id,
+		SessionID:  sessionID,
+		Barcode:    barcode,
+		BookCopyID: bookCopyID,
+		ScannedBy:  scannedBy,
+		ScannedAt:  scannedAt,
+	}
+}
+
+// Kinds of Result.
+const (
+	ResultExpected  = "expected"
+	ResultMisplaced = "misplaced"
+	ResultNotFound  = "not-found"
+	ResultOnLoan    = "on-loan"
+	ResultUnknown   = "unknown"
+)
+
+// Result domain model. A Result is a Book Copy, or an unknown barcode, as
+// the Report of a Session found it when the Session was closed. The Results
+// of a closed Session keep its Report as it was, however the Book Copies
+// change afterwards or are deleted, which is why a Result keeps the status
+// and the location the Book Copy had. BookCopyID is empty for unknown barcodes.
+type Result struct {
+	SessionID         string `json:"sessionID" db:"session_id"`
+	Kind              string `json:"kind" db:"kind"`
+	Barcode           string `json:"barcode" db:"barcode"`
+	BookCopyID        string `json:"bookCopyID" db:"bookcopy_id"`
+	BookID            string `json:"bookID" db:"book_id"`
+	Status            string `json:"status" db:"status"`
+	CurrentBranchID   string `json:"currentBranchID" db:"current_branch_id"`
+	CurrentLocationID string `json:"currentLocationID" db:"current_location_id"`
+}
+
+// NewResult creates a new instance of Result domain model.
+func NewResult(sessionID string, kind string, barcode string, bookCopyID string, bookID string, status string, currentBranchID string, currentLocationID string) *Result {
+	return &Result{
+		SessionID:         sessionID,
+		Kind:              kind,
+		Barcode:           barcode,
+		BookCopyID:        bookCopyID,
+		BookID:            bookID,
+		Status:            status,
+		CurrentBranchID:   currentBranchID,
+		CurrentLocationID: currentLocationID,
+	}
+}
+
+// bookCopyResult returns the Result of the Book Copy as it is now.
+func bookCopyResult(sessionID string, kind string, bookCopy *bookcopy.BookCopy) *Result {
+	return NewResult(sessionID, kind, bookCopy.Barcode, bookCopy.ID, bookCopy.BookID, bookCopy.Status, bookCopy.CurrentBranchID, bookCopy.CurrentLocationID)
+}
+
+// BookCopy returns the Book Copy of the Result as it was when the Session was closed.
+func (result *Result) BookCopy() *bookcopy.BookCopy {
+	return &bookcopy.BookCopy{
+		ID:                result.BookCopyID,
+		Barcode:           result.Barcode,
+		BookID:            result.BookID,
+		Status:            result.Status,
+		CurrentBranchID:   result.CurrentBranchID,
+		CurrentLocationID: result.CurrentLocationID,
+	}
+}
+
+// Report sums up a Session by comparing what was scanned with
+// what is expected at the Location.
+type Report struct {
+	Session *Session `json:"session"`
+	Scanned int      `json:"scanned"`
+	// Expected is the number of available Book Copies expected at the Location.
+	Expected int `json:"expected"`
+
+	// Misplaced are Book Copies scanned but not expected at the Location.
+	Misplaced []*bookcopy.BookCopy `json:"misplaced"`
+	// NotFound are Book Copies expected at the Location but not scanned,
+	// which are candidates for being marked missing.
+	NotFound []*bookcopy.BookCopy `json:"notFound"`
+	// OnLoan are Book Copies scanned while they are on loan.
+	OnLoan []*bookcopy.BookCopy `json:"onLoan"`
+	// UnknownBarcodes are scanned barcodes that are not of any Book Copy.
+	UnknownBarcodes []string `json:"unknownBarcodes"`
+}
@@ -0,0 +1,235 @@
+package stocktake
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/barcode"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+)
+
+var stocktakeRepository = &MockRepository{}
+var bookRepository = &book.MockRepository{}
+var bookCopyRepository = &bookcopy.MockRepository{}
+var branchRepository = &branch.MockRepository{}
+
+var bookService = book.NewBookService(bookRepository)
+var branchService = branch.NewBranchService(branchRepository)
+var bookCopyService = bookcopy.NewBookCopyService(bookCopyRepository, bookService, branchService, barcode.NewScheme([]string{}, map[string]string{}, barcode.DefaultLength))
+var stocktakeService = NewStocktakeService(stocktakeRepository, bookCopyService, branchService)
+
+var mainBranch = &branch.Branch{ID: util.NewID(), Code: "MAIN"}
+var stacks = &branch.Location{ID: util.NewID(), BranchID: mainBranch.ID, Name: "Stacks"}
+var reference = &branch.Location{ID: util.NewID(), BranchID: mainBranch.ID, Name: "Reference"}
+
+func init() {
+	branchRepository.On("GetBranch", mainBranch.ID).Return(mainBranch, nil)
+	branchRepository.On("GetLocation", stacks.ID).Return(stacks, nil)
+	branchRepository.On("GetLocation", reference.ID).Return(reference, nil)
+}
+
+func newBookCopy(status string, locationID string) *bookcopy.BookCopy {
+	bookCopy := &bookcopy.BookCopy{
+		ID:                util.NewID(),
+		Barcode:           util.NewID(),
+		Status:            status,
+		CurrentBranchID:   mainBranch.ID,
+		CurrentLocationID: locationID,
+	}
+	bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+	bookCopyRepository.On("GetByBarcode", bookCopy.Barcode).Return(bookCopy, nil)
+
+	return bookCopy
+}
+
+// keptBookCopy returns what the Results of a Session keep of the Book Copy.
+func keptBookCopy(bookCopy *bookcopy.BookCopy) *bookcopy.BookCopy {
+	return &bookcopy.BookCopy{
+		ID:                bookCopy.ID,
+		Barcode:           bookCopy.Barcode,
+		BookID:            bookCopy.BookID,
+		Status:            bookCopy.Status,
+		CurrentBranchID:   bookCopy.CurrentBranchID,
+		CurrentLocationID: bookCopy.CurrentLocationID,
+	}
+}
+
+func TestOpen(t *testing.T) {
+	tt := []struct {
+		name       string
+		branchID   string
+		locationID string
+		err        error
+	}{
+		{
+			name:       "success opening a Session",
+			branchID:   mainBranch.ID,
+			locationID: stacks.ID,
+			err:        nil,
+		},
+		{
+			name:       "Session without a Location",
+			branchID:   mainBranch.ID,
+			locationID: "",
+			err:        ErrNoLocation,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			stocktakeRepository.On("SaveSession", mock.AnythingOfType("*stocktake.Session")).Return(func(session *Session) *Session { return session }, nil)
+
+			session, err := stocktakeService.Open("librarian", tc.branchID, tc.locationID)
+
+			require.Equal(t, tc.err, err)
+			if tc.err == nil {
+				require.Equal(t, SessionOpen, session.Status)
+				require.Equal(t, tc.locationID, session.LocationID)
+			}
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	openSession := NewSession(util.NewID(), mainBranch.ID, stacks.ID, SessionOpen, "librarian", "", time.Now(), nil, false)
+	closedSession := NewSession(util.NewID(), mainBranch.ID, stacks.ID, SessionClosed, "librarian", "librarian", time.Now(), nil, false)
+	stocktakeRepository.On("GetSession", openSession.ID).Return(openSession, nil)
+	stocktakeRepository.On("GetSession", closedSession.ID).Return(closedSession, nil)
+	stocktakeRepository.On("SaveScan", mock.AnythingOfType("*stocktake.Scan")).Return(func(scan *Scan) *Scan { return scan }, nil)
+
+	bookCopy := newBookCopy(bookcopy.StatusAvailable, stacks.ID)
+	unknownBarcode := util.NewID()
+	bookCopyRepository.On("GetByBarcode", unknownBarcode).Return(nil, errors.New("sql: no rows in result set"))
+
+	scans, err := stocktakeService.Scan("librarian", openSession.ID, []string{bookCopy.Barcode, "", unknownBarcode})
+	require.Nil(t, err)
+	require.Len(t, scans, 2)
+	require.Equal(t, bookCopy.ID, scans[0].BookCopyID)
+	require.Equal(t, "", scans[1].BookCopyID)
+
+	_, err = stocktakeService.Scan("librarian", closedSession.ID, []string{bookCopy.Barcode})
+	require.Equal(t, ErrSessionClosed, err)
+
+	_, err = stocktakeService.Scan("librarian", openSession.ID, []string{})
+	require.Equal(t, ErrNoBarcodes, err)
+}
+
+func TestClose(t *testing.T) {
+	session := NewSession(util.NewID(), mainBranch.ID, stacks.ID, SessionOpen, "librarian", "", time.Now(), nil, false)
+	stocktakeRepository.On("GetSession", session.ID).Return(session, nil)
+
+	// The Results kept at the close are what the Report of the closed Session is read from.
+	var results []*Result
+	stocktakeRepository.On("CloseSession", session, mock.AnythingOfType("[]*stocktake.Result")).Return(session, nil).Run(func(args mock.Arguments) {
+		results = args.Get(1).([]*Result)
+	})
+	stocktakeRepository.On("GetResults", session.ID).Return(func(sessionID string) []*Result {
+		return results
+	}, nil)
+
+	shelved := newBookCopy(bookcopy.StatusAvailable, stacks.ID)
+	notFound := newBookCopy(bookcopy.StatusAvailable, stacks.ID)
+	onLoan := newBookCopy(bookcopy.StatusOnLoan, stacks.ID)
+	misplaced := newBookCopy(bookcopy.StatusAvailable, reference.ID)
+	inRepair := newBookCopy(bookcopy.StatusInRepair, stacks.ID)
+	bookCopyRepository.On("ListByLocation", stacks.ID).Return([]*bookcopy.BookCopy{shelved, notFound, onLoan, inRepair}, nil)
+
+	unknownBarcode := util.NewID()
+	scans := []*Scan{
+		NewScan(util.NewID(), session.ID, shelved.Barcode, shelved.ID, "librarian", time.Now()),
+		NewScan(util.NewID(), session.ID, shelved.Barcode, shelved.ID, "librarian", time.Now()),
+		NewScan(util.NewID(), session.ID, onLoan.Barcode, onLoan.ID, "librarian", time.Now()),
+		NewScan(util.NewID(), session.ID, misplaced.Barcode, misplaced.ID, "librarian", time.Now()),
+		NewScan(util.NewID(), session.ID, unknownBarcode, "", "librarian", time.Now()),
+	}
+	stocktakeRepository.On("GetScans", session.ID).Return(scans, nil)
+
+	report, err := stocktakeService.Close("librarian", session.ID)
+	require.Nil(t, err)
+	require.Equal(t, SessionClosed, report.Session.Status)
+	require.NotNil(t, report.Session.ClosedAt)
+	require.Equal(t, 4, report.Scanned)
+	require.Equal(t, 2, report.Expected)
+	require.Equal(t, []*bookcopy.BookCopy{notFound}, report.NotFound)
+	require.Equal(t, []*bookcopy.BookCopy{onLoan}, report.OnLoan)
+	require.Equal(t, []*bookcopy.BookCopy{misplaced}, report.Misplaced)
+	require.Equal(t, []string{unknownBarcode}, report.UnknownBarcodes)
+
+	require.True(t, report.Session.ResultsKept)
+
+	// The Report of the closed Session is kept as it was, however the Book Copies change afterwards.
+	keptNotFound := keptBookCopy(notFound)
+	keptOnLoan := keptBookCopy(onLoan)
+	keptMisplaced := keptBookCopy(misplaced)
+	notFound.Status = bookcopy.StatusMissing
+	onLoan.Status = bookcopy.StatusAvailable
+	misplaced.CurrentLocationID = stacks.ID
+
+	closedReport, err := stocktakeService.Report(session.ID)
+	require.Nil(t, err)
+	require.Equal(t, 4, closedReport.Scanned)
+	require.Equal(t, 2, closedReport.Expected)
+	require.Equal(t, []*bookcopy.BookCopy{keptNotFound}, closedReport.NotFound)
+	require.Equal(t, []*bookcopy.BookCopy{keptOnLoan}, closedReport.OnLoan)
+	require.Equal(t, []*bookcopy.BookCopy{keptMisplaced}, closedReport.Misplaced)
+	require.Equal(t, []string{unknownBarcode}, closedReport.UnknownBarcodes)
+
+	// A closed Session can not be closed again.
+	_, err = stocktakeService.Close("librarian", session.ID)
+	require.Equal(t, ErrSessionClosed, err)
+}
+
+func TestReportClosedSession(t *testing.T) {
+	closedAt := time.Now()
+
+	// The Report of a Session closed before Results were kept is compared with the current Book Copies.
+	legacyLocationID := util.NewID()
+	legacySession := NewSession(util.NewID(), mainBranch.ID, legacyLocationID, SessionClosed, "librarian", "librarian", time.Now(), &closedAt, false)
+	stocktakeRepository.On("GetSession", legacySession.ID).Return(legacySession, nil)
+	stocktakeRepository.On("GetScans", legacySession.ID).Return([]*Scan{}, nil)
+
+	shelved := newBookCopy(bookcopy.StatusAvailable, legacyLocationID)
+	bookCopyRepository.On("ListByLocation", legacyLocationID).Return([]*bookcopy.BookCopy{shelved}, nil)
+
+	report, err := stocktakeService.Report(legacySession.ID)
+	require.Nil(t, err)
+	require.Equal(t, 1, report.Expected)
+	require.Equal(t, []*bookcopy.BookCopy{shelved}, report.NotFound)
+	stocktakeRepository.AssertNotCalled(t, "GetResults", legacySession.ID)
+
+	// The Report kept for a Session is read from its Results, even an empty one,
+	// and lists Book Copies deleted since as they were.
+	deletedBookCopyID := util.NewID()
+	keptSession := NewSession(util.NewID(), mainBranch.ID, reference.ID, SessionClosed, "librarian", "librarian", time.Now(), &closedAt, true)
+	emptySession := NewSession(util.NewID(), mainBranch.ID, reference.ID, SessionClosed, "librarian", "librarian", time.Now(), &closedAt, true)
+	stocktakeRepository.On("GetSession", keptSession.ID).Return(keptSession, nil)
+	stocktakeRepository.On("GetSession", emptySession.ID).Return(emptySession, nil)
+	stocktakeRepository.On("GetScans", keptSession.ID).Return([]*Scan{}, nil)
+	stocktakeRepository.On("GetScans", emptySession.ID).Return([]*Scan{}, nil)
+	stocktakeRepository.On("GetResults", keptSession.ID).Return([]*Result{
+		NewResult(keptSession.ID, ResultExpected, "deleted", deletedBookCopyID, "", bookcopy.StatusAvailable, mainBranch.ID, reference.ID),
+		NewResult(keptSession.ID, ResultNotFound, "deleted", deletedBookCopyID, "", bookcopy.StatusAvailable, mainBranch.ID, reference.ID),
+	}, nil)
+	stocktakeRepository.On("GetResults", emptySession.ID).Return([]*Result{}, nil)
+	bookCopyRepository.On("Get", deletedBookCopyID).Return(nil, errors.New("sql: no rows in result set"))
+
+	report, err = stocktakeService.Report(keptSession.ID)
+	require.Nil(t, err)
+	require.Equal(t, 1, report.Expected)
+	require.Len(t, report.NotFound, 1)
+	require.Equal(t, deletedBookCopyID, report.NotFound[0].ID)
+	require.Equal(t, reference.ID, report.NotFound[0].CurrentLocationID)
+
+	report, err = stocktakeService.Report(emptySession.ID)
+	require.Nil(t, err)
+	require.Equal(t, 0, report.Expected)
+	require.Empty(t, report.NotFound)
+	bookCopyRepository.AssertNotCalled(t, "ListByLocation", reference.ID)
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type fileSystemStore struct {
+	directory string
+}
+
+// NewFileSystemStore returns a BlobStore keeping each blob in a file of the
+// directory, at the path of its key. The media type of a blob is sniffed
+// from its data when it is read.
+func NewFileSystemStore(directory string) BlobStore {
+	return &fileSystemStore{
+		directory: directory,
+	}
+}
+
+func (store *fileSystemStore) Put(key string, data []byte, contentType string) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	// The blob is written aside and moved in place, so that it is never read half written.
+	temporary := path + ".tmp"
+	err = ioutil.WriteFile(temporary, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(temporary, path)
+}
+
+func (store *fileSystemStore) Get(key string) (*Blob, error) {
+	path, err := store.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Blob{
+		Data:        data,
+		ContentType: http.DetectContentType(data),
+		ModifiedAt:  info.ModTime(),
+	}, nil
+}
+
+func (store *fileSystemStore) Delete(key string) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// path returns the path of the file of the key, which must not lead outside of the directory.
+func (store *fileSystemStore) path(key string) (string, error) {
+	if key == "" || strings.Contains(key, "..") || strings.HasPrefix(key, "/") {
+		return "", ErrInvalidKey
+	}
+
+	return filepath.Join(store.directory, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,61 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package storage
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockBlobStore is an autogenerated mock type for the BlobStore type
+type MockBlobStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: key
+func (_m *MockBlobStore) Delete(key string) error {
+	ret := _m.Called(key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: key
+func (_m *MockBlobStore) Get(key string) (*Blob, error) {
+	ret := _m.Called(key)
+
+	var r0 *Blob
+	if rf, ok := ret.Get(0).(func(string) *Blob); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Blob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Put provides a mock function with given fields: key, data, contentType
+func (_m *MockBlobStore) Put(key string, data []byte, contentType string) error {
+	ret := _m.Called(key, data, contentType)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []byte, string) error); ok {
+		r0 = rf(key, data, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,248 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service        = "s3"
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFormat    = "20060102T150405Z"
+	requestTimeout   = 30 * time.Second
+)
+
+type s3Store struct {
+	endpoint  string
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+	now       func() time.Time
+}
+
+// NewS3Store returns a BlobStore keeping each blob as an object of the bucket
+// of an S3-compatible object storage, such as MinIO, at the endpoint. Objects
+// are addressed path-style, as /bucket/key, and requests are signed with
+// Signature Version 4.
+func NewS3Store(endpoint string, bucket string, region string, accessKey string, secretKey string) BlobStore {
+	return &s3Store{
+		endpoint:  strings.TrimRight(endpoint, "/"),
+		bucket:    bucket,
+		region:    region,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		client:    &http.Client{Timeout: requestTimeout},
+		now:       time.Now,
+	}
+}
+
+func (store *s3Store) Put(key string, data []byte, contentType string) error {
+	response, err := store.do("PUT", key, data, contentType)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return statusError(response)
+	}
+
+	return nil
+}
+
+func (store *s3Store) Get(key string) (*Blob, error) {
+	response, err := store.do("GET", key, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, ErrBlobNotFound
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, statusError(response)
+	}
+
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	modifiedAt, err := http.ParseTime(response.Header.Get("Last-Modified"))
+	if err != nil {
+		modifiedAt = time.Time{}
+	}
+
+	return &Blob{
+		Data:        data,
+		ContentType: response.Header.Get("Content-Type"),
+		ModifiedAt:  modifiedAt,
+	}, nil
+}
+
+func (store *s3Store) Delete(key string) error {
+	response, err := store.do("DELETE", key, nil, "")
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	// Deleting an object which does not exist is not an error in S3 either.
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNotFound {
+		return statusError(response)
+	}
+
+	return nil
+}
+
+func (store *s3Store) do(method string, key string, data []byte, contentType string) (*http.Response, error) {
+	if key == "" || strings.Contains(key, "..") {
+		return nil, ErrInvalidKey
+	}
+
+	request, err := http.NewRequest(method, store.endpoint+"/"+store.bucket+"/"+key, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	sign(request, data, store.accessKey, store.secretKey, store.region, store.now())
+
+	return store.client.Do(request)
+}
+
+func statusError(response *http.Response) error {
+	return fmt.Errorf("Object storage responded with status %d", response.StatusCode)
+}
+
+// sign adds the Signature Version 4 authorization of the request with its payload,
+// signing its host, range, content type and x-amz- headers.
+func sign(request *http.Request, payload []byte, accessKey string, secretKey string, region string, now time.Time) {
+	amzDate := now.UTC().Format(amzDateFormat)
+	date := amzDate[:8]
+	payloadHash := hashHex(payload)
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	host := request.Host
+	if host == "" {
+		host = request.URL.Host
+	}
+
+	headers := map[string]string{"host": host}
+	for name, values := range request.Header {
+		name = strings.ToLower(name)
+		if name == "range" || name == "content-type" || strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+
+	names := []string{}
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		canonicalURI(request.URL),
+		canonicalQuery(request.URL),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/" + s3Service + "/aws4_request"
+	stringToSign := strings.Join([]string{signingAlgorithm, amzDate, scope, hashHex([]byte(canonicalRequest))}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, s3Service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s,SignedHeaders=%s,Signature=%s", signingAlgorithm, accessKey, scope, signedHeaders, signature))
+}
+
+// canonicalURI encodes each of the segments of the path, but not the slashes between them.
+func canonicalURI(u *url.URL) string {
+	segments := strings.Split(u.Path, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+
+	path := strings.Join(segments, "/")
+	if path == "" {
+		return "/"
+	}
+
+	return path
+}
+
+func canonicalQuery(u *url.URL) string {
+	query := u.Query()
+
+	keys := []string{}
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parameters := []string{}
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			parameters = append(parameters, uriEncode(key)+"="+uriEncode(value))
+		}
+	}
+
+	return strings.Join(parameters, "&")
+}
+
+// uriEncode percent-encodes everything but the unreserved characters, as Signature Version 4 does.
+func uriEncode(value string) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		if ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') || b == '-' || b == '_' || b == '.' || b == '~' {
+			encoded.WriteByte(b)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+
+	return encoded.String()
+}
+
+func hashHex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
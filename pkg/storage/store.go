@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// Backends a BlobStore can be configured with in BLOB_STORE.
+const (
+	BackendFileSystem = "filesystem"
+	BackendS3         = "s3"
+)
+
+// Defaults of the configuration of the backends.
+const (
+	DefaultDirectory = "blobs"
+	DefaultRegion    = "us-east-1"
+)
+
+// Errors definition.
+var (
+	ErrBlobNotFound = errors.New("Blob not found")
+	ErrInvalidKey   = errors.New("Invalid blob key")
+)
+
+// Blob is the data stored under a key along with its media type.
+type Blob struct {
+	Data        []byte
+	ContentType string
+	ModifiedAt  time.Time
+}
+
+// BlobStore stores blobs of data, such as images, under slash separated keys.
+// Get returns ErrBlobNotFound for a key nothing is stored under.
+type BlobStore interface {
+	Put(key string, data []byte, contentType string) error
+	Get(key string) (*Blob, error)
+	Delete(key string) error
+}
+
+// NewBlobStoreFromEnv creates the BlobStore configured by BLOB_STORE, which is
+// either the local file system, in BLOB_DIR, or an S3-compatible object storage,
+// at S3_ENDPOINT in the S3_BUCKET with the S3_REGION, S3_ACCESS_KEY and S3_SECRET_KEY.
+func NewBlobStoreFromEnv() BlobStore {
+	if os.Getenv("BLOB_STORE") == BackendS3 {
+		region := os.Getenv("S3_REGION")
+		if region == "" {
+			region = DefaultRegion
+		}
+
+		return NewS3Store(os.Getenv("S3_ENDPOINT"), os.Getenv("S3_BUCKET"), region, os.Getenv("S3_ACCESS_KEY"), os.Getenv("S3_SECRET_KEY"))
+	}
+
+	directory := os.Getenv("BLOB_DIR")
+	if directory == "" {
+		directory = DefaultDirectory
+	}
+
+	return NewFileSystemStore(directory)
+}
@@ -0,0 +1,8 @@
+package pkg
+
+// Transaction is a unit of work of the store. The writes of the repositories
+// bound to a Transaction are committed, or rolled back, all together.
+type Transaction interface {
+	Commit() error
+	Rollback() error
+}
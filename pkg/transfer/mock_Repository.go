@@ -0,0 +1,167 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package transfer
+
+import (
+	pkg "github.com/joshuabezaleel/library-server/pkg"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+// Begin provides a mock function with given fields:
+func (_m *MockRepository) Begin() (pkg.Transaction, error) {
+	ret := _m.Called()
+
+	var r0 pkg.Transaction
+	if rf, ok := ret.Get(0).(func() pkg.Transaction); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(pkg.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByBookCopyID provides a mock function with given fields: bookCopyID
+func (_m *MockRepository) GetByBookCopyID(bookCopyID string) ([]*Transfer, error) {
+	ret := _m.Called(bookCopyID)
+
+	var r0 []*Transfer
+	if rf, ok := ret.Get(0).(func(string) []*Transfer); ok {
+		r0 = rf(bookCopyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Transfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(bookCopyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInTransitFrom provides a mock function with given fields: branchID
+func (_m *MockRepository) GetInTransitFrom(branchID string) ([]*Transfer, error) {
+	ret := _m.Called(branchID)
+
+	var r0 []*Transfer
+	if rf, ok := ret.Get(0).(func(string) []*Transfer); ok {
+		r0 = rf(branchID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Transfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(branchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInTransitTo provides a mock function with given fields: branchID
+func (_m *MockRepository) GetInTransitTo(branchID string) ([]*Transfer, error) {
+	ret := _m.Called(branchID)
+
+	var r0 []*Transfer
+	if rf, ok := ret.Get(0).(func(string) []*Transfer); ok {
+		r0 = rf(branchID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Transfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(branchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Receive provides a mock function with given fields: transfer
+func (_m *MockRepository) Receive(transfer *Transfer) (*Transfer, error) {
+	ret := _m.Called(transfer)
+
+	var r0 *Transfer
+	if rf, ok := ret.Get(0).(func(*Transfer) *Transfer); ok {
+		r0 = rf(transfer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Transfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Transfer) error); ok {
+		r1 = rf(transfer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: transfer
+func (_m *MockRepository) Save(transfer *Transfer) (*Transfer, error) {
+	ret := _m.Called(transfer)
+
+	var r0 *Transfer
+	if rf, ok := ret.Get(0).(func(*Transfer) *Transfer); ok {
+		r0 = rf(transfer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Transfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*Transfer) error); ok {
+		r1 = rf(transfer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithTransaction provides a mock function with given fields: tx
+func (_m *MockRepository) WithTransaction(tx pkg.Transaction) Repository {
+	ret := _m.Called(tx)
+
+	var r0 Repository
+	if rf, ok := ret.Get(0).(func(pkg.Transaction) Repository); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Repository)
+		}
+	}
+
+	return r0
+}
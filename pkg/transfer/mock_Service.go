@@ -0,0 +1,144 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package transfer
+
+import (
+	pkg "github.com/joshuabezaleel/library-server/pkg"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+// GetHistory provides a mock function with given fields: bookCopyID
+func (_m *MockService) GetHistory(bookCopyID string) ([]*Transfer, error) {
+	ret := _m.Called(bookCopyID)
+
+	var r0 []*Transfer
+	if rf, ok := ret.Get(0).(func(string) []*Transfer); ok {
+		r0 = rf(bookCopyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Transfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(bookCopyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIncoming provides a mock function with given fields: branchID
+func (_m *MockService) GetIncoming(branchID string) ([]*Slip, error) {
+	ret := _m.Called(branchID)
+
+	var r0 []*Slip
+	if rf, ok := ret.Get(0).(func(string) []*Slip); ok {
+		r0 = rf(branchID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Slip)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(branchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSlips provides a mock function with given fields: branchID
+func (_m *MockService) GetSlips(branchID string) ([]*Slip, error) {
+	ret := _m.Called(branchID)
+
+	var r0 []*Slip
+	if rf, ok := ret.Get(0).(func(string) []*Slip); ok {
+		r0 = rf(branchID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Slip)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(branchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Receive provides a mock function with given fields: username, branchID, barcode
+func (_m *MockService) Receive(username string, branchID string, barcode string) (*Transfer, error) {
+	ret := _m.Called(username, branchID, barcode)
+
+	var r0 *Transfer
+	if rf, ok := ret.Get(0).(func(string, string, string) *Transfer); ok {
+		r0 = rf(username, branchID, barcode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Transfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(username, branchID, barcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Send provides a mock function with given fields: username, bookCopyID, toBranchID, note
+func (_m *MockService) Send(username string, bookCopyID string, toBranchID string, note string) (*Transfer, error) {
+	ret := _m.Called(username, bookCopyID, toBranchID, note)
+
+	var r0 *Transfer
+	if rf, ok := ret.Get(0).(func(string, string, string, string) *Transfer); ok {
+		r0 = rf(username, bookCopyID, toBranchID, note)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Transfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, string) error); ok {
+		r1 = rf(username, bookCopyID, toBranchID, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithTransaction provides a mock function with given fields: tx
+func (_m *MockService) WithTransaction(tx pkg.Transaction) Service {
+	ret := _m.Called(tx)
+
+	var r0 Service
+	if rf, ok := ret.Get(0).(func(pkg.Transaction) Service); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Service)
+		}
+	}
+
+	return r0
+}
@@ -0,0 +1,19 @@
+package transfer
+
+import (
+	util "github.com/joshuabezaleel/library-server/pkg"
+)
+
+// Repository provides access to the Transfer store.
+type Repository interface {
+	Save(transfer *Transfer) (*Transfer, error)
+	Receive(transfer *Transfer) (*Transfer, error)
+	GetByBookCopyID(bookCopyID string) ([]*Transfer, error)
+	GetInTransitFrom(branchID string) ([]*Transfer, error)
+	GetInTransitTo(branchID string) ([]*Transfer, error)
+
+	// Begin begins the Transaction a Book Copy is sent in.
+	Begin() (util.Transaction, error)
+	// WithTransaction returns the repository working in the Transaction.
+	WithTransaction(tx util.Transaction) Repository
+}
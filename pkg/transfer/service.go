@@ -0,0 +1,299 @@
+package transfer
+
+import (
+	"errors"
+	"time"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+)
+
+// Errors definition.
+var (
+	ErrSendTransfer      = errors.New("Error sending Transfer")
+	ErrReceiveTransfer   = errors.New("Error receiving Transfer")
+	ErrGetTransfers      = errors.New("Error retrieving Transfers")
+	ErrSameBranch        = errors.New("Book copy is already at the destination Branch")
+	ErrNoCurrentBranch   = errors.New("Book copy is not shelved in any Branch")
+	ErrAlreadyInTransit  = errors.New("Book copy is already in transit")
+	ErrNotInTransit      = errors.New("Book copy is not in transit")
+	ErrWrongDestination  = errors.New("Book copy is in transit to another Branch")
+	ErrInvalidSlipBranch = errors.New("Transfer slips need a Branch")
+)
+
+// Service provides basic operations on Transfer domain model.
+type Service interface {
+	Send(username string, bookCopyID string, toBranchID string, note string) (*Transfer, error)
+	Receive(username string, branchID string, barcode string) (*Transfer, error)
+	GetHistory(bookCopyID string) ([]*Transfer, error)
+	GetSlips(branchID string) ([]*Slip, error)
+	GetIncoming(branchID string) ([]*Slip, error)
+
+	WithTransaction(tx util.Transaction) Service
+}
+
+type service struct {
+	transferRepository Repository
+	bookCopyService    bookcopy.Service
+	bookService        book.Service
+	branchService      branch.Service
+	tx                 util.Transaction
+}
+
+// NewTransferService creates an instance of the service for the Transfer domain model
+// with all of the necessary dependencies.
+func NewTransferService(transferRepository Repository, bookCopyService bookcopy.Service, bookService book.Service, branchService branch.Service) Service {
+	return &service{
+		transferRepository: transferRepository,
+		bookCopyService:    bookCopyService,
+		bookService:        bookService,
+		branchService:      branchService,
+	}
+}
+
+// WithTransaction returns the service sending in the Transaction, so that a Book Copy
+// is sent along with the rest of what is committed or rolled back in it.
+func (s *service) WithTransaction(tx util.Transaction) Service {
+	return &service{
+		transferRepository: s.transferRepository.WithTransaction(tx),
+		bookCopyService:    s.bookCopyService.WithTransaction(tx),
+		bookService:        s.bookService,
+		branchService:      s.branchService,
+		tx:                 tx,
+	}
+}
+
+// Send ships the Book Copy from the Branch it is currently at to toBranchID
+// and marks it in transit until it is received there. The Book Copy is marked
+// in transit along with saving its Transfer, all at once or not at all.
+func (s *service) Send(username string, bookCopyID string, toBranchID string, note string) (*Transfer, error) {
+	toBranch, err := s.branchService.GetBranch(toBranchID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookCopy, err := s.bookCopyService.Get(bookCopyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if bookCopy.CurrentBranchID == "" {
+		return nil, ErrNoCurrentBranch
+	}
+	if bookCopy.CurrentBranchID == toBranch.ID {
+		return nil, ErrSameBranch
+	}
+
+	activeTransfer, err := s.activeTransfer(bookCopyID)
+	if err != nil {
+		return nil, err
+	}
+	if activeTransfer != nil {
+		return nil, ErrAlreadyInTransit
+	}
+
+	newTransfer := NewTransfer(util.NewID(), bookCopyID, bookCopy.CurrentBranchID, toBranch.ID, StatusInTransit, note, username, "", time.Now(), nil)
+
+	err = s.inTransaction(func(s *service) error {
+		if bookCopy.Status != bookcopy.StatusInTransit {
+			_, err := s.bookCopyService.ChangeStatus(bookCopyID, bookcopy.StatusInTransit, username, "Transfer to "+toBranch.Code)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := s.transferRepository.Save(newTransfer)
+		if err != nil {
+			return ErrSendTransfer
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newTransfer, nil
+}
+
+// Receive checks in the Book Copy with the barcode at branchID, the destination
+// of its Transfer. A Book Copy received at its home Branch goes back to its home Location.
+// The Book Copy stays in transit until it is shelved, or trapped for a Hold, by borrowing.
+func (s *service) Receive(username string, branchID string, barcode string) (*Transfer, error) {
+	bookCopy, err := s.bookCopyService.GetByBarcode(barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	activeTransfer, err := s.activeTransfer(bookCopy.ID)
+	if err != nil {
+		return nil, err
+	}
+	if activeTransfer == nil {
+		return nil, ErrNotInTransit
+	}
+	if activeTransfer.ToBranchID != branchID {
+		return nil, ErrWrongDestination
+	}
+
+	locationID := ""
+	if branchID == bookCopy.HomeBranchID {
+		locationID = bookCopy.HomeLocationID
+	}
+
+	_, err = s.bookCopyService.Relocate(bookCopy.ID, branchID, locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	receivedAt := time.Now()
+	activeTransfer.Status = StatusReceived
+	activeTransfer.ReceivedBy = username
+	activeTransfer.ReceivedAt = &receivedAt
+
+	receivedTransfer, err := s.transferRepository.Receive(activeTransfer)
+	if err != nil {
+		return nil, ErrReceiveTransfer
+	}
+
+	return receivedTransfer, nil
+}
+
+func (s *service) GetHistory(bookCopyID string) ([]*Transfer, error) {
+	transfers, err := s.transferRepository.GetByBookCopyID(bookCopyID)
+	if err != nil {
+		return nil, ErrGetTransfers
+	}
+
+	return transfers, nil
+}
+
+// GetSlips returns the slips of the Book Copies in transit from the Branch.
+func (s *service) GetSlips(branchID string) ([]*Slip, error) {
+	if branchID == "" {
+		return nil, ErrInvalidSlipBranch
+	}
+
+	transfers, err := s.transferRepository.GetInTransitFrom(branchID)
+	if err != nil {
+		return nil, ErrGetTransfers
+	}
+
+	return s.slips(transfers)
+}
+
+// GetIncoming returns the slips of the Book Copies in transit to the Branch.
+func (s *service) GetIncoming(branchID string) ([]*Slip, error) {
+	if branchID == "" {
+		return nil, ErrInvalidSlipBranch
+	}
+
+	transfers, err := s.transferRepository.GetInTransitTo(branchID)
+	if err != nil {
+		return nil, ErrGetTransfers
+	}
+
+	return s.slips(transfers)
+}
+
+// inTransaction runs the operation on the service sending in a Transaction, which
+// is the Transaction of the service if it has one.
+func (s *service) inTransaction(operation func(s *service) error) error {
+	if s.tx != nil {
+		return operation(s)
+	}
+
+	tx, err := s.transferRepository.Begin()
+	if err != nil {
+		return ErrSendTransfer
+	}
+
+	err = operation(s.WithTransaction(tx).(*service))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return ErrSendTransfer
+	}
+
+	return nil
+}
+
+// activeTransfer returns the Transfer the Book Copy is in transit with,
+// or nil if it is not in transit.
+func (s *service) activeTransfer(bookCopyID string) (*Transfer, error) {
+	transfers, err := s.GetHistory(bookCopyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, transfer := range transfers {
+		if transfer.Status == StatusInTransit {
+			return transfer, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *service) slips(transfers []*Transfer) ([]*Slip, error) {
+	branchCodes := map[string]string{}
+
+	slips := []*Slip{}
+	for _, transfer := range transfers {
+		bookCopy, err := s.bookCopyService.Get(transfer.BookCopyID)
+		if err != nil {
+			return nil, err
+		}
+
+		book, err := s.bookService.Get(bookCopy.BookID)
+		if err != nil {
+			return nil, err
+		}
+
+		fromBranch, err := s.branchCode(branchCodes, transfer.FromBranchID)
+		if err != nil {
+			return nil, err
+		}
+
+		toBranch, err := s.branchCode(branchCodes, transfer.ToBranchID)
+		if err != nil {
+			return nil, err
+		}
+
+		slips = append(slips, &Slip{
+			TransferID: transfer.ID,
+			BookCopyID: bookCopy.ID,
+			Barcode:    bookCopy.Barcode,
+			Title:      book.Title,
+			CallNumber: book.CallNumber,
+			FromBranch: fromBranch,
+			ToBranch:   toBranch,
+			Note:       transfer.Note,
+			SentAt:     transfer.SentAt,
+		})
+	}
+
+	return slips, nil
+}
+
+// branchCode returns the Code of the Branch, looking it up only once per slip list.
+func (s *service) branchCode(branchCodes map[string]string, branchID string) (string, error) {
+	if code, ok := branchCodes[branchID]; ok {
+		return code, nil
+	}
+
+	branch, err := s.branchService.GetBranch(branchID)
+	if err != nil {
+		return "", err
+	}
+
+	branchCodes[branchID] = branch.Code
+
+	return branch.Code, nil
+}
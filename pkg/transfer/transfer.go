@@ -0,0 +1,57 @@
+package transfer
+
+import (
+	"time"
+)
+
+// Statuses of a Transfer.
+const (
+	StatusInTransit = "in-transit"
+	StatusReceived  = "received"
+)
+
+// Transfer domain model. A Transfer records a Book Copy being shipped
+// from one Branch to another, from the moment it is sent until it is
+// received by scanning its barcode at the destination.
+type Transfer struct {
+	ID           string     `json:"id" db:"id"`
+	BookCopyID   string     `json:"bookCopyID" db:"bookcopy_id"`
+	FromBranchID string     `json:"fromBranchID" db:"from_branch_id"`
+	ToBranchID   string     `json:"toBranchID" db:"to_branch_id"`
+	Status       string     `json:"status" db:"status"`
+	Note         string     `json:"note" db:"note"`
+	SentBy       string     `json:"sentBy" db:"sent_by"`
+	ReceivedBy   string     `json:"receivedBy" db:"received_by"`
+	SentAt       time.Time  `json:"sentAt" db:"sent_at"`
+	ReceivedAt   *time.Time `json:"receivedAt" db:"received_at"`
+}
+
+// NewTransfer creates a new instance of Transfer domain model.
+func NewTransfer(id string, bookCopyID string, fromBranchID string, toBranchID string, status string, note string, sentBy string, receivedBy string, sentAt time.Time, receivedAt *time.Time) *Transfer {
+	return &Transfer{
+		ID:           id,
+		BookCopyID:   bookCopyID,
+		FromBranchID: fromBranchID,
+		ToBranchID:   toBranchID,
+		Status:       status,
+		Note:         note,
+		SentBy:       sentBy,
+		ReceivedBy:   receivedBy,
+		SentAt:       sentAt,
+		ReceivedAt:   receivedAt,
+	}
+}
+
+// Slip is what is printed and packed with a Book Copy in transit,
+// telling the staff of both Branches what to ship and where to.
+type Slip struct {
+	TransferID string    `json:"transferID"`
+	BookCopyID string    `json:"bookCopyID"`
+	Barcode    string    `json:"barcode"`
+	Title      string    `json:"title"`
+	CallNumber string    `json:"callNumber"`
+	FromBranch string    `json:"fromBranch"`
+	ToBranch   string    `json:"toBranch"`
+	Note       string    `json:"note"`
+	SentAt     time.Time `json:"sentAt"`
+}
@@ -0,0 +1,264 @@
+package transfer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/barcode"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+)
+
+var transferRepository = &MockRepository{}
+var bookRepository = &book.MockRepository{}
+var bookCopyRepository = &bookcopy.MockRepository{}
+var branchRepository = &branch.MockRepository{}
+
+var bookService = book.NewBookService(bookRepository)
+var branchService = branch.NewBranchService(branchRepository)
+var bookCopyService = bookcopy.NewBookCopyService(bookCopyRepository, bookService, branchService, barcode.NewScheme([]string{}, map[string]string{}, barcode.DefaultLength))
+var transferService = NewTransferService(transferRepository, bookCopyService, bookService, branchService)
+
+var mainBranch = &branch.Branch{ID: util.NewID(), Code: "MAIN"}
+var engineeringBranch = &branch.Branch{ID: util.NewID(), Code: "ENG"}
+var stacks = &branch.Location{ID: util.NewID(), BranchID: mainBranch.ID, Name: "Stacks"}
+
+// tx is the Transaction every Book Copy is sent in.
+var tx = &transaction{}
+
+type transaction struct {
+	commits   int
+	rollbacks int
+}
+
+func (tx *transaction) Commit() error {
+	tx.commits++
+	return nil
+}
+
+func (tx *transaction) Rollback() error {
+	tx.rollbacks++
+	return nil
+}
+
+func init() {
+	transferRepository.On("Begin").Return(tx, nil)
+	transferRepository.On("WithTransaction", tx).Return(transferRepository)
+	bookCopyRepository.On("WithTransaction", tx).Return(bookCopyRepository)
+	bookRepository.On("WithTransaction", tx).Return(bookRepository)
+
+	branchRepository.On("GetBranch", mainBranch.ID).Return(mainBranch, nil)
+	branchRepository.On("GetBranch", engineeringBranch.ID).Return(engineeringBranch, nil)
+	branchRepository.On("GetLocation", stacks.ID).Return(stacks, nil)
+
+	// Every status change of a Book Copy is recorded.
+	bookCopyRepository.On("UpdateStatus", mock.AnythingOfType("*bookcopy.BookCopy")).Return(nil)
+	bookCopyRepository.On("SaveStatusChange", mock.AnythingOfType("*bookcopy.StatusChange")).Return(nil, nil)
+}
+
+func newBookCopy(status string, currentBranchID string) *bookcopy.BookCopy {
+	bookCopy := &bookcopy.BookCopy{
+		ID:              util.NewID(),
+		Barcode:         util.NewID(),
+		BookID:          util.NewID(),
+		Status:          status,
+		HomeBranchID:    mainBranch.ID,
+		HomeLocationID:  stacks.ID,
+		CurrentBranchID: currentBranchID,
+	}
+	bookCopyRepository.On("Get", bookCopy.ID).Return(bookCopy, nil)
+	bookCopyRepository.On("GetByBarcode", bookCopy.Barcode).Return(bookCopy, nil)
+
+	return bookCopy
+}
+
+func TestSend(t *testing.T) {
+	tt := []struct {
+		name       string
+		status     string
+		current    string
+		toBranchID string
+		history    []*Transfer
+		err        error
+	}{
+		{
+			name:       "success sending an available Book Copy home",
+			status:     bookcopy.StatusAvailable,
+			current:    engineeringBranch.ID,
+			toBranchID: mainBranch.ID,
+			history:    []*Transfer{},
+			err:        nil,
+		},
+		{
+			name:       "Book Copy already at the destination",
+			status:     bookcopy.StatusAvailable,
+			current:    mainBranch.ID,
+			toBranchID: mainBranch.ID,
+			history:    []*Transfer{},
+			err:        ErrSameBranch,
+		},
+		{
+			name:       "Book Copy not shelved in any Branch",
+			status:     bookcopy.StatusAvailable,
+			current:    "",
+			toBranchID: mainBranch.ID,
+			history:    []*Transfer{},
+			err:        ErrNoCurrentBranch,
+		},
+		{
+			name:       "Book Copy already in transit",
+			status:     bookcopy.StatusInTransit,
+			current:    engineeringBranch.ID,
+			toBranchID: mainBranch.ID,
+			history:    []*Transfer{{ID: util.NewID(), Status: StatusInTransit}},
+			err:        ErrAlreadyInTransit,
+		},
+		{
+			name:       "Book Copy that can not be sent",
+			status:     bookcopy.StatusWithdrawn,
+			current:    engineeringBranch.ID,
+			toBranchID: mainBranch.ID,
+			history:    []*Transfer{},
+			err:        bookcopy.ErrInvalidTransition,
+		},
+		{
+			name:       "unknown destination",
+			status:     bookcopy.StatusAvailable,
+			current:    engineeringBranch.ID,
+			toBranchID: util.NewID(),
+			history:    []*Transfer{},
+			err:        branch.ErrGetBranch,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookCopy := newBookCopy(tc.status, tc.current)
+			transferRepository.On("GetByBookCopyID", bookCopy.ID).Return(tc.history, nil)
+			transferRepository.On("Save", mock.MatchedBy(func(transfer *Transfer) bool { return transfer.BookCopyID == bookCopy.ID })).Return(func(transfer *Transfer) *Transfer { return transfer }, nil)
+			if tc.err == branch.ErrGetBranch {
+				branchRepository.On("GetBranch", tc.toBranchID).Return(nil, branch.ErrGetBranch)
+			}
+
+			transfer, err := transferService.Send("librarian", bookCopy.ID, tc.toBranchID, "")
+
+			require.Equal(t, tc.err, err)
+			if tc.err == nil {
+				require.Equal(t, StatusInTransit, transfer.Status)
+				require.Equal(t, engineeringBranch.ID, transfer.FromBranchID)
+				require.Equal(t, mainBranch.ID, transfer.ToBranchID)
+				require.Equal(t, bookcopy.StatusInTransit, bookCopy.Status)
+			}
+		})
+	}
+}
+
+func TestSendRollback(t *testing.T) {
+	bookCopy := newBookCopy(bookcopy.StatusAvailable, engineeringBranch.ID)
+	transferRepository.On("GetByBookCopyID", bookCopy.ID).Return([]*Transfer{}, nil)
+	transferRepository.On("Save", mock.MatchedBy(func(transfer *Transfer) bool { return transfer.BookCopyID == bookCopy.ID })).Return(nil, ErrSendTransfer)
+
+	rollbacks := tx.rollbacks
+
+	// The Book Copy is not left in transit without a Transfer.
+	transfer, err := transferService.Send("librarian", bookCopy.ID, mainBranch.ID, "")
+
+	require.Nil(t, transfer)
+	require.Equal(t, ErrSendTransfer, err)
+	require.Equal(t, rollbacks+1, tx.rollbacks)
+}
+
+func TestReceive(t *testing.T) {
+	tt := []struct {
+		name              string
+		branchID          string
+		toBranchID        string
+		inTransit         bool
+		currentLocationID string
+		err               error
+	}{
+		{
+			name:              "success receiving a Book Copy at home",
+			branchID:          mainBranch.ID,
+			toBranchID:        mainBranch.ID,
+			inTransit:         true,
+			currentLocationID: stacks.ID,
+			err:               nil,
+		},
+		{
+			name:              "success receiving a Book Copy away from home",
+			branchID:          engineeringBranch.ID,
+			toBranchID:        engineeringBranch.ID,
+			inTransit:         true,
+			currentLocationID: "",
+			err:               nil,
+		},
+		{
+			name:       "Book Copy received at the wrong Branch",
+			branchID:   engineeringBranch.ID,
+			toBranchID: mainBranch.ID,
+			inTransit:  true,
+			err:        ErrWrongDestination,
+		},
+		{
+			name:       "Book Copy not in transit",
+			branchID:   mainBranch.ID,
+			toBranchID: mainBranch.ID,
+			inTransit:  false,
+			err:        ErrNotInTransit,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookCopy := newBookCopy(bookcopy.StatusInTransit, util.NewID())
+			bookCopyRepository.On("UpdateLocation", bookCopy).Return(nil)
+
+			history := []*Transfer{NewTransfer(util.NewID(), bookCopy.ID, bookCopy.CurrentBranchID, tc.toBranchID, StatusReceived, "", "librarian", "librarian", time.Now(), nil)}
+			if tc.inTransit {
+				history[0].Status = StatusInTransit
+			}
+			transferRepository.On("GetByBookCopyID", bookCopy.ID).Return(history, nil)
+			transferRepository.On("Receive", history[0]).Return(history[0], nil)
+
+			transfer, err := transferService.Receive("librarian", tc.branchID, bookCopy.Barcode)
+
+			require.Equal(t, tc.err, err)
+			if tc.err == nil {
+				require.Equal(t, StatusReceived, transfer.Status)
+				require.NotNil(t, transfer.ReceivedAt)
+				require.Equal(t, bookcopy.StatusInTransit, bookCopy.Status)
+				require.Equal(t, tc.branchID, bookCopy.CurrentBranchID)
+				require.Equal(t, tc.currentLocationID, bookCopy.CurrentLocationID)
+			}
+		})
+	}
+}
+
+func TestGetSlips(t *testing.T) {
+	bookCopy := newBookCopy(bookcopy.StatusInTransit, engineeringBranch.ID)
+	slipBook := &book.Book{ID: bookCopy.BookID, Title: "The Go Programming Language", CallNumber: "QA76.73.G63 D66 2016"}
+	bookRepository.On("Get", slipBook.ID).Return(slipBook, nil)
+	bookRepository.On("GetBookSubjectIDs", slipBook.ID).Return([]int64{}, nil)
+	bookRepository.On("GetSubjectsByID", []int64{}).Return([]string{}, nil)
+	bookRepository.On("GetBookAuthors", slipBook.ID).Return([]*book.BookAuthor{}, nil)
+
+	transfers := []*Transfer{NewTransfer(util.NewID(), bookCopy.ID, engineeringBranch.ID, mainBranch.ID, StatusInTransit, "", "librarian", "", time.Now(), nil)}
+	transferRepository.On("GetInTransitFrom", engineeringBranch.ID).Return(transfers, nil)
+
+	slips, err := transferService.GetSlips(engineeringBranch.ID)
+	require.Nil(t, err)
+	require.Len(t, slips, 1)
+	require.Equal(t, bookCopy.Barcode, slips[0].Barcode)
+	require.Equal(t, slipBook.Title, slips[0].Title)
+	require.Equal(t, "ENG", slips[0].FromBranch)
+	require.Equal(t, "MAIN", slips[0].ToBranch)
+
+	_, err = transferService.GetSlips("")
+	require.Equal(t, ErrInvalidSlipBranch, err)
+}
@@ -0,0 +1,183 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/joshuabezaleel/library-server/pkg/auth"
+	"github.com/joshuabezaleel/library-server/pkg/core/author"
+
+	"github.com/gorilla/mux"
+)
+
+type authorHandler struct {
+	authorService author.Service
+	authService   auth.Service
+}
+
+type mergeAuthorRequest struct {
+	DuplicateID int64 `json:"duplicateID"`
+}
+
+func (handler *authorHandler) registerRouter(router *mux.Router) {
+	router.HandleFunc("/authors", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.createAuthor))).Methods("POST")
+	router.HandleFunc("/authors", handler.getAuthors).Methods("GET")
+	router.HandleFunc("/authors/{authorID}", handler.getAuthor).Methods("GET")
+	router.HandleFunc("/authors/{authorID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.updateAuthor))).Methods("PUT")
+	router.HandleFunc("/authors/{authorID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.deleteAuthor))).Methods("DELETE")
+	router.HandleFunc("/authors/{authorID}/merge", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.mergeAuthors))).Methods("POST")
+	router.HandleFunc("/authors/{authorID}/books", handler.getAuthorBooks).Methods("GET")
+}
+
+func (handler *authorHandler) createAuthor(w http.ResponseWriter, r *http.Request) {
+	newAuthor := author.Author{}
+
+	err := json.NewDecoder(r.Body).Decode(&newAuthor)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	createdAuthor, err := handler.authorService.Create(&newAuthor)
+	if err == author.ErrInvalidAuthor || err == author.ErrInvalidLifespan {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, createdAuthor)
+}
+
+func (handler *authorHandler) getAuthors(w http.ResponseWriter, r *http.Request) {
+	authors, err := handler.authorService.List()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, authors)
+}
+
+func (handler *authorHandler) getAuthor(w http.ResponseWriter, r *http.Request) {
+	authorID, ok := int64FromPath(r, "authorID")
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	retrievedAuthor, err := handler.authorService.Get(authorID)
+	if err == author.ErrGetAuthor {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, retrievedAuthor)
+}
+
+func (handler *authorHandler) updateAuthor(w http.ResponseWriter, r *http.Request) {
+	updatedAuthor := author.Author{}
+
+	err := json.NewDecoder(r.Body).Decode(&updatedAuthor)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	authorID, ok := int64FromPath(r, "authorID")
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+	updatedAuthor.ID = authorID
+
+	savedAuthor, err := handler.authorService.Update(&updatedAuthor)
+	if err == author.ErrInvalidAuthor || err == author.ErrInvalidLifespan {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, savedAuthor)
+}
+
+func (handler *authorHandler) deleteAuthor(w http.ResponseWriter, r *http.Request) {
+	authorID, ok := int64FromPath(r, "authorID")
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	err := handler.authorService.Delete(authorID)
+	if err == author.ErrAuthorHasBooks {
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, "Author "+strconv.FormatInt(authorID, 10)+" deleted")
+}
+
+func (handler *authorHandler) mergeAuthors(w http.ResponseWriter, r *http.Request) {
+	request := mergeAuthorRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	authorID, ok := int64FromPath(r, "authorID")
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	mergedAuthor, err := handler.authorService.Merge(authorID, request.DuplicateID)
+	switch err {
+	case nil:
+		respondWithJSON(w, http.StatusOK, mergedAuthor)
+	case author.ErrMergeSameAuthor:
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case author.ErrGetAuthor:
+		respondWithError(w, http.StatusNotFound, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func (handler *authorHandler) getAuthorBooks(w http.ResponseWriter, r *http.Request) {
+	authorID, ok := int64FromPath(r, "authorID")
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	books, err := handler.authorService.GetBooks(authorID)
+	if err == author.ErrGetAuthor {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, books)
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/author"
+)
+
+func TestAuthorCreate(t *testing.T) {
+	validAuthor := &author.Author{Name: "Ursula K. Le Guin", BirthYear: 1929, DeathYear: 2018}
+	invalidAuthor := &author.Author{Name: "Time Traveller", BirthYear: 2018, DeathYear: 1929}
+	failedAuthor := &author.Author{Name: "Octavia E. Butler"}
+
+	tt := []struct {
+		name              string
+		requestPayload    interface{}
+		mockReturnPayload interface{}
+		statusCode        int
+		err               error
+	}{
+		{
+			name:              "success creating a valid Author",
+			requestPayload:    validAuthor,
+			mockReturnPayload: &author.Author{ID: 1, Name: validAuthor.Name},
+			statusCode:        http.StatusCreated,
+			err:               nil,
+		},
+		{
+			name:              "invalid request payload",
+			requestPayload:    "a plain string, not an Author",
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               nil,
+		},
+		{
+			name:              "invalid lifespan of the Author",
+			requestPayload:    invalidAuthor,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               author.ErrInvalidLifespan,
+		},
+		{
+			name:              "failed creating an Author",
+			requestPayload:    failedAuthor,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusInternalServerError,
+			err:               errors.New("Error creating Author"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if requestAuthor, ok := tc.requestPayload.(*author.Author); ok {
+				authorService.On("Create", mock.MatchedBy(func(a *author.Author) bool { return a.Name == requestAuthor.Name })).Return(tc.mockReturnPayload, tc.err)
+			}
+
+			reqByte, err := json.Marshal(tc.requestPayload)
+			require.Nil(t, err)
+
+			req := httptest.NewRequest("POST", "/authors", bytes.NewReader(reqByte))
+			w := httptest.NewRecorder()
+
+			authorTestingHandler.createAuthor(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestAuthorDelete(t *testing.T) {
+	tt := []struct {
+		name       string
+		authorID   string
+		statusCode int
+		err        error
+	}{
+		{
+			name:       "success deleting an Author",
+			authorID:   "1",
+			statusCode: http.StatusOK,
+			err:        nil,
+		},
+		{
+			name:       "invalid authorID",
+			authorID:   "one",
+			statusCode: http.StatusBadRequest,
+			err:        nil,
+		},
+		{
+			name:       "Author still has Books",
+			authorID:   "2",
+			statusCode: http.StatusConflict,
+			err:        author.ErrAuthorHasBooks,
+		},
+	}
+
+	authorService.On("Delete", int64(1)).Return(nil)
+	authorService.On("Delete", int64(2)).Return(author.ErrAuthorHasBooks)
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("DELETE", "/authors/"+tc.authorID, nil)
+			req = mux.SetURLVars(req, map[string]string{"authorID": tc.authorID})
+			w := httptest.NewRecorder()
+
+			authorTestingHandler.deleteAuthor(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestAuthorMerge(t *testing.T) {
+	tt := []struct {
+		name        string
+		authorID    string
+		duplicateID int64
+		statusCode  int
+		err         error
+	}{
+		{
+			name:        "success merging a duplicate Author",
+			authorID:    "10",
+			duplicateID: 11,
+			statusCode:  http.StatusOK,
+			err:         nil,
+		},
+		{
+			name:        "merging an Author into itself",
+			authorID:    "12",
+			duplicateID: 12,
+			statusCode:  http.StatusBadRequest,
+			err:         author.ErrMergeSameAuthor,
+		},
+		{
+			name:        "duplicate Author does not exist",
+			authorID:    "13",
+			duplicateID: 99,
+			statusCode:  http.StatusNotFound,
+			err:         author.ErrGetAuthor,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var mergedAuthor *author.Author
+			if tc.err == nil {
+				mergedAuthor = &author.Author{ID: 10, Name: "Mark Twain"}
+			}
+			authorService.On("Merge", mock.AnythingOfType("int64"), tc.duplicateID).Return(mergedAuthor, tc.err)
+
+			reqByte, err := json.Marshal(mergeAuthorRequest{DuplicateID: tc.duplicateID})
+			require.Nil(t, err)
+
+			req := httptest.NewRequest("POST", "/authors/"+tc.authorID+"/merge", bytes.NewReader(reqByte))
+			req = mux.SetURLVars(req, map[string]string{"authorID": tc.authorID})
+			w := httptest.NewRecorder()
+
+			authorTestingHandler.mergeAuthors(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
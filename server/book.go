@@ -3,6 +3,7 @@ package server
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/joshuabezaleel/library-server/pkg/auth"
 	"github.com/joshuabezaleel/library-server/pkg/core/book"
@@ -18,24 +19,31 @@ type bookHandler struct {
 func (handler *bookHandler) registerRouter(router *mux.Router) {
 	// CRUD endpoints.
 	router.HandleFunc("/books", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.createBook))).Methods("POST")
+	router.HandleFunc("/books", handler.listBooks).Methods("GET")
+	router.HandleFunc("/books/lookup", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.lookupBook))).Methods("GET")
 	router.HandleFunc("/books/{bookID}", handler.getBook).Methods("GET")
 	router.HandleFunc("/books/{bookID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.updateBook))).Methods("PUT")
 	router.HandleFunc("/books/{bookID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.deleteBook))).Methods("DELETE")
 
 	// Other endpoints.
+	router.HandleFunc("/search", handler.searchBooks).Methods("GET")
 }
 
 func (handler *bookHandler) createBook(w http.ResponseWriter, r *http.Request) {
-	book := book.Book{}
+	requestBook := book.Book{}
 
-	err := json.NewDecoder(r.Body).Decode(&book)
+	err := json.NewDecoder(r.Body).Decode(&requestBook)
 	if err != nil {
 		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
 		return
 	}
 	defer r.Body.Close()
 
-	newBook, err := handler.bookService.Create(&book)
+	newBook, err := handler.bookService.Create(&requestBook)
+	if err == book.ErrInvalidAuthorRole || err == book.ErrInvalidBookAuthors || err == book.ErrUnknownAuthor || err == book.ErrUnknownSubject || err == book.ErrInvalidISBN {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -44,6 +52,29 @@ func (handler *bookHandler) createBook(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusCreated, newBook)
 }
 
+// lookupBook retrieves a draft of the Book with the ISBN of the query
+// from the metadata providers, for cataloguing it.
+func (handler *bookHandler) lookupBook(w http.ResponseWriter, r *http.Request) {
+	draft, err := handler.bookService.Lookup(r.URL.Query().Get("isbn"))
+	switch err {
+	case nil:
+	case book.ErrInvalidISBN:
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	case book.ErrMetadataNotFound:
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	case book.ErrLookupMetadata:
+		respondWithError(w, http.StatusBadGateway, err.Error())
+		return
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, draft)
+}
+
 func (handler *bookHandler) getBook(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	bookID, ok := vars["bookID"]
@@ -61,10 +92,77 @@ func (handler *bookHandler) getBook(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusOK, book)
 }
 
+func (handler *bookHandler) listBooks(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseBookFilter(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidQueryParameter.Error())
+		return
+	}
+
+	books, total, err := handler.bookService.List(filter)
+	if err == book.ErrInvalidFilter || err == book.ErrInvalidISBN {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	facets, err := handler.bookService.GetFacets("", filter)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"books":  books,
+		"facets": facets,
+		"total":  total,
+		"limit":  filter.Limit,
+		"offset": filter.Offset,
+	})
+}
+
+func (handler *bookHandler) searchBooks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+
+	filter, err := parseBookFilter(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidQueryParameter.Error())
+		return
+	}
+
+	results, total, err := handler.bookService.Search(query, filter)
+	if err == book.ErrEmptySearchQuery || err == book.ErrInvalidFilter || err == book.ErrInvalidISBN {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	facets, err := handler.bookService.GetFacets(query, filter)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"query":   query,
+		"results": results,
+		"facets":  facets,
+		"total":   total,
+		"limit":   filter.Limit,
+		"offset":  filter.Offset,
+	})
+}
+
 func (handler *bookHandler) updateBook(w http.ResponseWriter, r *http.Request) {
-	book := book.Book{}
+	requestBook := book.Book{}
 
-	err := json.NewDecoder(r.Body).Decode(&book)
+	err := json.NewDecoder(r.Body).Decode(&requestBook)
 	if err != nil {
 		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
 		return
@@ -77,9 +175,13 @@ func (handler *bookHandler) updateBook(w http.ResponseWriter, r *http.Request) {
 		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
 		return
 	}
-	book.ID = bookID
+	requestBook.ID = bookID
 
-	updatedBook, err := handler.bookService.Update(&book)
+	updatedBook, err := handler.bookService.Update(&requestBook)
+	if err == book.ErrInvalidISBN {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -104,3 +206,61 @@ func (handler *bookHandler) deleteBook(w http.ResponseWriter, r *http.Request) {
 
 	respondWithJSON(w, http.StatusOK, "Book "+bookID+" deleted")
 }
+
+// parseBookFilter reads the filters, facet selections, sorting and
+// pagination of the Books listing and searching from the URL query.
+func parseBookFilter(r *http.Request) (*book.Filter, error) {
+	query := r.URL.Query()
+	filter := book.NewFilter()
+
+	filter.ISBN = query.Get("isbn")
+	filter.Publisher = query.Get("publisher")
+	filter.LOCClassification = query.Get("locClassification")
+	filter.Subject = query.Get("subject")
+	filter.Author = query.Get("author")
+
+	if sort := query.Get("sort"); sort != "" {
+		filter.SortBy = sort
+	}
+
+	if order := query.Get("order"); order != "" {
+		switch order {
+		case "asc":
+			filter.Descending = false
+		case "desc":
+			filter.Descending = true
+		default:
+			return nil, errInvalidQueryParameter
+		}
+	}
+
+	if available := query.Get("available"); available != "" {
+		isAvailable, err := strconv.ParseBool(available)
+		if err != nil {
+			return nil, errInvalidQueryParameter
+		}
+		filter.Available = &isAvailable
+	}
+
+	intParameters := map[string]*int{
+		"yearFrom": &filter.YearFrom,
+		"yearTo":   &filter.YearTo,
+		"decade":   &filter.Decade,
+		"limit":    &filter.Limit,
+		"offset":   &filter.Offset,
+	}
+	for name, field := range intParameters {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errInvalidQueryParameter
+		}
+		*field = number
+	}
+
+	return filter, nil
+}
@@ -141,6 +141,181 @@ func TestBookGet(t *testing.T) {
 	}
 }
 
+func TestBookList(t *testing.T) {
+	books := []*book.Book{
+		{ID: util.NewID(), Title: "title"},
+	}
+
+	facets := &book.Facets{
+		Publisher: []*book.FacetValue{{Value: "publisher", Count: 1}},
+	}
+
+	validFilter := book.NewFilter()
+	validFilter.Publisher = "publisher"
+	validFilter.SortBy = book.SortByYearPublished
+	validFilter.Descending = true
+	validFilter.YearFrom = 1990
+	validFilter.Decade = 1990
+	available := true
+	validFilter.Available = &available
+	validFilter.Limit = 10
+
+	unavailableFilter := book.NewFilter()
+	unavailable := false
+	unavailableFilter.Available = &unavailable
+
+	failedFilter := book.NewFilter()
+	failedFilter.Publisher = "failed publisher"
+
+	invalidFilter := book.NewFilter()
+	invalidFilter.SortBy = "isbn"
+
+	invalidISBNFilter := book.NewFilter()
+	invalidISBNFilter.ISBN = "978-0-13-468599-0"
+
+	tt := []struct {
+		name              string
+		query             string
+		filter            *book.Filter
+		mockReturnPayload []*book.Book
+		statusCode        int
+		err               error
+	}{
+		{
+			name:              "success listing Books",
+			query:             "publisher=publisher&sort=yearPublished&order=desc&yearFrom=1990&decade=1990&available=true&limit=10",
+			filter:            validFilter,
+			mockReturnPayload: books,
+			statusCode:        http.StatusOK,
+			err:               nil,
+		},
+		{
+			name:              "success listing unavailable Books",
+			query:             "available=false",
+			filter:            unavailableFilter,
+			mockReturnPayload: books,
+			statusCode:        http.StatusOK,
+			err:               nil,
+		},
+		{
+			name:              "invalid number in query",
+			query:             "limit=ten",
+			filter:            nil,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               nil,
+		},
+		{
+			name:              "invalid availability in query",
+			query:             "available=maybe",
+			filter:            nil,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               nil,
+		},
+		{
+			name:              "invalid sorting key",
+			query:             "sort=isbn",
+			filter:            invalidFilter,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               book.ErrInvalidFilter,
+		},
+		{
+			name:              "invalid ISBN",
+			query:             "isbn=978-0-13-468599-0",
+			filter:            invalidISBNFilter,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               book.ErrInvalidISBN,
+		},
+		{
+			name:              "failed listing Books",
+			query:             "publisher=failed+publisher",
+			filter:            failedFilter,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusInternalServerError,
+			err:               book.ErrListBooks,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.filter != nil {
+				bookService.On("List", tc.filter).Return(tc.mockReturnPayload, len(tc.mockReturnPayload), tc.err)
+				bookService.On("GetFacets", "", tc.filter).Return(facets, nil)
+			}
+
+			req := httptest.NewRequest("GET", "/books?"+tc.query, nil)
+
+			w := httptest.NewRecorder()
+
+			bookTestingHandler.listBooks(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestBookSearch(t *testing.T) {
+	results := []*book.SearchResult{
+		book.NewSearchResult(&book.Book{ID: util.NewID(), Title: "Calculus"}, 0.8, "<mark>Calculus</mark>", ""),
+	}
+
+	facets := &book.Facets{
+		LOCClassification: []*book.FacetValue{{Value: "Q", Count: 1}},
+	}
+
+	tt := []struct {
+		name              string
+		query             string
+		searchQuery       string
+		mockReturnPayload []*book.SearchResult
+		statusCode        int
+		err               error
+	}{
+		{
+			name:              "success searching Books",
+			query:             "q=calculus",
+			searchQuery:       "calculus",
+			mockReturnPayload: results,
+			statusCode:        http.StatusOK,
+			err:               nil,
+		},
+		{
+			name:              "empty search query",
+			query:             "q=",
+			searchQuery:       "",
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               book.ErrEmptySearchQuery,
+		},
+		{
+			name:              "failed searching Books",
+			query:             "q=failed",
+			searchQuery:       "failed",
+			mockReturnPayload: nil,
+			statusCode:        http.StatusInternalServerError,
+			err:               book.ErrSearchBooks,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookService.On("Search", tc.searchQuery, book.NewFilter()).Return(tc.mockReturnPayload, len(tc.mockReturnPayload), tc.err)
+			bookService.On("GetFacets", tc.searchQuery, book.NewFilter()).Return(facets, nil)
+
+			req := httptest.NewRequest("GET", "/search?"+tc.query, nil)
+
+			w := httptest.NewRecorder()
+
+			bookTestingHandler.searchBooks(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
 func TestBookUpdate(t *testing.T) {
 	initialBook := &book.Book{
 		ID:    util.NewID(),
@@ -282,3 +457,54 @@ func TestBookDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestBookLookup(t *testing.T) {
+	draft := &book.Book{ISBN: "9780134190440", Title: "The Go Programming Language"}
+
+	tt := []struct {
+		name       string
+		isbn       string
+		draft      *book.Book
+		statusCode int
+		err        error
+	}{
+		{
+			name:       "success looking up an ISBN",
+			isbn:       "0134190440",
+			draft:      draft,
+			statusCode: http.StatusOK,
+			err:        nil,
+		},
+		{
+			name:       "invalid ISBN",
+			isbn:       "0134190441",
+			statusCode: http.StatusBadRequest,
+			err:        book.ErrInvalidISBN,
+		},
+		{
+			name:       "unknown ISBN",
+			isbn:       "9780262011532",
+			statusCode: http.StatusNotFound,
+			err:        book.ErrMetadataNotFound,
+		},
+		{
+			name:       "providers failing",
+			isbn:       "9780804429573",
+			statusCode: http.StatusBadGateway,
+			err:        book.ErrLookupMetadata,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookService.On("Lookup", tc.isbn).Return(tc.draft, tc.err)
+
+			req := httptest.NewRequest("GET", "/books/lookup?isbn="+tc.isbn, nil)
+			w := httptest.NewRecorder()
+
+			bookTestingHandler.lookupBook(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	"github.com/joshuabezaleel/library-server/pkg/auth"
+	"github.com/joshuabezaleel/library-server/pkg/barcode"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
 	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
 
 	"github.com/gorilla/mux"
@@ -23,6 +25,10 @@ func (handler *bookCopyHandler) registerRouter(router *mux.Router) {
 	router.HandleFunc("/books/{bookID}/bookcopies/{bookCopyID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.deleteBookCopy))).Methods("DELETE")
 
 	// Other endpoints.
+	router.HandleFunc("/books/{bookID}/bookcopies/{bookCopyID}/barcode", handler.getBarcodeImage).Methods("GET")
+	router.HandleFunc("/books/{bookID}/bookcopies/{bookCopyID}/status", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.changeStatus))).Methods("POST")
+	router.HandleFunc("/books/{bookID}/bookcopies/{bookCopyID}/status/history", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.getStatusHistory))).Methods("GET")
+	router.HandleFunc("/books/{bookID}/bookcopies/{bookCopyID}/location", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.relocate))).Methods("POST")
 }
 
 func (handler *bookCopyHandler) createBookCopy(w http.ResponseWriter, r *http.Request) {
@@ -44,6 +50,10 @@ func (handler *bookCopyHandler) createBookCopy(w http.ResponseWriter, r *http.Re
 	bookCopy.BookID = bookID
 
 	newBookCopy, err := handler.bookCopyService.Create(&bookCopy)
+	if err == bookcopy.ErrInvalidBarcode || err == bookcopy.ErrOtherBranch || isInvalidShelving(err) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -88,6 +98,10 @@ func (handler *bookCopyHandler) updateBookCopy(w http.ResponseWriter, r *http.Re
 	bookCopy.ID = bookCopyID
 
 	updatedBookCopy, err := handler.bookCopyService.Update(&bookCopy)
+	if err == bookcopy.ErrInvalidBarcode || err == bookcopy.ErrOtherBranch || isInvalidShelving(err) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -112,3 +126,152 @@ func (handler *bookCopyHandler) deleteBookCopy(w http.ResponseWriter, r *http.Re
 
 	respondWithJSON(w, http.StatusOK, "Book copy "+bookCopyID+" deleted")
 }
+
+// changeStatus moves the Book Copy to the toStatus of the request payload.
+// Lending statuses are left to borrowing, returning and Holds.
+func (handler *bookCopyHandler) changeStatus(w http.ResponseWriter, r *http.Request) {
+	statusChange := bookcopy.StatusChange{}
+
+	err := json.NewDecoder(r.Body).Decode(&statusChange)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	bookCopyID, ok := vars["bookCopyID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	if !bookcopy.IsLibrarianStatus(statusChange.ToStatus) {
+		respondWithError(w, http.StatusBadRequest, bookcopy.ErrInvalidStatus.Error())
+		return
+	}
+
+	username := r.Context().Value("username").(string)
+
+	bookCopy, err := handler.bookCopyService.ChangeStatus(bookCopyID, statusChange.ToStatus, username, statusChange.Note)
+	if err == bookcopy.ErrInvalidStatus {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err == bookcopy.ErrInvalidTransition {
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, bookCopy)
+}
+
+func (handler *bookCopyHandler) getStatusHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookCopyID, ok := vars["bookCopyID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	statusChanges, err := handler.bookCopyService.GetStatusHistory(bookCopyID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, statusChanges)
+}
+
+// relocate records the Branch and optionally the Location
+// the Book Copy is currently shelved at.
+func (handler *bookCopyHandler) relocate(w http.ResponseWriter, r *http.Request) {
+	shelving := struct {
+		BranchID   string `json:"branchID"`
+		LocationID string `json:"locationID"`
+	}{}
+
+	err := json.NewDecoder(r.Body).Decode(&shelving)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	bookCopyID, ok := vars["bookCopyID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	bookCopy, err := handler.bookCopyService.Relocate(bookCopyID, shelving.BranchID, shelving.LocationID)
+	if isInvalidShelving(err) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, bookCopy)
+}
+
+// getBarcodeImage renders the barcode of the Book Copy as Codabar
+// in the format query parameter, which is svg by default or png.
+func (handler *bookCopyHandler) getBarcodeImage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookCopyID, ok := vars["bookCopyID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "svg"
+	}
+	if format != "svg" && format != "png" {
+		respondWithError(w, http.StatusBadRequest, errInvalidQueryParameter.Error())
+		return
+	}
+
+	bookCopy, err := handler.bookCopyService.Get(bookCopyID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	modules, err := barcode.EncodeCodabar(bookCopy.Barcode)
+	if err != nil {
+		respondWithError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	image := barcode.SVG(modules, barcode.DefaultModuleWidth, barcode.DefaultHeight, bookCopy.Barcode)
+	contentType := "image/svg+xml"
+
+	if format == "png" {
+		image, err = barcode.PNG(modules, barcode.DefaultModuleWidth, barcode.DefaultHeight)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		contentType = "image/png"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(image)
+}
+
+// isInvalidShelving reports whether err is caused by a Branch or Location
+// of the request that does not exist or does not belong together.
+func isInvalidShelving(err error) bool {
+	return err == branch.ErrGetBranch || err == branch.ErrGetLocation || err == branch.ErrLocationNotInBranch
+}
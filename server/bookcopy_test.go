@@ -2,6 +2,7 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
 	"github.com/joshuabezaleel/library-server/pkg/core/book"
 	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
 )
@@ -310,3 +312,178 @@ func TestBookCopyDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestBookCopyChangeStatus(t *testing.T) {
+	username := "librarian"
+
+	tt := []struct {
+		name              string
+		status            string
+		mockReturnPayload interface{}
+		statusCode        int
+		err               error
+	}{
+		{
+			name:              "success sending a Book Copy to repair",
+			status:            bookcopy.StatusInRepair,
+			mockReturnPayload: &bookcopy.BookCopy{Status: bookcopy.StatusInRepair},
+			statusCode:        http.StatusOK,
+			err:               nil,
+		},
+		{
+			name:              "lending status set by hand",
+			status:            bookcopy.StatusOnLoan,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               nil,
+		},
+		{
+			name:              "transition not allowed",
+			status:            bookcopy.StatusAvailable,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusConflict,
+			err:               bookcopy.ErrInvalidTransition,
+		},
+		{
+			name:              "failed changing the status",
+			status:            bookcopy.StatusLost,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusInternalServerError,
+			err:               bookcopy.ErrChangeStatus,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookCopyID := util.NewID()
+			bookCopyService.On("ChangeStatus", bookCopyID, tc.status, username, "Water damage").Return(tc.mockReturnPayload, tc.err)
+
+			reqByte, err := json.Marshal(&bookcopy.StatusChange{ToStatus: tc.status, Note: "Water damage"})
+			require.Nil(t, err)
+
+			req := httptest.NewRequest("POST", "/books/"+util.NewID()+"/bookcopies/"+bookCopyID+"/status", bytes.NewReader(reqByte))
+			req = req.WithContext(context.WithValue(req.Context(), "username", username))
+			req = mux.SetURLVars(req, map[string]string{"bookCopyID": bookCopyID})
+
+			w := httptest.NewRecorder()
+
+			bookCopyTestingHandler.changeStatus(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestBookCopyGetBarcodeImage(t *testing.T) {
+	bookCopy := &bookcopy.BookCopy{
+		ID:      util.NewID(),
+		Barcode: "31234000000016",
+	}
+	bookCopyService.On("Get", bookCopy.ID).Return(bookCopy, nil)
+
+	legacyBookCopy := &bookcopy.BookCopy{
+		ID:      util.NewID(),
+		Barcode: "LEGACY-01",
+	}
+	bookCopyService.On("Get", legacyBookCopy.ID).Return(legacyBookCopy, nil)
+
+	tt := []struct {
+		name        string
+		ID          string
+		format      string
+		statusCode  int
+		contentType string
+	}{
+		{
+			name:        "SVG barcode by default",
+			ID:          bookCopy.ID,
+			format:      "",
+			statusCode:  http.StatusOK,
+			contentType: "image/svg+xml",
+		},
+		{
+			name:        "PNG barcode",
+			ID:          bookCopy.ID,
+			format:      "png",
+			statusCode:  http.StatusOK,
+			contentType: "image/png",
+		},
+		{
+			name:        "unsupported format",
+			ID:          bookCopy.ID,
+			format:      "gif",
+			statusCode:  http.StatusBadRequest,
+			contentType: "application/json",
+		},
+		{
+			name:        "barcode Codabar can not encode",
+			ID:          legacyBookCopy.ID,
+			format:      "svg",
+			statusCode:  http.StatusUnprocessableEntity,
+			contentType: "application/json",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/books/"+util.NewID()+"/bookcopies/"+tc.ID+"/barcode?format="+tc.format, nil)
+			req = mux.SetURLVars(req, map[string]string{"bookCopyID": tc.ID})
+
+			w := httptest.NewRecorder()
+
+			bookCopyTestingHandler.getBarcodeImage(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			require.Equal(t, tc.contentType, w.Header().Get("Content-Type"))
+		})
+	}
+}
+
+func TestBookCopyRelocate(t *testing.T) {
+	tt := []struct {
+		name       string
+		ID         string
+		branchID   string
+		statusCode int
+		err        error
+	}{
+		{
+			name:       "success relocating a Book Copy",
+			ID:         util.NewID(),
+			branchID:   util.NewID(),
+			statusCode: http.StatusOK,
+			err:        nil,
+		},
+		{
+			name:       "unknown Branch",
+			ID:         util.NewID(),
+			branchID:   util.NewID(),
+			statusCode: http.StatusBadRequest,
+			err:        branch.ErrGetBranch,
+		},
+		{
+			name:       "Location not in the Branch",
+			ID:         util.NewID(),
+			branchID:   util.NewID(),
+			statusCode: http.StatusBadRequest,
+			err:        branch.ErrLocationNotInBranch,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookCopyService.On("Relocate", tc.ID, tc.branchID, "").Return(nil, tc.err)
+
+			reqByte, err := json.Marshal(map[string]string{"branchID": tc.branchID})
+			require.Nil(t, err)
+
+			req := httptest.NewRequest("POST", "/books/bookID/bookcopies/"+tc.ID+"/location", bytes.NewReader(reqByte))
+			req = mux.SetURLVars(req, map[string]string{"bookCopyID": tc.ID})
+			w := httptest.NewRecorder()
+
+			bookCopyTestingHandler.relocate(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
@@ -17,6 +17,22 @@ type borrowingHandler struct {
 func (handler *borrowingHandler) registerRouter(router *mux.Router) {
 	router.HandleFunc("/books/{bookID}/bookcopies/{bookCopyID}/borrow", handler.authService.CheckLoggedInMiddleware(handler.borrowBookCopy)).Methods("POST")
 	router.HandleFunc("/books/{bookID}/bookcopies/{bookCopyID}/return", handler.authService.CheckLoggedInMiddleware(handler.returnBookCopy)).Methods("POST")
+	router.HandleFunc("/books/{bookID}/bookcopies/{bookCopyID}/renew", handler.authService.CheckLoggedInMiddleware(handler.renewBookCopy)).Methods("POST")
+	router.HandleFunc("/borrows/{borrowID}/renewals", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.getRenewals))).Methods("GET")
+
+	// Lost and damaged endpoints.
+	router.HandleFunc("/books/{bookID}/bookcopies/{bookCopyID}/lost", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.reportLost))).Methods("POST")
+	router.HandleFunc("/books/{bookID}/bookcopies/{bookCopyID}/damaged", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.reportDamaged))).Methods("POST")
+	router.HandleFunc("/books/{bookID}/bookcopies/{bookCopyID}/found", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.reportFound))).Methods("POST")
+
+	// Loan history endpoints.
+	router.HandleFunc("/users/{userID}/loans", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckSameUserOrLibrarian(handler.getUserLoans))).Methods("GET")
+	router.HandleFunc("/books/{bookID}/bookcopies/{bookCopyID}/loans", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.getBookCopyLoans))).Methods("GET")
+
+	// Hold endpoints.
+	router.HandleFunc("/books/{bookID}/holds", handler.authService.CheckLoggedInMiddleware(handler.placeHold)).Methods("POST")
+	router.HandleFunc("/books/{bookID}/holds", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.getHolds))).Methods("GET")
+	router.HandleFunc("/books/{bookID}/holds/{holdID}", handler.authService.CheckLoggedInMiddleware(handler.cancelHold)).Methods("DELETE")
 }
 
 func (handler *borrowingHandler) borrowBookCopy(w http.ResponseWriter, r *http.Request) {
@@ -29,7 +45,15 @@ func (handler *borrowingHandler) borrowBookCopy(w http.ResponseWriter, r *http.R
 
 	username := r.Context().Value("username").(string)
 
-	borrow, err := handler.borrowingService.Borrow(username, bookCopyID)
+	borrow, err := handler.borrowingService.Borrow(username, bookCopyID, username)
+	if blockedErr, ok := err.(*borrowing.BlockedError); ok {
+		respondWithReason(w, http.StatusForbidden, blockedErr.Message, blockedErr.Reason)
+		return
+	}
+	if err == borrowing.ErrBookCopyNotAvailable || err == borrowing.ErrBookCopyOnHold {
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -48,7 +72,195 @@ func (handler *borrowingHandler) returnBookCopy(w http.ResponseWriter, r *http.R
 
 	username := r.Context().Value("username").(string)
 
-	borrow, err := handler.borrowingService.Return(username, bookCopyID)
+	// The Branch the Book Copy is returned at, its home Branch by default.
+	branchID := r.URL.Query().Get("branch")
+
+	borrow, err := handler.borrowingService.Return(username, bookCopyID, branchID, username)
+	if isInvalidShelving(err) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, borrow)
+}
+
+func (handler *borrowingHandler) getUserLoans(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID, ok := vars["userID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+
+	borrows, err := handler.borrowingService.GetLoans(userID, status)
+	if err == borrowing.ErrInvalidLoanStatus {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, borrows)
+}
+
+func (handler *borrowingHandler) getBookCopyLoans(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookCopyID, ok := vars["bookCopyID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	borrows, err := handler.borrowingService.GetBookCopyLoans(bookCopyID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, borrows)
+}
+
+func (handler *borrowingHandler) renewBookCopy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookCopyID, ok := vars["bookCopyID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	username := r.Context().Value("username").(string)
+
+	borrow, err := handler.borrowingService.Renew(username, bookCopyID)
+	switch err {
+	case nil:
+	case borrowing.ErrBorrowReturned, borrowing.ErrRenewOnHold, borrowing.ErrRenewOverdue, borrowing.ErrMaxRenewals:
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, borrow)
+}
+
+func (handler *borrowingHandler) getRenewals(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	borrowID, ok := vars["borrowID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	renewals, err := handler.borrowingService.GetRenewals(borrowID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, renewals)
+}
+
+func (handler *borrowingHandler) placeHold(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookID, ok := vars["bookID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	username := r.Context().Value("username").(string)
+
+	hold, err := handler.borrowingService.PlaceHold(username, bookID)
+	if err == borrowing.ErrAlreadyOnHold || err == borrowing.ErrBookAvailable {
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, hold)
+}
+
+func (handler *borrowingHandler) getHolds(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookID, ok := vars["bookID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	holds, err := handler.borrowingService.GetActiveHolds(bookID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, holds)
+}
+
+func (handler *borrowingHandler) cancelHold(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	holdID, ok := vars["holdID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	username := r.Context().Value("username").(string)
+
+	hold, err := handler.borrowingService.CancelHold(username, holdID)
+	if err == borrowing.ErrNotHoldOwner {
+		respondWithError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	if err == borrowing.ErrHoldNotActive {
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, hold)
+}
+
+func (handler *borrowingHandler) reportLost(w http.ResponseWriter, r *http.Request) {
+	handler.closeWithReplacement(w, r, handler.borrowingService.ReportLost)
+}
+
+func (handler *borrowingHandler) reportDamaged(w http.ResponseWriter, r *http.Request) {
+	handler.closeWithReplacement(w, r, handler.borrowingService.ReportDamaged)
+}
+
+// closeWithReplacement closes the loan of the Book Copy with the report,
+// charging the patron the replacement cost of the Book Copy.
+func (handler *borrowingHandler) closeWithReplacement(w http.ResponseWriter, r *http.Request, report func(username string, bookCopyID string) (*borrowing.Borrow, error)) {
+	vars := mux.Vars(r)
+	bookCopyID, ok := vars["bookCopyID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	username := r.Context().Value("username").(string)
+
+	borrow, err := report(username, bookCopyID)
+	if err == borrowing.ErrBookCopyNotOnLoan {
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -56,3 +268,26 @@ func (handler *borrowingHandler) returnBookCopy(w http.ResponseWriter, r *http.R
 
 	respondWithJSON(w, http.StatusOK, borrow)
 }
+
+func (handler *borrowingHandler) reportFound(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookCopyID, ok := vars["bookCopyID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	username := r.Context().Value("username").(string)
+
+	bookCopy, err := handler.borrowingService.ReportFound(username, bookCopyID)
+	if err == borrowing.ErrBookCopyNotLost {
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, bookCopy)
+}
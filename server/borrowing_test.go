@@ -0,0 +1,213 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/borrowing"
+)
+
+func TestBorrowingRenew(t *testing.T) {
+	username := "username"
+
+	renewedBorrow := &borrowing.Borrow{
+		ID:         util.NewID(),
+		BookCopyID: util.NewID(),
+	}
+
+	tt := []struct {
+		name              string
+		bookCopyID        string
+		mockReturnPayload interface{}
+		statusCode        int
+		err               error
+	}{
+		{
+			name:              "success renewing a Borrow",
+			bookCopyID:        renewedBorrow.BookCopyID,
+			mockReturnPayload: renewedBorrow,
+			statusCode:        http.StatusOK,
+			err:               nil,
+		},
+		{
+			name:              "invalid path",
+			bookCopyID:        "invalidBookCopyID",
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               nil,
+		},
+		{
+			name:              "renewal refused because of a hold",
+			bookCopyID:        util.NewID(),
+			mockReturnPayload: nil,
+			statusCode:        http.StatusConflict,
+			err:               borrowing.ErrRenewOnHold,
+		},
+		{
+			name:              "renewal refused because of the maximum renewals",
+			bookCopyID:        util.NewID(),
+			mockReturnPayload: nil,
+			statusCode:        http.StatusConflict,
+			err:               borrowing.ErrMaxRenewals,
+		},
+		{
+			name:              "failed renewing a Borrow",
+			bookCopyID:        util.NewID(),
+			mockReturnPayload: nil,
+			statusCode:        http.StatusInternalServerError,
+			err:               errors.New("Borrow not found"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			borrowService.On("Renew", username, tc.bookCopyID).Return(tc.mockReturnPayload, tc.err)
+
+			req := httptest.NewRequest("POST", "/books/"+util.NewID()+"/bookcopies/"+tc.bookCopyID+"/renew", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "username", username))
+
+			if tc.statusCode != http.StatusBadRequest {
+				req = mux.SetURLVars(req, map[string]string{"bookCopyID": tc.bookCopyID})
+			}
+
+			w := httptest.NewRecorder()
+
+			borrowTestingHandler.renewBookCopy(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestBorrowingBorrowBlocked(t *testing.T) {
+	username := "username"
+	bookCopyID := util.NewID()
+
+	blockedErr := borrowing.NewBlockedError(borrowing.ReasonMaxLoans, "User already has 5 active Borrows, the maximum allowed")
+	borrowService.On("Borrow", username, bookCopyID, username).Return(nil, blockedErr)
+
+	req := httptest.NewRequest("POST", "/books/"+util.NewID()+"/bookcopies/"+bookCopyID+"/borrow", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "username", username))
+	req = mux.SetURLVars(req, map[string]string{"bookCopyID": bookCopyID})
+
+	w := httptest.NewRecorder()
+
+	borrowTestingHandler.borrowBookCopy(w, req)
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	response := map[string]string{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	require.Nil(t, err)
+	require.Equal(t, borrowing.ReasonMaxLoans, response["Reason"])
+	require.Equal(t, blockedErr.Message, response["Error"])
+}
+
+func TestBorrowingGetUserLoans(t *testing.T) {
+	userID := util.NewID()
+
+	activeBorrow := &borrowing.Borrow{
+		ID:     util.NewID(),
+		UserID: userID,
+	}
+
+	tt := []struct {
+		name              string
+		status            string
+		mockReturnPayload interface{}
+		statusCode        int
+		err               error
+	}{
+		{
+			name:              "success retrieving the active loans",
+			status:            borrowing.LoanActive,
+			mockReturnPayload: []*borrowing.Borrow{activeBorrow},
+			statusCode:        http.StatusOK,
+			err:               nil,
+		},
+		{
+			name:              "invalid loan status",
+			status:            "overdue",
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               borrowing.ErrInvalidLoanStatus,
+		},
+		{
+			name:              "failed retrieving the loans",
+			status:            borrowing.LoanReturned,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusInternalServerError,
+			err:               borrowing.ErrGetLoans,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			borrowService.On("GetLoans", userID, tc.status).Return(tc.mockReturnPayload, tc.err)
+
+			req := httptest.NewRequest("GET", "/users/"+userID+"/loans?status="+tc.status, nil)
+			req = mux.SetURLVars(req, map[string]string{"userID": userID})
+
+			w := httptest.NewRecorder()
+
+			borrowTestingHandler.getUserLoans(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestBorrowingReportLost(t *testing.T) {
+	username := "librarian"
+
+	tt := []struct {
+		name              string
+		mockReturnPayload interface{}
+		statusCode        int
+		err               error
+	}{
+		{
+			name:              "success reporting a lost Book Copy",
+			mockReturnPayload: &borrowing.Borrow{ID: util.NewID(), ReplacementCharge: 5000},
+			statusCode:        http.StatusOK,
+			err:               nil,
+		},
+		{
+			name:              "Book Copy not on loan",
+			mockReturnPayload: nil,
+			statusCode:        http.StatusConflict,
+			err:               borrowing.ErrBookCopyNotOnLoan,
+		},
+		{
+			name:              "failed reporting a lost Book Copy",
+			mockReturnPayload: nil,
+			statusCode:        http.StatusInternalServerError,
+			err:               errors.New("Error charging fine"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookCopyID := util.NewID()
+			borrowService.On("ReportLost", username, bookCopyID).Return(tc.mockReturnPayload, tc.err)
+
+			req := httptest.NewRequest("POST", "/books/"+util.NewID()+"/bookcopies/"+bookCopyID+"/lost", nil)
+			req = req.WithContext(context.WithValue(req.Context(), "username", username))
+			req = mux.SetURLVars(req, map[string]string{"bookCopyID": bookCopyID})
+
+			w := httptest.NewRecorder()
+
+			borrowTestingHandler.reportLost(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/joshuabezaleel/library-server/pkg/auth"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+
+	"github.com/gorilla/mux"
+)
+
+type branchHandler struct {
+	branchService branch.Service
+	authService   auth.Service
+}
+
+func (handler *branchHandler) registerRouter(router *mux.Router) {
+	// Branch endpoints.
+	router.HandleFunc("/branches", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.createBranch))).Methods("POST")
+	router.HandleFunc("/branches", handler.getBranches).Methods("GET")
+	router.HandleFunc("/branches/{branchID}", handler.getBranch).Methods("GET")
+	router.HandleFunc("/branches/{branchID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.updateBranch))).Methods("PUT")
+	router.HandleFunc("/branches/{branchID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.deleteBranch))).Methods("DELETE")
+
+	// Location endpoints.
+	router.HandleFunc("/branches/{branchID}/locations", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.createLocation))).Methods("POST")
+	router.HandleFunc("/branches/{branchID}/locations", handler.getLocations).Methods("GET")
+	router.HandleFunc("/branches/{branchID}/locations/{locationID}", handler.getLocation).Methods("GET")
+	router.HandleFunc("/branches/{branchID}/locations/{locationID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.updateLocation))).Methods("PUT")
+	router.HandleFunc("/branches/{branchID}/locations/{locationID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.deleteLocation))).Methods("DELETE")
+}
+
+func (handler *branchHandler) createBranch(w http.ResponseWriter, r *http.Request) {
+	libraryBranch := branch.Branch{}
+
+	err := json.NewDecoder(r.Body).Decode(&libraryBranch)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	newBranch, err := handler.branchService.CreateBranch(&libraryBranch)
+	if err == branch.ErrInvalidBranch {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, newBranch)
+}
+
+func (handler *branchHandler) getBranches(w http.ResponseWriter, r *http.Request) {
+	branches, err := handler.branchService.GetBranches()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, branches)
+}
+
+func (handler *branchHandler) getBranch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	branchID, ok := vars["branchID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	libraryBranch, err := handler.branchService.GetBranch(branchID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, libraryBranch)
+}
+
+func (handler *branchHandler) updateBranch(w http.ResponseWriter, r *http.Request) {
+	libraryBranch := branch.Branch{}
+
+	err := json.NewDecoder(r.Body).Decode(&libraryBranch)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	branchID, ok := vars["branchID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+	libraryBranch.ID = branchID
+
+	updatedBranch, err := handler.branchService.UpdateBranch(&libraryBranch)
+	if err == branch.ErrInvalidBranch {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, updatedBranch)
+}
+
+func (handler *branchHandler) deleteBranch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	branchID, ok := vars["branchID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	err := handler.branchService.DeleteBranch(branchID)
+	if err == branch.ErrBranchHasLocations {
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, "Branch "+branchID+" deleted")
+}
+
+func (handler *branchHandler) createLocation(w http.ResponseWriter, r *http.Request) {
+	location := branch.Location{}
+
+	err := json.NewDecoder(r.Body).Decode(&location)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	branchID, ok := vars["branchID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+	location.BranchID = branchID
+
+	newLocation, err := handler.branchService.CreateLocation(&location)
+	if err == branch.ErrInvalidLocation {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err == branch.ErrGetBranch {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, newLocation)
+}
+
+func (handler *branchHandler) getLocations(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	branchID, ok := vars["branchID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	locations, err := handler.branchService.GetLocations(branchID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, locations)
+}
+
+func (handler *branchHandler) getLocation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	locationID, ok := vars["locationID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	location, err := handler.branchService.GetLocation(locationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, location)
+}
+
+func (handler *branchHandler) updateLocation(w http.ResponseWriter, r *http.Request) {
+	location := branch.Location{}
+
+	err := json.NewDecoder(r.Body).Decode(&location)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	branchID, ok := vars["branchID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+	locationID, ok := vars["locationID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+	location.ID = locationID
+	location.BranchID = branchID
+
+	updatedLocation, err := handler.branchService.UpdateLocation(&location)
+	if err == branch.ErrInvalidLocation || err == branch.ErrLocationNotInBranch {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, updatedLocation)
+}
+
+func (handler *branchHandler) deleteLocation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	locationID, ok := vars["locationID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	err := handler.branchService.DeleteLocation(locationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, "Location "+locationID+" deleted")
+}
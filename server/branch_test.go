@@ -0,0 +1,132 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+)
+
+func TestBranchCreate(t *testing.T) {
+	validBranch := &branch.Branch{
+		ID:   util.NewID(),
+		Code: "MAIN",
+		Name: "Main Library",
+	}
+
+	invalidBranch := &branch.Branch{
+		ID: util.NewID(),
+	}
+
+	failedBranch := &branch.Branch{
+		ID:   util.NewID(),
+		Code: "ENG",
+		Name: "Engineering Library",
+	}
+
+	tt := []struct {
+		name              string
+		requestPayload    interface{}
+		mockReturnPayload interface{}
+		statusCode        int
+		err               error
+	}{
+		{
+			name:              "success creating a valid Branch",
+			requestPayload:    validBranch,
+			mockReturnPayload: validBranch,
+			statusCode:        http.StatusCreated,
+			err:               nil,
+		},
+		{
+			name:              "invalid request payload",
+			requestPayload:    "a plain string, not a Branch",
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               nil,
+		},
+		{
+			name:              "invalid Branch",
+			requestPayload:    invalidBranch,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               branch.ErrInvalidBranch,
+		},
+		{
+			name:              "failed creating a Branch",
+			requestPayload:    failedBranch,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusInternalServerError,
+			err:               errors.New("Error creating Branch"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if requestBranch, ok := tc.requestPayload.(*branch.Branch); ok {
+				branchService.On("CreateBranch", mock.MatchedBy(func(b *branch.Branch) bool { return b.ID == requestBranch.ID })).Return(tc.mockReturnPayload, tc.err)
+			}
+
+			reqByte, err := json.Marshal(tc.requestPayload)
+			require.Nil(t, err)
+
+			req := httptest.NewRequest("POST", "/branches", bytes.NewReader(reqByte))
+			w := httptest.NewRecorder()
+
+			branchTestingHandler.createBranch(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestBranchDelete(t *testing.T) {
+	tt := []struct {
+		name       string
+		ID         string
+		statusCode int
+		err        error
+	}{
+		{
+			name:       "success deleting a Branch",
+			ID:         util.NewID(),
+			statusCode: http.StatusOK,
+			err:        nil,
+		},
+		{
+			name:       "Branch still has Locations",
+			ID:         util.NewID(),
+			statusCode: http.StatusConflict,
+			err:        branch.ErrBranchHasLocations,
+		},
+		{
+			name:       "failed deleting a Branch",
+			ID:         util.NewID(),
+			statusCode: http.StatusInternalServerError,
+			err:        branch.ErrDeleteBranch,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			branchService.On("DeleteBranch", tc.ID).Return(tc.err)
+
+			req := httptest.NewRequest("DELETE", "/branches/"+tc.ID, nil)
+			req = mux.SetURLVars(req, map[string]string{"branchID": tc.ID})
+			w := httptest.NewRecorder()
+
+			branchTestingHandler.deleteBranch(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/joshuabezaleel/library-server/pkg/auth"
+	"github.com/joshuabezaleel/library-server/pkg/calendar"
+
+	"github.com/gorilla/mux"
+)
+
+// queryDateLayout is the layout of the dates in query parameters.
+const queryDateLayout = "2006-01-02"
+
+type calendarHandler struct {
+	calendarService calendar.Service
+	authService     auth.Service
+}
+
+func (handler *calendarHandler) registerRouter(router *mux.Router) {
+	// Opening hours endpoints.
+	router.HandleFunc("/calendar/hours", handler.getOpeningHours).Methods("GET")
+	router.HandleFunc("/calendar/hours", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.setOpeningHours))).Methods("PUT")
+
+	// Closure endpoints.
+	router.HandleFunc("/calendar/closures", handler.getClosures).Methods("GET")
+	router.HandleFunc("/calendar/closures", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.createClosure))).Methods("POST")
+	router.HandleFunc("/calendar/closures/{closureID}", handler.getClosure).Methods("GET")
+	router.HandleFunc("/calendar/closures/{closureID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.deleteClosure))).Methods("DELETE")
+}
+
+func (handler *calendarHandler) getOpeningHours(w http.ResponseWriter, r *http.Request) {
+	hours, err := handler.calendarService.GetOpeningHours()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, hours)
+}
+
+func (handler *calendarHandler) setOpeningHours(w http.ResponseWriter, r *http.Request) {
+	hours := []*calendar.OpeningHours{}
+
+	err := json.NewDecoder(r.Body).Decode(&hours)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	savedHours, err := handler.calendarService.SetOpeningHours(hours)
+	if err == calendar.ErrInvalidOpeningHours {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, savedHours)
+}
+
+func (handler *calendarHandler) createClosure(w http.ResponseWriter, r *http.Request) {
+	closure := calendar.Closure{}
+
+	err := json.NewDecoder(r.Body).Decode(&closure)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	newClosure, err := handler.calendarService.CreateClosure(&closure)
+	if err == calendar.ErrInvalidClosure {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, newClosure)
+}
+
+func (handler *calendarHandler) getClosure(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	closureID, ok := vars["closureID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	closure, err := handler.calendarService.GetClosure(closureID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, closure)
+}
+
+func (handler *calendarHandler) deleteClosure(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	closureID, ok := vars["closureID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	err := handler.calendarService.DeleteClosure(closureID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, "Closure "+closureID+" deleted")
+}
+
+// getClosures lists the Closures between the from and to query parameters,
+// which default to today and a year from the from date.
+func (handler *calendarHandler) getClosures(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	from := time.Now()
+	if value := query.Get("from"); value != "" {
+		parsed, err := time.Parse(queryDateLayout, value)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, errInvalidQueryParameter.Error())
+			return
+		}
+		from = parsed
+	}
+
+	to := from.AddDate(1, 0, 0)
+	if value := query.Get("to"); value != "" {
+		parsed, err := time.Parse(queryDateLayout, value)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, errInvalidQueryParameter.Error())
+			return
+		}
+		to = parsed
+	}
+
+	closures, err := handler.calendarService.GetClosures(from, to)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, closures)
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshuabezaleel/library-server/pkg/calendar"
+)
+
+func TestCalendarSetOpeningHours(t *testing.T) {
+	validHours := []*calendar.OpeningHours{calendar.NewOpeningHours(time.Monday, "09:00", "17:00", false)}
+	invalidHours := []*calendar.OpeningHours{calendar.NewOpeningHours(time.Tuesday, "17:00", "09:00", false)}
+
+	tt := []struct {
+		name              string
+		requestPayload    interface{}
+		mockReturnPayload interface{}
+		statusCode        int
+		err               error
+	}{
+		{
+			name:              "success setting the opening hours",
+			requestPayload:    validHours,
+			mockReturnPayload: validHours,
+			statusCode:        http.StatusOK,
+			err:               nil,
+		},
+		{
+			name:              "invalid request payload",
+			requestPayload:    "a plain string, not opening hours",
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               nil,
+		},
+		{
+			name:              "invalid opening hours",
+			requestPayload:    invalidHours,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               calendar.ErrInvalidOpeningHours,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if requestHours, ok := tc.requestPayload.([]*calendar.OpeningHours); ok {
+				calendarService.On("SetOpeningHours", mock.MatchedBy(func(hours []*calendar.OpeningHours) bool {
+					return len(hours) == 1 && hours[0].Weekday == requestHours[0].Weekday
+				})).Return(tc.mockReturnPayload, tc.err)
+			}
+
+			reqByte, err := json.Marshal(tc.requestPayload)
+			require.Nil(t, err)
+
+			req := httptest.NewRequest("PUT", "/calendar/hours", bytes.NewReader(reqByte))
+			w := httptest.NewRecorder()
+
+			calendarTestingHandler.setOpeningHours(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestCalendarGetClosures(t *testing.T) {
+	from, _ := time.Parse(queryDateLayout, "2026-12-01")
+	to, _ := time.Parse(queryDateLayout, "2026-12-31")
+
+	closure := calendar.NewClosure("closureID", calendar.ClosureHoliday, "Christmas", to.AddDate(0, 0, -6), to.AddDate(0, 0, -6), time.Now())
+	calendarService.On("GetClosures", from, to).Return([]*calendar.Closure{closure}, nil)
+
+	tt := []struct {
+		name       string
+		query      string
+		statusCode int
+	}{
+		{
+			name:       "success retrieving the closures",
+			query:      "?from=2026-12-01&to=2026-12-31",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "invalid from date",
+			query:      "?from=01/12/2026",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/calendar/closures"+tc.query, nil)
+			w := httptest.NewRecorder()
+
+			calendarTestingHandler.getClosures(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/joshuabezaleel/library-server/pkg/auth"
+	"github.com/joshuabezaleel/library-server/pkg/borrowing"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+	"github.com/joshuabezaleel/library-server/pkg/circulation"
+
+	"github.com/gorilla/mux"
+)
+
+type circulationHandler struct {
+	circulationService circulation.Service
+	authService        auth.Service
+}
+
+func (handler *circulationHandler) registerRouter(router *mux.Router) {
+	router.HandleFunc("/circulation/checkout", handler.authService.CheckLoggedInMiddleware(handler.checkout)).Methods("POST")
+	router.HandleFunc("/circulation/checkin", handler.authService.CheckLoggedInMiddleware(handler.checkin)).Methods("POST")
+}
+
+func (handler *circulationHandler) checkout(w http.ResponseWriter, r *http.Request) {
+	handler.scan(w, r, handler.circulationService.Checkout)
+}
+
+func (handler *circulationHandler) checkin(w http.ResponseWriter, r *http.Request) {
+	handler.scan(w, r, handler.circulationService.Checkin)
+}
+
+// scan runs the circulation desk action on the Scan of the request payload.
+func (handler *circulationHandler) scan(w http.ResponseWriter, r *http.Request, action func(username string, scan *circulation.Scan) (*circulation.Slip, error)) {
+	scan := circulation.Scan{}
+
+	err := json.NewDecoder(r.Body).Decode(&scan)
+	if err != nil || scan.Barcode == "" {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	username := r.Context().Value("username").(string)
+
+	slip, err := action(username, &scan)
+	if blockedErr, ok := err.(*borrowing.BlockedError); ok {
+		respondWithReason(w, http.StatusForbidden, blockedErr.Message, blockedErr.Reason)
+		return
+	}
+	switch err {
+	case nil:
+	case circulation.ErrPatronNotFound, circulation.ErrBarcodeNotFound:
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	case branch.ErrGetBranch, branch.ErrGetLocation:
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	case circulation.ErrNotOnBehalf:
+		respondWithError(w, http.StatusForbidden, err.Error())
+		return
+	case circulation.ErrAmbiguousPatron, borrowing.ErrBookCopyNotAvailable, borrowing.ErrBookCopyOnHold, borrowing.ErrBookCopyNotOnLoan, borrowing.ErrBorrowReturned:
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, slip)
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/borrowing"
+	"github.com/joshuabezaleel/library-server/pkg/circulation"
+)
+
+func TestCirculationCheckout(t *testing.T) {
+	username := "librarian"
+
+	tt := []struct {
+		name              string
+		scan              interface{}
+		mockReturnPayload interface{}
+		statusCode        int
+		err               error
+	}{
+		{
+			name:              "success checking out a Book Copy",
+			scan:              &circulation.Scan{Patron: "12345678", Barcode: util.NewID()},
+			mockReturnPayload: &circulation.Slip{Action: circulation.ActionCheckout, DueDate: time.Now().AddDate(0, 0, 14)},
+			statusCode:        http.StatusOK,
+			err:               nil,
+		},
+		{
+			name:              "scan without a barcode",
+			scan:              &circulation.Scan{Patron: "12345678"},
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               nil,
+		},
+		{
+			name:              "unknown barcode",
+			scan:              &circulation.Scan{Patron: "12345678", Barcode: util.NewID()},
+			mockReturnPayload: nil,
+			statusCode:        http.StatusNotFound,
+			err:               circulation.ErrBarcodeNotFound,
+		},
+		{
+			name:              "patron acting on behalf of another patron",
+			scan:              &circulation.Scan{Patron: "12345678", Barcode: util.NewID()},
+			mockReturnPayload: nil,
+			statusCode:        http.StatusForbidden,
+			err:               circulation.ErrNotOnBehalf,
+		},
+		{
+			name:              "Book Copy not available",
+			scan:              &circulation.Scan{Patron: "12345678", Barcode: util.NewID()},
+			mockReturnPayload: nil,
+			statusCode:        http.StatusConflict,
+			err:               borrowing.ErrBookCopyNotAvailable,
+		},
+		{
+			name:              "failed checking out a Book Copy",
+			scan:              &circulation.Scan{Patron: "12345678", Barcode: util.NewID()},
+			mockReturnPayload: nil,
+			statusCode:        http.StatusInternalServerError,
+			err:               errors.New("Error retrieving User's total fine"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			circulationService.On("Checkout", username, tc.scan).Return(tc.mockReturnPayload, tc.err)
+
+			reqByte, err := json.Marshal(tc.scan)
+			require.Nil(t, err)
+
+			req := httptest.NewRequest("POST", "/circulation/checkout", bytes.NewReader(reqByte))
+			req = req.WithContext(context.WithValue(req.Context(), "username", username))
+
+			w := httptest.NewRecorder()
+
+			circulationTestingHandler.checkout(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestCirculationCheckinNotOnLoan(t *testing.T) {
+	username := "librarian"
+	scan := &circulation.Scan{Barcode: util.NewID()}
+
+	circulationService.On("Checkin", username, scan).Return(nil, borrowing.ErrBookCopyNotOnLoan)
+
+	reqByte, err := json.Marshal(scan)
+	require.Nil(t, err)
+
+	req := httptest.NewRequest("POST", "/circulation/checkin", bytes.NewReader(reqByte))
+	req = req.WithContext(context.WithValue(req.Context(), "username", username))
+
+	w := httptest.NewRecorder()
+
+	circulationTestingHandler.checkin(w, req)
+
+	require.Equal(t, http.StatusConflict, w.Code)
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/joshuabezaleel/library-server/pkg/auth"
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/cover"
+
+	"github.com/gorilla/mux"
+)
+
+// coverMaxAge is how long, in seconds, clients and proxies may cache a cover.
+// Covers are addressed by a digest of the image in Book's CoverPicture, so a
+// new cover is fetched from a new URL.
+const coverMaxAge = "86400"
+
+// multipartOverhead is the room left in a request for the rest of the multipart form.
+const multipartOverhead = 1 << 20
+
+type coverHandler struct {
+	coverService cover.Service
+	authService  auth.Service
+}
+
+func (handler *coverHandler) registerRouter(router *mux.Router) {
+	router.HandleFunc("/books/{bookID}/cover", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.uploadCover))).Methods("PUT")
+	router.HandleFunc("/books/{bookID}/cover", handler.getCover).Methods("GET")
+}
+
+// uploadCover stores the image in the cover field of the multipart form as the
+// cover of the Book, along with its thumbnails.
+func (handler *coverHandler) uploadCover(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookID, ok := vars["bookID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, cover.MaxSize+multipartOverhead)
+	err := r.ParseMultipartForm(cover.MaxSize)
+	if err != nil {
+		if strings.Contains(err.Error(), "request body too large") {
+			respondWithError(w, http.StatusRequestEntityTooLarge, cover.ErrCoverTooLarge.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, _, err := r.FormFile("cover")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer file.Close()
+
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+
+	coverBook, err := handler.coverService.Upload(bookID, data)
+	switch err {
+	case nil:
+	case cover.ErrCoverTooLarge:
+		respondWithError(w, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	case cover.ErrUnsupportedImage:
+		respondWithError(w, http.StatusUnsupportedMediaType, err.Error())
+		return
+	case cover.ErrInvalidImage:
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	case book.ErrGetBook:
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, coverBook)
+}
+
+// getCover serves the cover of the Book in the size of the size query parameter,
+// which is the original image unless it is small, medium or large.
+func (handler *coverHandler) getCover(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookID, ok := vars["bookID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	blob, err := handler.coverService.Get(bookID, r.URL.Query().Get("size"))
+	switch err {
+	case nil:
+	case cover.ErrInvalidSize:
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	case cover.ErrCoverNotFound:
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	digest := sha1.Sum(blob.Data)
+
+	w.Header().Set("Content-Type", blob.ContentType)
+	w.Header().Set("Cache-Control", "public, max-age="+coverMaxAge)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(digest[:])+`"`)
+	http.ServeContent(w, r, "", blob.ModifiedAt, bytes.NewReader(blob.Data))
+}
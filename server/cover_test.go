@@ -0,0 +1,165 @@
+package server
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/cover"
+	"github.com/joshuabezaleel/library-server/pkg/storage"
+)
+
+// newCoverRequest returns a PUT of the data in the field of a multipart form.
+func newCoverRequest(t *testing.T, bookID string, field string, data []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(field, "cover.png")
+	require.Nil(t, err)
+	_, err = part.Write(data)
+	require.Nil(t, err)
+	require.Nil(t, writer.Close())
+
+	req := httptest.NewRequest("PUT", "/books/"+bookID+"/cover", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return mux.SetURLVars(req, map[string]string{"bookID": bookID})
+}
+
+func TestCoverUpload(t *testing.T) {
+	tt := []struct {
+		name       string
+		field      string
+		data       []byte
+		statusCode int
+		err        error
+	}{
+		{
+			name:       "success uploading a cover",
+			field:      "cover",
+			data:       []byte("cover of The Go Programming Language"),
+			statusCode: http.StatusOK,
+			err:        nil,
+		},
+		{
+			name:       "unsupported image",
+			field:      "cover",
+			data:       []byte("cover as a PDF"),
+			statusCode: http.StatusUnsupportedMediaType,
+			err:        cover.ErrUnsupportedImage,
+		},
+		{
+			name:       "invalid image",
+			field:      "cover",
+			data:       []byte("truncated cover"),
+			statusCode: http.StatusBadRequest,
+			err:        cover.ErrInvalidImage,
+		},
+		{
+			name:       "missing Book",
+			field:      "cover",
+			data:       []byte("cover of a missing Book"),
+			statusCode: http.StatusNotFound,
+			err:        book.ErrGetBook,
+		},
+		{
+			name:       "missing cover field",
+			field:      "image",
+			data:       []byte("cover in the wrong field"),
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bookID := util.NewID()
+
+			var coverBook *book.Book
+			if tc.err == nil {
+				coverBook = &book.Book{ID: bookID, CoverPicture: "/books/" + bookID + "/cover?v=0123456789ab"}
+			}
+			coverService.On("Upload", bookID, tc.data).Return(coverBook, tc.err)
+
+			req := newCoverRequest(t, bookID, tc.field, tc.data)
+			w := httptest.NewRecorder()
+
+			coverTestingHandler.uploadCover(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestCoverUploadTooLarge(t *testing.T) {
+	bookID := util.NewID()
+
+	req := newCoverRequest(t, bookID, "cover", make([]byte, cover.MaxSize+multipartOverhead))
+	w := httptest.NewRecorder()
+
+	coverTestingHandler.uploadCover(w, req)
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	coverService.AssertNotCalled(t, "Upload", bookID, mock.Anything)
+}
+
+func TestCoverGet(t *testing.T) {
+	bookID := util.NewID()
+	missingBookID := util.NewID()
+
+	blob := &storage.Blob{
+		Data:        []byte("small cover of The Go Programming Language"),
+		ContentType: "image/jpeg",
+		ModifiedAt:  time.Date(2019, 11, 1, 0, 0, 0, 0, time.UTC),
+	}
+	coverService.On("Get", bookID, cover.SizeSmall).Return(blob, nil)
+	coverService.On("Get", bookID, "huge").Return(nil, cover.ErrInvalidSize)
+	coverService.On("Get", missingBookID, "").Return(nil, cover.ErrCoverNotFound)
+
+	req := httptest.NewRequest("GET", "/books/"+bookID+"/cover?size=small", nil)
+	req = mux.SetURLVars(req, map[string]string{"bookID": bookID})
+	w := httptest.NewRecorder()
+
+	coverTestingHandler.getCover(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+	require.Equal(t, "public, max-age=86400", w.Header().Get("Cache-Control"))
+	require.Equal(t, blob.Data, w.Body.Bytes())
+
+	// A client holding the cover is told it has not been modified.
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	req = httptest.NewRequest("GET", "/books/"+bookID+"/cover?size=small", nil)
+	req.Header.Set("If-None-Match", etag)
+	req = mux.SetURLVars(req, map[string]string{"bookID": bookID})
+	w = httptest.NewRecorder()
+
+	coverTestingHandler.getCover(w, req)
+
+	require.Equal(t, http.StatusNotModified, w.Code)
+
+	req = httptest.NewRequest("GET", "/books/"+bookID+"/cover?size=huge", nil)
+	req = mux.SetURLVars(req, map[string]string{"bookID": bookID})
+	w = httptest.NewRecorder()
+
+	coverTestingHandler.getCover(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest("GET", "/books/"+missingBookID+"/cover", nil)
+	req = mux.SetURLVars(req, map[string]string{"bookID": missingBookID})
+	w = httptest.NewRecorder()
+
+	coverTestingHandler.getCover(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
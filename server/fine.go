@@ -0,0 +1,175 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/joshuabezaleel/library-server/pkg/auth"
+	"github.com/joshuabezaleel/library-server/pkg/fine"
+
+	"github.com/gorilla/mux"
+)
+
+type fineHandler struct {
+	fineService fine.Service
+	authService auth.Service
+}
+
+func (handler *fineHandler) registerRouter(router *mux.Router) {
+	router.HandleFunc("/users/{userID}/fines", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckSameUserOrLibrarian(handler.getStatement))).Methods("GET")
+	router.HandleFunc("/users/{userID}/fines/payments", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.pay))).Methods("POST")
+	router.HandleFunc("/users/{userID}/fines/payments/{paymentID}/receipt", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckSameUserOrLibrarian(handler.getReceipt))).Methods("GET")
+	router.HandleFunc("/users/{userID}/fines/waivers", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.waive))).Methods("POST")
+	router.HandleFunc("/users/{userID}/fines/refunds", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.refund))).Methods("POST")
+}
+
+func (handler *fineHandler) getStatement(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID, ok := vars["userID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	statement, err := handler.fineService.GetStatement(userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, statement)
+}
+
+func (handler *fineHandler) pay(w http.ResponseWriter, r *http.Request) {
+	transaction := fine.Transaction{}
+
+	err := json.NewDecoder(r.Body).Decode(&transaction)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	userID, ok := vars["userID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	username := r.Context().Value("username").(string)
+
+	receipt, err := handler.fineService.Pay(userID, transaction.Amount, transaction.Note, username)
+	if isFineRefusal(err) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, receipt)
+}
+
+func (handler *fineHandler) getReceipt(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID, ok := vars["userID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	paymentID, ok := vars["paymentID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	receipt, err := handler.fineService.GetReceipt(userID, paymentID)
+	if err == fine.ErrNotPayment {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, receipt)
+}
+
+func (handler *fineHandler) waive(w http.ResponseWriter, r *http.Request) {
+	transaction := fine.Transaction{}
+
+	err := json.NewDecoder(r.Body).Decode(&transaction)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	userID, ok := vars["userID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	username := r.Context().Value("username").(string)
+
+	waiver, err := handler.fineService.Waive(userID, transaction.BorrowID, transaction.Amount, transaction.Note, username)
+	if isFineRefusal(err) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, waiver)
+}
+
+func (handler *fineHandler) refund(w http.ResponseWriter, r *http.Request) {
+	transaction := fine.Transaction{}
+
+	err := json.NewDecoder(r.Body).Decode(&transaction)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	userID, ok := vars["userID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	username := r.Context().Value("username").(string)
+
+	refund, err := handler.fineService.Refund(userID, transaction.PaymentID, transaction.Amount, transaction.Note, username)
+	if isFineRefusal(err) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, refund)
+}
+
+// isFineRefusal returns whether the fines ledger refused the
+// transaction because of the request rather than failing.
+func isFineRefusal(err error) bool {
+	switch err {
+	case fine.ErrInvalidAmount, fine.ErrExceedsBalance, fine.ErrExceedsPayment, fine.ErrNotPayment:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/fine"
+)
+
+func TestFinePay(t *testing.T) {
+	username := "librarian"
+	userID := util.NewID()
+
+	payment := fine.NewTransaction(util.NewID(), userID, "", "", fine.TransactionPayment, 3000, 1000, "", username, time.Now())
+
+	tt := []struct {
+		name              string
+		amount            uint32
+		mockReturnPayload interface{}
+		statusCode        int
+		err               error
+	}{
+		{
+			name:              "success recording a payment",
+			amount:            3000,
+			mockReturnPayload: fine.NewReceipt(payment),
+			statusCode:        http.StatusCreated,
+			err:               nil,
+		},
+		{
+			name:              "payment over the balance",
+			amount:            5000,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               fine.ErrExceedsBalance,
+		},
+		{
+			name:              "failed recording a payment",
+			amount:            4000,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusInternalServerError,
+			err:               errors.New("Error recording payment"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			fineService.On("Pay", userID, tc.amount, "Cash", username).Return(tc.mockReturnPayload, tc.err)
+
+			reqByte, err := json.Marshal(&fine.Transaction{Amount: tc.amount, Note: "Cash"})
+			require.Nil(t, err)
+
+			req := httptest.NewRequest("POST", "/users/"+userID+"/fines/payments", bytes.NewReader(reqByte))
+			req = req.WithContext(context.WithValue(req.Context(), "username", username))
+			req = mux.SetURLVars(req, map[string]string{"userID": userID})
+
+			w := httptest.NewRecorder()
+
+			fineTestingHandler.pay(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestFineGetReceipt(t *testing.T) {
+	userID := util.NewID()
+
+	payment := fine.NewTransaction(util.NewID(), userID, "", "", fine.TransactionPayment, 3000, 1000, "", "librarian", time.Now())
+	fineService.On("GetReceipt", userID, payment.ID).Return(fine.NewReceipt(payment), nil)
+
+	otherPaymentID := util.NewID()
+	fineService.On("GetReceipt", userID, otherPaymentID).Return(nil, fine.ErrNotPayment)
+
+	tt := []struct {
+		name       string
+		paymentID  string
+		statusCode int
+	}{
+		{
+			name:       "success retrieving a receipt",
+			paymentID:  payment.ID,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "receipt of another User's payment",
+			paymentID:  otherPaymentID,
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/users/"+userID+"/fines/payments/"+tc.paymentID+"/receipt", nil)
+			req = mux.SetURLVars(req, map[string]string{"userID": userID, "paymentID": tc.paymentID})
+
+			w := httptest.NewRecorder()
+
+			fineTestingHandler.getReceipt(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/joshuabezaleel/library-server/pkg/auth"
+	"github.com/joshuabezaleel/library-server/pkg/importing"
+
+	"github.com/gorilla/mux"
+)
+
+type importingHandler struct {
+	importingService importing.Service
+	authService      auth.Service
+}
+
+func (handler *importingHandler) registerRouter(router *mux.Router) {
+	router.HandleFunc("/imports", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.importCSV))).Methods("POST")
+	router.HandleFunc("/imports/{jobID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.getJob))).Methods("GET")
+	router.HandleFunc("/imports/{jobID}/report", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.getReport))).Methods("GET")
+}
+
+// importCSV imports the Books and Copies of the CSV of the request body.
+// The mode query parameter is all-or-nothing unless it is best-effort,
+// and with dryRun=true the rows are only validated.
+func (handler *importingHandler) importCSV(w http.ResponseWriter, r *http.Request) {
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = importing.ModeAllOrNothing
+	}
+
+	dryRun := false
+	if value := r.URL.Query().Get("dryRun"); value != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(value)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, errInvalidQueryParameter.Error())
+			return
+		}
+	}
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	username := r.Context().Value("username").(string)
+
+	job, err := handler.importingService.Import(username, data, mode, dryRun)
+	switch err {
+	case nil:
+	case importing.ErrInvalidMode, importing.ErrInvalidCSV, importing.ErrInvalidHeader, importing.ErrNoRows:
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, job)
+}
+
+func (handler *importingHandler) getJob(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	jobID, ok := vars["jobID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	job, err := handler.importingService.Get(jobID)
+	if err == importing.ErrGetJob {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, job)
+}
+
+// getReport downloads the outcome of each of the rows of the Job as CSV.
+func (handler *importingHandler) getReport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	jobID, ok := vars["jobID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	report, err := handler.importingService.Report(jobID)
+	if err == importing.ErrGetJob {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="import-`+jobID+`.csv"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(report)
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/importing"
+)
+
+func TestImportingImportCSV(t *testing.T) {
+	username := "librarian"
+
+	tt := []struct {
+		name       string
+		query      string
+		body       string
+		mode       string
+		dryRun     bool
+		statusCode int
+		err        error
+	}{
+		{
+			name:       "success validating a CSV",
+			query:      "?dryRun=true",
+			body:       "title\nThe Go Programming Language",
+			mode:       importing.ModeAllOrNothing,
+			dryRun:     true,
+			statusCode: http.StatusCreated,
+			err:        nil,
+		},
+		{
+			name:       "success importing a CSV",
+			query:      "?mode=best-effort",
+			body:       "title\nStructure and Interpretation of Computer Programs",
+			mode:       importing.ModeBestEffort,
+			statusCode: http.StatusCreated,
+			err:        nil,
+		},
+		{
+			name:       "invalid header",
+			query:      "",
+			body:       "name\nThe Alchemist",
+			mode:       importing.ModeAllOrNothing,
+			statusCode: http.StatusBadRequest,
+			err:        importing.ErrInvalidHeader,
+		},
+		{
+			name:       "invalid dryRun",
+			query:      "?dryRun=maybe",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var job *importing.Job
+			if tc.err == nil {
+				job = &importing.Job{ID: util.NewID(), Mode: tc.mode, DryRun: tc.dryRun}
+			}
+			importingService.On("Import", username, []byte(tc.body), tc.mode, tc.dryRun).Return(job, tc.err)
+
+			req := httptest.NewRequest("POST", "/imports"+tc.query, bytes.NewReader([]byte(tc.body)))
+			req = req.WithContext(context.WithValue(req.Context(), "username", username))
+			w := httptest.NewRecorder()
+
+			importingTestingHandler.importCSV(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestImportingGetReport(t *testing.T) {
+	jobID := util.NewID()
+	missingJobID := util.NewID()
+
+	report := []byte("row,title,isbn,call_number,status,book_id,copies_added,errors\n")
+	importingService.On("Report", jobID).Return(report, nil)
+	importingService.On("Report", missingJobID).Return(nil, importing.ErrGetJob)
+
+	req := httptest.NewRequest("GET", "/imports/"+jobID+"/report", nil)
+	req = mux.SetURLVars(req, map[string]string{"jobID": jobID})
+	w := httptest.NewRecorder()
+
+	importingTestingHandler.getReport(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="import-`+jobID+`.csv"`, w.Header().Get("Content-Disposition"))
+	require.Equal(t, report, w.Body.Bytes())
+
+	req = httptest.NewRequest("GET", "/imports/"+missingJobID+"/report", nil)
+	req = mux.SetURLVars(req, map[string]string{"jobID": missingJobID})
+	w = httptest.NewRecorder()
+
+	importingTestingHandler.getReport(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
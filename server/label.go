@@ -0,0 +1,61 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/joshuabezaleel/library-server/pkg/auth"
+	"github.com/joshuabezaleel/library-server/pkg/barcode"
+	"github.com/joshuabezaleel/library-server/pkg/label"
+
+	"github.com/gorilla/mux"
+)
+
+type labelHandler struct {
+	labelService label.Service
+	authService  auth.Service
+}
+
+func (handler *labelHandler) registerRouter(router *mux.Router) {
+	router.HandleFunc("/bookcopies/labels", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.getLabelSheet))).Methods("POST")
+	router.HandleFunc("/bookcopies/labels/layouts", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.getLayouts))).Methods("GET")
+}
+
+// getLabelSheet responds with a PDF of the labels of the Book Copies
+// selected by the request payload.
+func (handler *labelHandler) getLabelSheet(w http.ResponseWriter, r *http.Request) {
+	request := label.Request{}
+
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	sheet, err := handler.labelService.Sheet(&request)
+	switch err {
+	case nil:
+	case label.ErrUnknownLayout, label.ErrNoSelection, label.ErrInvalidDateRange, label.ErrInvalidSkip:
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	case label.ErrNoBookCopies:
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	case barcode.ErrInvalidCodabar:
+		respondWithError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `inline; filename="labels.pdf"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(sheet)
+}
+
+func (handler *labelHandler) getLayouts(w http.ResponseWriter, r *http.Request) {
+	respondWithJSON(w, http.StatusOK, label.Layouts())
+}
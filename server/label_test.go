@@ -0,0 +1,82 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/barcode"
+	"github.com/joshuabezaleel/library-server/pkg/label"
+)
+
+func TestGetLabelSheet(t *testing.T) {
+	tt := []struct {
+		name              string
+		request           *label.Request
+		mockReturnPayload interface{}
+		statusCode        int
+		err               error
+	}{
+		{
+			name:              "success printing labels",
+			request:           &label.Request{BookCopyIDs: []string{util.NewID()}},
+			mockReturnPayload: []byte("%PDF-1.4"),
+			statusCode:        http.StatusOK,
+			err:               nil,
+		},
+		{
+			name:              "unknown layout",
+			request:           &label.Request{BookCopyIDs: []string{util.NewID()}, Layout: "avery-0000"},
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               label.ErrUnknownLayout,
+		},
+		{
+			name:              "no Book Copies to print labels for",
+			request:           &label.Request{BookCopyIDs: []string{util.NewID()}},
+			mockReturnPayload: nil,
+			statusCode:        http.StatusNotFound,
+			err:               label.ErrNoBookCopies,
+		},
+		{
+			name:              "barcode Codabar can not encode",
+			request:           &label.Request{BookCopyIDs: []string{util.NewID()}},
+			mockReturnPayload: nil,
+			statusCode:        http.StatusUnprocessableEntity,
+			err:               barcode.ErrInvalidCodabar,
+		},
+		{
+			name:              "failed printing labels",
+			request:           &label.Request{BookCopyIDs: []string{util.NewID()}},
+			mockReturnPayload: nil,
+			statusCode:        http.StatusInternalServerError,
+			err:               errors.New("Error retrieving Book Copy"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			labelService.On("Sheet", tc.request).Return(tc.mockReturnPayload, tc.err)
+
+			reqByte, err := json.Marshal(tc.request)
+			require.Nil(t, err)
+
+			req := httptest.NewRequest("POST", "/bookcopies/labels", bytes.NewReader(reqByte))
+			w := httptest.NewRecorder()
+
+			labelTestingHandler.getLabelSheet(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+
+			if tc.err == nil {
+				require.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
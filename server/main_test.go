@@ -6,9 +6,22 @@ import (
 
 	"github.com/joshuabezaleel/library-server/pkg/auth"
 	"github.com/joshuabezaleel/library-server/pkg/borrowing"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+	"github.com/joshuabezaleel/library-server/pkg/calendar"
+	"github.com/joshuabezaleel/library-server/pkg/circulation"
+	"github.com/joshuabezaleel/library-server/pkg/core/author"
 	"github.com/joshuabezaleel/library-server/pkg/core/book"
 	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+	"github.com/joshuabezaleel/library-server/pkg/core/subject"
 	"github.com/joshuabezaleel/library-server/pkg/core/user"
+	"github.com/joshuabezaleel/library-server/pkg/cover"
+	"github.com/joshuabezaleel/library-server/pkg/fine"
+	"github.com/joshuabezaleel/library-server/pkg/importing"
+	"github.com/joshuabezaleel/library-server/pkg/label"
+	"github.com/joshuabezaleel/library-server/pkg/marc"
+	"github.com/joshuabezaleel/library-server/pkg/policy"
+	"github.com/joshuabezaleel/library-server/pkg/stocktake"
+	"github.com/joshuabezaleel/library-server/pkg/transfer"
 )
 
 var (
@@ -17,12 +30,40 @@ var (
 	borrowTestingHandler   borrowingHandler
 	bookCopyTestingHandler bookCopyHandler
 	userTestingHandler     userHandler
+	policyTestingHandler   policyHandler
+	calendarTestingHandler calendarHandler
+	fineTestingHandler     fineHandler
+
+	circulationTestingHandler circulationHandler
+	labelTestingHandler       labelHandler
+	branchTestingHandler      branchHandler
+	transferTestingHandler    transferHandler
+	stocktakeTestingHandler   stocktakeHandler
+	authorTestingHandler      authorHandler
+	subjectTestingHandler     subjectHandler
+	marcTestingHandler        marcHandler
+	importingTestingHandler   importingHandler
+	coverTestingHandler       coverHandler
 
 	authService     *auth.MockService
 	borrowService   *borrowing.MockService
 	bookService     *book.MockService
 	bookCopyService *bookcopy.MockService
 	userService     *user.MockService
+	policyService   *policy.MockService
+	calendarService *calendar.MockService
+	fineService     *fine.MockService
+
+	circulationService *circulation.MockService
+	labelService       *label.MockService
+	branchService      *branch.MockService
+	transferService    *transfer.MockService
+	stocktakeService   *stocktake.MockService
+	authorService      *author.MockService
+	subjectService     *subject.MockService
+	marcService        *marc.MockService
+	importingService   *importing.MockService
+	coverService       *cover.MockService
 )
 
 func TestMain(m *testing.M) {
@@ -32,6 +73,19 @@ func TestMain(m *testing.M) {
 	bookService = &book.MockService{}
 	bookCopyService = &bookcopy.MockService{}
 	userService = &user.MockService{}
+	policyService = &policy.MockService{}
+	calendarService = &calendar.MockService{}
+	fineService = &fine.MockService{}
+	circulationService = &circulation.MockService{}
+	labelService = &label.MockService{}
+	branchService = &branch.MockService{}
+	transferService = &transfer.MockService{}
+	stocktakeService = &stocktake.MockService{}
+	authorService = &author.MockService{}
+	subjectService = &subject.MockService{}
+	marcService = &marc.MockService{}
+	importingService = &importing.MockService{}
+	coverService = &cover.MockService{}
 
 	// Initiating handlers with dependency to mock service.
 	authTestingHandler = authHandler{authService}
@@ -39,6 +93,19 @@ func TestMain(m *testing.M) {
 	bookTestingHandler = bookHandler{bookService, authService}
 	bookCopyTestingHandler = bookCopyHandler{bookCopyService, authService}
 	userTestingHandler = userHandler{userService, authService}
+	policyTestingHandler = policyHandler{policyService, authService}
+	calendarTestingHandler = calendarHandler{calendarService, authService}
+	fineTestingHandler = fineHandler{fineService, authService}
+	circulationTestingHandler = circulationHandler{circulationService, authService}
+	labelTestingHandler = labelHandler{labelService, authService}
+	branchTestingHandler = branchHandler{branchService, authService}
+	transferTestingHandler = transferHandler{transferService, borrowService, authService}
+	stocktakeTestingHandler = stocktakeHandler{stocktakeService, authService}
+	authorTestingHandler = authorHandler{authorService, authService}
+	subjectTestingHandler = subjectHandler{subjectService, authService}
+	marcTestingHandler = marcHandler{marcService, authService}
+	importingTestingHandler = importingHandler{importingService, authService}
+	coverTestingHandler = coverHandler{coverService, authService}
 
 	code := m.Run()
 
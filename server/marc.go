@@ -0,0 +1,79 @@
+package server
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/joshuabezaleel/library-server/pkg/auth"
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/marc"
+
+	"github.com/gorilla/mux"
+)
+
+// marcContentTypes are the media types of the MARC21 formats.
+var marcContentTypes = map[string]string{
+	marc.FormatISO2709: "application/marc",
+	marc.FormatMARCXML: "application/marcxml+xml",
+}
+
+type marcHandler struct {
+	marcService marc.Service
+	authService auth.Service
+}
+
+// registerRouter has to be called before the one of the Book handler,
+// whose /books/{bookID} would match the exports otherwise.
+func (handler *marcHandler) registerRouter(router *mux.Router) {
+	router.HandleFunc("/books/marc", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.importRecords))).Methods("POST")
+	router.HandleFunc("/books/{bookID}.mrc", handler.exportRecord(marc.FormatISO2709)).Methods("GET")
+	router.HandleFunc("/books/{bookID}.xml", handler.exportRecord(marc.FormatMARCXML)).Methods("GET")
+}
+
+// importRecords creates Books of the MARC21 records of the request body,
+// either ISO 2709 or MARCXML, and responds with the result of each record.
+func (handler *marcHandler) importRecords(w http.ResponseWriter, r *http.Request) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	results, err := handler.marcService.Import(data)
+	if err == marc.ErrInvalidRecord || err == marc.ErrNoRecords {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, results)
+}
+
+func (handler *marcHandler) exportRecord(format string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bookID, ok := vars["bookID"]
+		if !ok {
+			respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+			return
+		}
+
+		record, err := handler.marcService.Export(bookID, format)
+		if err == book.ErrGetBook {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", marcContentTypes[format])
+		w.WriteHeader(http.StatusOK)
+		w.Write(record)
+	}
+}
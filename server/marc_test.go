@@ -0,0 +1,110 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/auth"
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/marc"
+)
+
+func TestMARCExport(t *testing.T) {
+	bookID := util.NewID()
+	missingBookID := util.NewID()
+
+	marcService.On("Export", bookID, marc.FormatISO2709).Return([]byte("00026nam a2200025 i 4500\x1e\x1d"), nil)
+	marcService.On("Export", bookID, marc.FormatMARCXML).Return([]byte("<collection/>"), nil)
+	marcService.On("Export", missingBookID, mock.AnythingOfType("string")).Return(nil, book.ErrGetBook)
+
+	// The exports are routed past /books/{bookID}, registered the way NewServer does.
+	passThrough := func(next http.HandlerFunc) http.HandlerFunc { return next }
+	routerAuthService := &auth.MockService{}
+	routerAuthService.On("CheckLoggedInMiddleware", mock.Anything).Return(passThrough)
+	routerAuthService.On("CheckLibrarian", mock.Anything).Return(passThrough)
+
+	router := mux.NewRouter()
+	(&marcHandler{marcService, routerAuthService}).registerRouter(router)
+	(&bookHandler{bookService, routerAuthService}).registerRouter(router)
+
+	tt := []struct {
+		name        string
+		path        string
+		statusCode  int
+		contentType string
+	}{
+		{
+			name:        "success exporting a Book as ISO 2709",
+			path:        "/books/" + bookID + ".mrc",
+			statusCode:  http.StatusOK,
+			contentType: "application/marc",
+		},
+		{
+			name:        "success exporting a Book as MARCXML",
+			path:        "/books/" + bookID + ".xml",
+			statusCode:  http.StatusOK,
+			contentType: "application/marcxml+xml",
+		},
+		{
+			name:       "Book does not exist",
+			path:       "/books/" + missingBookID + ".mrc",
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.path, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.contentType != "" {
+				require.Equal(t, tc.contentType, w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestMARCImport(t *testing.T) {
+	results := []*marc.Result{{Index: 0, Book: &book.Book{ID: util.NewID()}}}
+
+	marcService.On("Import", []byte("<collection/>")).Return(nil, marc.ErrNoRecords)
+	marcService.On("Import", []byte("<record/>")).Return(results, nil)
+
+	tt := []struct {
+		name       string
+		body       string
+		statusCode int
+	}{
+		{
+			name:       "success importing records",
+			body:       "<record/>",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "no records to import",
+			body:       "<collection/>",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/books/marc", bytes.NewReader([]byte(tc.body)))
+			w := httptest.NewRecorder()
+
+			marcTestingHandler.importRecords(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/joshuabezaleel/library-server/pkg/auth"
+	"github.com/joshuabezaleel/library-server/pkg/policy"
+
+	"github.com/gorilla/mux"
+)
+
+type policyHandler struct {
+	policyService policy.Service
+	authService   auth.Service
+}
+
+func (handler *policyHandler) registerRouter(router *mux.Router) {
+	// CRUD endpoints.
+	router.HandleFunc("/policies", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.createPolicy))).Methods("POST")
+	router.HandleFunc("/policies/{policyID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.getPolicy))).Methods("GET")
+	router.HandleFunc("/policies/{policyID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.updatePolicy))).Methods("PUT")
+	router.HandleFunc("/policies/{policyID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.deletePolicy))).Methods("DELETE")
+
+	// Other endpoints.
+	router.HandleFunc("/policies", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.getPolicies))).Methods("GET")
+}
+
+func (handler *policyHandler) createPolicy(w http.ResponseWriter, r *http.Request) {
+	loanPolicy := policy.Policy{}
+
+	err := json.NewDecoder(r.Body).Decode(&loanPolicy)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	newPolicy, err := handler.policyService.Create(&loanPolicy)
+	if err == policy.ErrInvalidPolicy {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, newPolicy)
+}
+
+func (handler *policyHandler) getPolicy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	policyID, ok := vars["policyID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	loanPolicy, err := handler.policyService.Get(policyID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, loanPolicy)
+}
+
+func (handler *policyHandler) updatePolicy(w http.ResponseWriter, r *http.Request) {
+	loanPolicy := policy.Policy{}
+
+	err := json.NewDecoder(r.Body).Decode(&loanPolicy)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	policyID, ok := vars["policyID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+	loanPolicy.ID = policyID
+
+	updatedPolicy, err := handler.policyService.Update(&loanPolicy)
+	if err == policy.ErrInvalidPolicy {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, updatedPolicy)
+}
+
+func (handler *policyHandler) deletePolicy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	policyID, ok := vars["policyID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	err := handler.policyService.Delete(policyID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, "Policy "+policyID+" deleted")
+}
+
+func (handler *policyHandler) getPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := handler.policyService.GetAll()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, policies)
+}
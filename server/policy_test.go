@@ -0,0 +1,93 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/policy"
+)
+
+func TestPolicyCreate(t *testing.T) {
+	validPolicy := &policy.Policy{
+		ID:             util.NewID(),
+		Role:           "staff",
+		Category:       "reference",
+		LoanPeriodDays: 3,
+	}
+
+	invalidPolicy := &policy.Policy{
+		ID:       util.NewID(),
+		Role:     "visitor",
+		Category: "reference",
+	}
+
+	failedPolicy := &policy.Policy{
+		ID:             util.NewID(),
+		Role:           "student",
+		Category:       "reference",
+		LoanPeriodDays: 1,
+	}
+
+	tt := []struct {
+		name              string
+		requestPayload    interface{}
+		mockReturnPayload interface{}
+		statusCode        int
+		err               error
+	}{
+		{
+			name:              "success creating a valid Policy",
+			requestPayload:    validPolicy,
+			mockReturnPayload: validPolicy,
+			statusCode:        http.StatusCreated,
+			err:               nil,
+		},
+		{
+			name:              "invalid request payload",
+			requestPayload:    "a plain string, not a Policy",
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               nil,
+		},
+		{
+			name:              "invalid Policy",
+			requestPayload:    invalidPolicy,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusBadRequest,
+			err:               policy.ErrInvalidPolicy,
+		},
+		{
+			name:              "failed creating a Policy",
+			requestPayload:    failedPolicy,
+			mockReturnPayload: nil,
+			statusCode:        http.StatusInternalServerError,
+			err:               errors.New("Error creating Policy"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if requestPolicy, ok := tc.requestPayload.(*policy.Policy); ok {
+				policyService.On("Create", mock.MatchedBy(func(p *policy.Policy) bool { return p.ID == requestPolicy.ID })).Return(tc.mockReturnPayload, tc.err)
+			}
+
+			reqByte, err := json.Marshal(tc.requestPayload)
+			require.Nil(t, err)
+
+			req := httptest.NewRequest("POST", "/policies", bytes.NewReader(reqByte))
+			w := httptest.NewRecorder()
+
+			policyTestingHandler.createPolicy(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
@@ -7,9 +7,22 @@ import (
 
 	"github.com/joshuabezaleel/library-server/pkg/auth"
 	"github.com/joshuabezaleel/library-server/pkg/borrowing"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+	"github.com/joshuabezaleel/library-server/pkg/calendar"
+	"github.com/joshuabezaleel/library-server/pkg/circulation"
+	"github.com/joshuabezaleel/library-server/pkg/core/author"
 	"github.com/joshuabezaleel/library-server/pkg/core/book"
 	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+	"github.com/joshuabezaleel/library-server/pkg/core/subject"
 	"github.com/joshuabezaleel/library-server/pkg/core/user"
+	"github.com/joshuabezaleel/library-server/pkg/cover"
+	"github.com/joshuabezaleel/library-server/pkg/fine"
+	"github.com/joshuabezaleel/library-server/pkg/importing"
+	"github.com/joshuabezaleel/library-server/pkg/label"
+	"github.com/joshuabezaleel/library-server/pkg/marc"
+	"github.com/joshuabezaleel/library-server/pkg/policy"
+	"github.com/joshuabezaleel/library-server/pkg/stocktake"
+	"github.com/joshuabezaleel/library-server/pkg/transfer"
 
 	"github.com/gorilla/mux"
 )
@@ -21,19 +34,47 @@ type Server struct {
 	bookCopyService bookcopy.Service
 	userService     user.Service
 	borrowService   borrowing.Service
+	policyService   policy.Service
+	calendarService calendar.Service
+	fineService     fine.Service
+
+	circulationService circulation.Service
+	labelService       label.Service
+	branchService      branch.Service
+	transferService    transfer.Service
+	stocktakeService   stocktake.Service
+	authorService      author.Service
+	subjectService     subject.Service
+	marcService        marc.Service
+	importingService   importing.Service
+	coverService       cover.Service
 
 	Router *mux.Router
 }
 
 // NewServer returns a new HTTP server
 // with all of the necessary dependencies.
-func NewServer(authService auth.Service, bookService book.Service, bookCopyService bookcopy.Service, userService user.Service, borrowService borrowing.Service) *Server {
+func NewServer(authService auth.Service, bookService book.Service, bookCopyService bookcopy.Service, userService user.Service, borrowService borrowing.Service, policyService policy.Service, calendarService calendar.Service, fineService fine.Service, circulationService circulation.Service, labelService label.Service, branchService branch.Service, transferService transfer.Service, stocktakeService stocktake.Service, authorService author.Service, subjectService subject.Service, marcService marc.Service, importingService importing.Service, coverService cover.Service) *Server {
 	server := &Server{
 		authService:     authService,
 		bookService:     bookService,
 		bookCopyService: bookCopyService,
 		userService:     userService,
 		borrowService:   borrowService,
+		policyService:   policyService,
+		calendarService: calendarService,
+		fineService:     fineService,
+
+		circulationService: circulationService,
+		labelService:       labelService,
+		branchService:      branchService,
+		transferService:    transferService,
+		stocktakeService:   stocktakeService,
+		authorService:      authorService,
+		subjectService:     subjectService,
+		marcService:        marcService,
+		importingService:   importingService,
+		coverService:       coverService,
 	}
 
 	authHandler := authHandler{authService}
@@ -41,14 +82,40 @@ func NewServer(authService auth.Service, bookService book.Service, bookCopyServi
 	bookCopyHandler := bookCopyHandler{bookCopyService, authService}
 	userHandler := userHandler{userService, authService}
 	borrowHandler := borrowingHandler{borrowService, authService}
+	policyHandler := policyHandler{policyService, authService}
+	calendarHandler := calendarHandler{calendarService, authService}
+	fineHandler := fineHandler{fineService, authService}
+	circulationHandler := circulationHandler{circulationService, authService}
+	labelHandler := labelHandler{labelService, authService}
+	branchHandler := branchHandler{branchService, authService}
+	transferHandler := transferHandler{transferService, borrowService, authService}
+	stocktakeHandler := stocktakeHandler{stocktakeService, authService}
+	authorHandler := authorHandler{authorService, authService}
+	subjectHandler := subjectHandler{subjectService, authService}
+	marcHandler := marcHandler{marcService, authService}
+	importingHandler := importingHandler{importingService, authService}
+	coverHandler := coverHandler{coverService, authService}
 
 	router := mux.NewRouter()
 
 	authHandler.registerRouter(router)
+	marcHandler.registerRouter(router)
 	bookHandler.registerRouter(router)
 	bookCopyHandler.registerRouter(router)
 	userHandler.registerRouter(router)
 	borrowHandler.registerRouter(router)
+	policyHandler.registerRouter(router)
+	calendarHandler.registerRouter(router)
+	fineHandler.registerRouter(router)
+	circulationHandler.registerRouter(router)
+	labelHandler.registerRouter(router)
+	branchHandler.registerRouter(router)
+	transferHandler.registerRouter(router)
+	stocktakeHandler.registerRouter(router)
+	authorHandler.registerRouter(router)
+	subjectHandler.registerRouter(router)
+	importingHandler.registerRouter(router)
+	coverHandler.registerRouter(router)
 
 	server.Router = router
 
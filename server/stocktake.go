@@ -0,0 +1,161 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/joshuabezaleel/library-server/pkg/auth"
+	"github.com/joshuabezaleel/library-server/pkg/stocktake"
+
+	"github.com/gorilla/mux"
+)
+
+type stocktakeHandler struct {
+	stocktakeService stocktake.Service
+	authService      auth.Service
+}
+
+func (handler *stocktakeHandler) registerRouter(router *mux.Router) {
+	router.HandleFunc("/stocktakes", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.openSession))).Methods("POST")
+	router.HandleFunc("/stocktakes/{sessionID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.getSession))).Methods("GET")
+	router.HandleFunc("/stocktakes/{sessionID}/scans", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.scanBarcodes))).Methods("POST")
+	router.HandleFunc("/stocktakes/{sessionID}/close", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.closeSession))).Methods("POST")
+	router.HandleFunc("/stocktakes/{sessionID}/report", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.getReport))).Methods("GET")
+}
+
+func (handler *stocktakeHandler) openSession(w http.ResponseWriter, r *http.Request) {
+	session := stocktake.Session{}
+
+	err := json.NewDecoder(r.Body).Decode(&session)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	username := r.Context().Value("username").(string)
+
+	newSession, err := handler.stocktakeService.Open(username, session.BranchID, session.LocationID)
+	if err == stocktake.ErrNoLocation || isInvalidShelving(err) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, newSession)
+}
+
+func (handler *stocktakeHandler) getSession(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	sessionID, ok := vars["sessionID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	session, err := handler.stocktakeService.GetSession(sessionID)
+	if err == stocktake.ErrGetSession {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, session)
+}
+
+// scanBarcodes records a batch of barcodes read on the shelves, so that
+// scanners can stream them to the Session as the shelves are scanned.
+func (handler *stocktakeHandler) scanBarcodes(w http.ResponseWriter, r *http.Request) {
+	batch := struct {
+		Barcodes []string `json:"barcodes"`
+	}{}
+
+	err := json.NewDecoder(r.Body).Decode(&batch)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	sessionID, ok := vars["sessionID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	username := r.Context().Value("username").(string)
+
+	scans, err := handler.stocktakeService.Scan(username, sessionID, batch.Barcodes)
+	switch err {
+	case nil:
+	case stocktake.ErrNoBarcodes:
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	case stocktake.ErrGetSession:
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	case stocktake.ErrSessionClosed:
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, scans)
+}
+
+func (handler *stocktakeHandler) closeSession(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	sessionID, ok := vars["sessionID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	username := r.Context().Value("username").(string)
+
+	report, err := handler.stocktakeService.Close(username, sessionID)
+	switch err {
+	case nil:
+	case stocktake.ErrGetSession:
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	case stocktake.ErrSessionClosed:
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+func (handler *stocktakeHandler) getReport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	sessionID, ok := vars["sessionID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	report, err := handler.stocktakeService.Report(sessionID)
+	if err == stocktake.ErrGetSession {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, report)
+}
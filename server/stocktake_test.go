@@ -0,0 +1,104 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/stocktake"
+)
+
+func TestStocktakeScan(t *testing.T) {
+	username := "librarian"
+
+	tt := []struct {
+		name       string
+		sessionID  string
+		barcodes   []string
+		statusCode int
+		err        error
+	}{
+		{
+			name:       "success scanning barcodes",
+			sessionID:  util.NewID(),
+			barcodes:   []string{"31234000000016", "31234000000024"},
+			statusCode: http.StatusCreated,
+			err:        nil,
+		},
+		{
+			name:       "no barcodes",
+			sessionID:  util.NewID(),
+			barcodes:   []string{},
+			statusCode: http.StatusBadRequest,
+			err:        stocktake.ErrNoBarcodes,
+		},
+		{
+			name:       "unknown Session",
+			sessionID:  util.NewID(),
+			barcodes:   []string{"31234000000016"},
+			statusCode: http.StatusNotFound,
+			err:        stocktake.ErrGetSession,
+		},
+		{
+			name:       "closed Session",
+			sessionID:  util.NewID(),
+			barcodes:   []string{"31234000000016"},
+			statusCode: http.StatusConflict,
+			err:        stocktake.ErrSessionClosed,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var scans []*stocktake.Scan
+			if tc.err == nil {
+				scans = []*stocktake.Scan{}
+			}
+			stocktakeService.On("Scan", username, tc.sessionID, tc.barcodes).Return(scans, tc.err)
+
+			reqByte, err := json.Marshal(map[string][]string{"barcodes": tc.barcodes})
+			require.Nil(t, err)
+
+			req := httptest.NewRequest("POST", "/stocktakes/"+tc.sessionID+"/scans", bytes.NewReader(reqByte))
+			req = mux.SetURLVars(req, map[string]string{"sessionID": tc.sessionID})
+			req = req.WithContext(context.WithValue(req.Context(), "username", username))
+			w := httptest.NewRecorder()
+
+			stocktakeTestingHandler.scanBarcodes(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestStocktakeClose(t *testing.T) {
+	username := "librarian"
+	sessionID := util.NewID()
+
+	report := &stocktake.Report{
+		Session:         &stocktake.Session{ID: sessionID, Status: stocktake.SessionClosed},
+		UnknownBarcodes: []string{"unknown"},
+	}
+	stocktakeService.On("Close", username, sessionID).Return(report, nil)
+
+	req := httptest.NewRequest("POST", "/stocktakes/"+sessionID+"/close", nil)
+	req = mux.SetURLVars(req, map[string]string{"sessionID": sessionID})
+	req = req.WithContext(context.WithValue(req.Context(), "username", username))
+	w := httptest.NewRecorder()
+
+	stocktakeTestingHandler.closeSession(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	closedReport := stocktake.Report{}
+	err := json.NewDecoder(w.Body).Decode(&closedReport)
+	require.Nil(t, err)
+	require.Equal(t, []string{"unknown"}, closedReport.UnknownBarcodes)
+}
@@ -0,0 +1,228 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/joshuabezaleel/library-server/pkg/auth"
+	"github.com/joshuabezaleel/library-server/pkg/core/subject"
+
+	"github.com/gorilla/mux"
+)
+
+type subjectHandler struct {
+	subjectService subject.Service
+	authService    auth.Service
+}
+
+type subjectRelationRequest struct {
+	Relation  string `json:"relation"`
+	RelatedID int64  `json:"relatedID"`
+}
+
+func (handler *subjectHandler) registerRouter(router *mux.Router) {
+	router.HandleFunc("/subjects", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.createSubject))).Methods("POST")
+	router.HandleFunc("/subjects", handler.getSubjects).Methods("GET")
+	router.HandleFunc("/subjects/{subjectID}", handler.getSubject).Methods("GET")
+	router.HandleFunc("/subjects/{subjectID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.updateSubject))).Methods("PUT")
+	router.HandleFunc("/subjects/{subjectID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.deleteSubject))).Methods("DELETE")
+	router.HandleFunc("/subjects/{subjectID}/relations", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.addRelation))).Methods("POST")
+	router.HandleFunc("/subjects/{subjectID}/relations/{relation}/{relatedID}", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.removeRelation))).Methods("DELETE")
+	router.HandleFunc("/subjects/{subjectID}/books", handler.getSubjectBooks).Methods("GET")
+}
+
+func (handler *subjectHandler) createSubject(w http.ResponseWriter, r *http.Request) {
+	newSubject := subject.Subject{}
+
+	err := json.NewDecoder(r.Body).Decode(&newSubject)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	createdSubject, err := handler.subjectService.Create(&newSubject)
+	if err == subject.ErrInvalidSubject || err == subject.ErrInvalidPreferredTerm {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, createdSubject)
+}
+
+func (handler *subjectHandler) getSubjects(w http.ResponseWriter, r *http.Request) {
+	subjects, err := handler.subjectService.List()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, subjects)
+}
+
+// getSubject redirects a synonym to its preferred term.
+func (handler *subjectHandler) getSubject(w http.ResponseWriter, r *http.Request) {
+	subjectID, ok := int64FromPath(r, "subjectID")
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	retrievedSubject, err := handler.subjectService.Get(subjectID)
+	if err == subject.ErrGetSubject {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if retrievedSubject.IsSynonym() {
+		http.Redirect(w, r, "/subjects/"+strconv.FormatInt(retrievedSubject.PreferredID, 10), http.StatusMovedPermanently)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, retrievedSubject)
+}
+
+func (handler *subjectHandler) updateSubject(w http.ResponseWriter, r *http.Request) {
+	updatedSubject := subject.Subject{}
+
+	err := json.NewDecoder(r.Body).Decode(&updatedSubject)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	subjectID, ok := int64FromPath(r, "subjectID")
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+	updatedSubject.ID = subjectID
+
+	savedSubject, err := handler.subjectService.Update(&updatedSubject)
+	switch err {
+	case nil:
+		respondWithJSON(w, http.StatusOK, savedSubject)
+	case subject.ErrInvalidSubject, subject.ErrInvalidPreferredTerm:
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case subject.ErrGetSubject:
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case subject.ErrSynonymInUse:
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func (handler *subjectHandler) deleteSubject(w http.ResponseWriter, r *http.Request) {
+	subjectID, ok := int64FromPath(r, "subjectID")
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	err := handler.subjectService.Delete(subjectID)
+	if err == subject.ErrSubjectHasBooks {
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, "Subject "+strconv.FormatInt(subjectID, 10)+" deleted")
+}
+
+func (handler *subjectHandler) addRelation(w http.ResponseWriter, r *http.Request) {
+	request := subjectRelationRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	subjectID, ok := int64FromPath(r, "subjectID")
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	relatedSubject, err := handler.subjectService.AddRelation(subjectID, request.Relation, request.RelatedID)
+	handler.respondWithRelation(w, relatedSubject, err)
+}
+
+func (handler *subjectHandler) removeRelation(w http.ResponseWriter, r *http.Request) {
+	subjectID, ok := int64FromPath(r, "subjectID")
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	relatedID, ok := int64FromPath(r, "relatedID")
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	relatedSubject, err := handler.subjectService.RemoveRelation(subjectID, mux.Vars(r)["relation"], relatedID)
+	handler.respondWithRelation(w, relatedSubject, err)
+}
+
+func (handler *subjectHandler) respondWithRelation(w http.ResponseWriter, relatedSubject *subject.Subject, err error) {
+	switch err {
+	case nil:
+		respondWithJSON(w, http.StatusOK, relatedSubject)
+	case subject.ErrInvalidRelation, subject.ErrSelfRelation, subject.ErrRelationToSynonym:
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case subject.ErrGetSubject:
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case subject.ErrRelationCycle:
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// getSubjectBooks retrieves the Books of the Subject and, with
+// includeNarrower=true, the Books of all of its narrower terms too.
+func (handler *subjectHandler) getSubjectBooks(w http.ResponseWriter, r *http.Request) {
+	subjectID, ok := int64FromPath(r, "subjectID")
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	includeNarrower := false
+	if value := r.URL.Query().Get("includeNarrower"); value != "" {
+		var err error
+		includeNarrower, err = strconv.ParseBool(value)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, errInvalidQueryParameter.Error())
+			return
+		}
+	}
+
+	books, err := handler.subjectService.GetBooks(subjectID, includeNarrower)
+	if err == subject.ErrGetSubject {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, books)
+}
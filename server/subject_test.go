@@ -0,0 +1,162 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshuabezaleel/library-server/pkg/core/book"
+	"github.com/joshuabezaleel/library-server/pkg/core/subject"
+)
+
+func TestSubjectGet(t *testing.T) {
+	mathematics := &subject.Subject{ID: 1, Term: "Mathematics"}
+	maths := &subject.Subject{ID: 2, Term: "Maths", PreferredID: mathematics.ID}
+
+	subjectService.On("Get", mathematics.ID).Return(mathematics, nil)
+	subjectService.On("Get", maths.ID).Return(maths, nil)
+	subjectService.On("Get", int64(3)).Return(nil, subject.ErrGetSubject)
+
+	tt := []struct {
+		name       string
+		subjectID  string
+		statusCode int
+		location   string
+	}{
+		{
+			name:       "success retrieving a preferred term",
+			subjectID:  "1",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "synonym redirects to its preferred term",
+			subjectID:  "2",
+			statusCode: http.StatusMovedPermanently,
+			location:   "/subjects/1",
+		},
+		{
+			name:       "Subject does not exist",
+			subjectID:  "3",
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "invalid subjectID",
+			subjectID:  "maths",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/subjects/"+tc.subjectID, nil)
+			req = mux.SetURLVars(req, map[string]string{"subjectID": tc.subjectID})
+			w := httptest.NewRecorder()
+
+			subjectTestingHandler.getSubject(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+			if tc.location != "" {
+				require.Equal(t, tc.location, w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestSubjectAddRelation(t *testing.T) {
+	tt := []struct {
+		name       string
+		subjectID  string
+		request    subjectRelationRequest
+		statusCode int
+		err        error
+	}{
+		{
+			name:       "success adding a broader term",
+			subjectID:  "10",
+			request:    subjectRelationRequest{Relation: subject.RelationBroader, RelatedID: 11},
+			statusCode: http.StatusOK,
+			err:        nil,
+		},
+		{
+			name:       "invalid relation",
+			subjectID:  "10",
+			request:    subjectRelationRequest{Relation: "sibling", RelatedID: 11},
+			statusCode: http.StatusBadRequest,
+			err:        subject.ErrInvalidRelation,
+		},
+		{
+			name:       "relation makes a cycle",
+			subjectID:  "10",
+			request:    subjectRelationRequest{Relation: subject.RelationNarrower, RelatedID: 12},
+			statusCode: http.StatusConflict,
+			err:        subject.ErrRelationCycle,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var relatedSubject *subject.Subject
+			if tc.err == nil {
+				relatedSubject = &subject.Subject{ID: 10, Term: "Algebra"}
+			}
+			subjectService.On("AddRelation", int64(10), tc.request.Relation, tc.request.RelatedID).Return(relatedSubject, tc.err)
+
+			reqByte, err := json.Marshal(tc.request)
+			require.Nil(t, err)
+
+			req := httptest.NewRequest("POST", "/subjects/"+tc.subjectID+"/relations", bytes.NewReader(reqByte))
+			req = mux.SetURLVars(req, map[string]string{"subjectID": tc.subjectID})
+			w := httptest.NewRecorder()
+
+			subjectTestingHandler.addRelation(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestSubjectGetBooks(t *testing.T) {
+	subjectService.On("GetBooks", int64(20), false).Return([]*book.Book{}, nil)
+	subjectService.On("GetBooks", int64(20), true).Return([]*book.Book{}, nil)
+
+	tt := []struct {
+		name       string
+		query      string
+		statusCode int
+	}{
+		{
+			name:       "Books of the Subject only",
+			query:      "",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Books of the Subject and its narrower terms",
+			query:      "?includeNarrower=true",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "invalid includeNarrower",
+			query:      "?includeNarrower=sometimes",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/subjects/20/books"+tc.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"subjectID": "20"})
+			w := httptest.NewRecorder()
+
+			subjectTestingHandler.getSubjectBooks(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+
+	subjectService.AssertCalled(t, "GetBooks", int64(20), true)
+}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/joshuabezaleel/library-server/pkg/auth"
+	"github.com/joshuabezaleel/library-server/pkg/borrowing"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+	"github.com/joshuabezaleel/library-server/pkg/transfer"
+
+	"github.com/gorilla/mux"
+)
+
+type transferHandler struct {
+	transferService transfer.Service
+	borrowService   borrowing.Service
+	authService     auth.Service
+}
+
+func (handler *transferHandler) registerRouter(router *mux.Router) {
+	// Book Copy endpoints.
+	router.HandleFunc("/books/{bookID}/bookcopies/{bookCopyID}/transfers", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.sendTransfer))).Methods("POST")
+	router.HandleFunc("/books/{bookID}/bookcopies/{bookCopyID}/transfers", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.getTransferHistory))).Methods("GET")
+
+	// Branch endpoints.
+	router.HandleFunc("/branches/{branchID}/transfers/slips", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.getTransferSlips))).Methods("GET")
+	router.HandleFunc("/branches/{branchID}/transfers/incoming", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.getIncomingTransfers))).Methods("GET")
+	router.HandleFunc("/branches/{branchID}/transfers/receive", handler.authService.CheckLoggedInMiddleware(handler.authService.CheckLibrarian(handler.receiveTransfer))).Methods("POST")
+}
+
+func (handler *transferHandler) sendTransfer(w http.ResponseWriter, r *http.Request) {
+	request := struct {
+		ToBranchID string `json:"toBranchID"`
+		Note       string `json:"note"`
+	}{}
+
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	bookCopyID, ok := vars["bookCopyID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	username := r.Context().Value("username").(string)
+
+	sentTransfer, err := handler.transferService.Send(username, bookCopyID, request.ToBranchID, request.Note)
+	switch err {
+	case nil:
+	case branch.ErrGetBranch:
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	case bookcopy.ErrGetBookCopy:
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	case transfer.ErrSameBranch, transfer.ErrNoCurrentBranch, transfer.ErrAlreadyInTransit, bookcopy.ErrInvalidTransition:
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, sentTransfer)
+}
+
+func (handler *transferHandler) getTransferHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookCopyID, ok := vars["bookCopyID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	transfers, err := handler.transferService.GetHistory(bookCopyID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, transfers)
+}
+
+// getTransferSlips lists the slips of the Book Copies to be shipped from the Branch.
+func (handler *transferHandler) getTransferSlips(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	branchID, ok := vars["branchID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	slips, err := handler.transferService.GetSlips(branchID)
+	if err == transfer.ErrInvalidSlipBranch {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, slips)
+}
+
+// getIncomingTransfers lists the slips of the Book Copies on their way to the Branch.
+func (handler *transferHandler) getIncomingTransfers(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	branchID, ok := vars["branchID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	slips, err := handler.transferService.GetIncoming(branchID)
+	if err == transfer.ErrInvalidSlipBranch {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, slips)
+}
+
+// receiveTransfer checks in the Book Copy whose barcode is scanned at the
+// destination Branch of its Transfer, and traps it for the next Hold on its Book.
+func (handler *transferHandler) receiveTransfer(w http.ResponseWriter, r *http.Request) {
+	scan := struct {
+		Barcode string `json:"barcode"`
+	}{}
+
+	err := json.NewDecoder(r.Body).Decode(&scan)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, errInvalidRequestPayload.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	branchID, ok := vars["branchID"]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, errInvalidURLPath.Error())
+		return
+	}
+
+	username := r.Context().Value("username").(string)
+
+	receivedTransfer, err := handler.borrowService.ReceiveTransfer(username, branchID, scan.Barcode)
+	switch err {
+	case nil:
+	case bookcopy.ErrGetBookCopy:
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	case transfer.ErrNotInTransit, transfer.ErrWrongDestination, bookcopy.ErrInvalidTransition:
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, receivedTransfer)
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	util "github.com/joshuabezaleel/library-server/pkg"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+	"github.com/joshuabezaleel/library-server/pkg/transfer"
+)
+
+func TestTransferSend(t *testing.T) {
+	username := "librarian"
+
+	tt := []struct {
+		name       string
+		ID         string
+		toBranchID string
+		statusCode int
+		err        error
+	}{
+		{
+			name:       "success sending a Book Copy",
+			ID:         util.NewID(),
+			toBranchID: util.NewID(),
+			statusCode: http.StatusCreated,
+			err:        nil,
+		},
+		{
+			name:       "unknown destination",
+			ID:         util.NewID(),
+			toBranchID: util.NewID(),
+			statusCode: http.StatusBadRequest,
+			err:        branch.ErrGetBranch,
+		},
+		{
+			name:       "Book Copy already in transit",
+			ID:         util.NewID(),
+			toBranchID: util.NewID(),
+			statusCode: http.StatusConflict,
+			err:        transfer.ErrAlreadyInTransit,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var sentTransfer *transfer.Transfer
+			if tc.err == nil {
+				sentTransfer = &transfer.Transfer{ID: util.NewID(), BookCopyID: tc.ID, ToBranchID: tc.toBranchID, Status: transfer.StatusInTransit}
+			}
+			transferService.On("Send", username, tc.ID, tc.toBranchID, "").Return(sentTransfer, tc.err)
+
+			reqByte, err := json.Marshal(map[string]string{"toBranchID": tc.toBranchID})
+			require.Nil(t, err)
+
+			req := httptest.NewRequest("POST", "/books/bookID/bookcopies/"+tc.ID+"/transfers", bytes.NewReader(reqByte))
+			req = mux.SetURLVars(req, map[string]string{"bookCopyID": tc.ID})
+			req = req.WithContext(context.WithValue(req.Context(), "username", username))
+			w := httptest.NewRecorder()
+
+			transferTestingHandler.sendTransfer(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
+
+func TestTransferReceive(t *testing.T) {
+	username := "librarian"
+	branchID := util.NewID()
+
+	tt := []struct {
+		name       string
+		barcode    string
+		statusCode int
+		err        error
+	}{
+		{
+			name:       "success receiving a Book Copy",
+			barcode:    util.NewID(),
+			statusCode: http.StatusOK,
+			err:        nil,
+		},
+		{
+			name:       "unknown barcode",
+			barcode:    util.NewID(),
+			statusCode: http.StatusNotFound,
+			err:        bookcopy.ErrGetBookCopy,
+		},
+		{
+			name:       "Book Copy in transit to another Branch",
+			barcode:    util.NewID(),
+			statusCode: http.StatusConflict,
+			err:        transfer.ErrWrongDestination,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var receivedTransfer *transfer.Transfer
+			if tc.err == nil {
+				receivedTransfer = &transfer.Transfer{ID: util.NewID(), ToBranchID: branchID, Status: transfer.StatusReceived}
+			}
+			borrowService.On("ReceiveTransfer", username, branchID, tc.barcode).Return(receivedTransfer, tc.err)
+
+			reqByte, err := json.Marshal(map[string]string{"barcode": tc.barcode})
+			require.Nil(t, err)
+
+			req := httptest.NewRequest("POST", "/branches/"+branchID+"/transfers/receive", bytes.NewReader(reqByte))
+			req = mux.SetURLVars(req, map[string]string{"branchID": branchID})
+			req = req.WithContext(context.WithValue(req.Context(), "username", username))
+			w := httptest.NewRecorder()
+
+			transferTestingHandler.receiveTransfer(w, req)
+
+			require.Equal(t, tc.statusCode, w.Code)
+		})
+	}
+}
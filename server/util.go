@@ -4,17 +4,26 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
 )
 
 var (
 	errInvalidRequestPayload = errors.New("Invalid request payload")
 	errInvalidURLPath        = errors.New("Invalid URL path")
+	errInvalidQueryParameter = errors.New("Invalid query parameter")
 )
 
 func respondWithError(w http.ResponseWriter, code int, message string) {
 	respondWithJSON(w, code, map[string]string{"Error": message})
 }
 
+// respondWithReason responds with an error along with its machine-readable reason code.
+func respondWithReason(w http.ResponseWriter, code int, message string, reason string) {
+	respondWithJSON(w, code, map[string]string{"Error": message, "Reason": reason})
+}
+
 func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 	response, _ := json.Marshal(payload)
 
@@ -22,3 +31,18 @@ func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 	w.WriteHeader(code)
 	w.Write(response)
 }
+
+// int64FromPath parses the numeric ID with the name in the URL path.
+func int64FromPath(r *http.Request, name string) (int64, bool) {
+	value, ok := mux.Vars(r)[name]
+	if !ok {
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return id, true
+}
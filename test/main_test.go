@@ -8,10 +8,25 @@ import (
 
 	"github.com/joshuabezaleel/library-server/persistence"
 	"github.com/joshuabezaleel/library-server/pkg/auth"
+	"github.com/joshuabezaleel/library-server/pkg/barcode"
 	"github.com/joshuabezaleel/library-server/pkg/borrowing"
+	"github.com/joshuabezaleel/library-server/pkg/branch"
+	"github.com/joshuabezaleel/library-server/pkg/calendar"
+	"github.com/joshuabezaleel/library-server/pkg/circulation"
+	"github.com/joshuabezaleel/library-server/pkg/core/author"
 	"github.com/joshuabezaleel/library-server/pkg/core/book"
 	"github.com/joshuabezaleel/library-server/pkg/core/bookcopy"
+	"github.com/joshuabezaleel/library-server/pkg/core/subject"
 	"github.com/joshuabezaleel/library-server/pkg/core/user"
+	"github.com/joshuabezaleel/library-server/pkg/cover"
+	"github.com/joshuabezaleel/library-server/pkg/fine"
+	"github.com/joshuabezaleel/library-server/pkg/importing"
+	"github.com/joshuabezaleel/library-server/pkg/label"
+	"github.com/joshuabezaleel/library-server/pkg/marc"
+	"github.com/joshuabezaleel/library-server/pkg/policy"
+	"github.com/joshuabezaleel/library-server/pkg/stocktake"
+	"github.com/joshuabezaleel/library-server/pkg/storage"
+	"github.com/joshuabezaleel/library-server/pkg/transfer"
 	"github.com/joshuabezaleel/library-server/server"
 )
 
@@ -38,10 +53,23 @@ func TestMain(m *testing.M) {
 	userService := user.NewUserService(repository.UserRepository)
 	authService := auth.NewAuthService(repository.AuthRepository, userService)
 	bookService := book.NewBookService(repository.BookRepository)
-	bookCopyService := bookcopy.NewBookCopyService(repository.BookCopyRepository, bookService)
-	borrowService := borrowing.NewBorrowingService(repository.BorrowRepository, userService, bookCopyService)
+	branchService := branch.NewBranchService(repository.BranchRepository)
+	bookCopyService := bookcopy.NewBookCopyService(repository.BookCopyRepository, bookService, branchService, barcode.NewSchemeFromEnv())
+	policyService := policy.NewPolicyService(repository.PolicyRepository)
+	calendarService := calendar.NewCalendarService(repository.CalendarRepository, calendar.LocationFromEnv())
+	fineService := fine.NewFineService(repository.FineRepository, userService)
+	transferService := transfer.NewTransferService(repository.TransferRepository, bookCopyService, bookService, branchService)
+	borrowService := borrowing.NewBorrowingService(repository.BorrowRepository, userService, bookService, bookCopyService, policyService, calendarService, fineService, transferService)
+	circulationService := circulation.NewCirculationService(borrowService, userService, bookCopyService)
+	labelService := label.NewLabelService(bookCopyService, bookService)
+	stocktakeService := stocktake.NewStocktakeService(repository.StocktakeRepository, bookCopyService, branchService)
+	authorService := author.NewAuthorService(repository.AuthorRepository, bookService)
+	subjectService := subject.NewSubjectService(repository.SubjectRepository, bookService)
+	marcService := marc.NewMARCService(bookService)
+	importingService := importing.NewImportingService(repository.ImportingRepository, bookService, bookCopyService, branchService)
+	coverService := cover.NewCoverService(storage.NewBlobStoreFromEnv(), bookService)
 
-	srv = server.NewServer(authService, bookService, bookCopyService, userService, borrowService)
+	srv = server.NewServer(authService, bookService, bookCopyService, userService, borrowService, policyService, calendarService, fineService, circulationService, labelService, branchService, transferService, stocktakeService, authorService, subjectService, marcService, importingService, coverService)
 
 	go srv.Run()
 